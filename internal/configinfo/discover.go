@@ -0,0 +1,899 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	loaderTypeName  = "Loader"
+	loadMethodName  = "Load"
+	defaultFileName = "default.yaml"
+	envVarPrefix    = "ECO_"
+)
+
+// ConfigStruct is a config read somewhere in the tests directory. It is either a struct loaded by a config.Loader, a
+// struct with envconfig tags loaded some other way, or the variables a package reads directly from the environment.
+type ConfigStruct struct {
+	// Section is the Section of the Loader, used as the key in ECO_CONFIG_FILE. For configs not loaded by a Loader it
+	// is the name of the package.
+	Section string `json:"section"`
+	// Package is the directory of the package declaring the struct, relative to the repository root.
+	Package string `json:"package"`
+	// Type is the name of the struct type. It is empty for variables read directly with os.Getenv or os.LookupEnv.
+	Type string `json:"type,omitempty"`
+	// EnvPrefix is the EnvPrefix of the Loader, if set.
+	EnvPrefix string `json:"envPrefix,omitempty"`
+	// DefaultFile is the path of the default YAML file, relative to the repository root. It is empty for configs not
+	// loaded by a Loader, which are not layered with ECO_CONFIG_FILE either.
+	DefaultFile string         `json:"defaultFile,omitempty"`
+	Fields      []*ConfigField `json:"fields"`
+}
+
+// ConfigField is a single field of a ConfigStruct that can be set from YAML or the environment. Fields of embedded
+// structs are included unless the embedded struct is a ConfigStruct itself. Fields of named struct fields are included
+// as Outer.Inner, with a YAML key of outer.inner.
+type ConfigField struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	YAMLKey string `json:"yamlKey,omitempty"`
+	EnvVar  string `json:"envVar,omitempty"`
+	// Secret is true if the values of this field are masked.
+	Secret bool `json:"secret"`
+	// Default is the value from the default file or, failing that, the default tag, if present.
+	Default string `json:"default,omitempty"`
+	// Value is the effective value after all layers are applied.
+	Value string `json:"value,omitempty"`
+	// Layer is the layer that set Value: default, the path of an override file, or environment. It is empty if no
+	// layer set the field.
+	Layer string `json:"layer,omitempty"`
+}
+
+// goPackage is the parsed contents of a single Go package directory.
+type goPackage struct {
+	dir   string
+	name  string
+	files []*ast.File
+}
+
+// typeKey identifies a named type by the directory of its package and its name.
+type typeKey struct {
+	dir  string
+	name string
+}
+
+// loaderCall is a call to Loader.Load on a struct declared in the same package.
+type loaderCall struct {
+	section   string
+	envPrefix string
+	typeName  string
+}
+
+// DiscoverConfigs finds every config read in the tests directory under root, sorted by section. These are the structs
+// loaded by a call to config.Loader.Load, the structs with envconfig tags that are not fields of another config, and
+// the ECO_ variables each package reads directly from the environment that are not already a field of a config.
+// Loaders with Partial set are skipped since their structs are embedded in another config.
+func DiscoverConfigs(root string) ([]*ConfigStruct, error) {
+	klog.V(100).Infof("Discovering config structs under %s", root)
+
+	modulePath, err := readModulePath(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	packages, err := parsePackages(filepath.Join(root, "tests"))
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &fieldResolver{
+		root:       root,
+		modulePath: modulePath,
+		packages:   packages,
+		loaded:     make(map[typeKey]bool),
+		nested:     make(map[typeKey]bool),
+	}
+
+	var configs []*ConfigStruct
+
+	for _, goPkg := range packages {
+		relativeDir, err := filepath.Rel(root, goPkg.dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, call := range findLoaderCalls(goPkg) {
+			configs = append(configs, &ConfigStruct{
+				Section:     call.section,
+				Package:     relativeDir,
+				Type:        call.typeName,
+				EnvPrefix:   call.envPrefix,
+				DefaultFile: filepath.Join(relativeDir, defaultFileName),
+			})
+			resolver.loaded[typeKey{dir: goPkg.dir, name: call.typeName}] = true
+		}
+	}
+
+	for _, goPkg := range packages {
+		relativeDir, err := filepath.Rel(root, goPkg.dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, typeName := range findEnvconfigStructs(goPkg) {
+			if resolver.loaded[typeKey{dir: goPkg.dir, name: typeName}] {
+				continue
+			}
+
+			configs = append(configs, &ConfigStruct{Section: goPkg.name, Package: relativeDir, Type: typeName})
+		}
+	}
+
+	for _, config := range configs {
+		config.Fields, err = resolver.collectFields(filepath.Join(root, config.Package), config.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect fields of %s.%s: %w", config.Package, config.Type, err)
+		}
+	}
+
+	configs = slices.DeleteFunc(configs, func(config *ConfigStruct) bool {
+		key := typeKey{dir: filepath.Join(root, config.Package), name: config.Type}
+
+		return config.DefaultFile == "" && resolver.nested[key]
+	})
+
+	lookupConfigs, err := resolver.collectEnvLookups(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	configs = append(configs, lookupConfigs...)
+
+	slices.SortFunc(configs, func(configA, configB *ConfigStruct) int {
+		return cmp.Or(
+			strings.Compare(configA.Section, configB.Section),
+			strings.Compare(configA.Package, configB.Package),
+			strings.Compare(configA.Type, configB.Type))
+	})
+
+	return configs, nil
+}
+
+// readModulePath reads the module path from the go.mod file at goModPath.
+func readModulePath(goModPath string) (string, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if modulePath, found := strings.CutPrefix(scanner.Text(), "module "); found {
+			return strings.TrimSpace(modulePath), nil
+		}
+	}
+
+	return "", fmt.Errorf("no module directive found in %s", goModPath)
+}
+
+// parsePackages parses every non-test Go file under dir, grouped by directory.
+func parsePackages(dir string) (map[string]*goPackage, error) {
+	fileSet := token.NewFileSet()
+	packages := make(map[string]*goPackage)
+
+	err := filepath.WalkDir(dir, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if dirEntry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fileSet, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+
+		pkgDir := filepath.Dir(path)
+		if packages[pkgDir] == nil {
+			packages[pkgDir] = &goPackage{dir: pkgDir, name: file.Name.Name}
+		}
+
+		packages[pkgDir].files = append(packages[pkgDir].files, file)
+
+		return nil
+	})
+
+	return packages, err
+}
+
+// findLoaderCalls returns the calls in goPkg of the form Loader{...}.Load(&variable) or loader.Load(&variable), where
+// loader is assigned a Loader literal and variable is declared with a named type in the same function.
+func findLoaderCalls(goPkg *goPackage) []loaderCall {
+	var calls []loaderCall
+
+	for _, file := range goPkg.files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				callExpr, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				if call, ok := parseLoaderCall(goPkg, callExpr, funcDecl.Body); ok {
+					calls = append(calls, call)
+				}
+
+				return true
+			})
+		}
+	}
+
+	return calls
+}
+
+// parseLoaderCall returns the loaderCall for callExpr if it is a call to Load on a non-partial Loader with a pointer
+// to a variable declared in body.
+func parseLoaderCall(goPkg *goPackage, callExpr *ast.CallExpr, body *ast.BlockStmt) (loaderCall, bool) {
+	selector, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != loadMethodName || len(callExpr.Args) != 1 {
+		return loaderCall{}, false
+	}
+
+	literal := findLoaderLiteral(goPkg, selector.X, body)
+	if literal == nil {
+		return loaderCall{}, false
+	}
+
+	unary, ok := callExpr.Args[0].(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return loaderCall{}, false
+	}
+
+	variable, ok := unary.X.(*ast.Ident)
+	if !ok {
+		return loaderCall{}, false
+	}
+
+	call := loaderCall{typeName: findVariableType(variable.Name, body)}
+	if call.typeName == "" {
+		return loaderCall{}, false
+	}
+
+	for _, element := range literal.Elts {
+		keyValue, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, _ := keyValue.Key.(*ast.Ident)
+		if key == nil {
+			continue
+		}
+
+		switch key.Name {
+		case "Section":
+			call.section = stringLiteral(keyValue.Value)
+		case "EnvPrefix":
+			call.envPrefix = stringLiteral(keyValue.Value)
+		case "Partial":
+			if value, ok := keyValue.Value.(*ast.Ident); ok && value.Name == "true" {
+				return loaderCall{}, false
+			}
+		}
+	}
+
+	return call, call.section != ""
+}
+
+// findLoaderLiteral returns the Loader composite literal for expr, either directly, through a variable assigned in
+// body, or through a function in goPkg returning it.
+func findLoaderLiteral(goPkg *goPackage, expr ast.Expr, body *ast.BlockStmt) *ast.CompositeLit {
+	if paren, ok := expr.(*ast.ParenExpr); ok {
+		expr = paren.X
+	}
+
+	if literal, ok := expr.(*ast.CompositeLit); ok && isLoaderType(literal.Type) {
+		return literal
+	}
+
+	if call, ok := expr.(*ast.CallExpr); ok {
+		return findReturnedLoaderLiteral(goPkg, call.Fun)
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	var found *ast.CompositeLit
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return found == nil
+		}
+
+		for i, lhs := range assign.Lhs {
+			lhsIdent, ok := lhs.(*ast.Ident)
+			if !ok || lhsIdent.Name != ident.Name {
+				continue
+			}
+
+			if literal, ok := assign.Rhs[i].(*ast.CompositeLit); ok && isLoaderType(literal.Type) {
+				found = literal
+			}
+		}
+
+		return found == nil
+	})
+
+	return found
+}
+
+// findReturnedLoaderLiteral returns the Loader composite literal returned by the function named funcExpr in goPkg.
+func findReturnedLoaderLiteral(goPkg *goPackage, funcExpr ast.Expr) *ast.CompositeLit {
+	funcIdent, ok := funcExpr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	var found *ast.CompositeLit
+
+	for _, file := range goPkg.files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil || funcDecl.Name.Name != funcIdent.Name || funcDecl.Body == nil {
+				continue
+			}
+
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				returnStmt, ok := node.(*ast.ReturnStmt)
+				if !ok || len(returnStmt.Results) != 1 {
+					return found == nil
+				}
+
+				if literal, ok := returnStmt.Results[0].(*ast.CompositeLit); ok && isLoaderType(literal.Type) {
+					found = literal
+				}
+
+				return found == nil
+			})
+		}
+	}
+
+	return found
+}
+
+// isLoaderType returns true if expr is Loader or a selector ending in Loader.
+func isLoaderType(expr ast.Expr) bool {
+	switch typeExpr := expr.(type) {
+	case *ast.Ident:
+		return typeExpr.Name == loaderTypeName
+	case *ast.SelectorExpr:
+		return typeExpr.Sel.Name == loaderTypeName
+	default:
+		return false
+	}
+}
+
+// findVariableType returns the name of the type of the variable declared as var name Type in body, or an empty string
+// if there is no such declaration.
+func findVariableType(name string, body *ast.BlockStmt) string {
+	var typeName string
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		valueSpec, ok := node.(*ast.ValueSpec)
+		if !ok {
+			return typeName == ""
+		}
+
+		typeIdent, ok := valueSpec.Type.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		for _, specName := range valueSpec.Names {
+			if specName.Name == name {
+				typeName = typeIdent.Name
+			}
+		}
+
+		return typeName == ""
+	})
+
+	return typeName
+}
+
+// stringLiteral returns the value of expr if it is a string literal and an empty string otherwise.
+func stringLiteral(expr ast.Expr) string {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return ""
+	}
+
+	value, err := strconv.Unquote(literal.Value)
+	if err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// fieldResolver collects the fields of struct types across packages, following embedded and nested structs.
+type fieldResolver struct {
+	root       string
+	modulePath string
+	packages   map[string]*goPackage
+	// loaded holds every struct type loaded by a Loader, so that its fields are not repeated in the configs embedding
+	// it.
+	loaded map[typeKey]bool
+	// nested holds every struct type embedded in or used as a named field of a config, so that it is not listed on its
+	// own.
+	nested map[typeKey]bool
+}
+
+// collectFields returns the fields of the struct typeName declared in the package at dir.
+func (resolver *fieldResolver) collectFields(dir, typeName string) ([]*ConfigField, error) {
+	return resolver.collectStructFields(typeKey{dir: dir, name: typeName}, make(map[typeKey]bool))
+}
+
+// collectStructFields returns the fields of the struct identified by key. Structs already in visiting are skipped so
+// that recursive types terminate.
+func (resolver *fieldResolver) collectStructFields(key typeKey, visiting map[typeKey]bool) ([]*ConfigField, error) {
+	if visiting[key] {
+		return nil, nil
+	}
+
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	goPkg, ok := resolver.packages[key.dir]
+	if !ok {
+		return nil, fmt.Errorf("package %s not found", key.dir)
+	}
+
+	structType, file := findStructType(goPkg, key.name)
+	if structType == nil {
+		return nil, fmt.Errorf("struct type %s not found in %s", key.name, key.dir)
+	}
+
+	return resolver.collectFieldList(key.dir, file, structType, visiting)
+}
+
+// collectFieldList returns the fields of structType, declared in file of the package at dir.
+func (resolver *fieldResolver) collectFieldList(
+	dir string, file *ast.File, structType *ast.StructType, visiting map[typeKey]bool) ([]*ConfigField, error) {
+	var fields []*ConfigField
+
+	for _, astField := range structType.Fields.List {
+		var tag reflect.StructTag
+
+		if astField.Tag != nil {
+			unquoted, err := strconv.Unquote(astField.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			tag = reflect.StructTag(unquoted)
+		}
+
+		fieldKey, isStruct := resolver.resolveStruct(dir, file, astField.Type)
+
+		if len(astField.Names) == 0 {
+			if !isStruct || resolver.loaded[fieldKey] {
+				continue
+			}
+
+			embeddedFields, err := resolver.collectStructFields(fieldKey, visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			resolver.nested[fieldKey] = true
+			fields = append(fields, embeddedFields...)
+
+			continue
+		}
+
+		yamlKey, _, _ := strings.Cut(tag.Get("yaml"), ",")
+		if yamlKey == "-" {
+			yamlKey = ""
+		}
+
+		inlineStruct, isInline := astField.Type.(*ast.StructType)
+
+		if (isStruct || isInline) && tag.Get("ignored") != "true" {
+			var (
+				nestedFields []*ConfigField
+				err          error
+			)
+
+			if isInline {
+				nestedFields, err = resolver.collectFieldList(dir, file, inlineStruct, visiting)
+			} else {
+				nestedFields, err = resolver.collectStructFields(fieldKey, visiting)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if len(nestedFields) > 0 {
+				if isStruct {
+					resolver.nested[fieldKey] = true
+				}
+
+				fields = append(fields, nestFields(astField.Names, yamlKey, nestedFields)...)
+
+				continue
+			}
+		}
+
+		envVar := strings.ToUpper(tag.Get("envconfig"))
+		if tag.Get("ignored") == "true" || envVar == "-" {
+			envVar = ""
+		}
+
+		if yamlKey == "" && envVar == "" {
+			continue
+		}
+
+		for _, name := range astField.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			field := &ConfigField{
+				Name:    name.Name,
+				Type:    types.ExprString(astField.Type),
+				YAMLKey: yamlKey,
+				EnvVar:  envVar,
+				Default: tag.Get("default"),
+			}
+			field.Secret = isSecret(field)
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// nestFields returns a copy of nestedFields for each exported name of the named struct field declaring them, with
+// the name and YAML key prefixed by those of the field. The YAML key is dropped if the field has none, since the nested
+// value cannot be set from YAML then.
+func nestFields(names []*ast.Ident, yamlKey string, nestedFields []*ConfigField) []*ConfigField {
+	var fields []*ConfigField
+
+	for _, name := range names {
+		if !name.IsExported() {
+			continue
+		}
+
+		for _, nestedField := range nestedFields {
+			field := *nestedField
+			field.Name = name.Name + "." + nestedField.Name
+			field.YAMLKey = ""
+
+			if yamlKey != "" && nestedField.YAMLKey != "" {
+				field.YAMLKey = yamlKey + "." + nestedField.YAMLKey
+			}
+
+			if field.YAMLKey == "" && field.EnvVar == "" {
+				continue
+			}
+
+			field.Secret = isSecret(&field)
+			fields = append(fields, &field)
+		}
+	}
+
+	return fields
+}
+
+// resolveStruct returns the key of the struct type referred to by typeExpr in file of the package at dir, following a
+// pointer. It returns false if typeExpr is not a struct type declared in this module.
+func (resolver *fieldResolver) resolveStruct(dir string, file *ast.File, typeExpr ast.Expr) (typeKey, bool) {
+	if star, ok := typeExpr.(*ast.StarExpr); ok {
+		typeExpr = star.X
+	}
+
+	var key typeKey
+
+	switch named := typeExpr.(type) {
+	case *ast.Ident:
+		key = typeKey{dir: dir, name: named.Name}
+	case *ast.SelectorExpr:
+		pkgIdent, ok := named.X.(*ast.Ident)
+		if !ok {
+			return typeKey{}, false
+		}
+
+		importDir, ok := resolver.importDir(file, pkgIdent.Name)
+		if !ok {
+			return typeKey{}, false
+		}
+
+		key = typeKey{dir: importDir, name: named.Sel.Name}
+	default:
+		return typeKey{}, false
+	}
+
+	goPkg, ok := resolver.packages[key.dir]
+	if !ok {
+		return typeKey{}, false
+	}
+
+	structType, _ := findStructType(goPkg, key.name)
+
+	return key, structType != nil
+}
+
+// collectEnvLookups returns a ConfigStruct for each package that reads ECO_ variables directly with os.Getenv or
+// os.LookupEnv, listing those that are not already the EnvVar of a field in configs.
+func (resolver *fieldResolver) collectEnvLookups(configs []*ConfigStruct) ([]*ConfigStruct, error) {
+	known := make(map[string]bool)
+
+	for _, config := range configs {
+		for _, field := range config.Fields {
+			known[field.EnvVar] = true
+		}
+	}
+
+	var lookupConfigs []*ConfigStruct
+
+	for _, goPkg := range resolver.packages {
+		var fields []*ConfigField
+
+		for _, envVar := range resolver.findEnvLookups(goPkg) {
+			if known[envVar] {
+				continue
+			}
+
+			known[envVar] = true
+			field := &ConfigField{Type: "string", EnvVar: envVar}
+			field.Secret = isSecret(field)
+			fields = append(fields, field)
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		relativeDir, err := filepath.Rel(resolver.root, goPkg.dir)
+		if err != nil {
+			return nil, err
+		}
+
+		lookupConfigs = append(lookupConfigs, &ConfigStruct{Section: goPkg.name, Package: relativeDir, Fields: fields})
+	}
+
+	return lookupConfigs, nil
+}
+
+// findEnvLookups returns the sorted ECO_ variables read in goPkg by os.Getenv or os.LookupEnv with a string literal or
+// a constant.
+func (resolver *fieldResolver) findEnvLookups(goPkg *goPackage) []string {
+	var envVars []string
+
+	for _, file := range goPkg.files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			callExpr, ok := node.(*ast.CallExpr)
+			if !ok || len(callExpr.Args) != 1 {
+				return true
+			}
+
+			selector, ok := callExpr.Fun.(*ast.SelectorExpr)
+			if !ok || (selector.Sel.Name != "Getenv" && selector.Sel.Name != "LookupEnv") {
+				return true
+			}
+
+			if pkgIdent, ok := selector.X.(*ast.Ident); !ok || pkgIdent.Name != "os" {
+				return true
+			}
+
+			envVar := resolver.resolveString(goPkg, file, callExpr.Args[0])
+			if strings.HasPrefix(envVar, envVarPrefix) {
+				envVars = append(envVars, envVar)
+			}
+
+			return true
+		})
+	}
+
+	slices.Sort(envVars)
+
+	return slices.Compact(envVars)
+}
+
+// resolveString returns the value of expr in file of goPkg if it is a string literal or a string constant declared in
+// this module, and an empty string otherwise.
+func (resolver *fieldResolver) resolveString(goPkg *goPackage, file *ast.File, expr ast.Expr) string {
+	switch value := expr.(type) {
+	case *ast.BasicLit:
+		return stringLiteral(value)
+	case *ast.Ident:
+		return findStringConst(goPkg, value.Name)
+	case *ast.SelectorExpr:
+		pkgIdent, ok := value.X.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+
+		importDir, ok := resolver.importDir(file, pkgIdent.Name)
+		if !ok || resolver.packages[importDir] == nil {
+			return ""
+		}
+
+		return findStringConst(resolver.packages[importDir], value.Sel.Name)
+	default:
+		return ""
+	}
+}
+
+// importDir returns the directory of the package imported as name in file, if it is in this module.
+func (resolver *fieldResolver) importDir(file *ast.File, name string) (string, bool) {
+	for _, importSpec := range file.Imports {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		importName := filepath.Base(importPath)
+		if importSpec.Name != nil {
+			importName = importSpec.Name.Name
+		}
+
+		if importName != name {
+			continue
+		}
+
+		relativePath, found := strings.CutPrefix(importPath, resolver.modulePath+"/")
+		if !found {
+			return "", false
+		}
+
+		return filepath.Join(resolver.root, relativePath), true
+	}
+
+	return "", false
+}
+
+// findStructType returns the struct type named typeName in goPkg along with the file declaring it.
+func findStructType(goPkg *goPackage, typeName string) (*ast.StructType, *ast.File) {
+	for _, file := range goPkg.files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Name != typeName {
+					continue
+				}
+
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+					return structType, file
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// findEnvconfigStructs returns the names of the struct types declared in goPkg with at least one envconfig tag.
+func findEnvconfigStructs(goPkg *goPackage) []string {
+	var typeNames []string
+
+	for _, file := range goPkg.files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if ok && hasEnvconfigTag(structType) {
+					typeNames = append(typeNames, typeSpec.Name.Name)
+				}
+			}
+		}
+	}
+
+	return typeNames
+}
+
+// hasEnvconfigTag returns true if any field of structType has an envconfig tag.
+func hasEnvconfigTag(structType *ast.StructType) bool {
+	for _, astField := range structType.Fields.List {
+		if astField.Tag == nil {
+			continue
+		}
+
+		unquoted, err := strconv.Unquote(astField.Tag.Value)
+		if err == nil && reflect.StructTag(unquoted).Get("envconfig") != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findStringConst returns the value of the string constant name declared in goPkg, or an empty string if there is
+// none.
+func findStringConst(goPkg *goPackage, name string) string {
+	for _, file := range goPkg.files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				for i, specName := range valueSpec.Names {
+					if specName.Name == name && i < len(valueSpec.Values) {
+						return stringLiteral(valueSpec.Values[i])
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// isSecret returns true if the name of the field, its environment variable, or its YAML key suggests that it holds a
+// password, token, or key.
+func isSecret(field *ConfigField) bool {
+	for _, name := range []string{field.Name, field.EnvVar, field.YAMLKey} {
+		name = strings.ToUpper(name)
+
+		for _, word := range []string{"PASS", "SECRET", "TOKEN", "CREDENTIAL"} {
+			if strings.Contains(name, word) {
+				return true
+			}
+		}
+
+		if strings.HasSuffix(name, "KEY") {
+			return true
+		}
+	}
+
+	return false
+}
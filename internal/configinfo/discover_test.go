@@ -0,0 +1,294 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// repositoryRoot is the root of this repository relative to the package directory.
+const repositoryRoot = "../.."
+
+var testFiles = map[string]string{
+	"go.mod": "module example.com/fixture\n",
+	"tests/internal/config/loader.go": `package config
+
+import "os"
+
+const ConfigFileEnvVar = "ECO_CONFIG_FILE"
+
+type Loader struct {
+	Section   string
+	EnvPrefix string
+	Partial   bool
+}
+
+func (loader Loader) Load(config any) error {
+	_, _ = os.LookupEnv(ConfigFileEnvVar)
+
+	return nil
+}
+`,
+	"tests/suite/internal/suiteconfig/config.go": `package suiteconfig
+
+import (
+	"os"
+
+	"example.com/fixture/tests/internal/config"
+	"example.com/fixture/tests/suite/internal/shared"
+)
+
+const pathEnvVar = "ECO_SUITE_CONFIG_FILE_PATH"
+
+type SuiteConfig struct {
+	shared.Common
+	Name     string   ` + "`yaml:\"name\" envconfig:\"ECO_SUITE_NAME\"`" + `
+	Workload Workload ` + "`yaml:\"workload\"`" + `
+	Inline   struct {
+		Domain string ` + "`yaml:\"domain\" envconfig:\"ECO_SUITE_INLINE_DOMAIN\"`" + `
+	} ` + "`yaml:\"inline\"`" + `
+	Skipped Workload ` + "`ignored:\"true\"`" + `
+	Token   string   ` + "`yaml:\"token\" envconfig:\"ECO_SUITE_TOKEN\"`" + `
+}
+
+type Workload struct {
+	Image string ` + "`yaml:\"image\" envconfig:\"ECO_SUITE_WORKLOAD_IMAGE\"`" + `
+}
+
+func newLoader() config.Loader {
+	return config.Loader{Section: "suite", EnvPrefix: "ECO_SUITE_"}
+}
+
+func NewSuiteConfig() *SuiteConfig {
+	_, _ = os.LookupEnv(pathEnvVar)
+	_ = os.Getenv("ECO_SUITE_NAME")
+
+	var suiteConfig SuiteConfig
+
+	_ = newLoader().Load(&suiteConfig)
+
+	return &suiteConfig
+}
+`,
+	"tests/suite/internal/suiteconfig/default.yaml": `name: suite
+workload:
+  image: quay.io/suite/workload:latest
+token: hidden
+`,
+	"tests/suite/internal/shared/common.go": `package shared
+
+type Common struct {
+	Verbose bool ` + "`yaml:\"verbose\" envconfig:\"ECO_SUITE_VERBOSE\"`" + `
+}
+`,
+	"tests/other/internal/otherconfig/config.go": `package otherconfig
+
+import "time"
+
+type OtherConfig struct {
+	Timeout time.Duration ` + "`envconfig:\"ECO_OTHER_TIMEOUT\" default:\"5m\"`" + `
+	Limits  Limits
+}
+
+type Limits struct {
+	Max int ` + "`envconfig:\"ECO_OTHER_LIMITS_MAX\" default:\"3\"`" + `
+}
+`,
+}
+
+func TestDiscoverConfigs(t *testing.T) {
+	root := writeTestFiles(t)
+
+	configs, err := DiscoverConfigs(root)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"config", "otherconfig", "suite", "suiteconfig"}, configSections(configs))
+
+	suiteConfig := findConfig(configs, "suite")
+	assert.Equal(t, "SuiteConfig", suiteConfig.Type)
+	assert.Equal(t, "ECO_SUITE_", suiteConfig.EnvPrefix)
+	assert.Equal(t, filepath.Join("tests", "suite", "internal", "suiteconfig", "default.yaml"), suiteConfig.DefaultFile)
+	assert.Equal(t, []*ConfigField{
+		{Name: "Verbose", Type: "bool", YAMLKey: "verbose", EnvVar: "ECO_SUITE_VERBOSE"},
+		{Name: "Name", Type: "string", YAMLKey: "name", EnvVar: "ECO_SUITE_NAME"},
+		{Name: "Workload.Image", Type: "string", YAMLKey: "workload.image", EnvVar: "ECO_SUITE_WORKLOAD_IMAGE"},
+		{Name: "Inline.Domain", Type: "string", YAMLKey: "inline.domain", EnvVar: "ECO_SUITE_INLINE_DOMAIN"},
+		{Name: "Token", Type: "string", YAMLKey: "token", EnvVar: "ECO_SUITE_TOKEN", Secret: true},
+	}, suiteConfig.Fields)
+
+	otherConfig := findConfig(configs, "otherconfig")
+	assert.Equal(t, "OtherConfig", otherConfig.Type)
+	assert.Empty(t, otherConfig.DefaultFile)
+	assert.Equal(t, []*ConfigField{
+		{Name: "Timeout", Type: "time.Duration", EnvVar: "ECO_OTHER_TIMEOUT", Default: "5m"},
+		{Name: "Limits.Max", Type: "int", EnvVar: "ECO_OTHER_LIMITS_MAX", Default: "3"},
+	}, otherConfig.Fields)
+
+	assert.Equal(t, []string{"ECO_CONFIG_FILE"}, fieldEnvVars(findConfig(configs, "config")))
+	assert.Empty(t, findConfig(configs, "config").Type)
+	assert.Equal(t, []string{"ECO_SUITE_CONFIG_FILE_PATH"}, fieldEnvVars(findConfig(configs, "suiteconfig")))
+}
+
+func TestApplyLayers(t *testing.T) {
+	root := writeTestFiles(t)
+
+	configs, err := DiscoverConfigs(root)
+	assert.NoError(t, err)
+
+	overrideFile := filepath.Join(t.TempDir(), "overrides.yaml")
+	err = os.WriteFile(overrideFile, []byte("suite:\n  workload:\n    image: quay.io/override:1\n"), 0o600)
+	assert.NoError(t, err)
+
+	t.Setenv(configFileEnvVar, overrideFile)
+	t.Setenv("ECO_SUITE_NAME", "from-env")
+	t.Setenv("ECO_OTHER_LIMITS_MAX", "7")
+
+	err = ApplyLayers(root, configs)
+	assert.NoError(t, err)
+
+	suiteFields := findConfig(configs, "suite").Fields
+	assert.Equal(t, &ConfigField{
+		Name: "Name", Type: "string", YAMLKey: "name", EnvVar: "ECO_SUITE_NAME",
+		Default: "suite", Value: "from-env", Layer: LayerEnvironment,
+	}, suiteFields[1])
+	assert.Equal(t, &ConfigField{
+		Name: "Workload.Image", Type: "string", YAMLKey: "workload.image", EnvVar: "ECO_SUITE_WORKLOAD_IMAGE",
+		Default: "quay.io/suite/workload:latest", Value: "quay.io/override:1", Layer: overrideFile,
+	}, suiteFields[2])
+	assert.Equal(t, maskedValue, suiteFields[4].Value)
+	assert.Empty(t, suiteFields[3].Layer)
+
+	otherFields := findConfig(configs, "otherconfig").Fields
+	assert.Equal(t, "5m", otherFields[0].Value)
+	assert.Equal(t, LayerDefault, otherFields[0].Layer)
+	assert.Equal(t, "7", otherFields[1].Value)
+	assert.Equal(t, LayerEnvironment, otherFields[1].Layer)
+}
+
+func TestDiscoverConfigsRepository(t *testing.T) {
+	configs, err := DiscoverConfigs(repositoryRoot)
+	assert.NoError(t, err)
+
+	envVars := allEnvVars(configs)
+
+	knownEnvVars := []string{
+		"ECO_CONFIG_FILE",
+		"ECO_VERBOSE_LEVEL",
+		"ECO_SYSTEM_SPK_CONFIG_FILE_PATH",
+		"ECO_RDS_CORE_CONFIG_FILE_PATH",
+		"ECO_RANDU_TESTWORKLOAD_NAMESPACE",
+		"ECO_RANDU_CERTMANAGER_DNS_SERVER",
+		"ECO_IPSEC_TESTWORKLOAD_CREATE_METHOD",
+		"ECO_HWACCEL_NEURON_DRIVERS_IMAGE",
+		"ECO_HWACCEL_NEURON_SLO_TTFT_P95",
+		"ECO_HWACCEL_KMM_PULL_SECRET",
+		"ECO_HWACCEL_NFD_CATALOG_SOURCE",
+		"ECO_HWACCEL_AMD_DRIVER_VERSION",
+		"ECO_HWACCEL_NVIDIAGPU_INSTANCE_TYPE",
+		"ECO_LCA_IBU_MGMT_SEED_IMAGE",
+		"ECO_LCA_IPC_EXPECTED_STAGE",
+		"ECO_ACCEL_PULL_SECRET",
+		"ECO_ASSISTED_ZTP_SPOKE_KUBECONFIG",
+		"ECO_CNF_RAN_SPOKE1_NAME",
+	}
+
+	for _, envVar := range knownEnvVars {
+		assert.Contains(t, envVars, envVar)
+	}
+}
+
+// TestDiscoverConfigsRepositoryComplete checks that every ECO_ variable named in an envconfig tag or read with
+// os.Getenv or os.LookupEnv anywhere in the tests directory is listed.
+func TestDiscoverConfigsRepositoryComplete(t *testing.T) {
+	configs, err := DiscoverConfigs(repositoryRoot)
+	assert.NoError(t, err)
+
+	envVars := allEnvVars(configs)
+	envVarPattern := regexp.MustCompile(`(?:envconfig:|os\.Getenv\(|os\.LookupEnv\()"(ECO_[A-Z0-9_]+)"`)
+
+	testsDir := filepath.Join(repositoryRoot, "tests")
+
+	err = filepath.WalkDir(testsDir, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil || dirEntry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, match := range envVarPattern.FindAllSubmatch(contents, -1) {
+			assert.Contains(t, envVars, string(match[1]), "variable from %s is not listed", path)
+		}
+
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func writeTestFiles(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	for path, contents := range testFiles {
+		fullPath := filepath.Join(root, path)
+
+		err := os.MkdirAll(filepath.Dir(fullPath), 0o755)
+		assert.NoError(t, err)
+
+		err = os.WriteFile(fullPath, []byte(contents), 0o600)
+		assert.NoError(t, err)
+	}
+
+	return root
+}
+
+func configSections(configs []*ConfigStruct) []string {
+	var sections []string
+
+	for _, config := range configs {
+		sections = append(sections, config.Section)
+	}
+
+	return sections
+}
+
+func findConfig(configs []*ConfigStruct, section string) *ConfigStruct {
+	index := slices.IndexFunc(configs, func(config *ConfigStruct) bool {
+		return config.Section == section
+	})
+
+	if index < 0 {
+		return &ConfigStruct{}
+	}
+
+	return configs[index]
+}
+
+func fieldEnvVars(config *ConfigStruct) []string {
+	var envVars []string
+
+	for _, field := range config.Fields {
+		envVars = append(envVars, field.EnvVar)
+	}
+
+	return envVars
+}
+
+func allEnvVars(configs []*ConfigStruct) []string {
+	var envVars []string
+
+	for _, config := range configs {
+		envVars = append(envVars, fieldEnvVars(config)...)
+	}
+
+	return envVars
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// configFileEnvVar must match config.ConfigFileEnvVar in tests/internal/config.
+	configFileEnvVar = "ECO_CONFIG_FILE"
+
+	// LayerDefault is the layer for values from the default file of a config.
+	LayerDefault = "default"
+	// LayerEnvironment is the layer for values from environment variables.
+	LayerEnvironment = "environment"
+
+	maskedValue = "****"
+)
+
+// ApplyLayers sets the Default, Value, and Layer of every field in configs following the same order as config.Loader:
+// the default file, each override file in ECO_CONFIG_FILE, then the environment. Configs not loaded by a Loader only
+// have the default tag and the environment. Secret values are masked.
+func ApplyLayers(root string, configs []*ConfigStruct) error {
+	overrideFiles, err := readOverrideFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, config := range configs {
+		klog.V(100).Infof("Applying layers to config %s", config.Section)
+
+		var defaults yaml.MapSlice
+
+		if config.DefaultFile != "" {
+			defaults, err = readYAMLFile(filepath.Join(root, config.DefaultFile))
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, field := range config.Fields {
+			if value, ok := lookupYAML(defaults, field.YAMLKey); ok {
+				field.Default = formatValue(value)
+			}
+
+			if field.Default != "" {
+				field.Value = field.Default
+				field.Layer = LayerDefault
+			}
+
+			for _, overrideFile := range overrideFiles {
+				if config.DefaultFile == "" {
+					break
+				}
+
+				section, _ := lookupYAML(overrideFile.sections, config.Section)
+				sectionValues, _ := section.(yaml.MapSlice)
+
+				if value, ok := lookupYAML(sectionValues, field.YAMLKey); ok {
+					field.Value = formatValue(value)
+					field.Layer = overrideFile.path
+				}
+			}
+
+			if field.EnvVar != "" {
+				if value, ok := os.LookupEnv(field.EnvVar); ok {
+					field.Value = value
+					field.Layer = LayerEnvironment
+				}
+			}
+
+			if field.Secret {
+				field.Default = maskValue(field.Default)
+				field.Value = maskValue(field.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// overrideFile is a single parsed file from ECO_CONFIG_FILE.
+type overrideFile struct {
+	path     string
+	sections yaml.MapSlice
+}
+
+// readOverrideFiles reads every file listed in ECO_CONFIG_FILE, in order.
+func readOverrideFiles() ([]overrideFile, error) {
+	var overrideFiles []overrideFile
+
+	for _, path := range strings.Split(os.Getenv(configFileEnvVar), ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		sections, err := readYAMLFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		overrideFiles = append(overrideFiles, overrideFile{path: path, sections: sections})
+	}
+
+	return overrideFiles, nil
+}
+
+// readYAMLFile reads the top level mapping of the YAML file at path. A missing file is treated as empty.
+func readYAMLFile(path string) (yaml.MapSlice, error) {
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		klog.V(100).Infof("YAML file %s does not exist, treating as empty", path)
+
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var values yaml.MapSlice
+
+	err = yaml.Unmarshal(contents, &values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// lookupYAML returns the value for key in values, where key may be a dot separated path into nested mappings. Empty
+// keys are never found.
+func lookupYAML(values yaml.MapSlice, key string) (any, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	first, rest, nested := strings.Cut(key, ".")
+
+	for _, item := range values {
+		if fmt.Sprint(item.Key) != first {
+			continue
+		}
+
+		if !nested {
+			return item.Value, true
+		}
+
+		nestedValues, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, false
+		}
+
+		return lookupYAML(nestedValues, rest)
+	}
+
+	return nil, false
+}
+
+// formatValue formats a YAML value on a single line. Scalars are printed as is and everything else is marshaled to
+// YAML with whitespace collapsed.
+func formatValue(value any) string {
+	switch value.(type) {
+	case nil:
+		return ""
+	case yaml.MapSlice, []any:
+		contents, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+
+		return strings.Join(strings.Fields(string(contents)), " ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// maskValue returns the masked form of value, leaving empty values empty so it is clear they are unset.
+func maskValue(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return maskedValue
+}
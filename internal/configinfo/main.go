@@ -0,0 +1,195 @@
+/*
+Configinfo is a tool to list every config read in the tests directory: the structs loaded by config.Loader, the structs
+with envconfig tags loaded some other way, and the ECO_ variables packages read directly from the environment. For each
+field that can be set from YAML or the environment it prints the environment variable, YAML key, default value,
+effective value, and the layer that set the effective value. Fields of nested structs are listed as Outer.Inner. Values
+of fields that look like passwords, tokens, or keys are masked.
+
+Effective values are computed from the default.yaml file next to each config loaded by config.Loader, the files listed
+in ECO_CONFIG_FILE, and the current environment, in the same order as config.Loader. Other configs only use the default
+tag of each field and the environment. Values derived in code after loading are not shown.
+
+Upon success the exit code is 0. If any error occurs it will be logged to stderr and the exit code will be 1.
+
+Usage:
+
+	configinfo [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-f, -format string
+		Format for printing the configs to stdout. One of table or json (default "table")
+
+	-r, -root string
+		Path to the root of the repository (default ".")
+
+	-s, -section string
+		Comma-separated list of config sections to print, such as general,ran. Leave blank to print all sections
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// FormatTable prints a table of fields for each config.
+	FormatTable = "table"
+	// FormatJSON prints all configs as a JSON array of ConfigStruct.
+	FormatJSON = "json"
+)
+
+// Formats contains all of the supported output formats.
+var Formats = []string{FormatTable, FormatJSON}
+
+var (
+	help    bool
+	format  string
+	root    string
+	section string
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage    = "Print this help message"
+		formatUsage  = "Format for printing the configs to stdout. One of table or json"
+		rootUsage    = "Path to the root of the repository"
+		sectionUsage = "Comma-separated list of config sections to print, such as general,ran. Leave blank to print all"
+
+		defaultHelp    = false
+		defaultFormat  = FormatTable
+		defaultRoot    = "."
+		defaultSection = ""
+
+		shorthand = " (shorthand)"
+	)
+
+	klog.InitFlags(nil)
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&format, "format", defaultFormat, formatUsage)
+	flag.StringVar(&format, "f", defaultFormat, formatUsage+shorthand)
+
+	flag.StringVar(&root, "root", defaultRoot, rootUsage)
+	flag.StringVar(&root, "r", defaultRoot, rootUsage+shorthand)
+
+	flag.StringVar(&section, "section", defaultSection, sectionUsage)
+	flag.StringVar(&section, "s", defaultSection, sectionUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	if !slices.Contains(Formats, format) {
+		klog.Errorf("Invalid format \"%s\", must be one of %v", format, Formats)
+
+		os.Exit(1)
+	}
+
+	configs, err := DiscoverConfigs(root)
+	if err != nil {
+		klog.Errorf("Failed to discover configs when root=\"%s\": %v", root, err)
+
+		os.Exit(1)
+	}
+
+	if section != "" {
+		sections := strings.Split(section, ",")
+		configs = slices.DeleteFunc(configs, func(config *ConfigStruct) bool {
+			return !slices.Contains(sections, config.Section)
+		})
+	}
+
+	err = ApplyLayers(root, configs)
+	if err != nil {
+		klog.Errorf("Failed to apply config layers: %v", err)
+
+		os.Exit(1)
+	}
+
+	if format == FormatJSON {
+		err = writeJSON(os.Stdout, configs)
+	} else {
+		err = writeTable(os.Stdout, configs)
+	}
+
+	if err != nil {
+		klog.Errorf("Failed to print configs: %v", err)
+
+		os.Exit(1)
+	}
+}
+
+// writeTable writes a table of the fields of each config to writer, preceded by a line describing the config.
+func writeTable(writer io.Writer, configs []*ConfigStruct) error {
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	for _, config := range configs {
+		fmt.Fprintf(tabWriter, "# %s: %s\n", config.Section, describeConfig(config))
+		fmt.Fprintln(tabWriter, "ENV VAR\tYAML KEY\tDEFAULT\tVALUE\tLAYER")
+
+		for _, field := range config.Fields {
+			fmt.Fprintf(tabWriter, "%s\t%s\t%s\t%s\t%s\n",
+				orDash(field.EnvVar), orDash(field.YAMLKey), orDash(field.Default), orDash(field.Value), orDash(field.Layer))
+		}
+
+		fmt.Fprintln(tabWriter)
+	}
+
+	return tabWriter.Flush()
+}
+
+// describeConfig returns where config is declared and, if it is loaded by a Loader, its default file.
+func describeConfig(config *ConfigStruct) string {
+	switch {
+	case config.Type == "":
+		return fmt.Sprintf("%s (read directly from the environment)", config.Package)
+	case config.DefaultFile == "":
+		return fmt.Sprintf("%s.%s", config.Package, config.Type)
+	default:
+		return fmt.Sprintf("%s.%s (%s)", config.Package, config.Type, config.DefaultFile)
+	}
+}
+
+// writeJSON writes configs as indented JSON to writer.
+func writeJSON(writer io.Writer, configs []*ConfigStruct) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(configs)
+}
+
+// orDash returns value or a dash if value is empty so that table columns stay aligned.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -256,6 +256,13 @@ func (cache *Cache) GetOrCreate(repoPath string) (*SuiteTree, error) {
 
 	_ = os.Remove(reportPath)
 
+	err = tree.LoadOwners(repoPath)
+	if err != nil {
+		klog.V(100).Infof("Failed to load owners for repo %s: %v", repoPath, err)
+
+		return nil, err
+	}
+
 	key, err := cache.GetKeyFromPath(repoPath)
 	if err == nil {
 		cache.Trees[key] = tree
@@ -333,6 +340,17 @@ func (cache *Cache) GetKeyFromPath(repoPath string) (CacheKey, error) {
 	return CacheKey{Branch: branch, Revision: revision}, nil
 }
 
+// HistoryDirectory returns the directory used for storing run history. It is a subdirectory of the cache directory so
+// it is preserved alongside the cache but not expired with it.
+func (cache *Cache) HistoryDirectory() (string, error) {
+	cachePath, err := cache.getDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cachePath, historyDir), nil
+}
+
 // getDirectory returns the stored directory for this cache if it exists, otherwise it uses a subdirectory of the
 // OS-specific user cache directory. If the user cache directory does not exist, it returns an error.
 func (cache *Cache) getDirectory() (string, error) {
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// DiffSpec is a single spec in a SuiteTree as it is compared between branches.
+type DiffSpec struct {
+	SpecResultKey
+	// Labels contains all of the labels for the spec, including those from its containers, in sorted order.
+	Labels []string
+}
+
+// MovedSpec is a spec with the same full text on both branches but in a different suite.
+type MovedSpec struct {
+	From DiffSpec
+	To   DiffSpec
+}
+
+// RelabeledSpec is a spec in the same suite with the same full text on both branches but with different labels.
+type RelabeledSpec struct {
+	DiffSpec
+	AddedLabels   []string
+	RemovedLabels []string
+}
+
+// SpecDiff contains the differences in specs between the trees of two branches. All of the slices are sorted by suite
+// and then text.
+type SpecDiff struct {
+	From      CacheKey
+	To        CacheKey
+	Added     []DiffSpec
+	Removed   []DiffSpec
+	Moved     []MovedSpec
+	Relabeled []RelabeledSpec
+}
+
+// NewSpecDiff compares the specs in fromTree to those in toTree. Specs are matched first by their suite relative to the
+// tests directory and full text. Any specs that remain unmatched are then matched by full text alone to find specs that
+// moved between suites.
+func NewSpecDiff(fromKey CacheKey, fromTree *SuiteTree, toKey CacheKey, toTree *SuiteTree) *SpecDiff {
+	klog.V(100).Infof("Comparing specs from branch %s to branch %s", fromKey.Branch, toKey.Branch)
+
+	diff := &SpecDiff{From: fromKey, To: toKey}
+	fromSpecs := collectDiffSpecs(fromTree)
+	toSpecs := collectDiffSpecs(toTree)
+
+	var unmatchedFrom, unmatchedTo []DiffSpec
+
+	for key, fromSpec := range fromSpecs {
+		toSpec, ok := toSpecs[key]
+		if !ok {
+			unmatchedFrom = append(unmatchedFrom, fromSpec)
+
+			continue
+		}
+
+		added, removed := diffLabels(fromSpec.Labels, toSpec.Labels)
+		if len(added) > 0 || len(removed) > 0 {
+			diff.Relabeled = append(diff.Relabeled,
+				RelabeledSpec{DiffSpec: toSpec, AddedLabels: added, RemovedLabels: removed})
+		}
+	}
+
+	for key, toSpec := range toSpecs {
+		if _, ok := fromSpecs[key]; !ok {
+			unmatchedTo = append(unmatchedTo, toSpec)
+		}
+	}
+
+	sortDiffSpecs(unmatchedFrom)
+	sortDiffSpecs(unmatchedTo)
+
+	for _, fromSpec := range unmatchedFrom {
+		index := slices.IndexFunc(unmatchedTo, func(toSpec DiffSpec) bool {
+			return toSpec.Text == fromSpec.Text
+		})
+
+		if index < 0 {
+			diff.Removed = append(diff.Removed, fromSpec)
+
+			continue
+		}
+
+		diff.Moved = append(diff.Moved, MovedSpec{From: fromSpec, To: unmatchedTo[index]})
+		unmatchedTo = slices.Delete(unmatchedTo, index, index+1)
+	}
+
+	diff.Added = unmatchedTo
+
+	slices.SortFunc(diff.Relabeled, func(specA, specB RelabeledSpec) int {
+		return compareDiffSpecs(specA.DiffSpec, specB.DiffSpec)
+	})
+
+	return diff
+}
+
+// IsEmpty returns true if there are no differences between the two branches.
+func (diff *SpecDiff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Moved) == 0 && len(diff.Relabeled) == 0
+}
+
+// String returns a text representation of the diff. Each spec is on its own line prefixed by + for added, - for
+// removed, > for moved, and ~ for relabeled.
+func (diff *SpecDiff) String() string {
+	builder := &strings.Builder{}
+
+	fmt.Fprintf(builder, "Diff from branch %s (%s) to branch %s (%s)\n",
+		diff.From.Branch, diff.From.Revision[:7], diff.To.Branch, diff.To.Revision[:7])
+	fmt.Fprintf(builder, "%d added, %d removed, %d moved, %d relabeled\n",
+		len(diff.Added), len(diff.Removed), len(diff.Moved), len(diff.Relabeled))
+
+	for _, spec := range diff.Added {
+		fmt.Fprintf(builder, "+ %s: %s\n", spec.Suite, spec.Text)
+	}
+
+	for _, spec := range diff.Removed {
+		fmt.Fprintf(builder, "- %s: %s\n", spec.Suite, spec.Text)
+	}
+
+	for _, spec := range diff.Moved {
+		fmt.Fprintf(builder, "> %s -> %s: %s\n", spec.From.Suite, spec.To.Suite, spec.To.Text)
+	}
+
+	for _, spec := range diff.Relabeled {
+		fmt.Fprintf(builder, "~ %s: %s (added labels %v, removed labels %v)\n",
+			spec.Suite, spec.Text, spec.AddedLabels, spec.RemovedLabels)
+	}
+
+	return builder.String()
+}
+
+// collectDiffSpecs returns all of the specs in tree indexed by their suite and full text.
+func collectDiffSpecs(tree *SuiteTree) map[SpecResultKey]DiffSpec {
+	specs := make(map[SpecResultKey]DiffSpec)
+
+	for suite, leaf := range tree.Leaves() {
+		key := NewSpecResultKey(suite.Path, leaf.SpecReport.FullText())
+		labels := slices.Clone(leaf.SpecReport.Labels())
+		slices.Sort(labels)
+
+		specs[key] = DiffSpec{SpecResultKey: key, Labels: labels}
+	}
+
+	return specs
+}
+
+// diffLabels returns the labels in toLabels but not fromLabels as added and the labels in fromLabels but not toLabels
+// as removed. Both inputs must be sorted.
+func diffLabels(fromLabels, toLabels []string) (added, removed []string) {
+	for _, label := range toLabels {
+		if _, found := slices.BinarySearch(fromLabels, label); !found {
+			added = append(added, label)
+		}
+	}
+
+	for _, label := range fromLabels {
+		if _, found := slices.BinarySearch(toLabels, label); !found {
+			removed = append(removed, label)
+		}
+	}
+
+	return added, removed
+}
+
+// sortDiffSpecs sorts specs by suite and then by text.
+func sortDiffSpecs(specs []DiffSpec) {
+	slices.SortFunc(specs, compareDiffSpecs)
+}
+
+// compareDiffSpecs compares specs first by suite and then by text.
+func compareDiffSpecs(specA, specB DiffSpec) int {
+	if n := strings.Compare(specA.Suite, specB.Suite); n != 0 {
+		return n
+	}
+
+	return strings.Compare(specA.Text, specB.Text)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpecDiff(t *testing.T) {
+	testCases := []struct {
+		name              string
+		fromReports       []types.Report
+		toReports         []types.Report
+		expectedAdded     []DiffSpec
+		expectedRemoved   []DiffSpec
+		expectedMoved     []MovedSpec
+		expectedRelabeled []RelabeledSpec
+	}{
+		{
+			name:        "unchanged",
+			fromReports: []types.Report{newTestReport("/repo/tests/a", newTestSpec("one", "x"))},
+			toReports:   []types.Report{newTestReport("/repo/tests/a", newTestSpec("one", "x"))},
+		},
+		{
+			name:        "added and removed",
+			fromReports: []types.Report{newTestReport("/repo/tests/a", newTestSpec("one"), newTestSpec("two"))},
+			toReports:   []types.Report{newTestReport("/repo/tests/a", newTestSpec("one"), newTestSpec("three"))},
+			expectedAdded: []DiffSpec{
+				{SpecResultKey: SpecResultKey{Suite: "tests/a", Text: "three"}, Labels: []string{}}},
+			expectedRemoved: []DiffSpec{
+				{SpecResultKey: SpecResultKey{Suite: "tests/a", Text: "two"}, Labels: []string{}}},
+		},
+		{
+			name:        "moved between suites",
+			fromReports: []types.Report{newTestReport("/repo/tests/a", newTestSpec("one", "x"))},
+			toReports:   []types.Report{newTestReport("/other/tests/b", newTestSpec("one", "y"))},
+			expectedMoved: []MovedSpec{{
+				From: DiffSpec{SpecResultKey: SpecResultKey{Suite: "tests/a", Text: "one"}, Labels: []string{"x"}},
+				To:   DiffSpec{SpecResultKey: SpecResultKey{Suite: "tests/b", Text: "one"}, Labels: []string{"y"}},
+			}},
+		},
+		{
+			name:        "relabeled",
+			fromReports: []types.Report{newTestReport("/repo/tests/a", newTestSpec("one", "b", "a"))},
+			toReports:   []types.Report{newTestReport("/repo/tests/a", newTestSpec("one", "c", "a"))},
+			expectedRelabeled: []RelabeledSpec{{
+				DiffSpec: DiffSpec{
+					SpecResultKey: SpecResultKey{Suite: "tests/a", Text: "one"}, Labels: []string{"a", "c"}},
+				AddedLabels:   []string{"c"},
+				RemovedLabels: []string{"b"},
+			}},
+		},
+		{
+			name: "same text in two suites only moves one",
+			fromReports: []types.Report{
+				newTestReport("/repo/tests/a", newTestSpec("one")),
+				newTestReport("/repo/tests/b", newTestSpec("one")),
+			},
+			toReports: []types.Report{newTestReport("/repo/tests/c", newTestSpec("one"))},
+			expectedRemoved: []DiffSpec{
+				{SpecResultKey: SpecResultKey{Suite: "tests/b", Text: "one"}, Labels: []string{}}},
+			expectedMoved: []MovedSpec{{
+				From: DiffSpec{SpecResultKey: SpecResultKey{Suite: "tests/a", Text: "one"}, Labels: []string{}},
+				To:   DiffSpec{SpecResultKey: SpecResultKey{Suite: "tests/c", Text: "one"}, Labels: []string{}},
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diff := NewSpecDiff(testFromKey, NewFromReports(testCase.fromReports),
+				testToKey, NewFromReports(testCase.toReports))
+
+			assertEqualSpecs(t, testCase.expectedAdded, diff.Added)
+			assertEqualSpecs(t, testCase.expectedRemoved, diff.Removed)
+			assertEqualSpecs(t, testCase.expectedMoved, diff.Moved)
+			assertEqualSpecs(t, testCase.expectedRelabeled, diff.Relabeled)
+			assert.Equal(t, testCase.expectedAdded == nil && testCase.expectedRemoved == nil &&
+				testCase.expectedMoved == nil && testCase.expectedRelabeled == nil, diff.IsEmpty())
+		})
+	}
+}
+
+func TestSpecDiffString(t *testing.T) {
+	diff := NewSpecDiff(
+		testFromKey, NewFromReports([]types.Report{
+			newTestReport("/repo/tests/a", newTestSpec("removed"), newTestSpec("moved"), newTestSpec("labeled")),
+		}),
+		testToKey, NewFromReports([]types.Report{
+			newTestReport("/repo/tests/a", newTestSpec("added"), newTestSpec("labeled", "new")),
+			newTestReport("/repo/tests/b", newTestSpec("moved")),
+		}))
+
+	assert.Equal(t, "Diff from branch main (0123456) to branch release-4.18 (89abcde)\n"+
+		"1 added, 1 removed, 1 moved, 1 relabeled\n"+
+		"+ tests/a: added\n"+
+		"- tests/a: removed\n"+
+		"> tests/a -> tests/b: moved\n"+
+		"~ tests/a: labeled (added labels [new], removed labels [])\n", diff.String())
+}
+
+func TestDiffLabels(t *testing.T) {
+	testCases := []struct {
+		name            string
+		fromLabels      []string
+		toLabels        []string
+		expectedAdded   []string
+		expectedRemoved []string
+	}{
+		{name: "empty"},
+		{name: "equal", fromLabels: []string{"a", "b"}, toLabels: []string{"a", "b"}},
+		{name: "added", fromLabels: []string{"a"}, toLabels: []string{"a", "b"}, expectedAdded: []string{"b"}},
+		{name: "removed", fromLabels: []string{"a", "b"}, toLabels: []string{"b"}, expectedRemoved: []string{"a"}},
+		{
+			name:            "replaced",
+			fromLabels:      []string{"a", "c"},
+			toLabels:        []string{"b", "c", "d"},
+			expectedAdded:   []string{"b", "d"},
+			expectedRemoved: []string{"a"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			added, removed := diffLabels(testCase.fromLabels, testCase.toLabels)
+			assert.Equal(t, testCase.expectedAdded, added)
+			assert.Equal(t, testCase.expectedRemoved, removed)
+		})
+	}
+}
+
+var (
+	testFromKey = CacheKey{Branch: "main", Revision: "0123456789abcdef"}
+	testToKey   = CacheKey{Branch: "release-4.18", Revision: "89abcdef01234567"}
+)
+
+// assertEqualSpecs asserts that actual equals expected, treating nil and empty slices as equal.
+func assertEqualSpecs[T any](t *testing.T, expected, actual []T) {
+	t.Helper()
+
+	if len(expected) == 0 {
+		assert.Empty(t, actual)
+
+		return
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+// newTestReport returns a report for the suite at suitePath containing specs.
+func newTestReport(suitePath string, specs ...types.SpecReport) types.Report {
+	return types.Report{
+		SuitePath:   suitePath,
+		PreRunStats: types.PreRunStats{TotalSpecs: len(specs)},
+		SpecReports: specs,
+	}
+}
+
+// newTestSpec returns an It spec with text and labels and no containers.
+func newTestSpec(text string, labels ...string) types.SpecReport {
+	return types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: text, LeafNodeLabels: labels}
+}
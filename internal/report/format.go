@@ -0,0 +1,256 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// FormatText prints each tree as indented text.
+	FormatText = "text"
+	// FormatJSON prints all trees as a single JSON document following the JSONReport schema.
+	FormatJSON = "json"
+	// FormatMarkdown prints a Markdown table of suites for each tree.
+	FormatMarkdown = "markdown"
+
+	// JSONSchemaVersion is the version of the JSONReport schema. It must be incremented whenever a field is removed or
+	// its meaning changes. Adding optional fields does not require a new version.
+	JSONSchemaVersion = 1
+)
+
+// Formats contains all of the supported output formats.
+var Formats = []string{FormatText, FormatJSON, FormatMarkdown}
+
+// JSONReport is the top level of the JSON output format. Branches are sorted by name.
+type JSONReport struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	Branches      []JSONBranch `json:"branches"`
+}
+
+// JSONBranch contains the tree for a single branch and, if run history is available, the flaky specs on it.
+type JSONBranch struct {
+	Branch   string          `json:"branch"`
+	Revision string          `json:"revision"`
+	Tree     *JSONNode       `json:"tree"`
+	Flaky    []JSONFlakySpec `json:"flaky,omitempty"`
+}
+
+// JSONNode is a single node of a SuiteTree. Exactly one of Children or Spec will be set. Results is only set when run
+// results were provided.
+type JSONNode struct {
+	Name string `json:"name"`
+	// Path is relative to the tests directory, starting with tests.
+	Path        string       `json:"path"`
+	Description string       `json:"description,omitempty"`
+	Specs       int          `json:"specs"`
+	Results     *JSONResults `json:"results,omitempty"`
+	Children    []*JSONNode  `json:"children,omitempty"`
+	Spec        *JSONSpec    `json:"spec,omitempty"`
+}
+
+// JSONResults contains the number of specs with each outcome under a node.
+type JSONResults struct {
+	Passed         int     `json:"passed"`
+	Failed         int     `json:"failed"`
+	Skipped        int     `json:"skipped"`
+	NotRun         int     `json:"notRun"`
+	RunTimeSeconds float64 `json:"runTimeSeconds"`
+}
+
+// JSONSpec contains the details of a single spec. Outcome is one of passed, failed, skipped, or not run and, along with
+// the other run fields, is only set when run results were provided.
+type JSONSpec struct {
+	Text           string   `json:"text"`
+	Location       string   `json:"location"`
+	Labels         []string `json:"labels"`
+	Serial         bool     `json:"serial"`
+	Ordered        bool     `json:"ordered"`
+	Outcome        string   `json:"outcome,omitempty"`
+	RunTimeSeconds float64  `json:"runTimeSeconds,omitempty"`
+	FailureMessage string   `json:"failureMessage,omitempty"`
+}
+
+// JSONFlakySpec is a spec with a nonzero flake score in the run history.
+type JSONFlakySpec struct {
+	Suite      string  `json:"suite"`
+	Text       string  `json:"text"`
+	FlakeScore float64 `json:"flakeScore"`
+	PassRate   float64 `json:"passRate"`
+}
+
+// NewJSONReport converts the trees and flaky specs for each branch into a JSONReport.
+func NewJSONReport(treeMap map[CacheKey]*SuiteTree, flakyMap map[CacheKey][]FlakySpec) *JSONReport {
+	report := &JSONReport{SchemaVersion: JSONSchemaVersion, Branches: []JSONBranch{}}
+
+	for _, key := range sortedKeys(treeMap) {
+		branch := JSONBranch{Branch: key.Branch, Revision: key.Revision, Tree: newJSONNode(treeMap[key])}
+
+		for _, spec := range flakyMap[key] {
+			branch.Flaky = append(branch.Flaky, JSONFlakySpec{
+				Suite:      spec.Suite,
+				Text:       spec.Text,
+				FlakeScore: spec.History.FlakeScore,
+				PassRate:   spec.History.PassRate,
+			})
+		}
+
+		report.Branches = append(report.Branches, branch)
+	}
+
+	return report
+}
+
+// WriteJSONReport writes the trees and flaky specs as an indented JSONReport to writer.
+func WriteJSONReport(writer io.Writer, treeMap map[CacheKey]*SuiteTree, flakyMap map[CacheKey][]FlakySpec) error {
+	klog.V(100).Infof("Writing JSON report for %d trees", len(treeMap))
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(NewJSONReport(treeMap, flakyMap))
+}
+
+// WriteMarkdownReport writes a section for each branch to writer containing a table of every suite in the tree. When
+// run results were provided, the table includes the outcome counts for each suite.
+func WriteMarkdownReport(writer io.Writer, treeMap map[CacheKey]*SuiteTree, flakyMap map[CacheKey][]FlakySpec) error {
+	klog.V(100).Infof("Writing Markdown report for %d trees", len(treeMap))
+
+	builder := &strings.Builder{}
+
+	for _, key := range sortedKeys(treeMap) {
+		tree := treeMap[key]
+
+		fmt.Fprintf(builder, "## Branch %s (%s)\n\n", escapeMarkdown(key.Branch), key.Revision[:7])
+
+		if tree.Results == nil {
+			builder.WriteString("| Suite | Description | Specs |\n| --- | --- | ---: |\n")
+		} else {
+			builder.WriteString("| Suite | Description | Specs | Passed | Failed | Skipped | Not run |\n")
+			builder.WriteString("| --- | --- | ---: | ---: | ---: | ---: | ---: |\n")
+		}
+
+		for suite := range suiteNodes(tree) {
+			fmt.Fprintf(builder, "| %s | %s | %d |",
+				escapeMarkdown(RelativeTestsPath(suite.Path)), escapeMarkdown(suite.Description), suite.Specs)
+
+			if suite.Results != nil {
+				fmt.Fprintf(builder, " %d | %d | %d | %d |",
+					suite.Results.Passed, suite.Results.Failed, suite.Results.Skipped, suite.Results.NotRun)
+			}
+
+			builder.WriteByte('\n')
+		}
+
+		fmt.Fprintf(builder, "| **Total** | | %d |", tree.Specs)
+
+		if tree.Results != nil {
+			fmt.Fprintf(builder, " %d | %d | %d | %d |",
+				tree.Results.Passed, tree.Results.Failed, tree.Results.Skipped, tree.Results.NotRun)
+		}
+
+		builder.WriteString("\n\n")
+
+		if flakySpecs := flakyMap[key]; len(flakySpecs) > 0 {
+			builder.WriteString("| Flaky spec | Suite | Flake score | Pass rate |\n| --- | --- | ---: | ---: |\n")
+
+			for _, spec := range flakySpecs {
+				fmt.Fprintf(builder, "| %s | %s | %.2f | %d%% |\n",
+					escapeMarkdown(spec.Text), escapeMarkdown(spec.Suite), spec.History.FlakeScore,
+					spec.History.PassPercent())
+			}
+
+			builder.WriteByte('\n')
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// newJSONNode recursively converts tree into a JSONNode.
+func newJSONNode(tree *SuiteTree) *JSONNode {
+	node := &JSONNode{
+		Name:        tree.Name,
+		Path:        RelativeTestsPath(tree.Path),
+		Description: tree.Description,
+		Specs:       tree.Specs,
+	}
+
+	if tree.Results != nil {
+		node.Results = &JSONResults{
+			Passed:         tree.Results.Passed,
+			Failed:         tree.Results.Failed,
+			Skipped:        tree.Results.Skipped,
+			NotRun:         tree.Results.NotRun,
+			RunTimeSeconds: tree.Results.RunTime.Seconds(),
+		}
+	}
+
+	if tree.SpecReport != nil {
+		node.Spec = &JSONSpec{
+			Text: tree.SpecReport.FullText(),
+			Location: fmt.Sprintf("%s:%d",
+				RelativeTestsPath(tree.SpecReport.LeafNodeLocation.FileName), tree.SpecReport.LeafNodeLocation.LineNumber),
+			Labels:  append([]string{}, tree.SpecReport.Labels()...),
+			Serial:  tree.SpecReport.IsSerial,
+			Ordered: tree.SpecReport.IsInOrderedContainer,
+		}
+
+		if tree.Results != nil {
+			node.Spec.Outcome = tree.Result.Outcome()
+		}
+
+		if tree.Result != nil {
+			node.Spec.RunTimeSeconds = tree.Result.RunTime.Seconds()
+			node.Spec.FailureMessage = tree.Result.FailureMessage
+		}
+
+		return node
+	}
+
+	for _, child := range tree.Children {
+		node.Children = append(node.Children, newJSONNode(child))
+	}
+
+	return node
+}
+
+// suiteNodes returns an iterator over the suites in tree in the order they appear, where suites are the nodes
+// containing leaf nodes.
+func suiteNodes(tree *SuiteTree) iter.Seq[*SuiteTree] {
+	return func(yield func(*SuiteTree) bool) {
+		var previous *SuiteTree
+
+		for suite := range tree.Leaves() {
+			if suite == previous {
+				continue
+			}
+
+			previous = suite
+
+			if !yield(suite) {
+				return
+			}
+		}
+	}
+}
+
+// sortedKeys returns the keys of treeMap sorted by branch.
+func sortedKeys(treeMap map[CacheKey]*SuiteTree) []CacheKey {
+	return slices.SortedFunc(maps.Keys(treeMap), func(keyA, keyB CacheKey) int {
+		return strings.Compare(keyA.Branch, keyB.Branch)
+	})
+}
+
+// escapeMarkdown escapes the characters in text that would break a Markdown table cell.
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{text: "plain", expected: "plain"},
+		{text: "a | b", expected: `a \| b`},
+		{text: "first\nsecond", expected: "first second"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, escapeMarkdown(testCase.text))
+	}
+}
+
+func TestWriteMarkdownReport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		results  bool
+		flaky    []FlakySpec
+		expected string
+	}{
+		{
+			name: "tree only",
+			expected: "## Branch main (0123456)\n\n" +
+				"| Suite | Description | Specs |\n| --- | --- | ---: |\n" +
+				"| tests/a | Suite \\| A | 2 |\n" +
+				"| tests/b | Suite B | 1 |\n" +
+				"| **Total** | | 3 |\n\n",
+		},
+		{
+			name:    "results and flaky specs",
+			results: true,
+			flaky: []FlakySpec{{
+				SpecResultKey: SpecResultKey{Suite: "tests/a", Text: "two"},
+				History:       &SpecHistory{FlakeScore: 0.5, PassRate: 0.666},
+			}},
+			expected: "## Branch main (0123456)\n\n" +
+				"| Suite | Description | Specs | Passed | Failed | Skipped | Not run |\n" +
+				"| --- | --- | ---: | ---: | ---: | ---: | ---: |\n" +
+				"| tests/a | Suite \\| A | 2 | 1 | 1 | 0 | 0 |\n" +
+				"| tests/b | Suite B | 1 | 0 | 0 | 0 | 1 |\n" +
+				"| **Total** | | 3 | 1 | 1 | 0 | 1 |\n\n" +
+				"| Flaky spec | Suite | Flake score | Pass rate |\n| --- | --- | ---: | ---: |\n" +
+				"| two | tests/a | 0.50 | 67% |\n\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tree := newTestFormatTree(testCase.results)
+			output := &bytes.Buffer{}
+
+			err := WriteMarkdownReport(output, map[CacheKey]*SuiteTree{testFromKey: tree},
+				map[CacheKey][]FlakySpec{testFromKey: testCase.flaky})
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, output.String())
+		})
+	}
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	treeMap := map[CacheKey]*SuiteTree{
+		testToKey:   newTestFormatTree(false),
+		testFromKey: newTestFormatTree(true),
+	}
+	flakyMap := map[CacheKey][]FlakySpec{testFromKey: {{
+		SpecResultKey: SpecResultKey{Suite: "tests/a", Text: "two"},
+		History:       &SpecHistory{FlakeScore: 0.5, PassRate: 0.5},
+	}}}
+
+	output := &bytes.Buffer{}
+	assert.Nil(t, WriteJSONReport(output, treeMap, flakyMap))
+
+	var report JSONReport
+
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, JSONSchemaVersion, report.SchemaVersion)
+	assert.Len(t, report.Branches, 2)
+
+	withResults := report.Branches[0]
+	assert.Equal(t, "main", withResults.Branch)
+	assert.Equal(t, []JSONFlakySpec{{Suite: "tests/a", Text: "two", FlakeScore: 0.5, PassRate: 0.5}},
+		withResults.Flaky)
+	assert.Equal(t, &JSONResults{Passed: 1, Failed: 1, NotRun: 1, RunTimeSeconds: 3}, withResults.Tree.Results)
+
+	suiteA := findJSONNode(withResults.Tree, "tests/a")
+	assert.NotNil(t, suiteA)
+	assert.Len(t, suiteA.Children, 2)
+	assert.Equal(t, &JSONSpec{
+		Text:           "one",
+		Location:       "tests/a/a_test.go:10",
+		Labels:         []string{"tier1"},
+		Outcome:        OutcomePassed,
+		RunTimeSeconds: 1,
+	}, suiteA.Children[0].Spec)
+	assert.Equal(t, OutcomeFailed, suiteA.Children[1].Spec.Outcome)
+	assert.Equal(t, "expected true", suiteA.Children[1].Spec.FailureMessage)
+
+	suiteB := findJSONNode(withResults.Tree, "tests/b")
+	assert.NotNil(t, suiteB)
+	assert.Equal(t, OutcomeNotRun, suiteB.Children[0].Spec.Outcome)
+
+	withoutResults := report.Branches[1]
+	assert.Equal(t, "release-4.18", withoutResults.Branch)
+	assert.Empty(t, withoutResults.Flaky)
+	assert.Nil(t, withoutResults.Tree.Results)
+	assert.Empty(t, findJSONNode(withoutResults.Tree, "tests/a").Children[0].Spec.Outcome)
+}
+
+// newTestFormatTree returns a tree with suites tests/a and tests/b. When results is true, the first spec of tests/a
+// passed, the second failed, and the spec of tests/b was not run.
+func newTestFormatTree(results bool) *SuiteTree {
+	specOne := newTestSpec("one", "tier1")
+	specOne.LeafNodeLocation = types.CodeLocation{FileName: "/repo/tests/a/a_test.go", LineNumber: 10}
+	specTwo := newTestSpec("two")
+
+	reportA := newTestReport("/repo/tests/a", specOne, specTwo)
+	reportA.SuiteDescription = "Suite | A"
+	reportB := newTestReport("/repo/tests/b", newTestSpec("three"))
+	reportB.SuiteDescription = "Suite B"
+
+	tree := NewFromReports([]types.Report{reportA, reportB})
+
+	if results {
+		specOne.State = types.SpecStatePassed
+		specOne.RunTime = time.Second
+		specTwo.State = types.SpecStateFailed
+		specTwo.RunTime = 2 * time.Second
+		specTwo.Failure = types.Failure{Message: "expected true"}
+
+		tree.ApplyResults([]types.Report{newTestReport("/ci/tests/a", specOne, specTwo)})
+	}
+
+	return tree
+}
+
+// findJSONNode returns the first node under node with path, or nil if there is none.
+func findJSONNode(node *JSONNode, path string) *JSONNode {
+	if node.Path == path {
+		return node
+	}
+
+	for _, child := range node.Children {
+		if found := findJSONNode(child, path); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	historyDir           = "history"
+	historyFileExtension = ".json.zstd"
+)
+
+// HistoryRun contains the results of a single test run on a certain branch and revision. A run may consist of many
+// Ginkgo reports, such as when the suites are split across multiple CI jobs.
+type HistoryRun struct {
+	Key CacheKey
+	// Time is the earliest start time of all the reports in the run.
+	Time    time.Time
+	Results []HistoryResult
+}
+
+// HistoryResult is a single entry of a SpecResultMap. It is necessary since JSON cannot use structs as map keys.
+type HistoryResult struct {
+	SpecResultKey
+	SpecResult
+}
+
+// NewHistoryRun creates a new HistoryRun for the given key from the It specs in reports.
+func NewHistoryRun(key CacheKey, reports []types.Report) *HistoryRun {
+	klog.V(100).Infof("Creating HistoryRun for branch %s and revision %s from %d reports",
+		key.Branch, key.Revision, len(reports))
+
+	run := &HistoryRun{Key: key}
+
+	for _, report := range reports {
+		if run.Time.IsZero() || report.StartTime.Before(run.Time) {
+			run.Time = report.StartTime
+		}
+	}
+
+	for specKey, result := range NewSpecResultMap(reports) {
+		run.Results = append(run.Results, HistoryResult{SpecResultKey: specKey, SpecResult: result})
+	}
+
+	return run
+}
+
+// ResultMap converts the results of the run back into a SpecResultMap for easier lookup.
+func (run *HistoryRun) ResultMap() SpecResultMap {
+	results := make(SpecResultMap, len(run.Results))
+
+	for _, result := range run.Results {
+		results[result.SpecResultKey] = result.SpecResult
+	}
+
+	return results
+}
+
+// History is a store of the results of many runs. Each run is saved as its own file in the history directory so that
+// the history is independent of the source code sum used to expire the rest of the cache.
+type History struct {
+	Runs      []*HistoryRun
+	directory string
+}
+
+// LoadHistory loads all of the runs saved in directory. If the directory does not exist, the history will be empty but
+// it will be created when Save is called.
+func LoadHistory(directory string) (*History, error) {
+	klog.V(100).Infof("Loading run history from %s", directory)
+
+	history := &History{directory: directory}
+
+	dirEntries, err := os.ReadDir(directory)
+	if os.IsNotExist(err) {
+		klog.V(100).Infof("History directory %s does not exist, no runs will be loaded", directory)
+
+		return history, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.Type().IsRegular() || !strings.HasSuffix(dirEntry.Name(), historyFileExtension) {
+			continue
+		}
+
+		run, err := loadHistoryFile(filepath.Join(directory, dirEntry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		history.Runs = append(history.Runs, run)
+	}
+
+	return history, nil
+}
+
+// Add adds run to the history, replacing any existing run with the same key and time.
+func (history *History) Add(run *HistoryRun) {
+	klog.V(100).Infof("Adding run on branch %s from %s to history", run.Key.Branch, run.Time.Format(time.RFC3339))
+
+	history.Runs = slices.DeleteFunc(history.Runs, func(existing *HistoryRun) bool {
+		return existing.Key == run.Key && existing.Time.Equal(run.Time)
+	})
+	history.Runs = append(history.Runs, run)
+}
+
+// Recent returns up to count of the most recent runs on branch across all revisions, ordered from oldest to newest.
+func (history *History) Recent(branch string, count int) []*HistoryRun {
+	var runs []*HistoryRun
+
+	for _, run := range history.Runs {
+		if run.Key.Branch == branch {
+			runs = append(runs, run)
+		}
+	}
+
+	slices.SortFunc(runs, func(runA, runB *HistoryRun) int {
+		return runA.Time.Compare(runB.Time)
+	})
+
+	if len(runs) > count {
+		runs = runs[len(runs)-count:]
+	}
+
+	return runs
+}
+
+// Save saves every run in the history to the history directory, overwriting files for runs that already exist. It
+// returns early on any errors.
+func (history *History) Save() error {
+	klog.V(100).Infof("Saving history with %d runs to %s", len(history.Runs), history.directory)
+
+	err := os.MkdirAll(history.directory, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, run := range history.Runs {
+		err := saveHistoryFile(filepath.Join(history.directory, generateHistoryFileName(run)), run)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SpecHistory contains the outcomes of a single spec across multiple runs, ordered from oldest to newest.
+type SpecHistory struct {
+	// Outcomes contains one of the Outcome constants for each run, including runs where the spec was not run.
+	Outcomes []string
+	// RunTimes contains the run time of the spec for each run. It is zero for runs where the spec was not run.
+	RunTimes []time.Duration
+	// PassRate is the fraction of runs where the spec passed out of those where it either passed or failed.
+	PassRate float64
+	// FlakeScore is the fraction of consecutive pairs of passed or failed runs where the outcome flipped. A spec that
+	// always passes or always fails has a score of 0 and one that alternates every run has a score of 1.
+	FlakeScore float64
+	// DurationTrend is the slope of the line of best fit for the run times of passed or failed runs, in change of run
+	// time per run.
+	DurationTrend time.Duration
+}
+
+// NewSpecHistory creates a SpecHistory for the spec identified by key using the results of runs. It returns nil if the
+// spec was not run in any of them.
+func NewSpecHistory(key SpecResultKey, runs []SpecResultMap) *SpecHistory {
+	history := &SpecHistory{}
+	found := false
+
+	var (
+		decisive      []string
+		decisiveTimes []float64
+	)
+
+	for _, run := range runs {
+		result, ok := run[key]
+		if !ok {
+			history.Outcomes = append(history.Outcomes, OutcomeNotRun)
+			history.RunTimes = append(history.RunTimes, 0)
+
+			continue
+		}
+
+		found = true
+		outcome := result.Outcome()
+		history.Outcomes = append(history.Outcomes, outcome)
+		history.RunTimes = append(history.RunTimes, result.RunTime)
+
+		if outcome == OutcomePassed || outcome == OutcomeFailed {
+			decisive = append(decisive, outcome)
+			decisiveTimes = append(decisiveTimes, float64(result.RunTime))
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	if len(decisive) == 0 {
+		return history
+	}
+
+	passed := 0
+	flips := 0
+
+	for i, outcome := range decisive {
+		if outcome == OutcomePassed {
+			passed++
+		}
+
+		if i > 0 && outcome != decisive[i-1] {
+			flips++
+		}
+	}
+
+	history.PassRate = float64(passed) / float64(len(decisive))
+
+	if len(decisive) > 1 {
+		history.FlakeScore = float64(flips) / float64(len(decisive)-1)
+	}
+
+	history.DurationTrend = time.Duration(slope(decisiveTimes))
+
+	return history
+}
+
+// PassPercent returns the pass rate as a percentage rounded to the nearest integer for display.
+func (history *SpecHistory) PassPercent() int {
+	return int(history.PassRate*100 + 0.5)
+}
+
+// FlakySpec is a spec whose history has a nonzero flake score, used for listing flaky specs.
+type FlakySpec struct {
+	SpecResultKey
+	History *SpecHistory
+}
+
+// SortFlakySpecs sorts specs in descending order of flake score, then by suite and text.
+func SortFlakySpecs(specs []FlakySpec) {
+	slices.SortFunc(specs, func(specA, specB FlakySpec) int {
+		if n := cmp.Compare(specB.History.FlakeScore, specA.History.FlakeScore); n != 0 {
+			return n
+		}
+
+		if n := strings.Compare(specA.Suite, specB.Suite); n != 0 {
+			return n
+		}
+
+		return strings.Compare(specA.Text, specB.Text)
+	})
+}
+
+// slope returns the slope of the least squares line of best fit for values, using their indices as the x values. It
+// returns 0 if there are fewer than 2 values.
+func slope(values []float64) float64 {
+	count := float64(len(values))
+	if count < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+
+	for i, value := range values {
+		x := float64(i)
+		sumX += x
+		sumY += value
+		sumXY += x * value
+		sumXX += x * x
+	}
+
+	return (count*sumXY - sumX*sumY) / (count*sumXX - sumX*sumX)
+}
+
+// saveHistoryFile saves run at historyFileName, truncating if the file already exists.
+func saveHistoryFile(historyFileName string, run *HistoryRun) error {
+	klog.V(100).Infof("Saving history run to %s", historyFileName)
+
+	file, err := os.Create(historyFileName)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	compressor, err := zstd.NewWriter(file)
+	if err != nil {
+		return err
+	}
+
+	err = json.NewEncoder(compressor).Encode(run)
+	if err != nil {
+		_ = compressor.Close()
+
+		return err
+	}
+
+	// Close flushes the remaining compressed data, so its error must be checked to avoid saving a truncated run.
+	return compressor.Close()
+}
+
+// loadHistoryFile attempts to load a HistoryRun from historyFileName.
+func loadHistoryFile(historyFileName string) (*HistoryRun, error) {
+	klog.V(100).Infof("Loading history run from %s", historyFileName)
+
+	file, err := os.Open(historyFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	decompressor, err := zstd.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer decompressor.Close()
+
+	run := &HistoryRun{}
+
+	err = json.NewDecoder(decompressor).Decode(run)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode history run %s: %w", historyFileName, err)
+	}
+
+	return run, nil
+}
+
+// generateHistoryFileName generates the file name for run from its branch, revision, and time so that each run is
+// saved to a unique file.
+func generateHistoryFileName(run *HistoryRun) string {
+	// Branch names such as release/4.18 may contain slashes, which cannot be used in file names.
+	branch := strings.ReplaceAll(run.Key.Branch, "/", "_")
+
+	return fmt.Sprintf("%s %s %s%s",
+		branch, run.Key.Revision, strconv.FormatInt(run.Time.UnixNano(), 10), historyFileExtension)
+}
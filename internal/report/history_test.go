@@ -0,0 +1,175 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpecHistory(t *testing.T) {
+	key := SpecResultKey{Suite: "tests/example", Text: "example spec"}
+	passed := SpecResultMap{key: {State: types.SpecStatePassed, RunTime: time.Second}}
+	failed := SpecResultMap{key: {State: types.SpecStateFailed, RunTime: 3 * time.Second}}
+	skipped := SpecResultMap{key: {State: types.SpecStateSkipped}}
+	notRun := SpecResultMap{}
+
+	testCases := []struct {
+		name     string
+		runs     []SpecResultMap
+		expected *SpecHistory
+	}{
+		{
+			name:     "never run",
+			runs:     []SpecResultMap{notRun, notRun},
+			expected: nil,
+		},
+		{
+			name: "always passes",
+			runs: []SpecResultMap{passed, passed, passed},
+			expected: &SpecHistory{
+				Outcomes: []string{OutcomePassed, OutcomePassed, OutcomePassed},
+				RunTimes: []time.Duration{time.Second, time.Second, time.Second},
+				PassRate: 1,
+			},
+		},
+		{
+			name: "alternates every run",
+			runs: []SpecResultMap{passed, failed, passed},
+			expected: &SpecHistory{
+				Outcomes:   []string{OutcomePassed, OutcomeFailed, OutcomePassed},
+				RunTimes:   []time.Duration{time.Second, 3 * time.Second, time.Second},
+				PassRate:   2.0 / 3.0,
+				FlakeScore: 1,
+			},
+		},
+		{
+			name: "skipped and not run are ignored for scoring",
+			runs: []SpecResultMap{passed, skipped, notRun, failed, failed},
+			expected: &SpecHistory{
+				Outcomes:      []string{OutcomePassed, OutcomeSkipped, OutcomeNotRun, OutcomeFailed, OutcomeFailed},
+				RunTimes:      []time.Duration{time.Second, 0, 0, 3 * time.Second, 3 * time.Second},
+				PassRate:      1.0 / 3.0,
+				FlakeScore:    0.5,
+				DurationTrend: time.Second,
+			},
+		},
+		{
+			name: "only skipped",
+			runs: []SpecResultMap{skipped, notRun},
+			expected: &SpecHistory{
+				Outcomes: []string{OutcomeSkipped, OutcomeNotRun},
+				RunTimes: []time.Duration{0, 0},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			history := NewSpecHistory(key, testCase.runs)
+
+			if testCase.expected == nil {
+				assert.Nil(t, history)
+
+				return
+			}
+
+			assert.Equal(t, testCase.expected.Outcomes, history.Outcomes)
+			assert.Equal(t, testCase.expected.RunTimes, history.RunTimes)
+			assert.InDelta(t, testCase.expected.PassRate, history.PassRate, 1e-9)
+			assert.InDelta(t, testCase.expected.FlakeScore, history.FlakeScore, 1e-9)
+			assert.Equal(t, testCase.expected.DurationTrend, history.DurationTrend)
+		})
+	}
+}
+
+func TestSlope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{name: "empty", values: nil, expected: 0},
+		{name: "single value", values: []float64{5}, expected: 0},
+		{name: "constant", values: []float64{2, 2, 2}, expected: 0},
+		{name: "increasing", values: []float64{1, 3, 5, 7}, expected: 2},
+		{name: "decreasing", values: []float64{9, 6, 3}, expected: -3},
+		{name: "noisy", values: []float64{1, 3, 2, 4}, expected: 0.8},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.InDelta(t, testCase.expected, slope(testCase.values), 1e-9)
+		})
+	}
+}
+
+func TestSortFlakySpecs(t *testing.T) {
+	specs := []FlakySpec{
+		{SpecResultKey: SpecResultKey{Suite: "b", Text: "one"}, History: &SpecHistory{FlakeScore: 0.5}},
+		{SpecResultKey: SpecResultKey{Suite: "a", Text: "two"}, History: &SpecHistory{FlakeScore: 0.5}},
+		{SpecResultKey: SpecResultKey{Suite: "a", Text: "one"}, History: &SpecHistory{FlakeScore: 0.5}},
+		{SpecResultKey: SpecResultKey{Suite: "c", Text: "one"}, History: &SpecHistory{FlakeScore: 1}},
+	}
+
+	SortFlakySpecs(specs)
+
+	var keys []SpecResultKey
+
+	for _, spec := range specs {
+		keys = append(keys, spec.SpecResultKey)
+	}
+
+	assert.Equal(t, []SpecResultKey{{Suite: "c", Text: "one"}, {Suite: "a", Text: "one"},
+		{Suite: "a", Text: "two"}, {Suite: "b", Text: "one"}}, keys)
+}
+
+func TestHistoryRecent(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newRun := func(branch string, hours int) *HistoryRun {
+		return &HistoryRun{
+			Key: CacheKey{Branch: branch, Revision: "abc"}, Time: start.Add(time.Duration(hours) * time.Hour)}
+	}
+
+	history := &History{}
+	history.Add(newRun("main", 2))
+	history.Add(newRun("release-4.18", 1))
+	history.Add(newRun("main", 0))
+	history.Add(newRun("main", 1))
+	history.Add(newRun("main", 1))
+
+	assert.Len(t, history.Runs, 4)
+
+	recent := history.Recent("main", 2)
+	assert.Len(t, recent, 2)
+	assert.Equal(t, start.Add(time.Hour), recent[0].Time)
+	assert.Equal(t, start.Add(2*time.Hour), recent[1].Time)
+
+	assert.Len(t, history.Recent("release-4.18", 5), 1)
+	assert.Empty(t, history.Recent("release-4.17", 5))
+}
+
+func TestHistorySaveLoad(t *testing.T) {
+	directory := t.TempDir()
+	key := SpecResultKey{Suite: "tests/example", Text: "example spec"}
+
+	history, err := LoadHistory(directory)
+	assert.Nil(t, err)
+	assert.Empty(t, history.Runs)
+
+	history.Add(&HistoryRun{
+		Key:     CacheKey{Branch: "release/4.18", Revision: "abc"},
+		Time:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Results: []HistoryResult{{SpecResultKey: key, SpecResult: SpecResult{State: types.SpecStatePassed}}},
+	})
+	assert.Nil(t, history.Save())
+
+	loaded, err := LoadHistory(directory)
+	assert.Nil(t, err)
+	assert.Len(t, loaded.Runs, 1)
+	assert.Equal(t, "release/4.18", loaded.Runs[0].Key.Branch)
+
+	result := loaded.Runs[0].ResultMap()[key]
+	assert.Equal(t, OutcomePassed, result.Outcome())
+}
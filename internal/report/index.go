@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// testIDLabelPrefix is the prefix of the label added by reportxml.ID. The same call also adds the bare ID as a label,
+	// which is dropped from the index in favor of the test case ID.
+	testIDLabelPrefix = "test_id:"
+	// noOwner is used as the owner of specs that are not covered by any OWNERS file.
+	noOwner = "none"
+)
+
+// IndexSpec is a single spec as it appears in the LabelIndex.
+type IndexSpec struct {
+	SpecResultKey
+	Owners []string
+}
+
+// LabelIndex groups the specs in a tree by label, test case ID, and owner. Each group is sorted by suite and then by
+// text.
+type LabelIndex struct {
+	// Labels maps each label, excluding test case IDs, to the specs with that label.
+	Labels map[string][]IndexSpec
+	// TestCases maps each test case ID, including the test case prefix, to the specs with that ID.
+	TestCases map[string][]IndexSpec
+	// Owners maps each owner to the specs in suites they own.
+	Owners map[string][]IndexSpec
+	// Suites contains the path relative to the tests directory of every suite in the tree, sorted.
+	Suites []string
+}
+
+// NewLabelIndex creates a LabelIndex from all the specs in tree. Test case IDs are taken from labels added by
+// reportxml.ID and are prefixed with tcPrefix, which should match GeneralConfig.TCPrefix.
+func NewLabelIndex(tree *SuiteTree, tcPrefix string) *LabelIndex {
+	klog.V(100).Infof("Creating LabelIndex for tree with path %s", tree.Path)
+
+	index := &LabelIndex{
+		Labels:    make(map[string][]IndexSpec),
+		TestCases: make(map[string][]IndexSpec),
+		Owners:    make(map[string][]IndexSpec),
+	}
+
+	suites := make(map[string]bool)
+
+	for suite, leaf := range tree.Leaves() {
+		spec := IndexSpec{
+			SpecResultKey: NewSpecResultKey(suite.Path, leaf.SpecReport.FullText()),
+			Owners:        suite.Owners,
+		}
+		suites[spec.Suite] = true
+
+		labels, testCases := splitTestCaseLabels(leaf.SpecReport.Labels(), tcPrefix)
+
+		for _, label := range labels {
+			index.Labels[label] = append(index.Labels[label], spec)
+		}
+
+		for _, testCase := range testCases {
+			index.TestCases[testCase] = append(index.TestCases[testCase], spec)
+		}
+
+		if len(spec.Owners) == 0 {
+			index.Owners[noOwner] = append(index.Owners[noOwner], spec)
+		}
+
+		for _, owner := range spec.Owners {
+			index.Owners[owner] = append(index.Owners[owner], spec)
+		}
+	}
+
+	for _, group := range []map[string][]IndexSpec{index.Labels, index.TestCases, index.Owners} {
+		for _, specs := range group {
+			slices.SortFunc(specs, compareIndexSpecs)
+		}
+	}
+
+	index.Suites = slices.Sorted(maps.Keys(suites))
+
+	return index
+}
+
+// CoverageMatrix counts the specs with each label in each suite. Test case IDs are included as labels.
+type CoverageMatrix struct {
+	Suites []string
+	Rows   []CoverageRow
+}
+
+// CoverageRow is a single label in the CoverageMatrix.
+type CoverageRow struct {
+	Label string
+	// Counts contains the number of specs with the label in each suite, in the same order as CoverageMatrix.Suites.
+	Counts []int
+	// Total is the sum of Counts.
+	Total int
+}
+
+// CoverageMatrix creates a matrix of label by suite from the index. Rows are sorted by label.
+func (index *LabelIndex) CoverageMatrix() *CoverageMatrix {
+	matrix := &CoverageMatrix{Suites: index.Suites}
+
+	suiteIndices := make(map[string]int, len(index.Suites))
+	for i, suite := range index.Suites {
+		suiteIndices[suite] = i
+	}
+
+	for _, group := range []map[string][]IndexSpec{index.Labels, index.TestCases} {
+		for label, specs := range group {
+			row := CoverageRow{Label: label, Counts: make([]int, len(index.Suites)), Total: len(specs)}
+
+			for _, spec := range specs {
+				row.Counts[suiteIndices[spec.Suite]]++
+			}
+
+			matrix.Rows = append(matrix.Rows, row)
+		}
+	}
+
+	slices.SortFunc(matrix.Rows, func(rowA, rowB CoverageRow) int {
+		return strings.Compare(rowA.Label, rowB.Label)
+	})
+
+	return matrix
+}
+
+// WriteCSV writes the matrix as CSV to writer. The header row contains the suites and each following row starts with
+// the label and ends with the total.
+func (matrix *CoverageMatrix) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+
+	header := append([]string{"label"}, matrix.Suites...)
+	header = append(header, "total")
+
+	err := csvWriter.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range matrix.Rows {
+		record := []string{row.Label}
+		for _, count := range row.Counts {
+			record = append(record, strconv.Itoa(count))
+		}
+
+		record = append(record, strconv.Itoa(row.Total))
+
+		err = csvWriter.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// WriteJSON writes the matrix as indented JSON to writer.
+func (matrix *CoverageMatrix) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(matrix)
+}
+
+// splitTestCaseLabels separates labels into regular labels and test case IDs. Test case IDs are prefixed with
+// tcPrefix and the bare ID label that accompanies them is dropped.
+func splitTestCaseLabels(allLabels []string, tcPrefix string) (labels, testCases []string) {
+	ids := make(map[string]bool)
+
+	for _, label := range allLabels {
+		if id, found := strings.CutPrefix(label, testIDLabelPrefix); found {
+			ids[id] = true
+			testCases = append(testCases, tcPrefix+id)
+		}
+	}
+
+	for _, label := range allLabels {
+		if strings.HasPrefix(label, testIDLabelPrefix) || ids[label] {
+			continue
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, testCases
+}
+
+// compareIndexSpecs compares specs first by suite and then by text.
+func compareIndexSpecs(specA, specB IndexSpec) int {
+	if n := strings.Compare(specA.Suite, specB.Suite); n != 0 {
+		return n
+	}
+
+	return strings.Compare(specA.Text, specB.Text)
+}
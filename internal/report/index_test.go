@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTestCaseLabels(t *testing.T) {
+	testCases := []struct {
+		name              string
+		labels            []string
+		expectedLabels    []string
+		expectedTestCases []string
+	}{
+		{name: "no labels"},
+		{name: "only labels", labels: []string{"tier1", "ptp"}, expectedLabels: []string{"tier1", "ptp"}},
+		{
+			name:              "test case ID",
+			labels:            []string{"tier1", "test_id:12345", "12345"},
+			expectedLabels:    []string{"tier1"},
+			expectedTestCases: []string{"OCP-12345"},
+		},
+		{
+			name:              "bare ID without prefix label is kept",
+			labels:            []string{"12345", "test_id:67890", "67890"},
+			expectedLabels:    []string{"12345"},
+			expectedTestCases: []string{"OCP-67890"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			labels, testCases := splitTestCaseLabels(testCase.labels, "OCP-")
+			assert.Equal(t, testCase.expectedLabels, labels)
+			assert.Equal(t, testCase.expectedTestCases, testCases)
+		})
+	}
+}
+
+func TestNewLabelIndex(t *testing.T) {
+	index := NewLabelIndex(newTestIndexTree(), "OCP-")
+
+	keyA1 := SpecResultKey{Suite: "tests/a", Text: "one"}
+	keyA2 := SpecResultKey{Suite: "tests/a", Text: "two"}
+	keyB1 := SpecResultKey{Suite: "tests/b", Text: "one"}
+
+	assert.Equal(t, []string{"tests/a", "tests/b"}, index.Suites)
+	assert.Equal(t, map[string][]IndexSpec{
+		"tier1": {
+			{SpecResultKey: keyA1, Owners: []string{"alice", "bob"}},
+			{SpecResultKey: keyB1},
+		},
+		"tier2": {{SpecResultKey: keyA2, Owners: []string{"alice", "bob"}}},
+	}, index.Labels)
+	assert.Equal(t, map[string][]IndexSpec{
+		"OCP-100": {{SpecResultKey: keyA1, Owners: []string{"alice", "bob"}}},
+	}, index.TestCases)
+	assert.Equal(t, map[string][]IndexSpec{
+		"alice": {
+			{SpecResultKey: keyA1, Owners: []string{"alice", "bob"}},
+			{SpecResultKey: keyA2, Owners: []string{"alice", "bob"}},
+		},
+		"bob": {
+			{SpecResultKey: keyA1, Owners: []string{"alice", "bob"}},
+			{SpecResultKey: keyA2, Owners: []string{"alice", "bob"}},
+		},
+		noOwner: {{SpecResultKey: keyB1}},
+	}, index.Owners)
+}
+
+func TestCoverageMatrix(t *testing.T) {
+	matrix := NewLabelIndex(newTestIndexTree(), "OCP-").CoverageMatrix()
+
+	assert.Equal(t, &CoverageMatrix{
+		Suites: []string{"tests/a", "tests/b"},
+		Rows: []CoverageRow{
+			{Label: "OCP-100", Counts: []int{1, 0}, Total: 1},
+			{Label: "tier1", Counts: []int{1, 1}, Total: 2},
+			{Label: "tier2", Counts: []int{1, 0}, Total: 1},
+		},
+	}, matrix)
+
+	output := &bytes.Buffer{}
+	assert.Nil(t, matrix.WriteCSV(output))
+	assert.Equal(t, "label,tests/a,tests/b,total\nOCP-100,1,0,1\ntier1,1,1,2\ntier2,1,0,1\n", output.String())
+
+	output.Reset()
+	assert.Nil(t, matrix.WriteJSON(output))
+	assert.Contains(t, output.String(), `"Label": "OCP-100"`)
+}
+
+func TestReadOwnersFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contents string
+		expected []string
+	}{
+		{name: "missing file", expected: nil},
+		{name: "approvers", contents: "approvers:\n- alice\nreviewers:\n- bob\n", expected: []string{"alice"}},
+		{name: "reviewers only", contents: "reviewers:\n- bob\n", expected: []string{"bob"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ownersFileName)
+
+			if testCase.contents != "" {
+				assert.Nil(t, os.WriteFile(path, []byte(testCase.contents), 0644))
+			}
+
+			owners, err := ReadOwnersFile(path)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, owners)
+		})
+	}
+}
+
+// newTestIndexTree returns a tree with two suites, of which only tests/a has owners.
+func newTestIndexTree() *SuiteTree {
+	tree := NewFromReports([]types.Report{
+		newTestReport("/repo/tests/a", newTestSpec("one", "tier1", "test_id:100", "100"), newTestSpec("two", "tier2")),
+		newTestReport("/repo/tests/b", newTestSpec("one", "tier1")),
+	})
+
+	for suite := range tree.Leaves() {
+		if suite.Path == "/repo/tests/a" {
+			suite.Owners = []string{"alice", "bob"}
+		}
+	}
+
+	return tree
+}
@@ -15,6 +15,9 @@ The flags are:
 	-h, -help
 		Print this help message
 
+	-f, -format string
+		Format for printing the trees to stdout. One of text, json, or markdown (default "text")
+
 	-a, -action-url string
 		URL to the action generating this report. Only necessary with -o. Uses "/" if left blank
 
@@ -22,11 +25,23 @@ The flags are:
 		Space-separated list of globs to match branches. Leave blank to use the local directory
 
 	-c, -clean
-		Delete the test suite cache and exit without running
+		Delete the test suite cache, including run history, and exit without running
+
+	-d, -diff string
+		Two space-separated branches to compare. Prints added, removed, moved, and relabeled specs instead of trees
+
+	-n, -history-runs int
+		Number of recent runs per branch used to detect flaky specs. Run history is disabled if 0 (default 10)
 
 	-o, -output string
 		Directory to output static site to. Will not be generated if left blank
 
+	-r, -results string
+		Space-separated globs to match Ginkgo JSON reports from test runs. Results are added to all trees
+
+	-t, -tc-prefix string
+		Prefix for test case IDs in the label index. Should match ECO_TC_PREFIX for the test runs (default "TC-")
+
 	-v int
 		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
 */
@@ -37,39 +52,57 @@ import (
 	"flag"
 	"fmt"
 	"html/template"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/onsi/ginkgo/v2/types"
 	"k8s.io/klog/v2"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var (
-	help      bool
-	actionURL string
-	branch    string
-	clean     bool
-	output    string
+	help        bool
+	actionURL   string
+	branch      string
+	clean       bool
+	output      string
+	results     string
+	historyRuns int
+	diff        string
+	tcPrefix    string
+	format      string
 )
 
 //nolint:gochecknoinits // This is a main package so init is fine.
 func init() {
 	const (
 		helpUsage      = "Print this help message"
+		formatUsage    = "Format for printing the trees to stdout. One of text, json, or markdown"
 		actionURLUsage = "URL to the action generating this report. Only necessary with -o. Uses \"/\" if left blank"
 		branchUsage    = "Space-separated list of globs to match branches. Leave blank to use the local directory"
-		cleanUsage     = "Delete the test suite cache and exit without running"
+		diffUsage      = "Two space-separated branches to compare. Prints added, removed, moved, and relabeled specs"
+		cleanUsage     = "Delete the test suite cache, including run history, and exit without running"
+		tcPrefixUsage  = "Prefix for test case IDs in the label index. Should match ECO_TC_PREFIX for the test runs"
+		historyUsage   = "Number of recent runs per branch used to detect flaky specs. Run history is disabled if 0"
 		outputUsage    = "Directory to output static site to. Will not be generated if left blank"
+		resultsUsage   = "Space-separated globs to match Ginkgo JSON reports from test runs. Results are added to all trees"
 
 		defaultHelp      = false
+		defaultFormat    = FormatText
 		defaultActionURL = "/"
 		defaultBranch    = ""
 		defaultClean     = false
 		defaultOutput    = ""
+		defaultResults   = ""
+		defaultHistory   = 10
+		defaultDiff      = ""
+		defaultTCPrefix  = "TC-"
 
 		shorthand = " (shorthand)"
 	)
@@ -83,6 +116,9 @@ func init() {
 	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
 	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
 
+	flag.StringVar(&format, "format", defaultFormat, formatUsage)
+	flag.StringVar(&format, "f", defaultFormat, formatUsage+shorthand)
+
 	flag.StringVar(&actionURL, "action-url", defaultActionURL, actionURLUsage)
 	flag.StringVar(&actionURL, "a", defaultActionURL, actionURLUsage+shorthand)
 
@@ -94,6 +130,18 @@ func init() {
 
 	flag.StringVar(&output, "output", defaultOutput, outputUsage)
 	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+
+	flag.StringVar(&results, "results", defaultResults, resultsUsage)
+	flag.StringVar(&results, "r", defaultResults, resultsUsage+shorthand)
+
+	flag.IntVar(&historyRuns, "history-runs", defaultHistory, historyUsage)
+	flag.IntVar(&historyRuns, "n", defaultHistory, historyUsage+shorthand)
+
+	flag.StringVar(&diff, "diff", defaultDiff, diffUsage)
+	flag.StringVar(&diff, "d", defaultDiff, diffUsage+shorthand)
+
+	flag.StringVar(&tcPrefix, "tc-prefix", defaultTCPrefix, tcPrefixUsage)
+	flag.StringVar(&tcPrefix, "t", defaultTCPrefix, tcPrefixUsage+shorthand)
 }
 
 func main() {
@@ -105,6 +153,12 @@ func main() {
 		return
 	}
 
+	if !slices.Contains(Formats, format) {
+		klog.Errorf("Invalid format \"%s\", must be one of %v", format, Formats)
+
+		os.Exit(1)
+	}
+
 	if clean {
 		err := CleanCache()
 		if err != nil {
@@ -116,6 +170,17 @@ func main() {
 		return
 	}
 
+	if diff != "" {
+		err := diffBranches(strings.Fields(diff), output)
+		if err != nil {
+			klog.Errorf("Failed to diff branches when diff=\"%s\": %v", diff, err)
+
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	treeMap, err := getTrees(branch)
 	if err != nil {
 		klog.Errorf("Failed to get suite trees when branch=\"%s\": %v", branch, err)
@@ -123,10 +188,37 @@ func main() {
 		os.Exit(1)
 	}
 
-	printTreeMap(treeMap)
+	var reports []types.Report
+
+	if results != "" {
+		reports, err = applyResults(treeMap, strings.Fields(results))
+		if err != nil {
+			klog.Errorf("Failed to apply run results when results=\"%s\": %v", results, err)
+
+			os.Exit(1)
+		}
+	}
+
+	var flakyMap map[CacheKey][]FlakySpec
+
+	if historyRuns > 0 {
+		flakyMap, err = applyHistory(treeMap, reports, historyRuns)
+		if err != nil {
+			klog.Errorf("Failed to apply run history when history-runs=%d: %v", historyRuns, err)
+
+			os.Exit(1)
+		}
+	}
+
+	err = printTreeMap(treeMap, flakyMap, format)
+	if err != nil {
+		klog.Errorf("Failed to print tree map in format %s: %v", format, err)
+
+		os.Exit(1)
+	}
 
 	if output != "" {
-		err := templateTreeMap(treeMap, output)
+		err := templateTreeMap(treeMap, flakyMap, output)
 		if err != nil {
 			klog.Errorf("Failed to template tree map and save to %s: %v", output, err)
 
@@ -170,15 +262,153 @@ func getTrees(branch string) (map[CacheKey]*SuiteTree, error) {
 	return treeMap, nil
 }
 
-func printTreeMap(treeMap map[CacheKey]*SuiteTree) {
+// diffBranches gets the trees for exactly two branches and prints the diff between them. If output is not empty, an
+// html page for the diff is also generated in the output directory.
+func diffBranches(branches []string, output string) error {
+	if len(branches) != 2 {
+		return fmt.Errorf("exactly two branches must be provided to diff, got %d", len(branches))
+	}
+
+	treeMap, err := getTrees(strings.Join(branches, " "))
+	if err != nil {
+		return err
+	}
+
+	var (
+		keys  [2]CacheKey
+		trees [2]*SuiteTree
+	)
+
+	for i, branch := range branches {
+		for key, tree := range treeMap {
+			if key.Branch == branch {
+				keys[i] = key
+				trees[i] = tree
+			}
+		}
+
+		if trees[i] == nil {
+			return fmt.Errorf("failed to find tree for branch %s", branch)
+		}
+	}
+
+	specDiff := NewSpecDiff(keys[0], trees[0], keys[1], trees[1])
+
+	fmt.Print(specDiff)
+
+	if output == "" {
+		return nil
+	}
+
+	err = os.MkdirAll(output, 0755)
+	if err != nil {
+		return err
+	}
+
+	config := DiffTemplateConfig{
+		Diff:       specDiff,
+		Generated:  time.Now(),
+		ActionURL:  template.URL(actionURL),
+		RepoURL:    RemoteURL,
+		TimeFormat: time.RFC3339,
+	}
+	outputFileName := fmt.Sprintf("diff_%s_%s.html", keys[0].Branch, keys[1].Branch)
+
+	return TemplateDiff(config, filepath.Join(output, outputFileName))
+}
+
+// applyResults loads the run reports matching patterns and applies them to every tree in treeMap. The loaded reports
+// are returned so they can be added to the run history.
+func applyResults(treeMap map[CacheKey]*SuiteTree, patterns []string) ([]types.Report, error) {
+	reports, err := LoadResultFiles(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tree := range treeMap {
+		tree.ApplyResults(reports)
+	}
+
+	return reports, nil
+}
+
+// applyHistory adds reports, if any, to the run history as a run for the branch of the tree in treeMap and saves it.
+// Since reports do not record the branch they were generated from, they are only added when treeMap contains a single
+// tree. The most recent runs for the branch of each tree are then applied to it. Only trees with at least one run in
+// the history will be included in the returned map of flaky specs.
+func applyHistory(
+	treeMap map[CacheKey]*SuiteTree, reports []types.Report, runs int) (map[CacheKey][]FlakySpec, error) {
+	historyPath, err := (&Cache{}).HistoryDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := LoadHistory(historyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reports) > 0 {
+		err = recordHistoryRun(history, treeMap, reports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	flakyMap := make(map[CacheKey][]FlakySpec)
+
+	for key, tree := range treeMap {
+		recentRuns := history.Recent(key.Branch, runs)
+		if len(recentRuns) == 0 {
+			continue
+		}
+
+		flakyMap[key] = tree.ApplyHistory(recentRuns)
+	}
+
+	return flakyMap, nil
+}
+
+// recordHistoryRun adds reports to history as a run on the branch of the only tree in treeMap and saves the history.
+// If treeMap contains more than one tree, the branch of the reports is ambiguous and nothing is recorded.
+func recordHistoryRun(history *History, treeMap map[CacheKey]*SuiteTree, reports []types.Report) error {
+	if len(treeMap) != 1 {
+		klog.Warningf("Not adding results to history since they cannot be attributed to one of %d branches",
+			len(treeMap))
+
+		return nil
+	}
+
+	for key := range treeMap {
+		history.Add(NewHistoryRun(key, reports))
+	}
+
+	return history.Save()
+}
+
+// printTreeMap prints the trees and any flaky specs to stdout in the provided format.
+func printTreeMap(treeMap map[CacheKey]*SuiteTree, flakyMap map[CacheKey][]FlakySpec, format string) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSONReport(os.Stdout, treeMap, flakyMap)
+	case FormatMarkdown:
+		return WriteMarkdownReport(os.Stdout, treeMap, flakyMap)
+	}
+
 	for key, tree := range treeMap {
 		fmt.Println("---")
 		fmt.Printf("Branch %s (%s)\n", key.Branch, key.Revision[:7])
 		fmt.Print(tree)
+
+		for _, spec := range flakyMap[key] {
+			fmt.Printf("Flaky %.2f %s: %s\n", spec.History.FlakeScore, spec.Suite, spec.Text)
+		}
 	}
+
+	return nil
 }
 
-func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
+func templateTreeMap(treeMap map[CacheKey]*SuiteTree, flakyMap map[CacheKey][]FlakySpec, output string) error {
 	err := os.MkdirAll(output, 0755)
 	if err != nil {
 		return err
@@ -209,6 +439,19 @@ func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 			Revision:      key.Revision,
 			ShortRevision: key.Revision[:7],
 		}
+
+		if flakySpecs, ok := flakyMap[key]; ok {
+			branchReport.FlakyFile, err = templateFlaky(key, flakySpecs, output)
+			if err != nil {
+				return err
+			}
+		}
+
+		branchReport.IndexFile, err = templateIndex(key, tree, output)
+		if err != nil {
+			return err
+		}
+
 		branchReports = append(branchReports, branchReport)
 	}
 
@@ -229,6 +472,78 @@ func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 	return nil
 }
 
+// templateFlaky generates the page listing flakySpecs for the branch in key and returns the name of the generated file
+// in output.
+func templateFlaky(key CacheKey, flakySpecs []FlakySpec, output string) (string, error) {
+	config := FlakyTemplateConfig{
+		Specs:      flakySpecs,
+		Generated:  time.Now(),
+		Branch:     key.Branch,
+		ActionURL:  template.URL(actionURL),
+		RepoURL:    RemoteURL,
+		TimeFormat: time.RFC3339,
+	}
+	outputFileName := fmt.Sprintf("flaky_%s.html", key.Branch)
+
+	err := TemplateFlaky(config, filepath.Join(output, outputFileName))
+	if err != nil {
+		return "", err
+	}
+
+	return outputFileName, nil
+}
+
+// templateIndex generates the label and owner index page for tree along with the CSV and JSON coverage matrices. It
+// returns the name of the generated index page in output.
+func templateIndex(key CacheKey, tree *SuiteTree, output string) (string, error) {
+	index := NewLabelIndex(tree, tcPrefix)
+	matrix := index.CoverageMatrix()
+
+	csvFileName := fmt.Sprintf("coverage_%s.csv", key.Branch)
+	jsonFileName := fmt.Sprintf("coverage_%s.json", key.Branch)
+
+	err := writeFile(filepath.Join(output, csvFileName), matrix.WriteCSV)
+	if err != nil {
+		return "", err
+	}
+
+	err = writeFile(filepath.Join(output, jsonFileName), matrix.WriteJSON)
+	if err != nil {
+		return "", err
+	}
+
+	config := IndexTemplateConfig{
+		Index:      index,
+		CSVFile:    csvFileName,
+		JSONFile:   jsonFileName,
+		Generated:  time.Now(),
+		Branch:     key.Branch,
+		ActionURL:  template.URL(actionURL),
+		RepoURL:    RemoteURL,
+		TimeFormat: time.RFC3339,
+	}
+	outputFileName := fmt.Sprintf("index_%s.html", key.Branch)
+
+	err = TemplateIndex(config, filepath.Join(output, outputFileName))
+	if err != nil {
+		return "", err
+	}
+
+	return outputFileName, nil
+}
+
+// writeFile creates the file at outputFileName, truncating it if it already exists, and uses write to fill it.
+func writeFile(outputFileName string, write func(io.Writer) error) error {
+	file, err := os.Create(outputFileName)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return write(file)
+}
+
 func getLocalTreeMap(cache *Cache, repoPath string) (map[CacheKey]*SuiteTree, error) {
 	tree, err := cache.GetOrCreate(repoPath)
 	if err != nil {
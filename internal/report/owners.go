@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ownersFileName = "OWNERS"
+)
+
+// OwnersFile is the subset of the OWNERS file format used for determining who owns a suite.
+type OwnersFile struct {
+	Approvers []string `yaml:"approvers"`
+	Reviewers []string `yaml:"reviewers"`
+}
+
+// ReadOwnersFile returns the approvers from the OWNERS file at path, falling back to the reviewers if there are no
+// approvers. If the file does not exist, it returns nil without an error.
+func ReadOwnersFile(path string) ([]string, error) {
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Reading OWNERS file at %s", path)
+
+	ownersFile := &OwnersFile{}
+
+	err = yaml.Unmarshal(contents, ownersFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ownersFile.Approvers) > 0 {
+		return ownersFile.Approvers, nil
+	}
+
+	return ownersFile.Reviewers, nil
+}
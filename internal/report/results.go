@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// OutcomePassed is the outcome of a spec that passed.
+	OutcomePassed = "passed"
+	// OutcomeFailed is the outcome of a spec in any of the Ginkgo failure states, including panics and timeouts.
+	OutcomeFailed = "failed"
+	// OutcomeSkipped is the outcome of a spec that was either skipped or pending.
+	OutcomeSkipped = "skipped"
+	// OutcomeNotRun is the outcome of a spec that does not appear in any of the run reports.
+	OutcomeNotRun = "not run"
+)
+
+// SpecResult contains the outcome of actually running a single spec, taken from its SpecReport in a run report.
+type SpecResult struct {
+	State           types.SpecState
+	StartTime       time.Time
+	EndTime         time.Time
+	RunTime         time.Duration
+	FailureMessage  string
+	FailureLocation types.CodeLocation
+}
+
+// Outcome returns the outcome of the spec, which is one of the Outcome constants. A nil result is considered not run.
+func (result *SpecResult) Outcome() string {
+	switch {
+	case result == nil:
+		return OutcomeNotRun
+	case result.State.Is(types.SpecStatePassed):
+		return OutcomePassed
+	case result.State.Is(types.SpecStateFailureStates):
+		return OutcomeFailed
+	case result.State.Is(types.SpecStateSkipped | types.SpecStatePending):
+		return OutcomeSkipped
+	default:
+		return OutcomeNotRun
+	}
+}
+
+// RunResults contains the number of specs with each outcome and is meant to be summed across all the specs in a tree.
+type RunResults struct {
+	Passed  int
+	Failed  int
+	Skipped int
+	NotRun  int
+	// RunTime is the total time spent running all of the specs.
+	RunTime time.Duration
+}
+
+// Add adds the counts and run time of other to the receiver.
+func (results *RunResults) Add(other RunResults) {
+	results.Passed += other.Passed
+	results.Failed += other.Failed
+	results.Skipped += other.Skipped
+	results.NotRun += other.NotRun
+	results.RunTime += other.RunTime
+}
+
+// AddResult increments the count for the outcome of result and adds its run time. A nil result is counted as not run.
+func (results *RunResults) AddResult(result *SpecResult) {
+	switch result.Outcome() {
+	case OutcomePassed:
+		results.Passed++
+	case OutcomeFailed:
+		results.Failed++
+	case OutcomeSkipped:
+		results.Skipped++
+	default:
+		results.NotRun++
+	}
+
+	if result != nil {
+		results.RunTime += result.RunTime
+	}
+}
+
+// String returns a short summary of the counts, such as `(passed 3, failed 1, skipped 0, not run 2)`.
+func (results *RunResults) String() string {
+	return fmt.Sprintf("(%s %d, %s %d, %s %d, %s %d)",
+		OutcomePassed, results.Passed,
+		OutcomeFailed, results.Failed,
+		OutcomeSkipped, results.Skipped,
+		OutcomeNotRun, results.NotRun)
+}
+
+// SpecResultKey identifies a spec independent of the machine that ran it.
+type SpecResultKey struct {
+	// Suite is the path to the suite relative to the tests directory.
+	Suite string
+	// Text is the full text of the spec, including all of its containers.
+	Text string
+}
+
+// NewSpecResultKey creates the key for the spec with fullText in the suite at suitePath. The suite path may be absolute
+// since it is made relative to the tests directory.
+func NewSpecResultKey(suitePath, fullText string) SpecResultKey {
+	return SpecResultKey{Suite: RelativeTestsPath(suitePath), Text: fullText}
+}
+
+// SpecResultMap indexes spec results by their SpecResultKey. It should be created using [NewSpecResultMap].
+type SpecResultMap map[SpecResultKey]SpecResult
+
+// NewSpecResultMap creates a SpecResultMap from the It specs in reports. If the same spec appears more than once, the
+// one with the latest end time is kept.
+func NewSpecResultMap(reports []types.Report) SpecResultMap {
+	klog.V(100).Infof("Creating SpecResultMap from %d reports", len(reports))
+
+	results := make(SpecResultMap)
+
+	for _, report := range reports {
+		for _, spec := range report.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+			key := NewSpecResultKey(report.SuitePath, spec.FullText())
+
+			existing, ok := results[key]
+			if ok && existing.EndTime.After(spec.EndTime) {
+				continue
+			}
+
+			results[key] = SpecResult{
+				State:           spec.State,
+				StartTime:       spec.StartTime,
+				EndTime:         spec.EndTime,
+				RunTime:         spec.RunTime,
+				FailureMessage:  spec.FailureMessage(),
+				FailureLocation: spec.FailureLocation(),
+			}
+		}
+	}
+
+	return results
+}
+
+// Get returns the result for the spec with fullText in the suite at suitePath. The suite path may be absolute since
+// it is made relative to the tests directory before looking it up.
+func (results SpecResultMap) Get(suitePath, fullText string) (SpecResult, bool) {
+	result, ok := results[NewSpecResultKey(suitePath, fullText)]
+
+	return result, ok
+}
+
+// LoadResultFiles reads all of the Ginkgo JSON reports from files matching the provided glob patterns. It returns an
+// error if any pattern is malformed, if no files match, or if any matching file cannot be read.
+func LoadResultFiles(patterns []string) ([]types.Report, error) {
+	klog.V(100).Infof("Loading run reports from files matching patterns %v", patterns)
+
+	var reports []types.Report
+
+	matchedFiles := 0
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match run report pattern %s: %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			fileReports, err := ReadReportFile(match)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read run report %s: %w", match, err)
+			}
+
+			reports = append(reports, fileReports...)
+			matchedFiles++
+		}
+	}
+
+	if matchedFiles == 0 {
+		return nil, fmt.Errorf("no run reports found matching patterns %v", patterns)
+	}
+
+	return reports, nil
+}
+
+// RelativeTestsPath returns the part of path starting from the first tests directory. It works for both suite
+// directories and files within them. If there is no tests directory in the path, it is returned unchanged.
+func RelativeTestsPath(path string) string {
+	elements := strings.Split(filepath.ToSlash(path), "/")
+	for i, element := range elements {
+		if element == "tests" {
+			return strings.Join(elements[i:], "/")
+		}
+	}
+
+	return path
+}
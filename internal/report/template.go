@@ -2,6 +2,7 @@ package main
 
 import (
 	_ "embed"
+	"fmt"
 	"html/template"
 	"os"
 	"path/filepath"
@@ -16,14 +17,28 @@ var (
 
 	//go:embed report_template.html
 	reportTemplateFile string
+
+	//go:embed flaky_template.html
+	flakyTemplateFile string
+
+	//go:embed diff_template.html
+	diffTemplateFile string
+
+	//go:embed index_template.html
+	indexTemplateFile string
 )
 
 var (
 	funcMap = template.FuncMap{
-		"cleanPath": cleanPath,
+		"cleanPath":    cleanPath,
+		"outcomeClass": outcomeClass,
+		"sparkline":    sparkline,
 	}
 	treeTemplate   = template.Must(template.New("tree_template.html").Funcs(funcMap).Parse(treeTemplateFile))
 	reportTemplate = template.Must(template.New("report_template.html").Parse(reportTemplateFile))
+	flakyTemplate  = template.Must(template.New("flaky_template.html").Funcs(funcMap).Parse(flakyTemplateFile))
+	diffTemplate   = template.Must(template.New("diff_template.html").Parse(diffTemplateFile))
+	indexTemplate  = template.Must(template.New("index_template.html").Parse(indexTemplateFile))
 )
 
 // TreeTemplateConfig contains the data necessary to template a single SuiteTree into an html report.
@@ -51,9 +66,12 @@ type ReportTemplateConfig struct {
 }
 
 // BranchReportConfig contains the data necessary to include a single templated SuiteTree for a certain branch.
+// FlakyFile is optional and only set when there is run history for the branch.
 type BranchReportConfig struct {
 	Name          string
 	ReportFile    string
+	FlakyFile     string
+	IndexFile     string
 	Revision      string
 	ShortRevision string
 }
@@ -68,6 +86,53 @@ func TemplateReport(config ReportTemplateConfig, outputFileName string) error {
 	return executeTemplateAndSave(reportTemplate, config, outputFileName)
 }
 
+// FlakyTemplateConfig contains the data necessary to generate a page listing the flaky specs on a single branch.
+type FlakyTemplateConfig struct {
+	Specs      []FlakySpec
+	Generated  time.Time
+	Branch     string
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
+}
+
+// TemplateFlaky uses config to generate a page listing flaky specs and save it at outputFileName.
+func TemplateFlaky(config FlakyTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(flakyTemplate, config, outputFileName)
+}
+
+// DiffTemplateConfig contains the data necessary to generate a page showing the spec differences between two branches.
+type DiffTemplateConfig struct {
+	Diff       *SpecDiff
+	Generated  time.Time
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
+}
+
+// TemplateDiff uses config to generate a page showing the diff between two branches and save it at outputFileName.
+func TemplateDiff(config DiffTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(diffTemplate, config, outputFileName)
+}
+
+// IndexTemplateConfig contains the data necessary to generate a page indexing the specs on a single branch by label,
+// test case ID, and owner. CSVFile and JSONFile are the names of the coverage matrix files to link to.
+type IndexTemplateConfig struct {
+	Index      *LabelIndex
+	CSVFile    string
+	JSONFile   string
+	Generated  time.Time
+	Branch     string
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
+}
+
+// TemplateIndex uses config to generate a page indexing specs by label and owner and save it at outputFileName.
+func TemplateIndex(config IndexTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(indexTemplate, config, outputFileName)
+}
+
 // executeTemplateAndSave creates a file at outputFileName before executing tmpl with data provided by config. If
 // outputFileName already exists, then it is truncated.
 func executeTemplateAndSave(tmpl *template.Template, config any, outputFileName string) error {
@@ -98,3 +163,48 @@ func cleanPath(path string) string {
 
 	return path
 }
+
+// outcomeClass converts the provided spec outcome into a CSS class name by replacing spaces with dashes.
+func outcomeClass(outcome string) string {
+	return strings.ReplaceAll(outcome, " ", "-")
+}
+
+// sparkline generates an inline SVG showing the outcome of each run in history as a colored bar whose height is
+// proportional to the run time of the spec in that run. Colors are applied using the outcome CSS classes.
+func sparkline(history *SpecHistory) template.HTML {
+	const (
+		barWidth  = 8
+		barGap    = 2
+		height    = 24
+		minHeight = 4
+	)
+
+	if history == nil {
+		return ""
+	}
+
+	var maxRunTime time.Duration
+
+	for _, runTime := range history.RunTimes {
+		maxRunTime = max(maxRunTime, runTime)
+	}
+
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, `<svg class="sparkline" width="%d" height="%d">`, len(history.Outcomes)*(barWidth+barGap), height)
+
+	for i, outcome := range history.Outcomes {
+		barHeight := height
+		if maxRunTime > 0 {
+			barHeight = max(minHeight, int(int64(height)*int64(history.RunTimes[i])/int64(maxRunTime)))
+		}
+
+		fmt.Fprintf(builder, `<rect class="%s" x="%d" y="%d" width="%d" height="%d"><title>%s %s</title></rect>`,
+			outcomeClass(outcome), i*(barWidth+barGap), height-barHeight, barWidth, barHeight,
+			outcome, history.RunTimes[i])
+	}
+
+	builder.WriteString("</svg>")
+
+	// All of the values are generated by this program so they do not need escaping.
+	return template.HTML(builder.String())
+}
@@ -4,8 +4,10 @@ import (
 	"cmp"
 	"encoding/json"
 	"io"
+	"iter"
 	"os"
 	"path"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -29,6 +31,18 @@ type SuiteTree struct {
 	// SpecReport should only be set when Children is empty, meaning this is a leaf node representing a single spec.
 	// It should only have It specs.
 	SpecReport *types.SpecReport
+	// Result is the outcome of actually running the spec. Like SpecReport, it should only be set on leaf nodes and it
+	// will only be set after [SuiteTree.ApplyResults] is called with a run report containing this spec.
+	Result *SpecResult
+	// Results is the sum of the outcomes of all child specs, recursively. It is nil until [SuiteTree.ApplyResults] is
+	// called.
+	Results *RunResults
+	// Owners is the list of approvers from the nearest OWNERS file in or above the suite directory. It is only set on
+	// internal nodes and only after [SuiteTree.LoadOwners] is called.
+	Owners []string
+	// History is the outcome of the spec across previous runs. It should only be set on leaf nodes and it will only be
+	// set after [SuiteTree.ApplyHistory] is called with runs containing this spec.
+	History *SpecHistory
 }
 
 // NewFromReports creates a new SuiteTree from a list of reports. The root of the tree will be `/`.
@@ -52,6 +66,19 @@ func NewFromReports(reports []types.Report) *SuiteTree {
 func NewFromFile(path string) (*SuiteTree, error) {
 	klog.V(100).Infof("Creating SuiteTree from Ginkgo JSON report at path %s", path)
 
+	reports, err := ReadReportFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromReports(reports), nil
+}
+
+// ReadReportFile reads the list of reports from a Ginkgo JSON report file, such as one generated by the
+// --json-report flag.
+func ReadReportFile(path string) ([]types.Report, error) {
+	klog.V(100).Infof("Reading Ginkgo JSON report at path %s", path)
+
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -71,7 +98,7 @@ func NewFromFile(path string) (*SuiteTree, error) {
 		return nil, err
 	}
 
-	return NewFromReports(reports), nil
+	return reports, nil
 }
 
 // Insert adds a new suite to the tree as a leaf node and returns the node that was added. It will return nil if the
@@ -133,6 +160,68 @@ func (tree *SuiteTree) InsertSpecs(specs types.SpecReports) {
 	}
 }
 
+// ApplyResults attaches the outcome of each spec in the provided run reports to the matching leaf node in the tree
+// and then sums the outcomes for every internal node. Specs are matched using their suite path relative to the tests
+// directory and their full text, so the reports may come from a different machine than the one that created the
+// tree. When a spec appears in more than one report, the result that ended most recently is used. Specs in the tree
+// without a matching result are counted as not run.
+func (tree *SuiteTree) ApplyResults(reports []types.Report) {
+	klog.V(100).Infof("Applying results from %d reports to tree with path %s", len(reports), tree.Path)
+
+	results := NewSpecResultMap(reports)
+	tree.applyResults(results)
+}
+
+// ApplyHistory attaches the history of each spec across runs to the matching leaf node in the tree. Runs should be
+// ordered from oldest to newest. It returns all of the specs with a nonzero flake score, sorted from most to least
+// flaky.
+func (tree *SuiteTree) ApplyHistory(runs []*HistoryRun) []FlakySpec {
+	klog.V(100).Infof("Applying history from %d runs to tree with path %s", len(runs), tree.Path)
+
+	var resultMaps []SpecResultMap
+	for _, run := range runs {
+		resultMaps = append(resultMaps, run.ResultMap())
+	}
+
+	var flakySpecs []FlakySpec
+
+	for suite, leaf := range tree.Leaves() {
+		key := NewSpecResultKey(suite.Path, leaf.SpecReport.FullText())
+		leaf.History = NewSpecHistory(key, resultMaps)
+
+		if leaf.History != nil && leaf.History.FlakeScore > 0 {
+			flakySpecs = append(flakySpecs, FlakySpec{SpecResultKey: key, History: leaf.History})
+		}
+	}
+
+	SortFlakySpecs(flakySpecs)
+
+	return flakySpecs
+}
+
+// LoadOwners sets the owners of every internal node in the tree that is within rootPath, usually the root of the
+// repo. Each node is owned by the approvers in the OWNERS file in its directory or, if there is none, the nearest parent
+// directory with one. Since the OWNERS files are read from the file system, this must be called while the repo used to
+// create the tree still exists.
+func (tree *SuiteTree) LoadOwners(rootPath string) error {
+	klog.V(100).Infof("Loading owners for tree with path %s from repo at %s", tree.Path, rootPath)
+
+	rootPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return err
+	}
+
+	return tree.loadOwners(rootPath, nil)
+}
+
+// Leaves returns an iterator over all of the leaf nodes in the tree, recursively. Each leaf node is yielded along with
+// the suite node containing it.
+func (tree *SuiteTree) Leaves() iter.Seq2[*SuiteTree, *SuiteTree] {
+	return func(yield func(*SuiteTree, *SuiteTree) bool) {
+		tree.yieldLeaves(yield)
+	}
+}
+
 // Sort sorts the children of the tree first by the number of specs and then by name. If descending is true, the
 // children are sorted in descending order by number of specs, but the name is still sorted alphabetically.
 func (tree *SuiteTree) Sort(descending bool) {
@@ -193,6 +282,18 @@ func (tree *SuiteTree) stringLevel(builder *strings.Builder, level uint) {
 	builder.WriteString(tree.Name)
 	builder.WriteByte(' ')
 	builder.WriteString(strconv.Itoa(tree.Specs))
+
+	switch {
+	case tree.Results == nil:
+	case tree.SpecReport != nil:
+		builder.WriteString(" [")
+		builder.WriteString(tree.Result.Outcome())
+		builder.WriteByte(']')
+	default:
+		builder.WriteByte(' ')
+		builder.WriteString(tree.Results.String())
+	}
+
 	builder.WriteByte('\n')
 
 	for _, child := range tree.Children {
@@ -200,6 +301,87 @@ func (tree *SuiteTree) stringLevel(builder *strings.Builder, level uint) {
 	}
 }
 
+// applyResults is a helper function to recursively attach results to the leaf nodes of the tree and sum them for all
+// internal nodes. It returns the sum of results for the receiver so the parent can include it in its own sum.
+func (tree *SuiteTree) applyResults(results SpecResultMap) RunResults {
+	sum := RunResults{}
+
+	for _, child := range tree.Children {
+		if child.SpecReport == nil {
+			sum.Add(child.applyResults(results))
+
+			continue
+		}
+
+		result, ok := results.Get(tree.Path, child.SpecReport.FullText())
+		if !ok {
+			klog.V(100).Infof("No result found for spec %s in suite %s", child.SpecReport.FullText(), tree.Path)
+
+			child.Result = nil
+		} else {
+			child.Result = &result
+		}
+
+		child.Results = &RunResults{}
+		child.Results.AddResult(child.Result)
+		sum.Add(*child.Results)
+	}
+
+	tree.Results = &sum
+
+	return sum
+}
+
+// loadOwners is a helper function to recursively set the owners of internal nodes, inheriting owners from the parent
+// node when no OWNERS file exists in the node's directory.
+func (tree *SuiteTree) loadOwners(rootPath string, inherited []string) error {
+	if tree.SpecReport != nil {
+		return nil
+	}
+
+	tree.Owners = inherited
+
+	if strings.HasPrefix(tree.Path, rootPath) {
+		owners, err := ReadOwnersFile(filepath.Join(tree.Path, ownersFileName))
+		if err != nil {
+			return err
+		}
+
+		if len(owners) > 0 {
+			tree.Owners = owners
+		}
+	}
+
+	for _, child := range tree.Children {
+		err := child.loadOwners(rootPath, tree.Owners)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// yieldLeaves is a helper function for Leaves that recursively yields the leaf nodes of the tree. It returns false if
+// iteration should stop.
+func (tree *SuiteTree) yieldLeaves(yield func(*SuiteTree, *SuiteTree) bool) bool {
+	for _, child := range tree.Children {
+		if child.SpecReport == nil {
+			if !child.yieldLeaves(yield) {
+				return false
+			}
+
+			continue
+		}
+
+		if !yield(tree, child) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // findChild returns the child with the given name or nil if no child with that name exists. It only searches direct
 // children of the tree.
 func (tree *SuiteTree) findChild(name string) *SuiteTree {
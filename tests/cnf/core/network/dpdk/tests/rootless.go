@@ -17,14 +17,15 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/scc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/dpdk/internal/link"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/dpdk/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/cmd"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/define"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/iplink"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/sriovoperator"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/testpmd"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,6 +62,8 @@ const (
 	intelVendorID                = "8086"
 	maxMulticastNoiseRate        = 5000
 	minimumExpectedDPDKRate      = 1000000
+	testPmdRateWindow            = 2
+	vfTestPmdPortID              = 0
 	customUserID                 = 2005
 	customGroupID                = 2005
 	dummyVlanID                  = 200
@@ -266,7 +269,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				sleepCMD,
 			)
 
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName,
+			err = rxTrafficWithRateOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName,
 				"${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICYONE}"))
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
@@ -279,7 +282,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					secondInterfaceBasedOnTapOne: maxMulticastNoiseRate},
 			)
 
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName,
+			err = rxTrafficWithRateOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName,
 				"${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICYONE}"))
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 			checkRxOutputRateForInterfaces(
@@ -356,7 +359,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 
 				By("Running client dpdk-testpmd")
 
-				err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+				err = rxTrafficWithRateOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
 				Expect(err).ToNot(HaveOccurred(),
 					"The Receive traffic test on the the client pod failed")
 
@@ -365,7 +368,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					clientPod, map[string]int{
 						tapOneInterfaceName:         minimumExpectedDPDKRate,
 						firstInterfaceBasedOnTapOne: minimumExpectedDPDKRate})
-				err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+				err = rxTrafficWithRateOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
 				Expect(err).ToNot(HaveOccurred(),
 					"The Receive traffic test on the the client pod failed")
 
@@ -426,7 +429,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				clientPod.Object.Annotations["k8s.v1.cni.cncf.io/network-status"])
 			Expect(err).ToNot(HaveOccurred(), "Fail to collect PCI addresses")
 
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+			err = rxTrafficWithRateOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -435,7 +438,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					firstInterfaceBasedOnTapOne:  minimumExpectedDPDKRate,
 					secondInterfaceBasedOnTapOne: maxMulticastNoiseRate,
 				})
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+			err = rxTrafficWithRateOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -538,7 +541,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				deploymentPod.Object.Annotations["k8s.v1.cni.cncf.io/network-status"])
 			Expect(err).ToNot(HaveOccurred(), "Fail to collect PCI addresses")
 
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+			err = rxTrafficWithRateOnClientPod(deploymentPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -547,7 +550,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					firstVlanInterfaceBasedOnTapOne: minimumExpectedDPDKRate,
 				})
 
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+			err = rxTrafficWithRateOnClientPod(deploymentPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -575,7 +578,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				deploymentPod.Object.Annotations["k8s.v1.cni.cncf.io/network-status"])
 			Expect(err).ToNot(HaveOccurred(), "Fail to collect PCI addresses")
 
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+			err = rxTrafficWithRateOnClientPod(deploymentPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
 			Expect(err).ToNot(HaveOccurred(),
 				"The Receive traffic test on the the client pod failed %s")
 
@@ -584,7 +587,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					tapOneInterfaceName:             minimumExpectedDPDKRate,
 					firstVlanInterfaceBasedOnTapOne: minimumExpectedDPDKRate,
 				})
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+			err = rxTrafficWithRateOnClientPod(deploymentPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -787,11 +790,32 @@ func definePodNetwork(podNetMapList []map[string]string) []*types.NetworkSelecti
 }
 
 func defineTestPmdCmd(interfaceName string, pciAddress string) string {
-	return fmt.Sprintf("timeout -s SIGKILL 20 dpdk-testpmd "+
+	return fmt.Sprintf("timeout -s SIGKILL 30 dpdk-testpmd "+
 		"--vdev=virtio_user0,path=/dev/vhost-net,queues=2,queue_size=1024,iface=%s "+
 		"-a %s -- --stats-period 5", interfaceName, pciAddress)
 }
 
+// rxTrafficWithRateOnClientPod runs dpdk-testpmd on the client pod and verifies the VF port, probed before the
+// virtio_user port, sustained the minimum receive rate without errors over the last stats periods.
+func rxTrafficWithRateOnClientPod(clientPod *pod.Builder, clientRxCmd string) error {
+	stats, err := cmd.TestPmdStatsOnClientPod(clientPod, clientRxCmd)
+	if err != nil {
+		return err
+	}
+
+	window, err := stats.Window(vfTestPmdPortID, testPmdRateWindow)
+	if err != nil {
+		return err
+	}
+
+	err = testpmd.MinRxRate(window, testpmd.MinimumExpectedRxMpps)
+	if err != nil {
+		return err
+	}
+
+	return testpmd.NoErrors(window)
+}
+
 func checkRxOutputRateForInterfaces(clientPod *pod.Builder, interfaceTrafficRateMap map[string]int) {
 	for interfaceName, TrafficRate := range interfaceTrafficRateMap {
 		comparator := ">"
@@ -809,7 +833,7 @@ func getLinkRx(runningPod *pod.Builder, linkName string) int {
 	linkRawInfo, err := runningPod.ExecCommand(
 		[]string{"/bin/bash", "-c", fmt.Sprintf("ip --json -s link show dev %s", linkName)})
 	Expect(err).ToNot(HaveOccurred(), "Failed to collect link info")
-	linkInfo, err := link.NewBuilder(linkRawInfo)
+	linkInfo, err := iplink.ParseSingle(linkRawInfo.Bytes())
 	Expect(err).ToNot(HaveOccurred(), "Failed to collect link info")
 
 	return linkInfo.GetRxByte()
@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"net"
-	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/ipaddr"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/testpmd"
 	"k8s.io/klog/v2"
 )
 
@@ -86,6 +86,22 @@ func GetSrIovPf(vfInterfaceName, namespace, nodeName string) (string, error) {
 
 // RxTrafficOnClientPod verifies the incoming packets on the dpdk client pod from the dpdk server.
 func RxTrafficOnClientPod(clientPod *pod.Builder, clientRxCmd string) error {
+	stats, err := TestPmdStatsOnClientPod(clientPod, clientRxCmd)
+	if err != nil {
+		return err
+	}
+
+	if !stats.ReceivedPackets() {
+		return fmt.Errorf("failed to find received packets in the dpdk-pmd output of client pod %s",
+			clientPod.Definition.Name)
+	}
+
+	return nil
+}
+
+// TestPmdStatsOnClientPod runs the dpdk-testpmd command, which is expected to be killed by timeout, on the dpdk client
+// pod and returns the port statistics it printed.
+func TestPmdStatsOnClientPod(clientPod *pod.Builder, clientRxCmd string) (*testpmd.Stats, error) {
 	timeoutError := "command terminated with exit code 137"
 
 	klog.V(90).Infof("Checking dpdk-pmd traffic command %s from the client pod %s",
@@ -93,71 +109,30 @@ func RxTrafficOnClientPod(clientPod *pod.Builder, clientRxCmd string) error {
 
 	err := clientPod.WaitUntilRunning(time.Minute)
 	if err != nil {
-		return fmt.Errorf("failed to wait until pod is running with error %w", err)
+		return nil, fmt.Errorf("failed to wait until pod is running with error %w", err)
 	}
 
 	clientOut, err := clientPod.ExecCommand([]string{"/bin/bash", "-c", clientRxCmd})
 
+	if err == nil {
+		return nil, fmt.Errorf("the dpdk-pmd command %s exited before the timeout on the client pod with output %s",
+			clientRxCmd, clientOut.String())
+	}
+
 	if err.Error() != timeoutError {
-		return fmt.Errorf("failed to run the dpdk-pmd command on the client pod %s with output %s and %w",
+		return nil, fmt.Errorf("failed to run the dpdk-pmd command on the client pod %s with output %s and %w",
 			clientRxCmd, clientOut.String(), err)
 	}
 
 	// Parsing output from the DPDK application
 	klog.V(90).Infof("Processing testpdm output from client pod \n%s", clientOut.String())
-	outPutTrue := checkRxOnly(clientOut.String())
-
-	if !outPutTrue {
-		return fmt.Errorf("failed to parse the output from RxTrafficOnClientPod")
-	}
-
-	return nil
-}
-
-// checkRxOnly checks the number of incoming packets.
-func checkRxOnly(out string) bool {
-	lines := strings.Split(out, "\n")
-	for index, line := range lines {
-		if strings.Contains(line, "NIC statistics for port") {
-			if len(lines[index+1]) < 3 {
-				klog.V(90).Info("Fail: line list contains less than 3 elements")
 
-				return false
-			}
-
-			if len(lines) > index && getNumberOfPackets(lines[index+1], "RX") > 0 {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
-// getNumberOfPackets counts the number of packets in the lines with RX output.
-func getNumberOfPackets(line, firstFieldSubstr string) int {
-	splitLine := strings.Fields(line)
-
-	if !strings.Contains(splitLine[0], firstFieldSubstr) {
-		klog.V(90).Infof("Failed to find expected substring %s", firstFieldSubstr)
-
-		return 0
-	}
-
-	if len(splitLine) != 6 {
-		klog.V(90).Info("the slice doesn't contain 6 elements")
-
-		return 0
-	}
-
-	numberOfPackets, err := strconv.Atoi(splitLine[1])
+	stats, err := testpmd.Parse(clientOut.String())
 	if err != nil {
-		klog.V(90).Infof("failed to convert string to integer %s", err)
-
-		return 0
+		return nil, fmt.Errorf("failed to parse the output from client pod %s: %w", clientPod.Definition.Name, err)
 	}
 
-	return numberOfPackets
+	return stats, nil
 }
 
 // ValidateTCPTraffic runs the testcmd with tcp and specified interface, port and destination.
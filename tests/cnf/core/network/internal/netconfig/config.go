@@ -4,15 +4,13 @@ import (
 	"fmt"
 	"log"
 	"net"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/internal/coreconfig"
-	"gopkg.in/yaml.v2"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 const (
@@ -63,16 +61,9 @@ func NewNetConfig() *NetworkConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultCnfCoreNetParamsFile)
 
-	err := readFile(&netConf, confFile)
+	err := config.Loader{Section: "network", DefaultFile: confFile, EnvPrefix: "ECO_CNF_CORE_NET_"}.Load(&netConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&netConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load NetworkConfig: %v", err)
 
 		return nil
 	}
@@ -267,32 +258,3 @@ func (netConfig *NetworkConfig) GetClusterVlan() (string, error) {
 
 	return netConfig.ClusterVlan, nil
 }
-
-func readFile(netConfig *NetworkConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&netConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(netConfig *NetworkConfig) error {
-	err := envconfig.Process("", netConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -3,6 +3,10 @@ package tsparams
 const (
 	// LabelSuite represents metallb label that can be used for test cases selection.
 	LabelSuite = "metallb"
+	// PrometheusQuerierSAName is the ServiceAccount name for Prometheus API access.
+	PrometheusQuerierSAName = "metallb-prometheus-querier"
+	// PrometheusQuerierCRBName is the ClusterRoleBinding name for Prometheus API access.
+	PrometheusQuerierCRBName = "metallb-prometheus-querier-crb"
 	// LabelBFDTestCases represents bfd label that can be used for test cases selection.
 	LabelBFDTestCases = "bfd"
 	// LabelBGPTestCases represents bgp label that can be used for test cases selection.
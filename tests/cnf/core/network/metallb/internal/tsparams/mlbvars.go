@@ -78,8 +78,6 @@ var (
 	MetalLbSpeakerLabel = map[string]string{"metal": "test"}
 	// PrometheusMonitoringLabel represents the label which tells prometheus to monitor a given object.
 	PrometheusMonitoringLabel = "openshift.io/cluster-monitoring"
-	// PrometheusMonitoringPodLabel represents monitoring pod label selector.
-	PrometheusMonitoringPodLabel = "app.kubernetes.io/name=prometheus"
 	// EBGPProtocol represents external bgp protocol name.
 	EBGPProtocol = "eBGP"
 	// IBPGPProtocol represents internal bgp protocol name.
@@ -111,12 +111,7 @@ var _ = Describe("BFD", Ordered, Label(tsparams.LabelBFDTestCases), ContinueOnFa
 
 			frrk8sPods := verifyAndCreateFRRk8sPodList()
 
-			prometheusPods, err := pod.List(APIClient, NetConfig.PrometheusOperatorNamespace, metav1.ListOptions{
-				LabelSelector: tsparams.PrometheusMonitoringPodLabel,
-			})
-			Expect(err).ToNot(HaveOccurred(), "Failed to list prometheus pods")
-
-			verifyMetricPresentInPrometheus(frrk8sPods, prometheusPods[0], "frrk8s_bfd_")
+			verifyMetricPresentInPrometheus(frrk8sPods, "frrk8s_bfd_")
 		})
 
 		It("Verify BGP over BFD session remains stable during service change", reportxml.ID("76013"), func() {
@@ -161,15 +161,7 @@ var _ = Describe("BGP", Ordered, Label(tsparams.LabelBGPTestCases), ContinueOnFa
 			_, err = testNs.WithLabel(tsparams.PrometheusMonitoringLabel, "true").Update()
 			Expect(err).ToNot(HaveOccurred())
 
-			By("Listing prometheus pods")
-
-			prometheusPods, err := pod.List(APIClient, NetConfig.PrometheusOperatorNamespace, metav1.ListOptions{
-				LabelSelector: tsparams.PrometheusMonitoringPodLabel,
-			})
-			Expect(err).ToNot(HaveOccurred(), "Failed to list prometheus pods")
-
-			verifyMetricPresentInPrometheus(
-				frrk8sPods, prometheusPods[0], "frrk8s_bgp_", tsparams.MetalLbBgpMetrics)
+			verifyMetricPresentInPrometheus(frrk8sPods, "frrk8s_bgp_", tsparams.MetalLbBgpMetrics)
 		})
 
 		DescribeTable("Verify external FRR BGP Peer cannot propagate routes to Speaker",
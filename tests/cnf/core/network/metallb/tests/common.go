@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	typesGomega "github.com/onsi/gomega/types"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/daemonset"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
@@ -30,8 +32,8 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/metallb/internal/frr"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/metallb/internal/metallbenv"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/metallb/internal/prometheus"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/metallb/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -587,8 +589,15 @@ func setupNGNXPodAndSCTPServer(podName, nodeName, labelValue string, sctpIPv6 ..
 	Expect(err).ToNot(HaveOccurred(), "Failed to create nginx test pod")
 }
 
-func verifyMetricPresentInPrometheus(
-	frrk8sPods []*pod.Builder, prometheusPod *pod.Builder, metricPrefix string, expectedMetrics ...[]string) {
+func verifyMetricPresentInPrometheus(frrk8sPods []*pod.Builder, metricPrefix string, expectedMetrics ...[]string) {
+	By("Creating Prometheus API client")
+
+	prometheusClient, err := prometheus.NewClient(APIClient,
+		prometheus.WithServiceAccount(tsparams.PrometheusQuerierSAName, tsparams.PrometheusQuerierCRBName))
+	Expect(err).ToNot(HaveOccurred(), "Failed to create Prometheus API client")
+
+	DeferCleanup(prometheusClient.Cleanup)
+
 	By("Verifying if metrics are present in Prometheus database")
 
 	for _, frrk8sPod := range frrk8sPods {
@@ -610,12 +619,43 @@ func verifyMetricPresentInPrometheus(
 		}
 
 		Eventually(
-			prometheus.PodMetricsPresentInDB, 5*time.Minute, tsparams.DefaultRetryInterval).WithArguments(
-			prometheusPod, frrk8sPod.Definition.Name, metricsFromSpeaker).Should(
+			podMetricsPresentInDB, 5*time.Minute, tsparams.DefaultRetryInterval).WithArguments(
+			prometheusClient, frrk8sPod.Definition.Name, metricsFromSpeaker).Should(
 			BeTrue(), "Failed to match metric in prometheus")
 	}
 }
 
+// podMetricsPresentInDB returns true if the given metrics are present for the given pod in the prometheus database.
+func podMetricsPresentInDB(
+	prometheusAPI prometheusv1.API, podName string, uniqueMetricKeys []string) (bool, error) {
+	for _, metricsKey := range uniqueMetricKeys {
+		vector, err := prometheus.QueryVector(context.TODO(), prometheusAPI, metricsKey, time.Time{})
+		if err != nil {
+			klog.V(90).Infof("Fail to collect metric %s: %v", metricsKey, err)
+
+			return false, err
+		}
+
+		if len(vector) < 1 {
+			return false, fmt.Errorf("failed to detect metric %s", metricsKey)
+		}
+
+		metricFound := false
+
+		for _, sample := range vector {
+			if strings.Contains(string(sample.Metric["pod"]), podName) {
+				metricFound = true
+			}
+		}
+
+		if !metricFound {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func metalLbDaemonSetShouldMatchConditionAndBeInReadyState(
 	expectedCondition typesGomega.GomegaMatcher, errorMessage string) {
 	metalLbDs, err := daemonset.Pull(APIClient, tsparams.MetalLbDsName, NetConfig.MlbOperatorNamespace)
@@ -1,14 +1,10 @@
 package sriovenv
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
-	nadV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nad"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
@@ -21,12 +17,9 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/sriovoperator"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ActivateSCTPModuleOnWorkerNodes loads the SCTP kernel module on worker nodes when possible.
@@ -48,111 +41,65 @@ func ValidateSriovInterfaces(workerNodeList []*nodes.Builder, requestedNumber in
 		return err
 	}
 
-	for _, worker := range workerNodeList {
-		availableUpSriovInterfaces, err := sriov.NewNetworkNodeStateBuilder(APIClient,
-			worker.Definition.Name, NetConfig.SriovOperatorNamespace).GetUpNICs()
-		if err != nil {
-			return fmt.Errorf("failed to get SR-IOV devices from node %s: %w", worker.Definition.Name, err)
-		}
-
-		var validCount int
-
-		for _, availableUpSriovInterface := range availableUpSriovInterfaces {
-			for _, requestedSriovInterface := range requestedSriovInterfaceList {
-				if availableUpSriovInterface.Name == requestedSriovInterface {
-					validCount++
-
-					break
-				}
-			}
-		}
+	workerNodeNames := make([]string, 0, len(workerNodeList))
 
-		if validCount < requestedNumber {
-			return fmt.Errorf("requested interfaces %v are not all present on node %s (found %d of %d)",
-				requestedSriovInterfaceList, worker.Definition.Name, validCount, requestedNumber)
-		}
+	for _, worker := range workerNodeList {
+		workerNodeNames = append(workerNodeNames, worker.Definition.Name)
 	}
 
-	return nil
+	return sriovoperator.ValidateInterfacesOnNodes(
+		APIClient, NetConfig.SriovOperatorNamespace, workerNodeNames, requestedSriovInterfaceList)
 }
 
 // CreateSriovNetworkAndWaitForNADCreation creates a SriovNetwork and waits for NAD Creation on the test namespace.
 func CreateSriovNetworkAndWaitForNADCreation(sNet *sriov.NetworkBuilder, timeout time.Duration) error {
-	klog.V(90).Infof("Creating SriovNetwork %s and waiting for net-attach-def to be created", sNet.Definition.Name)
-
-	sriovNetwork, err := sNet.Create()
-	if err != nil {
-		return err
-	}
-
-	return WaitForNADCreation(sriovNetwork.Object.Name, TargetNamespaceOf(sriovNetwork), timeout)
+	return sriovoperator.CreateSriovNetworkAndWaitForNADCreation(APIClient, sNet, timeout)
 }
 
 // WaitForNADCreation waits for the NAD to be created.
 func WaitForNADCreation(name, namespace string, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.TODO(),
-		time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-			_, err := nad.Pull(APIClient, name, namespace)
-			if err != nil {
-				klog.V(100).Infof("Failed to get NAD %s in namespace %s: %v",
-					name, namespace, err)
-
-				return false, nil
-			}
-
-			return true, nil
-		})
+	return sriovoperator.WaitForNADCreation(APIClient, name, namespace, timeout)
 }
 
 // WaitForNADDeletion waits for the NAD to be deleted.
 func WaitForNADDeletion(name, namespace string, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.TODO(),
-		time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-			var testNAD nadV1.NetworkAttachmentDefinition
-
-			err := APIClient.Client.Get(context.TODO(), k8sclient.ObjectKey{Name: name, Namespace: namespace}, &testNAD)
-			if k8serrors.IsNotFound(err) {
-				return true, nil
-			}
-
-			return false, nil
-		})
+	return sriovoperator.WaitForNADDeletion(APIClient, name, namespace, timeout)
 }
 
 // TargetNamespaceOf returns the target namespace of a SriovNetwork.
 // If the target namespace is not set, it returns the namespace of the SriovNetwork.
 func TargetNamespaceOf(sriovNetwork *sriov.NetworkBuilder) string {
-	if sriovNetwork.Object.Spec.NetworkNamespace != "" {
-		return sriovNetwork.Object.Spec.NetworkNamespace
-	}
-
-	return sriovNetwork.Object.Namespace
+	return sriovoperator.TargetNamespaceOf(sriovNetwork)
 }
 
 // DefineAndCreateSriovNetwork creates an enhanced SriovNetwork with optional features and waits for NAD creation.
 func DefineAndCreateSriovNetwork(networkName, resourceName string, withStaticIP, withTrust bool) error {
-	networkBuilder := sriov.NewNetworkBuilder(
-		APIClient, networkName, NetConfig.SriovOperatorNamespace,
-		tsparams.TestNamespaceName, resourceName).
-		WithMacAddressSupport().
-		WithLogLevel(netparam.LogLevelDebug)
+	options := []sriovoperator.NetworkOption{
+		sriovoperator.WithMacAddressSupport(), sriovoperator.WithLogLevel(netparam.LogLevelDebug)}
 
 	// Enable VF trust for advanced network operations (balance-tlb/alb.)
 	if withTrust {
-		networkBuilder = networkBuilder.WithTrustFlag(true)
+		options = append(options, sriovoperator.WithTrust(true))
 	}
 
 	if withStaticIP {
-		networkBuilder = networkBuilder.WithStaticIpam()
+		options = append(options, sriovoperator.WithStaticIPAM())
 	}
 
-	return CreateSriovNetworkAndWaitForNADCreation(networkBuilder, tsparams.NADWaitTimeout)
+	return CreateSriovNetworkAndWaitForNADCreation(newSriovNetwork(networkName, resourceName, options...),
+		tsparams.NADWaitTimeout)
+}
+
+// newSriovNetwork defines a SriovNetwork targeting the test namespace.
+func newSriovNetwork(name, resourceName string, options ...sriovoperator.NetworkOption) *sriov.NetworkBuilder {
+	return sriovoperator.NewNetwork(APIClient, name, NetConfig.SriovOperatorNamespace,
+		tsparams.TestNamespaceName, resourceName, options...)
 }
 
 // DiscoverInterfaceUnderTestDeviceID discovers device ID for a given SR-IOV interface.
 func DiscoverInterfaceUnderTestDeviceID(srIovInterfaceUnderTest, workerNodeName string) string {
-	sriovInterfaces, err := sriov.NewNetworkNodeStateBuilder(
-		APIClient, workerNodeName, NetConfig.SriovOperatorNamespace).GetUpNICs()
+	deviceID, err := sriovoperator.DiscoverInterfaceUnderTestDeviceID(
+		APIClient, NetConfig.SriovOperatorNamespace, srIovInterfaceUnderTest, workerNodeName)
 	if err != nil {
 		klog.V(90).Infof("Failed to discover device ID for network interface %s: %v",
 			srIovInterfaceUnderTest, err)
@@ -160,13 +107,7 @@ func DiscoverInterfaceUnderTestDeviceID(srIovInterfaceUnderTest, workerNodeName
 		return ""
 	}
 
-	for _, srIovInterface := range sriovInterfaces {
-		if srIovInterface.Name == srIovInterfaceUnderTest {
-			return srIovInterface.DeviceID
-		}
-	}
-
-	return ""
+	return deviceID
 }
 
 // createAndWaitTestPods creates test pods and waits until they are in the ready state.
@@ -212,11 +153,14 @@ func CreateAndWaitTestPodWithSecondaryNetwork(
 	testIPs []string) (*pod.Builder, error) {
 	klog.V(90).Infof("Creating a test pod name %s", podName)
 
-	secNetwork := pod.StaticIPAnnotationWithMacAddress(sriovResNameTest, testIPs, testMac)
-
-	testPod, err := pod.NewBuilder(APIClient, podName, tsparams.TestNamespaceName, NetConfig.CnfNetTestContainer).
-		DefineOnNode(testNodeName).WithPrivilegedFlag().
-		WithSecondaryNetwork(secNetwork).CreateAndWaitUntilRunning(netparam.DefaultTimeout)
+	testPod, err := sriovoperator.CreateTestPod(APIClient, tsparams.TestNamespaceName, NetConfig.CnfNetTestContainer,
+		sriovoperator.TestPodConfig{
+			Name:        podName,
+			NodeName:    testNodeName,
+			NetworkName: sriovResNameTest,
+			MacAddress:  testMac,
+			IPAddresses: testIPs,
+		}, netparam.DefaultTimeout)
 	if err != nil {
 		klog.V(90).Infof("Failed to create pod %s with secondary network", podName)
 
@@ -295,13 +239,11 @@ func DefinePod(name, role, ifName, worker string, secondaryInterface bool) *pod.
 func CreateSriovNetworkWithStaticIPAM(name, resourceName string) error {
 	klog.V(90).Infof("Creating SR-IOV network %s with static IPAM", name)
 
-	networkBuilder := sriov.NewNetworkBuilder(
-		APIClient, name, NetConfig.SriovOperatorNamespace,
-		tsparams.TestNamespaceName, resourceName).
-		WithStaticIpam().
-		WithIPAddressSupport().
-		WithMacAddressSupport().
-		WithLogLevel(netparam.LogLevelDebug)
+	networkBuilder := newSriovNetwork(name, resourceName,
+		sriovoperator.WithStaticIPAM(),
+		sriovoperator.WithIPAddressSupport(),
+		sriovoperator.WithMacAddressSupport(),
+		sriovoperator.WithLogLevel(netparam.LogLevelDebug))
 
 	return CreateSriovNetworkAndWaitForNADCreation(networkBuilder, tsparams.NADWaitTimeout)
 }
@@ -354,17 +296,13 @@ func CreateSriovNetworkWithWhereaboutsIPAM(
 	klog.V(90).Infof("Creating SR-IOV network %s with whereabouts IPAM, range %s, gateway %s",
 		name, ipRange, gateway)
 
-	networkBuilder := sriov.NewNetworkBuilder(
-		APIClient, name, NetConfig.SriovOperatorNamespace,
-		tsparams.TestNamespaceName, resourceName)
-
+	ipamOption := sriovoperator.WithWhereaboutsIPAM(ipRange, gateway, networkName)
 	if ipv6Range != "" {
-		networkBuilder.Definition.Spec.IPAM = whereaboutsDualStackIPAMJSON(ipRange, ipv6Range, networkName)
-	} else {
-		networkBuilder = networkBuilder.WithWhereaboutsIPAM(ipRange, gateway, "", networkName)
+		ipamOption = sriovoperator.WithIPAM(whereaboutsDualStackIPAMJSON(ipRange, ipv6Range, networkName))
 	}
 
-	return CreateSriovNetworkAndWaitForNADCreation(networkBuilder, tsparams.NADWaitTimeout)
+	return CreateSriovNetworkAndWaitForNADCreation(newSriovNetwork(name, resourceName, ipamOption),
+		tsparams.NADWaitTimeout)
 }
 
 // CreateSriovNetworkWithVLANAndWhereabouts creates an SR-IOV network with Whereabouts IPAM and VLAN tagging.
@@ -380,47 +318,13 @@ func CreateSriovNetworkWithVLANAndWhereabouts(
 	klog.V(90).Infof("Creating SR-IOV network %s with Whereabouts IPAM, VLAN %d, range %s",
 		name, vlanID, ipRange)
 
-	networkBuilder := sriov.NewNetworkBuilder(
-		APIClient, name, NetConfig.SriovOperatorNamespace,
-		tsparams.TestNamespaceName, resourceName).WithVLAN(vlanID)
-
+	ipamOption := sriovoperator.WithWhereaboutsIPAM(ipRange, gateway, "")
 	if ipv6Range != "" {
-		networkBuilder.Definition.Spec.IPAM = whereaboutsDualStackIPAMJSON(ipRange, ipv6Range, "")
-	} else {
-		networkBuilder = networkBuilder.WithWhereaboutsIPAM(ipRange, gateway, "", "")
+		ipamOption = sriovoperator.WithIPAM(whereaboutsDualStackIPAMJSON(ipRange, ipv6Range, ""))
 	}
 
-	return CreateSriovNetworkAndWaitForNADCreation(networkBuilder, tsparams.NADWaitTimeout)
-}
-
-// getInterfaceIPs returns all IPs assigned to an interface from the pod's network-status annotation.
-func getInterfaceIPs(podBuilder *pod.Builder, interfaceName string) ([]string, error) {
-	podObj, err := pod.Pull(APIClient, podBuilder.Definition.Name, podBuilder.Definition.Namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to pull pod %s: %w", podBuilder.Definition.Name, err)
-	}
-
-	annotation := podObj.Object.Annotations["k8s.v1.cni.cncf.io/network-status"]
-	if annotation == "" {
-		return nil, fmt.Errorf("no network-status annotation on pod %s", podBuilder.Definition.Name)
-	}
-
-	var statuses []struct {
-		Interface string   `json:"interface"`
-		IPs       []string `json:"ips"`
-	}
-
-	if err := json.Unmarshal([]byte(annotation), &statuses); err != nil {
-		return nil, fmt.Errorf("failed to parse network-status annotation: %w", err)
-	}
-
-	for _, status := range statuses {
-		if status.Interface == interfaceName {
-			return status.IPs, nil
-		}
-	}
-
-	return nil, fmt.Errorf("interface %s not found in network-status annotation", interfaceName)
+	return CreateSriovNetworkAndWaitForNADCreation(
+		newSriovNetwork(name, resourceName, sriovoperator.WithVLAN(vlanID), ipamOption), tsparams.NADWaitTimeout)
 }
 
 // GetPodIPFromInterface retrieves an IP address of a specific interface from a pod's network-status annotation.
@@ -428,26 +332,19 @@ func getInterfaceIPs(podBuilder *pod.Builder, interfaceName string) ([]string, e
 func GetPodIPFromInterface(podBuilder *pod.Builder, interfaceName, ipFamily string) (string, error) {
 	klog.V(90).Infof("Getting %s from interface %s on pod %s", ipFamily, interfaceName, podBuilder.Definition.Name)
 
-	ips, err := getInterfaceIPs(podBuilder, interfaceName)
+	networkStatus, err := sriovoperator.InterfaceNetworkStatus(
+		APIClient, podBuilder.Definition.Name, podBuilder.Definition.Namespace, interfaceName)
 	if err != nil {
 		return "", err
 	}
 
-	for _, ip := range ips {
-		ipClean := strings.Split(ip, "/")[0]
-		isIPv6 := strings.Contains(ipClean, ":")
-
-		if ipFamily == "ipv4" && !isIPv6 {
-			return ipClean, nil
-		}
-
-		// Skip link-local addresses (fe80::) for IPv6 - return only global/ULA addresses.
-		if ipFamily == "ipv6" && isIPv6 && !strings.HasPrefix(strings.ToLower(ipClean), "fe80") {
-			return ipClean, nil
-		}
+	// Link-local IPv6 addresses (fe80::) are skipped, only global/ULA addresses are returned.
+	familyIPs := sriovoperator.IPsOfFamily(networkStatus.IPs, ipFamily)
+	if len(familyIPs) == 0 {
+		return "", fmt.Errorf("no %s found for interface %s in network-status annotation", ipFamily, interfaceName)
 	}
 
-	return "", fmt.Errorf("no %s found for interface %s in network-status annotation", ipFamily, interfaceName)
+	return familyIPs[0], nil
 }
 
 // CreatePodPair creates a client and server pod pair for traffic testing.
@@ -467,15 +364,17 @@ func CreatePodPair(
 ) (*pod.Builder, *pod.Builder, error) {
 	klog.V(90).Infof("Creating client pod %s and server pod %s", clientName, serverName)
 
-	client, err := CreateTestClientPod(clientName, clientNode, clientNetwork, clientMAC, clientIPs)
+	client, server, err := sriovoperator.CreateTestPodPair(
+		APIClient, tsparams.TestNamespaceName, NetConfig.CnfNetTestContainer,
+		testClientPodConfig(clientName, clientNode, clientNetwork, clientMAC, clientIPs),
+		testServerPodConfig(serverName, serverNode, serverNetwork, serverBindIP, serverMAC, serverIPs, mtu),
+		netparam.DefaultTimeout)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create client pod: %w", err)
+		return nil, nil, err
 	}
 
-	server, err := CreateTestServerPod(
-		serverName, serverNode, serverNetwork, serverBindIP, serverMAC, serverIPs, mtu)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create server pod: %w", err)
+	if err := WaitForServerReady(server, tsparams.WaitTimeout); err != nil {
+		return nil, nil, fmt.Errorf("server pod %s not ready: %w", serverName, err)
 	}
 
 	return client, server, nil
@@ -562,16 +461,16 @@ func CreateSriovPolicy(
 
 	const totalVFs = 10
 
-	_, err := sriov.NewPolicyBuilder(
+	_, err := sriovoperator.NewPolicy(
 		APIClient,
 		name,
 		NetConfig.SriovOperatorNamespace,
 		resourceName,
 		totalVFs,
 		[]string{pfName},
-		NetConfig.WorkerLabelMap).
-		WithMTU(mtu).
-		WithVFRange(vfStart, vfEnd).
+		NetConfig.WorkerLabelMap,
+		sriovoperator.WithMTU(mtu),
+		sriovoperator.WithVFRange(vfStart, vfEnd)).
 		Create()
 
 	return err
@@ -587,34 +486,22 @@ func CreateTestClientPod(
 ) (*pod.Builder, error) {
 	klog.V(90).Infof("Creating client pod %s on node %s", name, nodeName)
 
-	secNetwork := []*types.NetworkSelectionElement{{Name: networkName}}
-
-	if macAddress != "" {
-		secNetwork[0].MacRequest = macAddress
-	}
-
-	if len(ipAddresses) > 0 {
-		secNetwork[0].IPRequest = ipAddresses
-	}
-
-	command := []string{"bash", "-c", "sleep infinity"}
-
-	container, err := pod.NewContainerBuilder("test", NetConfig.CnfNetTestContainer, command).GetContainerCfg()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create container config: %w", err)
-	}
+	return sriovoperator.CreateTestPod(APIClient, tsparams.TestNamespaceName, NetConfig.CnfNetTestContainer,
+		testClientPodConfig(name, nodeName, networkName, macAddress, ipAddresses), netparam.DefaultTimeout)
+}
 
-	podBuilder, err := pod.NewBuilder(APIClient, name, tsparams.TestNamespaceName, NetConfig.CnfNetTestContainer).
-		DefineOnNode(nodeName).
-		RedefineDefaultContainer(*container).
-		WithPrivilegedFlag().
-		WithSecondaryNetwork(secNetwork).
-		CreateAndWaitUntilRunning(netparam.DefaultTimeout)
-	if err != nil {
-		return nil, err
+// testClientPodConfig returns the configuration of a client pod idling on the SR-IOV network.
+func testClientPodConfig(
+	name, nodeName, networkName, macAddress string, ipAddresses []string) sriovoperator.TestPodConfig {
+	return sriovoperator.TestPodConfig{
+		Name:          name,
+		NodeName:      nodeName,
+		NetworkName:   networkName,
+		MacAddress:    macAddress,
+		IPAddresses:   ipAddresses,
+		ContainerName: "test",
+		Command:       []string{"bash", "-c", "sleep infinity"},
 	}
-
-	return podBuilder, nil
 }
 
 // CreateTestServerPod creates a server pod with testcmd listeners for TCP, UDP, SCTP, and multicast.
@@ -629,29 +516,9 @@ func CreateTestServerPod(
 ) (*pod.Builder, error) {
 	klog.V(90).Infof("Creating server pod %s on node %s", name, nodeName)
 
-	secNetwork := []*types.NetworkSelectionElement{{Name: networkName}}
-
-	if macAddress != "" {
-		secNetwork[0].MacRequest = macAddress
-	}
-
-	if len(ipAddresses) > 0 {
-		secNetwork[0].IPRequest = ipAddresses
-	}
-
-	command := BuildServerCommand(serverBindIP, tsparams.Net1Interface, mtu)
-
-	container, err := pod.NewContainerBuilder("server", NetConfig.CnfNetTestContainer, command).GetContainerCfg()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create container config: %w", err)
-	}
-
-	serverPod, err := pod.NewBuilder(APIClient, name, tsparams.TestNamespaceName, NetConfig.CnfNetTestContainer).
-		DefineOnNode(nodeName).
-		RedefineDefaultContainer(*container).
-		WithPrivilegedFlag().
-		WithSecondaryNetwork(secNetwork).
-		CreateAndWaitUntilRunning(netparam.DefaultTimeout)
+	serverPod, err := sriovoperator.CreateTestPod(APIClient, tsparams.TestNamespaceName, NetConfig.CnfNetTestContainer,
+		testServerPodConfig(name, nodeName, networkName, serverBindIP, macAddress, ipAddresses, mtu),
+		netparam.DefaultTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -664,30 +531,26 @@ func CreateTestServerPod(
 	return serverPod, nil
 }
 
+// testServerPodConfig returns the configuration of a server pod running testcmd listeners on the SR-IOV network.
+func testServerPodConfig(
+	name, nodeName, networkName, serverBindIP, macAddress string, ipAddresses []string, mtu int,
+) sriovoperator.TestPodConfig {
+	return sriovoperator.TestPodConfig{
+		Name:          name,
+		NodeName:      nodeName,
+		NetworkName:   networkName,
+		MacAddress:    macAddress,
+		IPAddresses:   ipAddresses,
+		ContainerName: "server",
+		Command:       BuildServerCommand(serverBindIP, tsparams.Net1Interface, mtu),
+	}
+}
+
 // WaitForServerReady waits for the server pod's testcmd listeners to be ready.
 func WaitForServerReady(serverPod *pod.Builder, timeout time.Duration) error {
 	klog.V(90).Infof("Waiting for server pod %s to be ready", serverPod.Definition.Name)
 
-	err := wait.PollUntilContextTimeout(
-		context.TODO(),
-		tsparams.RetryInterval,
-		timeout,
-		true,
-		func(ctx context.Context) (bool, error) {
-			_, execErr := serverPod.ExecCommand([]string{"bash", "-c", "pgrep -f testcmd"})
-			if execErr != nil {
-				klog.V(90).Infof("testcmd not ready on pod %s: %v", serverPod.Definition.Name, execErr)
-
-				return false, nil
-			}
-
-			return true, nil
-		})
-	if err != nil {
-		return fmt.Errorf("testcmd listeners not ready on pod %s: %w", serverPod.Definition.Name, err)
-	}
-
-	return nil
+	return sriovoperator.WaitForTestcmdListeners(serverPod, tsparams.RetryInterval, timeout)
 }
 
 // BuildServerCommand builds the command to start testcmd listeners on the server pod.
@@ -697,15 +560,11 @@ func BuildServerCommand(serverBindIP, interfaceName string, mtu int) []string {
 	klog.V(90).Infof("Building server command for interface %s with MTU %d, serverBindIP=%q",
 		interfaceName, mtu, serverBindIP)
 
-	// Subtract header overhead to fit within MTU.
-	// Accounts for IP headers, protocol headers, and testcmd overhead.
-	packetSize := mtu - 100
-
 	if serverBindIP == "" {
-		return buildDynamicIPServerCommand(interfaceName, mtu, packetSize)
+		return buildDynamicIPServerCommand(interfaceName, mtu)
 	}
 
-	return buildStaticIPServerCommand(serverBindIP, interfaceName, mtu, packetSize)
+	return buildStaticIPServerCommand(serverBindIP, interfaceName, mtu)
 }
 
 // getIPv4MulticastConfig returns the IPv4 multicast group and MAC address based on MTU.
@@ -717,21 +576,6 @@ func getIPv4MulticastConfig(mtu int) (group, mac string) {
 	return tsparams.MulticastIPv4Group, tsparams.MulticastIPv4MAC
 }
 
-// buildTestcmdListeners returns the shell commands to start all testcmd listeners.
-// serverIP and mcastGroup can be shell variables (e.g., $SERVER_IP, $MCAST_GROUP) or literal values.
-func buildTestcmdListeners(interfaceName, serverIP, mcastGroup string, packetSize int) string {
-	return fmt.Sprintf(
-		"testcmd -listen -protocol tcp -port 5001 -interface %s -mtu %d & "+
-			"testcmd -listen -protocol udp -port 5002 -interface %s -mtu %d & "+
-			"testcmd -listen -protocol sctp -port 5003 -interface %s -server %s -mtu %d & "+
-			"testcmd -listen -multicast -protocol udp -port 5004 -interface %s -server %s -mtu %d & "+
-			"sleep infinity",
-		interfaceName, packetSize,
-		interfaceName, packetSize,
-		interfaceName, serverIP, packetSize,
-		interfaceName, mcastGroup, packetSize)
-}
-
 // buildMulticastSetup returns the shell command to configure multicast and the multicast group address.
 // For IPv6, it also adds a route to the local table for the multicast group.
 func buildMulticastSetup(isIPv6 bool, interfaceName string, mtu int) (setupCmd, multicastGroup string) {
@@ -758,7 +602,7 @@ func BuildMulticastSetup(isIPv6 bool, interfaceName string, mtu int) (setupCmd,
 
 // buildDynamicIPServerCommand builds the server command for Whereabouts IPAM
 // where the IP is discovered at runtime inside the pod.
-func buildDynamicIPServerCommand(interfaceName string, mtu, packetSize int) []string {
+func buildDynamicIPServerCommand(interfaceName string, mtu int) []string {
 	// Step 1: Discover server IP (try IPv4 first, then IPv6).
 	discoverIP := fmt.Sprintf(
 		"for _ in $(seq 1 10); do "+
@@ -785,17 +629,17 @@ func buildDynamicIPServerCommand(interfaceName string, mtu, packetSize int) []st
 		ipv4Group, ipv4Setup)
 
 	// Step 3: Start listeners using shell variables set above.
-	listeners := buildTestcmdListeners(interfaceName, "$SERVER_IP", "$MCAST_GROUP", packetSize)
+	listeners := sriovoperator.TestcmdListenersCommand(interfaceName, "$SERVER_IP", "$MCAST_GROUP", mtu)
 
 	return []string{"bash", "-c", discoverIP + setupMulticast + listeners}
 }
 
 // buildStaticIPServerCommand builds the server command when the IP is known at pod creation time.
-func buildStaticIPServerCommand(serverBindIP, interfaceName string, mtu, packetSize int) []string {
+func buildStaticIPServerCommand(serverBindIP, interfaceName string, mtu int) []string {
 	isIPv6 := strings.Contains(serverBindIP, ":")
 	multicastSetup, multicastGroup := buildMulticastSetup(isIPv6, interfaceName, mtu)
 
-	listeners := buildTestcmdListeners(interfaceName, serverBindIP, multicastGroup, packetSize)
+	listeners := sriovoperator.TestcmdListenersCommand(interfaceName, serverBindIP, multicastGroup, mtu)
 
 	return []string{"bash", "-c", multicastSetup + "sleep 5; " + listeners}
 }
@@ -854,40 +698,6 @@ func RunTrafficTest(clientPod *pod.Builder, serverIP string, mtu int) error {
 	klog.V(90).Infof("Running traffic tests against %s with MTU %d", serverIP, mtu)
 	serverIPAddress := ipaddr.RemovePrefix(serverIP)
 
-	// Subtract header overhead to fit within MTU.
-	// Accounts for IP headers, protocol headers, and testcmd overhead.
-	packetSize := mtu - 100
-
-	var failedProtocols []string
-
-	serverIPWithPrefix := serverIPAddress + "/32"
-	if strings.Contains(serverIPAddress, ":") {
-		serverIPWithPrefix = serverIPAddress + "/128"
-	}
-
-	if err := cmd.ICMPConnectivityCheck(
-		clientPod, []string{serverIPWithPrefix}, tsparams.Net1Interface); err != nil {
-		failedProtocols = append(failedProtocols, fmt.Sprintf("ICMP: %v", err))
-	}
-
-	if err := RunProtocolTest(clientPod, "TCP",
-		fmt.Sprintf("testcmd -protocol tcp -port 5001 -interface %s -server %s -mtu %d",
-			tsparams.Net1Interface, serverIPAddress, packetSize)); err != nil {
-		failedProtocols = append(failedProtocols, fmt.Sprintf("TCP: %v", err))
-	}
-
-	if err := RunProtocolTest(clientPod, "UDP",
-		fmt.Sprintf("testcmd -protocol udp -port 5002 -interface %s -server %s -mtu %d",
-			tsparams.Net1Interface, serverIPAddress, packetSize)); err != nil {
-		failedProtocols = append(failedProtocols, fmt.Sprintf("UDP: %v", err))
-	}
-
-	if err := RunProtocolTest(clientPod, "SCTP",
-		fmt.Sprintf("testcmd -protocol sctp -port 5003 -interface %s -server %s -mtu %d",
-			tsparams.Net1Interface, serverIPAddress, packetSize)); err != nil {
-		failedProtocols = append(failedProtocols, fmt.Sprintf("SCTP: %v", err))
-	}
-
 	multicastGroup := tsparams.MulticastIPv4Group
 	if strings.Contains(serverIPAddress, ":") {
 		multicastGroup = tsparams.MulticastIPv6Group
@@ -895,27 +705,13 @@ func RunTrafficTest(clientPod *pod.Builder, serverIP string, mtu int) error {
 		multicastGroup = tsparams.MulticastIPv4GroupLargeMTU
 	}
 
-	if err := RunProtocolTest(clientPod, "multicast",
-		fmt.Sprintf("testcmd -multicast -protocol udp -port 5004 -interface %s -server %s -mtu %d",
-			tsparams.Net1Interface, multicastGroup, packetSize)); err != nil {
-		failedProtocols = append(failedProtocols, fmt.Sprintf("multicast: %v", err))
-	}
-
-	if len(failedProtocols) > 0 {
-		return fmt.Errorf("traffic tests failed: %s", strings.Join(failedProtocols, "; "))
-	}
+	checks := append([]sriovoperator.TrafficCheck{sriovoperator.ICMPCheck(tsparams.Net1Interface, serverIPAddress)},
+		sriovoperator.TestcmdChecks(tsparams.Net1Interface, serverIPAddress, multicastGroup, mtu)...)
 
-	return nil
+	return sriovoperator.RunTrafficChecks(clientPod, checks)
 }
 
 // RunProtocolTest executes a protocol-specific connectivity test command.
 func RunProtocolTest(clientPod *pod.Builder, protocol, cmdStr string) error {
-	klog.V(90).Infof("Running %s connectivity test", protocol)
-
-	output, err := clientPod.ExecCommand([]string{"bash", "-c", cmdStr})
-	if err != nil {
-		return fmt.Errorf("%s connectivity check failed (output: %s): %w", protocol, output.String(), err)
-	}
-
-	return nil
+	return sriovoperator.RunProtocolTest(clientPod, protocol, cmdStr)
 }
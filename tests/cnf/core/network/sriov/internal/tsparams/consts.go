@@ -3,6 +3,10 @@ package tsparams
 const (
 	// LabelSuite represents sriov label that can be used for test cases selection.
 	LabelSuite = "sriov"
+	// PrometheusQuerierSAName is the ServiceAccount name for Prometheus API access.
+	PrometheusQuerierSAName = "sriov-prometheus-querier"
+	// PrometheusQuerierCRBName is the ClusterRoleBinding name for Prometheus API access.
+	PrometheusQuerierCRBName = "sriov-prometheus-querier-crb"
 	// TestNamespaceName sriov namespace where all test cases are performed.
 	TestNamespaceName = "sriov-tests"
 	// TestNamespaceName1 sriov namespace where all test cases are performed.
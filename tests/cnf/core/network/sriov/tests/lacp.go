@@ -25,6 +25,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/sriovenv"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/iplink"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/perfprofile"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/sriovoperator"
 	corev1 "k8s.io/api/core/v1"
@@ -1934,83 +1935,77 @@ func setVFsStateOnNode(nodeName, interfaceName string, vfIDs []int, state string
 func verifyVFsStateOnNode(nodeName, interfaceName string, vfIDs []int, expectedState string) error {
 	By(fmt.Sprintf("Verifying VF states are %s on interface %s for node %s", expectedState, interfaceName, nodeName))
 
-	nodeSelector := metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("kubernetes.io/hostname=%s", nodeName),
+	pfLink, err := getInterfaceLinkOnNode(nodeName, interfaceName)
+	if err != nil {
+		return err
 	}
 
-	command := fmt.Sprintf("ip link show %s", interfaceName)
+	for _, vfID := range vfIDs {
+		vfInfo, err := pfLink.VF(vfID)
+		if err != nil {
+			return err
+		}
 
-	outputs, err := cluster.ExecCmdWithStdout(APIClient, command, nodeSelector)
-	if err != nil {
-		return fmt.Errorf("failed to get interface %s information: %w", interfaceName, err)
-	}
+		if vfInfo.LinkState != expectedState {
+			return fmt.Errorf("VF %d is in %s state instead of %s on interface %s",
+				vfID, vfInfo.LinkState, expectedState, interfaceName)
+		}
 
-	output, exists := outputs[nodeName]
-	if !exists {
-		return fmt.Errorf("no output received from node %s for interface %s", nodeName, interfaceName)
+		By(fmt.Sprintf("VF %d is in %s state", vfID, expectedState))
 	}
 
-	for _, vfID := range vfIDs {
-		vfPattern := fmt.Sprintf("vf %d", vfID)
-		statePattern := fmt.Sprintf("state %s", expectedState)
+	return nil
+}
 
-		lines := strings.Split(output, "\n")
-		found := false
+func verifyVFsStateOnInterface(nodeName, interfaceName, expectedState string) error {
+	By(fmt.Sprintf("Verifying all VFs are in %s state on interface %s for node %s",
+		expectedState, interfaceName, nodeName))
 
-		for _, line := range lines {
-			if strings.Contains(line, vfPattern) && strings.Contains(line, statePattern) {
-				found = true
+	pfLink, err := getInterfaceLinkOnNode(nodeName, interfaceName)
+	if err != nil {
+		return err
+	}
 
-				By(fmt.Sprintf("VF %d is in %s state", vfID, expectedState))
+	vfCount := 0
 
-				break
-			}
+	for _, vfInfo := range pfLink.VFInfoList {
+		if vfInfo.LinkState == expectedState {
+			vfCount++
 		}
+	}
 
-		if !found {
-			return fmt.Errorf("VF %d is not in %s state on interface %s", vfID, expectedState, interfaceName)
-		}
+	if vfCount < expectedVFCount {
+		return fmt.Errorf("expected at least %d VFs in %s state, found %d on interface %s", expectedVFCount,
+			expectedState, vfCount, interfaceName)
 	}
 
 	return nil
 }
 
-func verifyVFsStateOnInterface(nodeName, interfaceName, expectedState string) error {
-	By(fmt.Sprintf("Verifying all VFs are in %s state on interface %s for node %s",
-		expectedState, interfaceName, nodeName))
-
+// getInterfaceLinkOnNode returns the ip link details of the interface on the node, including its VFs.
+func getInterfaceLinkOnNode(nodeName, interfaceName string) (*iplink.Link, error) {
 	nodeSelector := metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("kubernetes.io/hostname=%s", nodeName),
 	}
 
-	command := fmt.Sprintf("ip link show %s", interfaceName)
+	command := fmt.Sprintf("ip -j -d link show %s", interfaceName)
 
 	outputs, err := cluster.ExecCmdWithStdout(APIClient, command, nodeSelector)
 	if err != nil {
-		return fmt.Errorf("failed to get interface %s information: %w", interfaceName, err)
+		return nil, fmt.Errorf("failed to get interface %s information: %w", interfaceName, err)
 	}
 
 	output, exists := outputs[nodeName]
 	if !exists {
-		return fmt.Errorf("no output received from node %s for interface %s", nodeName, interfaceName)
+		return nil, fmt.Errorf("no output received from node %s for interface %s", nodeName, interfaceName)
 	}
 
-	lines := strings.Split(output, "\n")
-	statePattern := fmt.Sprintf("state %s", expectedState)
-	vfCount := 0
-
-	for _, line := range lines {
-		if strings.Contains(line, "vf ") && strings.Contains(line, statePattern) {
-			vfCount++
-		}
-	}
-
-	if vfCount < expectedVFCount {
-		return fmt.Errorf("expected at least %d VFs in %s state, found %d on interface %s", expectedVFCount,
-			expectedState, vfCount, interfaceName)
+	interfaceLink, err := iplink.ParseSingle([]byte(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse interface %s information on node %s: %w", interfaceName, nodeName, err)
 	}
 
-	return nil
+	return interfaceLink, nil
 }
 
 func verifyLACPPortState(nodeName, bondInterface, expectedState string) error {
@@ -2110,24 +2105,13 @@ func parseLACPPortStates(bondingOutput, bondInterface string) (string, string, e
 func verifyInterfaceIsUp(nodeName, interfaceName string) error {
 	By(fmt.Sprintf("Verifying interface %s is UP on node %s", interfaceName, nodeName))
 
-	nodeSelector := metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("kubernetes.io/hostname=%s", nodeName),
-	}
-
-	command := fmt.Sprintf("ip link show %s", interfaceName)
-
-	outputs, err := cluster.ExecCmdWithStdout(APIClient, command, nodeSelector)
+	interfaceLink, err := getInterfaceLinkOnNode(nodeName, interfaceName)
 	if err != nil {
-		return fmt.Errorf("failed to get interface %s status: %w", interfaceName, err)
-	}
-
-	output, exists := outputs[nodeName]
-	if !exists {
-		return fmt.Errorf("no output received from node %s for interface %s", nodeName, interfaceName)
+		return err
 	}
 
-	if !strings.Contains(output, "UP") {
-		return fmt.Errorf("interface %s is not UP on node %s. Output: %s", interfaceName, nodeName, output)
+	if !interfaceLink.HasFlag("UP") {
+		return fmt.Errorf("interface %s is not UP on node %s. Flags: %v", interfaceName, nodeName, interfaceLink.Flags)
 	}
 
 	return nil
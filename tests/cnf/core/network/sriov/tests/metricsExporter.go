@@ -1,9 +1,8 @@
 package tests
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +24,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/sriovenv"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/perfprofile"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,12 +37,12 @@ type testResource struct {
 	pod     *pod.Builder
 }
 
-var serverPodRXPromQL = []string{"bash", "-c", "promtool query instant -o json " +
-	"http://localhost:9090 \"sum(sriov_vf_rx_packets * on(pciAddr) group_left(pod) " +
-	"sriov_kubepoddevice{pod=\\\"serverpod\\\"}) by (pod)\""}
-var serverPodTXPromQL = []string{"bash", "-c", "promtool query instant -o json " +
-	"http://localhost:9090 \"sum(sriov_vf_tx_packets * on(pciAddr) group_left(pod) " +
-	"sriov_kubepoddevice{pod=\\\"serverpod\\\"}) by (pod)\""}
+const (
+	serverPodRXPromQL = `sum(sriov_vf_rx_packets * on(pciAddr) group_left(pod) ` +
+		`sriov_kubepoddevice{pod="serverpod"}) by (pod)`
+	serverPodTXPromQL = `sum(sriov_vf_tx_packets * on(pciAddr) group_left(pod) ` +
+		`sriov_kubepoddevice{pod="serverpod"}) by (pod)`
+)
 
 var _ = Describe(
 	"SriovMetricsExporter", Ordered, Label(tsparams.LabelSriovMetricsTestCases, tsparams.LabelSriovHWEnabled),
@@ -483,56 +483,31 @@ func createTestResources(cRes, sRes testResource) (*pod.Builder, *pod.Builder) {
 }
 
 func checkMetricsWithPromQL() {
-	By("Wait until promQL gives serverpod metrics")
-	Eventually(func() bool {
-		return strings.Contains(execPromQLandReturnString(serverPodRXPromQL), "serverpod")
-	},
-		130*time.Second, 30*time.Second).Should(BeTrue(), "PromQL output does not contain server pod metrics")
-
-	By("Verify RX and TX packets counters are > 0")
-	Eventually(func() int { return fetchScalarFromPromQLoutput(execPromQLandReturnString(serverPodRXPromQL)) },
-		2*time.Minute, 30*time.Second).Should(BeNumerically(">", 0), "RX counters are zero")
-	Eventually(func() int { return fetchScalarFromPromQLoutput(execPromQLandReturnString(serverPodTXPromQL)) },
-		2*time.Minute, 30*time.Second).Should(BeNumerically(">", 0), "TX counters are zero")
-}
-
-func execPromQLandReturnString(query []string) string {
-	By("Running PromQL to fetch metrics of serverpod")
-
-	promPods, err := pod.List(APIClient,
-		NetConfig.PrometheusOperatorNamespace, metav1.ListOptions{LabelSelector: "prometheus=k8s"})
-	Expect(err).ToNot(HaveOccurred(), "Failed to get prometheus pods")
+	By("Creating Prometheus API client")
 
-	By(fmt.Sprintf("Running PromQL query: %s", query))
-	output, err := promPods[0].ExecCommand(query, "prometheus")
-	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf(
-		"Failed to get promQL output from prometheus pod. Output: %s", output.String()))
-
-	By(fmt.Sprintf("Received PromQL output: %s", output.String()))
+	prometheusClient, err := prometheus.NewClient(APIClient,
+		prometheus.WithServiceAccount(tsparams.PrometheusQuerierSAName, tsparams.PrometheusQuerierCRBName))
+	Expect(err).ToNot(HaveOccurred(), "Failed to create Prometheus API client")
 
-	return output.String()
-}
+	DeferCleanup(prometheusClient.Cleanup)
 
-func fetchScalarFromPromQLoutput(res string) int {
-	type podMetricPromQLoutput []struct {
-		Metric struct {
-			Pod string `json:"pod,omitempty"`
-		}
-		Value []interface{} `json:"value,omitempty"`
-	}
+	By("Wait until promQL gives serverpod metrics")
 
-	By("Fetch the final value from the PromQL output")
+	err = prometheus.AssertQuery(context.TODO(), prometheusClient, serverPodRXPromQL, prometheus.NotEmpty(),
+		prometheus.AssertWithTimeout(130*time.Second), prometheus.AssertWithPollInterval(30*time.Second))
+	Expect(err).ToNot(HaveOccurred(), "PromQL output does not contain server pod metrics")
 
-	var outValue podMetricPromQLoutput
+	By("Verify RX and TX packets counters are > 0")
 
-	err := json.Unmarshal([]byte(res), &outValue)
-	Expect(err).ToNot(HaveOccurred(), "Failed to Unmarshal promQL output from prometheus pod")
+	greaterThanZero := prometheus.AllMatch("greater than 0", func(value float64) bool { return value > 0 })
 
-	//nolint:forcetypeassert
-	finalVal, err := strconv.Atoi(outValue[0].Value[1].(string))
-	Expect(err).To(Not(HaveOccurred()), "Failed to convert counter value to int")
+	err = prometheus.AssertQuery(context.TODO(), prometheusClient, serverPodRXPromQL, greaterThanZero,
+		prometheus.AssertWithTimeout(2*time.Minute), prometheus.AssertWithPollInterval(30*time.Second))
+	Expect(err).ToNot(HaveOccurred(), "RX counters are zero")
 
-	return finalVal
+	err = prometheus.AssertQuery(context.TODO(), prometheusClient, serverPodTXPromQL, greaterThanZero,
+		prometheus.AssertWithTimeout(2*time.Minute), prometheus.AssertWithPollInterval(30*time.Second))
+	Expect(err).ToNot(HaveOccurred(), "TX counters are zero")
 }
 
 func setMetricsExporter(flag bool) {
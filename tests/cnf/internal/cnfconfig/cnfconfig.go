@@ -2,13 +2,10 @@ package cnfconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -33,48 +30,12 @@ func NewCNFConfig() *CNFConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultCnfParamsFile)
 
-	err := readFile(&coreConf, confFile)
+	err := config.Loader{Section: "cnf", DefaultFile: confFile}.Load(&coreConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&coreConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load CNFConfig: %v", err)
 
 		return nil
 	}
 
 	return &coreConf
 }
-
-func readFile(cnfConfig *CNFConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&cnfConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(cnfConfig *CNFConfig) error {
-	err := envconfig.Process("", cnfConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
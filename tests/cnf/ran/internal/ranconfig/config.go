@@ -2,19 +2,17 @@ package ranconfig
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"runtime"
 	"time"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
-	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 )
 
@@ -60,6 +58,11 @@ type RANConfig struct {
 	// corresponding to the current Spoke 1 OCP version.
 	PtpMustGatherImage string `envconfig:"ECO_CNF_RAN_PTP_MUST_GATHER_IMAGE"`
 
+	// PtpExpectedTopology maps each PTP node to the node it should follow, for example
+	// "bc-node:gm-node,oc-node:bc-node". Nodes following a clock outside of the cluster map to external and
+	// grandmasters map to none. When empty, the discovered PTP topology is exported but not validated.
+	PtpExpectedTopology map[string]string `yaml:"ptpExpectedTopology" envconfig:"ECO_CNF_RAN_PTP_EXPECTED_TOPOLOGY"`
+
 	// ClusterTemplateAffix is the version-dependent affix used for naming ClusterTemplates and other O-RAN
 	// resources.
 	ClusterTemplateAffix string `envconfig:"ECO_CNF_RAN_CLUSTER_TEMPLATE_AFFIX"`
@@ -150,7 +153,7 @@ func NewRANConfig() *RANConfig {
 	baseDir := filepath.Dir(filename)
 	configFile := filepath.Join(baseDir, PathToDefaultCnfRanParamsFile)
 
-	err := readConfig(&ranConfig, configFile)
+	err := config.Loader{Section: "ran", DefaultFile: configFile, EnvPrefix: "ECO_CNF_RAN_"}.Load(&ranConfig)
 	if err != nil {
 		klog.V(ranparam.LogLevel).Infof("Error reading main RAN Config: %v", err)
 
@@ -169,7 +172,7 @@ func (ranconfig *RANConfig) newHubConfig(configFile string) {
 
 	ranconfig.HubConfig = new(HubConfig)
 
-	err := readConfig(ranconfig.HubConfig, configFile)
+	err := config.Loader{Section: "ran", DefaultFile: configFile, Partial: true}.Load(ranconfig.HubConfig)
 	if err != nil {
 		klog.V(ranparam.LogLevel).Infof("Failed to instantiate HubConfig: %v", err)
 	}
@@ -236,7 +239,7 @@ func (ranconfig *RANConfig) newSpoke1Config(configFile string) {
 
 	ranconfig.Spoke1Config = new(Spoke1Config)
 
-	err := readConfig(ranconfig.Spoke1Config, configFile)
+	err := config.Loader{Section: "ran", DefaultFile: configFile, Partial: true}.Load(ranconfig.Spoke1Config)
 	if err != nil {
 		klog.V(ranparam.LogLevel).Infof("Failed to instantiate Spoke1Config: %v", err)
 	}
@@ -291,7 +294,7 @@ func (ranconfig *RANConfig) newSpoke2Config(configFile string) {
 
 	ranconfig.Spoke2Config = new(Spoke2Config)
 
-	err := readConfig(ranconfig.Spoke2Config, configFile)
+	err := config.Loader{Section: "ran", DefaultFile: configFile, Partial: true}.Load(ranconfig.Spoke2Config)
 	if err != nil {
 		klog.V(ranparam.LogLevel).Infof("Failed to instantiate Spoke2Config: %v", err)
 	}
@@ -317,35 +320,3 @@ func (ranconfig *RANConfig) newSpoke2Config(configFile string) {
 
 	klog.V(ranparam.LogLevel).Infof("Found OCP version on spoke 2: %s", ranconfig.Spoke2Config.Spoke2OCPVersion)
 }
-
-func readConfig[C any](config *C, configFile string) error {
-	err := readFile(config, configFile)
-	if err != nil {
-		return err
-	}
-
-	return readEnv(config)
-}
-
-func readFile[C any](config *C, configFile string) error {
-	openedConfigFile, err := os.Open(configFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedConfigFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedConfigFile)
-
-	err = decoder.Decode(config)
-
-	return err
-}
-
-func readEnv[C any](config *C) error {
-	err := envconfig.Process("", config)
-
-	return err
-}
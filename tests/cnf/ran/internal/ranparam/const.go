@@ -56,18 +56,14 @@ const (
 	RetryCount = 3
 )
 
-// Querier package constants.
+// Prometheus client constants.
 const (
-	// ThanosQuerierRouteName is the name of the Thanos querier route.
-	ThanosQuerierRouteName = "thanos-querier"
 	// OpenshiftMonitoringNamespace is the namespace for the OpenShift Monitoring.
 	OpenshiftMonitoringNamespace = "openshift-monitoring"
-	// OpenshiftMonitoringViewRole is the role for the OpenShift Monitoring.
-	OpenshiftMonitoringViewRole = "cluster-monitoring-view"
-	// QuerierServiceAccountName is the name of the querier service account that gets created by the querier
-	// package.
+	// QuerierServiceAccountName is the name of the service account that RAN suites use with the shared prometheus
+	// client.
 	QuerierServiceAccountName = "ran-querier"
-	// QuerierCRBName is the name of the querier cluster role binding that gets created by the querier package to
+	// QuerierCRBName is the name of the cluster role binding that RAN suites use with the shared prometheus client to
 	// bind the querier service account to the cluster monitoring view role.
 	QuerierCRBName = "ran-querier-crb"
 )
@@ -0,0 +1,82 @@
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// exportFileName is the name, without extension, of the files the topology is exported to.
+	exportFileName = "ptp_topology"
+)
+
+// JSON returns the graph as indented JSON.
+func (graph *Graph) JSON() ([]byte, error) {
+	output, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PTP topology: %w", err)
+	}
+
+	return output, nil
+}
+
+// DOT returns the graph in the Graphviz DOT format. Edges point from the parent clock to its follower so the
+// grandmasters end up at the top of the rendered graph.
+func (graph *Graph) DOT() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph ptp {\n")
+	builder.WriteString("  rankdir=TB;\n")
+
+	for _, clock := range graph.Clocks {
+		ports := make([]string, 0, len(clock.Ports))
+
+		for _, port := range clock.Ports {
+			ports = append(ports, string(port.Interface))
+		}
+
+		fmt.Fprintf(&builder, "  %q [label=%q];\n", clock.Identity, fmt.Sprintf("%s %s\n%s\n%s\n%s",
+			clock.Role, clock.NodeName, strings.Join(clock.Profiles, ","), clock.Identity, strings.Join(ports, ",")))
+	}
+
+	for _, edge := range graph.Edges {
+		if edge.External {
+			fmt.Fprintf(&builder, "  %q [label=%q, shape=box, style=dashed];\n",
+				edge.Parent, fmt.Sprintf("%s\n%s", ExternalParent, edge.Parent))
+		}
+
+		fmt.Fprintf(&builder, "  %q -> %q;\n", edge.Parent, edge.Follower)
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+// Export writes the graph as ptp_topology.dot and ptp_topology.json into dir, creating it if needed.
+func (graph *Graph) Export(dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create PTP topology export directory %s: %w", dir, err)
+	}
+
+	jsonOutput, err := graph.JSON()
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, exportFileName+".json"), jsonOutput, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write PTP topology JSON: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, exportFileName+".dot"), []byte(graph.DOT()), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write PTP topology DOT: %w", err)
+	}
+
+	return nil
+}
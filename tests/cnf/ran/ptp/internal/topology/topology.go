@@ -0,0 +1,251 @@
+package topology
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/profiles"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"k8s.io/klog/v2"
+)
+
+// ClockRole is the role of a clock in the PTP hierarchy, derived from the type of the profile it runs.
+type ClockRole string
+
+const (
+	// ClockRoleGM is a grandmaster clock, with all ports acting as servers.
+	ClockRoleGM ClockRole = "GM"
+	// ClockRoleBC is a boundary clock, following a parent clock and serving time to its own followers.
+	ClockRoleBC ClockRole = "BC"
+	// ClockRoleOC is an ordinary clock, only following a parent clock.
+	ClockRoleOC ClockRole = "OC"
+)
+
+const (
+	// ExternalParent is the parent of nodes with a clock following a clock outside of the cluster, such as a lab
+	// switch or an external grandmaster.
+	ExternalParent = "external"
+	// NoParent is the parent of nodes whose clocks do not follow any other clock, such as GNSS-synced
+	// grandmasters.
+	NoParent = "none"
+)
+
+// Port is a PTP port of a clock.
+type Port struct {
+	Interface    iface.Name `json:"interface"`
+	PortIdentity string     `json:"portIdentity"`
+	Server       bool       `json:"server"`
+}
+
+// Clock is a PTP clock in the cluster. Clocks are identified by their clock identity, so ptp4l instances sharing a PHC
+// are merged into a single clock.
+type Clock struct {
+	Identity string    `json:"identity"`
+	NodeName string    `json:"nodeName"`
+	Role     ClockRole `json:"role"`
+	Profiles []string  `json:"profiles"`
+	Ports    []Port    `json:"ports"`
+	// ParentPortIdentity is the port identity this clock follows, as reported by PARENT_DATA_SET. Grandmasters
+	// report their own clock identity.
+	ParentPortIdentity string `json:"parentPortIdentity,omitempty"`
+}
+
+// Edge connects a clock to the clock it follows. When External is true, Parent is the port identity of a clock
+// outside of the cluster rather than the identity of a clock in the graph.
+type Edge struct {
+	Follower string `json:"follower"`
+	Parent   string `json:"parent"`
+	External bool   `json:"external,omitempty"`
+}
+
+// Graph is the cluster-wide PTP topology. Clocks and edges are sorted so the graph can be compared and exported
+// deterministically.
+type Graph struct {
+	Clocks []*Clock `json:"clocks"`
+	Edges  []Edge   `json:"edges"`
+}
+
+// Discover gathers the port identities of every PTP profile on every node of the cluster using pmc and joins them into
+// a cluster-wide topology graph.
+func Discover(client *clients.Settings) (*Graph, error) {
+	nodeInfoMap, err := profiles.GetNodeInfoMap(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node info map: %w", err)
+	}
+
+	for nodeName, nodeInfo := range nodeInfoMap {
+		err = nodeInfo.SetPortIdentitiesAndLink(client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set port identities on node %s: %w", nodeName, err)
+		}
+	}
+
+	graph := Build(nodeInfoMap)
+
+	klog.V(tsparams.LogLevel).Infof("Discovered PTP topology with %d clocks and %d edges",
+		len(graph.Clocks), len(graph.Edges))
+
+	return graph, nil
+}
+
+// Build joins the profiles of all nodes into a topology graph. Port identities must already be set, for example using
+// NodeInfo.SetPortIdentitiesAndLink. Interfaces without a port identity, such as those of HA profiles, are skipped.
+func Build(nodeInfoMap map[string]*profiles.NodeInfo) *Graph {
+	clocks := make(map[string]*Clock)
+
+	for nodeName, nodeInfo := range nodeInfoMap {
+		for _, profileInfo := range nodeInfo.Profiles {
+			for _, interfaceInfo := range profileInfo.Interfaces {
+				if interfaceInfo.PortIdentity == "" {
+					continue
+				}
+
+				clockIdentity := clockIdentityOf(interfaceInfo.PortIdentity)
+
+				clock, found := clocks[clockIdentity]
+				if !found {
+					clock = &Clock{Identity: clockIdentity, NodeName: nodeName}
+					clocks[clockIdentity] = clock
+				}
+
+				// Profiles sharing a PHC may disagree on the parent, for example when a T-BC transmitter reports
+				// the clock itself while the receiver reports its upstream port. Since maps are iterated in random
+				// order, a parent other than the clock itself always takes precedence.
+				if !found || (!followsOtherClock(clockIdentity, clock.ParentPortIdentity) &&
+					followsOtherClock(clockIdentity, interfaceInfo.ParentPortIdentity)) {
+					clock.Role = roleOf(profileInfo.ProfileType)
+					clock.ParentPortIdentity = interfaceInfo.ParentPortIdentity
+				}
+
+				if !slices.Contains(clock.Profiles, profileInfo.Reference.ProfileName) {
+					clock.Profiles = append(clock.Profiles, profileInfo.Reference.ProfileName)
+				}
+
+				clock.Ports = append(clock.Ports, Port{
+					Interface:    interfaceInfo.Name,
+					PortIdentity: interfaceInfo.PortIdentity,
+					Server:       interfaceInfo.ClockType == profiles.ClockTypeServer,
+				})
+			}
+		}
+	}
+
+	graph := &Graph{}
+
+	for _, clock := range clocks {
+		slices.Sort(clock.Profiles)
+		slices.SortFunc(clock.Ports, func(a, b Port) int {
+			return cmp.Compare(a.PortIdentity, b.PortIdentity)
+		})
+
+		graph.Clocks = append(graph.Clocks, clock)
+
+		if !followsOtherClock(clock.Identity, clock.ParentPortIdentity) {
+			continue
+		}
+
+		parentIdentity := clockIdentityOf(clock.ParentPortIdentity)
+
+		if _, parentFound := clocks[parentIdentity]; parentFound {
+			graph.Edges = append(graph.Edges, Edge{Follower: clock.Identity, Parent: parentIdentity})
+
+			continue
+		}
+
+		graph.Edges = append(graph.Edges,
+			Edge{Follower: clock.Identity, Parent: clock.ParentPortIdentity, External: true})
+	}
+
+	slices.SortFunc(graph.Clocks, func(a, b *Clock) int {
+		return cmp.Or(cmp.Compare(a.NodeName, b.NodeName), cmp.Compare(a.Identity, b.Identity))
+	})
+	slices.SortFunc(graph.Edges, func(a, b Edge) int {
+		return cmp.Or(cmp.Compare(a.Follower, b.Follower), cmp.Compare(a.Parent, b.Parent))
+	})
+
+	return graph
+}
+
+// GetClock returns the clock with the provided clock identity. It returns nil if no clock is found.
+func (graph *Graph) GetClock(identity string) *Clock {
+	for _, clock := range graph.Clocks {
+		if clock.Identity == identity {
+			return clock
+		}
+	}
+
+	return nil
+}
+
+// NodeParents returns, for every node with a clock, the sorted names of the nodes its clocks follow. Clocks following
+// a clock on the same node are ignored, clocks following a clock outside of the cluster are reported as
+// ExternalParent, and nodes following no other node are reported as NoParent.
+func (graph *Graph) NodeParents() map[string][]string {
+	nodeParents := make(map[string][]string)
+
+	for _, clock := range graph.Clocks {
+		if _, found := nodeParents[clock.NodeName]; !found {
+			nodeParents[clock.NodeName] = nil
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		follower := graph.GetClock(edge.Follower)
+		parentNode := ExternalParent
+
+		if !edge.External {
+			parentNode = graph.GetClock(edge.Parent).NodeName
+		}
+
+		if parentNode == follower.NodeName || slices.Contains(nodeParents[follower.NodeName], parentNode) {
+			continue
+		}
+
+		nodeParents[follower.NodeName] = append(nodeParents[follower.NodeName], parentNode)
+	}
+
+	for nodeName, parents := range nodeParents {
+		if len(parents) == 0 {
+			nodeParents[nodeName] = []string{NoParent}
+
+			continue
+		}
+
+		slices.Sort(parents)
+	}
+
+	return nodeParents
+}
+
+// clockIdentityOf returns the clock identity of a port identity by removing the port number suffix. For example,
+// "507c6f.fffe.5c4c82-1" becomes "507c6f.fffe.5c4c82".
+func clockIdentityOf(portIdentity string) string {
+	lastDash := strings.LastIndex(portIdentity, "-")
+	if lastDash == -1 {
+		return portIdentity
+	}
+
+	return portIdentity[:lastDash]
+}
+
+// followsOtherClock returns whether parentPortIdentity belongs to a clock other than the one with the provided clock
+// identity. Grandmasters and clocks without a known parent report no parent or their own clock identity.
+func followsOtherClock(clockIdentity, parentPortIdentity string) bool {
+	return parentPortIdentity != "" && clockIdentityOf(parentPortIdentity) != clockIdentity
+}
+
+// roleOf returns the role of a clock running a profile of the provided type.
+func roleOf(profileType profiles.PtpProfileType) ClockRole {
+	switch profileType {
+	case profiles.ProfileTypeGM, profiles.ProfileTypeMultiNICGM, profiles.ProfileTypeNTPFallback:
+		return ClockRoleGM
+	case profiles.ProfileTypeBC, profiles.ProfileTypeTBCReceiver, profiles.ProfileTypeTBCTransmitter:
+		return ClockRoleBC
+	default:
+		return ClockRoleOC
+	}
+}
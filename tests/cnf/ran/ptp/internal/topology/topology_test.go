@@ -0,0 +1,150 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/profiles"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	gmIdentity   = "507c6f.fffe.000001"
+	bcIdentity   = "507c6f.fffe.000002"
+	ocIdentity   = "507c6f.fffe.000003"
+	switchParent = "208810.ffff.151f00-8"
+)
+
+// newTestProfile returns a profile whose interfaces have the provided port identities and parent port identity.
+func newTestProfile(
+	name string,
+	profileType profiles.PtpProfileType,
+	parentPortIdentity string,
+	interfaces map[iface.Name]profiles.PtpClockType,
+	portIdentities map[iface.Name]string) *profiles.ProfileInfo {
+	profileInfo := &profiles.ProfileInfo{
+		ProfileType: profileType,
+		Reference:   profiles.ProfileReference{ProfileName: name},
+		Interfaces:  make(map[iface.Name]*profiles.InterfaceInfo),
+	}
+
+	for ifaceName, clockType := range interfaces {
+		profileInfo.Interfaces[ifaceName] = &profiles.InterfaceInfo{
+			Name:               ifaceName,
+			ClockType:          clockType,
+			PortIdentity:       portIdentities[ifaceName],
+			ParentPortIdentity: parentPortIdentity,
+			Profile:            profileInfo,
+		}
+	}
+
+	return profileInfo
+}
+
+// newTestNodeInfoMap returns a GM -> BC -> OC topology, with the OC following ocParent.
+func newTestNodeInfoMap(ocParent string) map[string]*profiles.NodeInfo {
+	return map[string]*profiles.NodeInfo{
+		"gm-node": {Name: "gm-node", Profiles: []*profiles.ProfileInfo{
+			newTestProfile("gm", profiles.ProfileTypeGM, gmIdentity+"-0",
+				map[iface.Name]profiles.PtpClockType{"ens1f0": profiles.ClockTypeServer},
+				map[iface.Name]string{"ens1f0": gmIdentity + "-1"}),
+		}},
+		"bc-node": {Name: "bc-node", Profiles: []*profiles.ProfileInfo{
+			newTestProfile("bc", profiles.ProfileTypeBC, gmIdentity+"-1",
+				map[iface.Name]profiles.PtpClockType{
+					"ens2f0": profiles.ClockTypeClient, "ens2f1": profiles.ClockTypeServer,
+				},
+				map[iface.Name]string{"ens2f0": bcIdentity + "-1", "ens2f1": bcIdentity + "-2"}),
+			newTestProfile("ha", profiles.ProfileTypeHA, "", nil, nil),
+		}},
+		"oc-node": {Name: "oc-node", Profiles: []*profiles.ProfileInfo{
+			newTestProfile("oc", profiles.ProfileTypeOC, ocParent,
+				map[iface.Name]profiles.PtpClockType{"ens3f0": profiles.ClockTypeClient},
+				map[iface.Name]string{"ens3f0": ocIdentity + "-1"}),
+		}},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	graph := Build(newTestNodeInfoMap(bcIdentity + "-2"))
+
+	assert.Len(t, graph.Clocks, 3)
+	assert.Equal(t, "bc-node", graph.Clocks[0].NodeName)
+	assert.Equal(t, ClockRoleBC, graph.Clocks[0].Role)
+	assert.Equal(t, []string{"bc"}, graph.Clocks[0].Profiles)
+	assert.Len(t, graph.Clocks[0].Ports, 2)
+	assert.Equal(t, ClockRoleGM, graph.GetClock(gmIdentity).Role)
+	assert.Equal(t, ClockRoleOC, graph.GetClock(ocIdentity).Role)
+
+	assert.Equal(t, []Edge{
+		{Follower: bcIdentity, Parent: gmIdentity},
+		{Follower: ocIdentity, Parent: bcIdentity},
+	}, graph.Edges)
+
+	graph = Build(newTestNodeInfoMap(switchParent))
+	assert.Equal(t, Edge{Follower: ocIdentity, Parent: switchParent, External: true}, graph.Edges[1])
+}
+
+func TestBuildPrefersParentOtherThanItself(t *testing.T) {
+	receiver := newTestProfile("tbc-rx", profiles.ProfileTypeTBCReceiver, gmIdentity+"-1",
+		map[iface.Name]profiles.PtpClockType{"ens2f0": profiles.ClockTypeClient},
+		map[iface.Name]string{"ens2f0": bcIdentity + "-1"})
+	transmitter := newTestProfile("tbc-tx", profiles.ProfileTypeTBCTransmitter, bcIdentity+"-0",
+		map[iface.Name]profiles.PtpClockType{"ens2f1": profiles.ClockTypeServer},
+		map[iface.Name]string{"ens2f1": bcIdentity + "-2"})
+
+	testCases := []struct {
+		name     string
+		profiles []*profiles.ProfileInfo
+	}{
+		{name: "receiver first", profiles: []*profiles.ProfileInfo{receiver, transmitter}},
+		{name: "transmitter first", profiles: []*profiles.ProfileInfo{transmitter, receiver}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			nodeInfoMap := newTestNodeInfoMap(bcIdentity + "-2")
+			nodeInfoMap["bc-node"].Profiles = testCase.profiles
+
+			graph := Build(nodeInfoMap)
+
+			bcClock := graph.GetClock(bcIdentity)
+			assert.NotNil(t, bcClock)
+			assert.Equal(t, gmIdentity+"-1", bcClock.ParentPortIdentity)
+			assert.Equal(t, ClockRoleBC, bcClock.Role)
+			assert.Equal(t, []string{"tbc-rx", "tbc-tx"}, bcClock.Profiles)
+			assert.Contains(t, graph.Edges, Edge{Follower: bcIdentity, Parent: gmIdentity})
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	expected := map[string]string{"gm-node": NoParent, "bc-node": "gm-node", "oc-node": "bc-node"}
+
+	graph := Build(newTestNodeInfoMap(bcIdentity + "-2"))
+	assert.NoError(t, graph.Validate(expected))
+
+	graph = Build(newTestNodeInfoMap(switchParent))
+	assert.Equal(t, map[string][]string{
+		"gm-node": {NoParent}, "bc-node": {"gm-node"}, "oc-node": {ExternalParent},
+	}, graph.NodeParents())
+	assert.EqualError(t, graph.Validate(expected), "PTP topology does not match the expected topology:\n"+
+		"  node oc-node: expected to follow bc-node, but follows external")
+
+	delete(expected, "gm-node")
+	expected["other-node"] = "bc-node"
+	expected["oc-node"] = ExternalParent
+	assert.EqualError(t, graph.Validate(expected), "PTP topology does not match the expected topology:\n"+
+		"  node other-node: expected to follow bc-node, but has no PTP clock\n"+
+		"  node gm-node: follows none, but is not in the expected topology")
+}
+
+func TestDOT(t *testing.T) {
+	graph := Build(newTestNodeInfoMap(switchParent))
+	dot := graph.DOT()
+
+	assert.Contains(t, dot, "digraph ptp {")
+	assert.Contains(t, dot, `"507c6f.fffe.000001" -> "507c6f.fffe.000002";`)
+	assert.Contains(t, dot, `"208810.ffff.151f00-8" [label="external\n208810.ffff.151f00-8", shape=box, style=dashed];`)
+	assert.Contains(t, dot, `"208810.ffff.151f00-8" -> "507c6f.fffe.000003";`)
+}
@@ -0,0 +1,49 @@
+package topology
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Validate compares the node-level view of the graph to expected, a map of node names to the node each should follow.
+// Use ExternalParent for nodes following a clock outside of the cluster and NoParent for grandmasters. Every
+// difference is listed in the returned error, including nodes running PTP that are missing from expected.
+func (graph *Graph) Validate(expected map[string]string) error {
+	nodeParents := graph.NodeParents()
+
+	var differences []string
+
+	for _, nodeName := range slices.Sorted(maps.Keys(expected)) {
+		expectedParent := expected[nodeName]
+
+		parents, found := nodeParents[nodeName]
+		if !found {
+			differences = append(differences, fmt.Sprintf(
+				"node %s: expected to follow %s, but has no PTP clock", nodeName, expectedParent))
+
+			continue
+		}
+
+		if len(parents) != 1 || parents[0] != expectedParent {
+			differences = append(differences, fmt.Sprintf("node %s: expected to follow %s, but follows %s",
+				nodeName, expectedParent, strings.Join(parents, ", ")))
+		}
+	}
+
+	for _, nodeName := range slices.Sorted(maps.Keys(nodeParents)) {
+		if _, found := expected[nodeName]; !found {
+			differences = append(differences, fmt.Sprintf(
+				"node %s: follows %s, but is not in the expected topology",
+				nodeName, strings.Join(nodeParents[nodeName], ", ")))
+		}
+	}
+
+	if len(differences) > 0 {
+		return fmt.Errorf("PTP topology does not match the expected topology:\n  %s",
+			strings.Join(differences, "\n  "))
+	}
+
+	return nil
+}
@@ -10,15 +10,18 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/nicinfo"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/querier"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/rancluster"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/consumer"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/metrics"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/mustgather"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/topology"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/tests"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
+	"k8s.io/klog/v2"
 )
 
 var (
@@ -42,6 +45,8 @@ var _ = BeforeSuite(func() {
 	isSpoke1Present := rancluster.AreClustersPresent([]*clients.Settings{Spoke1APIClient})
 	Expect(isSpoke1Present).To(BeTrue(), "Spoke 1 cluster must be present for PTP tests")
 
+	exportAndValidatePtpTopology()
+
 	By("updating the PTP ServiceMonitor scrape interval to 1s")
 
 	var err error
@@ -70,7 +75,8 @@ var _ = AfterSuite(func() {
 
 	By("cleaning up Prometheus API client resources")
 
-	err = querier.CleanupQuerierResources(RANConfig.Spoke1APIClient)
+	err = prometheus.CleanupServiceAccount(RANConfig.Spoke1APIClient,
+		prometheus.WithServiceAccount(ranparam.QuerierServiceAccountName, ranparam.QuerierCRBName))
 	Expect(err).ToNot(HaveOccurred(), "Failed to cleanup Prometheus API client resources")
 })
 
@@ -90,3 +96,32 @@ var _ = ReportAfterSuite("", func(report Report) {
 
 	AddReportEntry("nicinfo", nicinfoReport)
 })
+
+// exportAndValidatePtpTopology discovers the PTP topology of spoke 1 and exports it to the report directory. When an
+// expected topology is configured, it fails the suite on discovery errors or if the topology does not match, so that
+// miscabled labs fail fast rather than in individual tests.
+func exportAndValidatePtpTopology() {
+	By("discovering the PTP topology")
+
+	ptpTopology, err := topology.Discover(RANConfig.Spoke1APIClient)
+	if err != nil {
+		Expect(RANConfig.PtpExpectedTopology).To(BeEmpty(), "Failed to discover the PTP topology: %v", err)
+		klog.V(tsparams.LogLevel).Infof("Skipping PTP topology export: failed to discover the PTP topology: %v", err)
+
+		return
+	}
+
+	err = ptpTopology.Export(RANConfig.ReportsDirAbsPath)
+	if err != nil {
+		klog.V(tsparams.LogLevel).Infof("Failed to export the PTP topology: %v", err)
+	}
+
+	if len(RANConfig.PtpExpectedTopology) == 0 {
+		return
+	}
+
+	By("validating the PTP topology against the expected topology")
+
+	err = ptpTopology.Validate(RANConfig.PtpExpectedTopology)
+	Expect(err).ToNot(HaveOccurred(), "PTP topology does not match the expected topology")
+}
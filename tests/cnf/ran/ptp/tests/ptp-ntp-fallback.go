@@ -13,7 +13,6 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/nicinfo"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/querier"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
@@ -27,6 +26,8 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/profiles"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/ptpdaemon"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/ublox"
 	"k8s.io/klog/v2"
 )
 
@@ -48,7 +49,8 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 
 		By("creating a Prometheus API client")
 
-		prometheusAPI, err = querier.CreatePrometheusAPIForCluster(RANConfig.Spoke1APIClient)
+		prometheusAPI, err = prometheus.NewClient(RANConfig.Spoke1APIClient,
+			prometheus.WithServiceAccount(ranparam.QuerierServiceAccountName, ranparam.QuerierCRBName))
 		Expect(err).ToNot(HaveOccurred(), "Failed to create Prometheus API client")
 
 		By("ensuring clocks are locked before testing")
@@ -122,8 +124,8 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			ntpFallbackProfile, err := ntpFallbackProfiles[0].PullProfile(RANConfig.Spoke1APIClient)
 			Expect(err).ToNot(HaveOccurred(), "Failed to pull NTP fallback profile for node %s", nodeName)
 
-			protocolVersion, err := gnss.GetUbloxProtocolVersion(ntpFallbackProfile)
-			Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox protocol version for node %s", nodeName)
+			receiver, err := gnss.NewReceiver(RANConfig.Spoke1APIClient, nodeName, ntpFallbackProfile)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox receiver for node %s", nodeName)
 
 			// Include all interfaces from the profile in the interface information report for this suite.
 			nicinfo.Node(nodeName).MarkSeqTested(iface.NamesToStringSeq(maps.Keys(ntpFallbackProfiles[0].Interfaces)))
@@ -145,10 +147,10 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			Expect(chronycActivity).To(ContainSubstring("0 sources online"), "Chronyd has sources online on node %s", nodeName)
 
 			By("simulating GNSS sync loss")
-			DeferCleanup(cleanupGNSSSync(prometheusAPI, nodeName, protocolVersion))
+			DeferCleanup(cleanupGNSSSync(prometheusAPI, receiver))
 
 			gnssLossTime := time.Now()
-			err = gnss.SimulateSyncLoss(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+			err = receiver.SimulateSyncLoss()
 			Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync loss for node %s", nodeName)
 
 			By("getting the event pod for the node")
@@ -182,7 +184,7 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			By("restoring GNSS sync")
 
 			gnssRecoveryTime := time.Now()
-			err = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+			err = receiver.SimulateSyncRecovery()
 			Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync recovery for node %s", nodeName)
 
 			By("waiting for os-clock-sync-state LOCKED event")
@@ -370,8 +372,8 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			ntpFallbackProfile, err := ntpFallbackProfiles[0].PullProfile(RANConfig.Spoke1APIClient)
 			Expect(err).ToNot(HaveOccurred(), "Failed to pull NTP fallback profile for node %s", nodeName)
 
-			protocolVersion, err := gnss.GetUbloxProtocolVersion(ntpFallbackProfile)
-			Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox protocol version for node %s", nodeName)
+			receiver, err := gnss.NewReceiver(RANConfig.Spoke1APIClient, nodeName, ntpFallbackProfile)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox receiver for node %s", nodeName)
 
 			// Include all interfaces from the profile in the interface information report for this suite.
 			nicinfo.Node(nodeName).MarkSeqTested(iface.NamesToStringSeq(maps.Keys(ntpFallbackProfiles[0].Interfaces)))
@@ -391,10 +393,10 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			waitForLoadAndTS2PHCLocked(prometheusAPI, nodeName, invalidServerTime)
 
 			By("simulating GNSS sync loss")
-			DeferCleanup(cleanupGNSSSync(prometheusAPI, nodeName, protocolVersion))
+			DeferCleanup(cleanupGNSSSync(prometheusAPI, receiver))
 
 			gnssLossTime := time.Now()
-			err = gnss.SimulateSyncLoss(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+			err = receiver.SimulateSyncLoss()
 			Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync loss for node %s", nodeName)
 
 			By("getting the event pod for the node")
@@ -441,7 +443,7 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			By("restoring GNSS sync")
 
 			gnssRecoveryTime := time.Now()
-			err = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+			err = receiver.SimulateSyncRecovery()
 			Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync recovery for node %s", nodeName)
 
 			By("waiting for os-clock-sync-state LOCKED event")
@@ -491,8 +493,8 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			ntpFallbackProfile, err := ntpFallbackProfiles[0].PullProfile(RANConfig.Spoke1APIClient)
 			Expect(err).ToNot(HaveOccurred(), "Failed to pull NTP fallback profile for node %s", nodeName)
 
-			protocolVersion, err := gnss.GetUbloxProtocolVersion(ntpFallbackProfile)
-			Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox protocol version for node %s", nodeName)
+			receiver, err := gnss.NewReceiver(RANConfig.Spoke1APIClient, nodeName, ntpFallbackProfile)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox receiver for node %s", nodeName)
 
 			// Include all interfaces from the profile in the interface information report for this suite.
 			nicinfo.Node(nodeName).MarkSeqTested(iface.NamesToStringSeq(maps.Keys(ntpFallbackProfiles[0].Interfaces)))
@@ -532,10 +534,10 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			waitForLoadAndTS2PHCLocked(prometheusAPI, nodeName, updateTime)
 
 			By("simulating GNSS sync loss")
-			DeferCleanup(cleanupGNSSSync(prometheusAPI, nodeName, protocolVersion))
+			DeferCleanup(cleanupGNSSSync(prometheusAPI, receiver))
 
 			gnssLossTime := time.Now()
-			err = gnss.SimulateSyncLoss(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+			err = receiver.SimulateSyncLoss()
 			Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync loss for node %s", nodeName)
 
 			By("getting the event pod for the node")
@@ -562,7 +564,7 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 			By("restoring GNSS sync")
 
 			gnssRecoveryTime := time.Now()
-			err = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+			err = receiver.SimulateSyncRecovery()
 			Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync recovery for node %s", nodeName)
 
 			By("waiting for os-clock-sync-state LOCKED event")
@@ -596,9 +598,9 @@ var _ = Describe("PTP GNSS with NTP Fallback", Label(tsparams.LabelNTPFallback),
 	})
 })
 
-// cleanupGNSSSync restores GNSS sync and ensures the ts2phc process is locked on the given node. It returns a function
-// that can be used as the argument to [DeferCleanup].
-func cleanupGNSSSync(prometheusAPI prometheusv1.API, nodeName string, protocolVersion string) func() {
+// cleanupGNSSSync restores GNSS sync and ensures the ts2phc process is locked on the node of the given receiver. It
+// returns a function that can be used as the argument to [DeferCleanup].
+func cleanupGNSSSync(prometheusAPI prometheusv1.API, receiver *ublox.Receiver) func() {
 	return func() {
 		if !CurrentSpecReport().Failed() {
 			return
@@ -606,11 +608,11 @@ func cleanupGNSSSync(prometheusAPI prometheusv1.API, nodeName string, protocolVe
 
 		By("restoring GNSS sync")
 
-		err := gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
-		Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync recovery for node %s", nodeName)
+		err := receiver.SimulateSyncRecovery()
+		Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync recovery for node %s", receiver.NodeName)
 
 		By("ensuring ts2phc process is locked after restoring GNSS sync")
-		ensureTS2PHCProcessLocked(prometheusAPI, nodeName)
+		ensureTS2PHCProcessLocked(prometheusAPI, receiver.NodeName)
 	}
 }
 
@@ -12,7 +12,6 @@ import (
 	eventptp "github.com/redhat-cne/sdk-go/pkg/event/ptp"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/querier"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
@@ -26,6 +25,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/profiles"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/ptpdaemon"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	"k8s.io/klog/v2"
 )
 
@@ -42,7 +42,8 @@ var _ = Describe("PTP Process Restart", Label(tsparams.LabelProcessRestart), fun
 
 		var err error
 
-		prometheusAPI, err = querier.CreatePrometheusAPIForCluster(RANConfig.Spoke1APIClient)
+		prometheusAPI, err = prometheus.NewClient(RANConfig.Spoke1APIClient,
+			prometheus.WithServiceAccount(ranparam.QuerierServiceAccountName, ranparam.QuerierCRBName))
 		Expect(err).ToNot(HaveOccurred(), "Failed to create Prometheus API client")
 
 		By("ensuring clocks are locked before testing")
@@ -426,8 +427,8 @@ var _ = Describe("PTP Process Restart", Label(tsparams.LabelProcessRestart), fun
 				gmProfile, err := gmProfiles[0].PullProfile(RANConfig.Spoke1APIClient)
 				Expect(err).ToNot(HaveOccurred(), "Failed to pull GM profile for node %s", nodeInfo.Name)
 
-				protocolVersion, err := gnss.GetUbloxProtocolVersion(gmProfile)
-				Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox protocol version for node %s", nodeInfo.Name)
+				receiver, err := gnss.NewReceiver(RANConfig.Spoke1APIClient, nodeInfo.Name, gmProfile)
+				Expect(err).ToNot(HaveOccurred(), "Failed to get u-blox receiver for node %s", nodeInfo.Name)
 
 				By("restarting sidecar container in linuxptp-daemon")
 
@@ -443,20 +444,17 @@ var _ = Describe("PTP Process Restart", Label(tsparams.LabelProcessRestart), fun
 					ptpdaemon.WithRetryOnError(true))
 				Expect(err).ToNot(HaveOccurred(), "Failed to kill cloud-event-proxy process on node %s", nodeInfo.Name)
 
-				protocolVer := protocolVersion
-				nodeName := nodeInfo.Name
-
 				By("simulating GNSS lost to generate events while sidecar is recovering")
 				DeferCleanup(func() {
-					_ = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVer)
+					_ = receiver.SimulateSyncRecovery()
 				})
 
 				gpsRebootTime := time.Now()
-				err = gnss.SimulateSyncLoss(RANConfig.Spoke1APIClient, nodeInfo.Name, protocolVersion)
+				err = receiver.SimulateSyncLoss()
 				Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS sync loss for node %s", nodeInfo.Name)
 
 				By("restore GNSS via node " + nodeInfo.Name)
-				err = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeInfo.Name, protocolVersion)
+				err = receiver.SimulateSyncRecovery()
 				Expect(err).ToNot(HaveOccurred(), "Failed to restore GNSS sync for node %s", nodeInfo.Name)
 
 				By("getting the event pod for the node " + nodeInfo.Name)
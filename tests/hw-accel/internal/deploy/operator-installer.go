@@ -8,8 +8,8 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
 	operatorsV1alpha1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/olm/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/olmlifecycle"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,10 +40,12 @@ type OperatorInstallConfig struct {
 	InstallPlanApproval    string
 }
 
-// OperatorInstaller provides generic operator installation functionality.
+// OperatorInstaller provides generic operator installation functionality. The OLM resources are managed by an
+// olmlifecycle.Manager, which upgrade tests get from Manager.
 type OperatorInstaller struct {
 	config   OperatorInstallConfig
 	csvUtils *CSVUtils
+	manager  *olmlifecycle.Manager
 }
 
 // NewOperatorInstaller creates a new operator installer with the given configuration.
@@ -60,7 +62,8 @@ func NewOperatorInstaller(config OperatorInstallConfig) *OperatorInstaller {
 	}
 }
 
-// Install deploys the operator following the standard OLM pattern.
+// Install deploys the operator following the standard OLM pattern. If the namespace is terminating, its deletion is
+// awaited before the namespace, operator group and subscription are created.
 func (o *OperatorInstaller) Install() error {
 	klog.V(o.config.LogLevel).Infof("Starting operator installation: %s in namespace %s",
 		o.config.PackageName, o.config.Namespace)
@@ -69,32 +72,36 @@ func (o *OperatorInstaller) Install() error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	manager, err := o.Manager()
+	if err != nil {
+		return err
+	}
+
 	if !o.config.SkipNamespaceCreation {
-		if err := o.createNamespaceIfNotExist(); err != nil {
+		if err := o.waitForTerminatingNamespace(); err != nil {
 			return fmt.Errorf("failed to create namespace: %w", err)
 		}
-
-		klog.V(o.config.LogLevel).
-			Infof("SUCCESS: Namespace %s created/verified",
-				o.config.Namespace)
 	} else {
 		klog.V(o.config.LogLevel).
 			Infof("Skipping namespace creation for global namespace: %s",
 				o.config.Namespace)
 	}
 
-	if !o.config.SkipOperatorGroup {
-		if err := o.createOperatorGroup(); err != nil {
-			return fmt.Errorf("failed to create operator group: %w", err)
-		}
-
-		klog.V(o.config.LogLevel).Infof("SUCCESS: OperatorGroup %s created", o.config.OperatorGroupName)
-	} else {
-		klog.V(o.config.LogLevel).Infof("Skipping operator group creation, using existing: %s", o.config.OperatorGroupName)
+	if o.config.SkipOperatorGroup {
+		klog.V(o.config.LogLevel).Infof("Skipping operator group creation, using existing: %s",
+			o.config.OperatorGroupName)
 	}
 
-	if err := o.createSubscription(); err != nil {
-		return fmt.Errorf("failed to create subscription: %w", err)
+	err = manager.Install()
+	if err != nil {
+		if strings.Contains(err.Error(), "is being terminated") ||
+			strings.Contains(err.Error(), "NamespaceTerminating") {
+			return fmt.Errorf("cannot install operator in terminating namespace %s. "+
+				"Please wait for namespace deletion to complete or use a different namespace: %w",
+				o.config.Namespace, err)
+		}
+
+		return fmt.Errorf("failed to install operator %s: %w", o.config.PackageName, err)
 	}
 
 	klog.V(o.config.LogLevel).Infof("SUCCESS: Subscription %s created", o.config.SubscriptionName)
@@ -102,6 +109,36 @@ func (o *OperatorInstaller) Install() error {
 	return nil
 }
 
+// Manager returns the olmlifecycle.Manager of the operator, created from the installation configuration on first
+// use. Upgrade tests use it to switch the subscription channel and wait for the upgraded CSV.
+func (o *OperatorInstaller) Manager() (*olmlifecycle.Manager, error) {
+	if o.manager != nil {
+		return o.manager, nil
+	}
+
+	manager, err := olmlifecycle.NewManager(o.config.APIClient, olmlifecycle.Config{
+		Namespace:              o.config.Namespace,
+		PackageName:            o.config.PackageName,
+		SubscriptionName:       o.config.SubscriptionName,
+		OperatorGroupName:      o.config.OperatorGroupName,
+		CatalogSource:          o.config.CatalogSource,
+		CatalogSourceNamespace: o.config.CatalogSourceNamespace,
+		Channel:                o.config.Channel,
+		StartingCSV:            o.config.StartingCSV,
+		InstallPlanApproval:    convertToInstallPlanApproval(o.config.InstallPlanApproval),
+		TargetNamespaces:       o.config.TargetNamespaces,
+		SharedNamespace:        o.config.SkipNamespaceCreation,
+		SharedOperatorGroup:    o.config.SkipOperatorGroup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lifecycle manager for operator %s: %w", o.config.PackageName, err)
+	}
+
+	o.manager = manager
+
+	return manager, nil
+}
+
 // IsReady checks if the operator CSV is ready.
 func (o *OperatorInstaller) IsReady(timeout time.Duration) (bool, error) {
 	klog.V(o.config.LogLevel).Infof("Checking operator readiness for package=%s, namespace=%s",
@@ -170,49 +207,36 @@ func (o *OperatorInstaller) validateConfig() error {
 	return nil
 }
 
-// createNamespaceIfNotExist creates the namespace if it doesn't exist.
-func (o *OperatorInstaller) createNamespaceIfNotExist() error {
-	klog.V(o.config.LogLevel).Infof("Creating namespace %s if it doesn't exist", o.config.Namespace)
-
+// waitForTerminatingNamespace waits for the namespace to be deleted if it is terminating, so that it can be created
+// again.
+func (o *OperatorInstaller) waitForTerminatingNamespace() error {
 	nsBuilder := namespace.NewBuilder(o.config.APIClient, o.config.Namespace)
-
-	if nsBuilder.Exists() {
-		nsObj, err := o.config.APIClient.CoreV1Interface.Namespaces().Get(
-			context.TODO(), o.config.Namespace, metav1.GetOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to get namespace %s status: %w", o.config.Namespace, err)
-		}
-
-		if nsObj.Status.Phase == corev1.NamespaceTerminating {
-			klog.V(o.config.LogLevel).
-				Infof("Namespace %s is terminating, waiting for deletion to complete...",
-					o.config.Namespace)
-
-			if err := o.waitForNamespaceDeletion(); err != nil {
-				return fmt.Errorf("failed waiting for namespace %s deletion: %w", o.config.Namespace, err)
-			}
-
-			klog.V(o.config.LogLevel).
-				Infof("Namespace deletion complete, creating fresh namespace %s",
-					o.config.Namespace)
-		} else {
-			klog.V(o.config.LogLevel).
-				Infof("Namespace %s already exists and is active",
-					o.config.Namespace)
-
-			return nil
-		}
+	if !nsBuilder.Exists() {
+		return nil
 	}
 
-	_, err := nsBuilder.Create()
+	nsObj, err := o.config.APIClient.CoreV1Interface.Namespaces().Get(
+		context.TODO(), o.config.Namespace, metav1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to create namespace %s: %w", o.config.Namespace, err)
+		return fmt.Errorf("failed to get namespace %s status: %w", o.config.Namespace, err)
+	}
+
+	if nsObj.Status.Phase != corev1.NamespaceTerminating {
+		klog.V(o.config.LogLevel).
+			Infof("Namespace %s already exists and is active",
+				o.config.Namespace)
+
+		return nil
 	}
 
 	klog.V(o.config.LogLevel).
-		Infof("Successfully created namespace %s",
+		Infof("Namespace %s is terminating, waiting for deletion to complete...",
 			o.config.Namespace)
 
+	if err := o.waitForNamespaceDeletion(); err != nil {
+		return fmt.Errorf("failed waiting for namespace %s deletion: %w", o.config.Namespace, err)
+	}
+
 	return nil
 }
 
@@ -248,88 +272,6 @@ func (o *OperatorInstaller) waitForNamespaceDeletion() error {
 	return nil
 }
 
-// createOperatorGroup creates the operator group if it doesn't exist.
-func (o *OperatorInstaller) createOperatorGroup() error {
-	klog.V(o.config.LogLevel).Infof("Creating operator group %s in namespace %s",
-		o.config.OperatorGroupName, o.config.Namespace)
-
-	operatorGroupBuilder := olm.NewOperatorGroupBuilder(
-		o.config.APIClient, o.config.OperatorGroupName, o.config.Namespace)
-
-	if len(o.config.TargetNamespaces) > 0 {
-		operatorGroupBuilder.Definition.Spec.TargetNamespaces = o.config.TargetNamespaces
-		klog.V(o.config.LogLevel).Infof("OperatorGroup will watch namespaces: %v", o.config.TargetNamespaces)
-	} else {
-		operatorGroupBuilder.Definition.Spec.TargetNamespaces = nil
-
-		klog.V(o.config.LogLevel).Infof("OperatorGroup will watch AllNamespaces (targetNamespaces set to nil)")
-	}
-
-	if operatorGroupBuilder.Exists() {
-		klog.V(o.config.LogLevel).Infof("OperatorGroup %s already exists", o.config.OperatorGroupName)
-
-		return nil
-	}
-
-	_, err := operatorGroupBuilder.Create()
-	if err != nil {
-		if strings.Contains(err.Error(), "is being terminated") ||
-			strings.Contains(err.Error(), "NamespaceTerminating") {
-			return fmt.Errorf("cannot create operator group in terminating namespace %s. "+
-				"Please wait for namespace deletion to complete or use a different namespace: %w",
-				o.config.Namespace, err)
-		}
-
-		return fmt.Errorf("failed to create operator group %s: %w", o.config.OperatorGroupName, err)
-	}
-
-	return nil
-}
-
-// createSubscription creates the subscription if it doesn't exist.
-func (o *OperatorInstaller) createSubscription() error {
-	klog.V(o.config.LogLevel).Infof("Creating subscription %s in namespace %s",
-		o.config.SubscriptionName, o.config.Namespace)
-	klog.V(o.config.LogLevel).Infof("Subscription details: Package=%s, CatalogSource=%s, Channel=%s",
-		o.config.PackageName, o.config.CatalogSource, o.config.Channel)
-
-	sub := olm.NewSubscriptionBuilder(
-		o.config.APIClient,
-		o.config.SubscriptionName,
-		o.config.Namespace,
-		o.config.CatalogSource,
-		o.config.CatalogSourceNamespace,
-		o.config.PackageName)
-
-	sub.WithChannel(o.config.Channel)
-	sub.WithInstallPlanApproval(convertToInstallPlanApproval(o.config.InstallPlanApproval))
-
-	if o.config.StartingCSV != "" {
-		klog.V(o.config.LogLevel).Infof("Setting StartingCSV to %s", o.config.StartingCSV)
-		sub.WithStartingCSV(o.config.StartingCSV)
-	}
-
-	if sub.Exists() {
-		klog.V(o.config.LogLevel).Infof("Subscription %s already exists", o.config.SubscriptionName)
-
-		return nil
-	}
-
-	_, err := sub.Create()
-	if err != nil {
-		if strings.Contains(err.Error(), "is being terminated") ||
-			strings.Contains(err.Error(), "NamespaceTerminating") {
-			return fmt.Errorf("cannot create subscription in terminating namespace %s. "+
-				"Please wait for namespace deletion to complete or use a different namespace: %w",
-				o.config.Namespace, err)
-		}
-
-		return fmt.Errorf("failed to create subscription %s: %w", o.config.SubscriptionName, err)
-	}
-
-	return nil
-}
-
 func convertToInstallPlanApproval(approval string) operatorsV1alpha1.Approval {
 	switch strings.ToLower(approval) {
 	case "manual":
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -9,10 +10,8 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/kmm"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/serviceaccount"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/1upgrade/internal/await"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/1upgrade/internal/tsparams"
 	kmmawait "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/await"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/check"
@@ -20,6 +19,7 @@ import (
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmminittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/olmlifecycle"
 	"k8s.io/klog/v2"
 )
 
@@ -174,32 +174,40 @@ var _ = Describe("KMM", Ordered, Label(tsparams.LabelSuite), func() {
 
 		It("should upgrade successfully with module deployed", reportxml.ID("53609"), func() {
 			opNamespace := kmmparams.KmmOperatorNamespace
+			packageName := kmmparams.KmmOperatorPackageName
+
 			if check.IsKMMHub() {
 				opNamespace = kmmparams.KmmHubOperatorNamespace
+				packageName = kmmparams.KmmHubOperatorPackageName
 			}
 
 			By("Getting KMM subscription")
 
-			sub, err := olm.PullSubscription(APIClient, ModulesConfig.SubscriptionName, opNamespace)
+			kmmManager, err := olmlifecycle.NewManager(APIClient, olmlifecycle.Config{
+				Namespace:           opNamespace,
+				PackageName:         packageName,
+				SubscriptionName:    ModulesConfig.SubscriptionName,
+				SharedNamespace:     true,
+				SharedOperatorGroup: true,
+			})
+			Expect(err).ToNot(HaveOccurred(), "failed creating KMM lifecycle manager")
+
+			subscription, err := kmmManager.Subscription()
 			Expect(err).ToNot(HaveOccurred(), "failed getting subscription")
 
-			By("Update subscription to use new channel, if defined")
+			By("Update subscription to use new catalog source and channel, if defined")
+			klog.V(90).Infof("Subscription's catalog source: %s", subscription.Object.Spec.CatalogSource)
 
-			if ModulesConfig.CatalogSourceChannel != "" {
-				klog.V(90).Infof("setting subscription channel to: %s", ModulesConfig.CatalogSourceChannel)
-				sub.Definition.Spec.Channel = ModulesConfig.CatalogSourceChannel
-			}
-
-			By("Update subscription to use new catalog source")
-			klog.V(90).Infof("Subscription's catalog source: %s", sub.Object.Spec.CatalogSource)
-			sub.Definition.Spec.CatalogSource = ModulesConfig.CatalogSourceName
-			_, err = sub.Update()
+			err = kmmManager.SwitchChannel(ModulesConfig.CatalogSourceChannel, ModulesConfig.CatalogSourceName)
 			Expect(err).ToNot(HaveOccurred(), "failed updating subscription")
 
 			By("Await operator to be upgraded")
 
-			err = await.OperatorUpgrade(APIClient, ModulesConfig.UpgradeTargetVersion, 5*time.Minute)
+			csvName, err := kmmManager.WaitForInstalledVersion(
+				"^"+regexp.QuoteMeta(ModulesConfig.UpgradeTargetVersion)+"$", 5*time.Minute)
 			Expect(err).ToNot(HaveOccurred(), "failed awaiting subscription upgrade")
+			klog.V(90).Infof("KMM upgraded to CSV %s", csvName)
+
 			// Skip module verification for KMM-HUB since no module was deployed on hub
 			if !check.IsKMMHub() {
 				By("Check module label is still set on nodes after upgrade")
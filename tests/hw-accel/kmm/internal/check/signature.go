@@ -0,0 +1,345 @@
+package check
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/registry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// moduleSignatureMagic is appended by the kernel sign-file tool after the signature of a module.
+	moduleSignatureMagic = "~Module signature appended~\n"
+	// moduleSignatureInfoSize is the size of struct module_signature, which precedes the magic.
+	moduleSignatureInfoSize = 12
+	// moduleSignatureIDTypePKCS7 is the module_signature id_type of PKCS#7 signatures.
+	moduleSignatureIDTypePKCS7 = 2
+)
+
+var (
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+
+	// signatureAlgorithms maps the digest algorithm of a signature and the key algorithm of the signing certificate to
+	// the x509 signature algorithm used to verify it.
+	signatureAlgorithms = map[string]map[x509.PublicKeyAlgorithm]x509.SignatureAlgorithm{
+		"2.16.840.1.101.3.4.2.1": {x509.RSA: x509.SHA256WithRSA, x509.ECDSA: x509.ECDSAWithSHA256},
+		"2.16.840.1.101.3.4.2.2": {x509.RSA: x509.SHA384WithRSA, x509.ECDSA: x509.ECDSAWithSHA384},
+		"2.16.840.1.101.3.4.2.3": {x509.RSA: x509.SHA512WithRSA, x509.ECDSA: x509.ECDSAWithSHA512},
+	}
+)
+
+// pkcs7ContentInfo is the outer structure of a PKCS#7 message.
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+// pkcs7SignedData is the PKCS#7 SignedData structure. Module signatures are detached and carry no certificates.
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue     `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue     `asn1:"optional,tag:1"`
+	SignerInfos      []pkcs7SignerInfo `asn1:"set"`
+}
+
+// pkcs7SignerInfo is the PKCS#7 SignerInfo structure.
+type pkcs7SignerInfo struct {
+	Version            int
+	SignerIdentifier   asn1.RawValue
+	DigestAlgorithm    pkcs7AlgorithmIdentifier
+	SignedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkcs7AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+// pkcs7AlgorithmIdentifier is an algorithm identifier whose parameters are ignored.
+type pkcs7AlgorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// pkcs7IssuerAndSerial identifies the signing certificate by issuer and serial number.
+type pkcs7IssuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+// pkcs7Attribute is an attribute of the signed attributes.
+type pkcs7Attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// LocalImageModulesSigned verifies that the kernel modules named in modNames, found in the image pushed to the local
+// registry as repository:tag for architecture, carry a signature that verifies against certDER, a DER encoded
+// certificate such as the one returned by get.SigningData. Unlike ModuleSigned, the signature itself is verified and
+// no pod is needed since the image layers are read from the registry.
+func LocalImageModulesSigned(localRegistry *registry.Registry, repository, tag, architecture string,
+	modNames []string, certDER []byte) error {
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+
+	modules, err := localRegistry.KernelModules(repository, tag, architecture)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+
+	for _, modName := range modNames {
+		modulePath, module, found := findModule(modules, modName)
+		if !found {
+			errs = append(errs, fmt.Sprintf("module %s not found in image", modName))
+
+			continue
+		}
+
+		err = verifyModuleSignature(module, cert)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("module %s: %v", modulePath, err))
+
+			continue
+		}
+
+		klog.V(kmmparams.KmmLogLevel).Infof("Module %s is signed by %s", modulePath, cert.Subject.CommonName)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("signature verification failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// LocalImageArchitectures verifies that the image pushed to the local registry as repository:tag is available for
+// every architecture in architectures, either as a single manifest or as an entry of a multi-arch index.
+func LocalImageArchitectures(localRegistry *registry.Registry, repository, tag string, architectures []string) error {
+	platforms, err := localRegistry.Platforms(repository, tag)
+	if err != nil {
+		return err
+	}
+
+	var available []string
+
+	for _, platform := range platforms {
+		klog.V(kmmparams.KmmLogLevel).Infof("Image %s:%s is available for %s", repository, tag, platform)
+
+		available = append(available, platform.Architecture)
+	}
+
+	var missing []string
+
+	for _, architecture := range architectures {
+		if !slices.Contains(available, architecture) {
+			missing = append(missing, architecture)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("image %s:%s is not available for %v, only for %v", repository, tag, missing, available)
+	}
+
+	return nil
+}
+
+// findModule returns the module file named modName.ko from modules.
+func findModule(modules map[string][]byte, modName string) (string, []byte, bool) {
+	for modulePath, module := range modules {
+		if path.Base(modulePath) == modName+".ko" {
+			return modulePath, module, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// verifyModuleSignature verifies the PKCS#7 signature appended to module by the kernel sign-file tool against cert.
+func verifyModuleSignature(module []byte, cert *x509.Certificate) error {
+	content, signature, err := splitModuleSignature(module)
+	if err != nil {
+		return err
+	}
+
+	signerInfo, err := parseSignerInfo(signature)
+	if err != nil {
+		return err
+	}
+
+	err = verifySigner(signerInfo.SignerIdentifier, cert)
+	if err != nil {
+		return err
+	}
+
+	algorithm, found := signatureAlgorithms[signerInfo.DigestAlgorithm.Algorithm.String()][cert.PublicKeyAlgorithm]
+	if !found {
+		return fmt.Errorf("unsupported digest algorithm %s for %s key",
+			signerInfo.DigestAlgorithm.Algorithm, cert.PublicKeyAlgorithm)
+	}
+
+	signed := content
+
+	if len(signerInfo.SignedAttributes.FullBytes) > 0 {
+		signed, err = signedAttributes(signerInfo.SignedAttributes, content, algorithm)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cert.CheckSignature(algorithm, signed, signerInfo.Signature)
+	if err != nil {
+		return fmt.Errorf("signature does not verify against %s: %w", cert.Subject.CommonName, err)
+	}
+
+	return nil
+}
+
+// splitModuleSignature returns the module content and the PKCS#7 signature appended to it.
+func splitModuleSignature(module []byte) ([]byte, []byte, error) {
+	if !bytes.HasSuffix(module, []byte(moduleSignatureMagic)) {
+		return nil, nil, fmt.Errorf("module is not signed")
+	}
+
+	infoEnd := len(module) - len(moduleSignatureMagic)
+	if infoEnd < moduleSignatureInfoSize {
+		return nil, nil, fmt.Errorf("module signature is truncated")
+	}
+
+	info := module[infoEnd-moduleSignatureInfoSize : infoEnd]
+	if info[2] != moduleSignatureIDTypePKCS7 {
+		return nil, nil, fmt.Errorf("unsupported module signature type %d", info[2])
+	}
+
+	signatureLength := int(binary.BigEndian.Uint32(info[8:]))
+	signatureStart := infoEnd - moduleSignatureInfoSize - signatureLength
+
+	if signatureStart < 0 {
+		return nil, nil, fmt.Errorf("module signature length %d exceeds module size", signatureLength)
+	}
+
+	return module[:signatureStart], module[signatureStart : infoEnd-moduleSignatureInfoSize], nil
+}
+
+// parseSignerInfo returns the single SignerInfo of the PKCS#7 signature.
+func parseSignerInfo(signature []byte) (*pkcs7SignerInfo, error) {
+	contentInfo := pkcs7ContentInfo{}
+
+	_, err := asn1.Unmarshal(signature, &contentInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#7 signature: %w", err)
+	}
+
+	signedData := pkcs7SignedData{}
+
+	_, err = asn1.Unmarshal(contentInfo.Content.Bytes, &signedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#7 signed data: %w", err)
+	}
+
+	if len(signedData.SignerInfos) != 1 {
+		return nil, fmt.Errorf("expected one signer, found %d", len(signedData.SignerInfos))
+	}
+
+	return &signedData.SignerInfos[0], nil
+}
+
+// verifySigner checks that the signer identifier, either issuer and serial number or subject key identifier,
+// designates cert.
+func verifySigner(signerIdentifier asn1.RawValue, cert *x509.Certificate) error {
+	if signerIdentifier.Class == asn1.ClassContextSpecific && signerIdentifier.Tag == 0 {
+		if !bytes.Equal(signerIdentifier.Bytes, cert.SubjectKeyId) {
+			return fmt.Errorf("signed with key %X, not the key %X of %s",
+				signerIdentifier.Bytes, cert.SubjectKeyId, cert.Subject.CommonName)
+		}
+
+		return nil
+	}
+
+	issuerAndSerial := pkcs7IssuerAndSerial{}
+
+	_, err := asn1.Unmarshal(signerIdentifier.FullBytes, &issuerAndSerial)
+	if err != nil {
+		return fmt.Errorf("failed to parse signer identifier: %w", err)
+	}
+
+	if !bytes.Equal(issuerAndSerial.Issuer.FullBytes, cert.RawIssuer) ||
+		issuerAndSerial.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		return fmt.Errorf("signed by certificate with serial %X, not %s with serial %X",
+			issuerAndSerial.SerialNumber, cert.Subject.CommonName, cert.SerialNumber)
+	}
+
+	return nil
+}
+
+// signedAttributes checks that the message digest attribute matches content and returns the DER encoding of the
+// attributes, which is what the signature covers when they are present.
+func signedAttributes(
+	attributes asn1.RawValue, content []byte, algorithm x509.SignatureAlgorithm) ([]byte, error) {
+	var parsed []pkcs7Attribute
+
+	// The attributes are IMPLICIT [0] in SignerInfo but are signed as a SET.
+	encoded := slices.Clone(attributes.FullBytes)
+	encoded[0] = 0x31
+
+	_, err := asn1.UnmarshalWithParams(encoded, &parsed, "set")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signed attributes: %w", err)
+	}
+
+	hash, err := hashFor(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := hash.New()
+	digest.Write(content)
+
+	for _, attribute := range parsed {
+		if !attribute.Type.Equal(oidMessageDigest) {
+			continue
+		}
+
+		var messageDigest []byte
+
+		_, err = asn1.Unmarshal(attribute.Values.Bytes, &messageDigest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message digest attribute: %w", err)
+		}
+
+		if !bytes.Equal(messageDigest, digest.Sum(nil)) {
+			return nil, fmt.Errorf("message digest does not match module content")
+		}
+
+		return encoded, nil
+	}
+
+	return nil, fmt.Errorf("signed attributes have no message digest")
+}
+
+// hashFor returns the hash function of the x509 signature algorithm.
+func hashFor(algorithm x509.SignatureAlgorithm) (crypto.Hash, error) {
+	switch algorithm {
+	case x509.SHA256WithRSA, x509.ECDSAWithSHA256:
+		return crypto.SHA256, nil
+	case x509.SHA384WithRSA, x509.ECDSAWithSHA384:
+		return crypto.SHA384, nil
+	case x509.SHA512WithRSA, x509.ECDSAWithSHA512:
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported signature algorithm %s", algorithm)
+	}
+}
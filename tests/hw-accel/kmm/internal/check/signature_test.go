@@ -0,0 +1,406 @@
+package check
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	oidData        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidRSA         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSA       = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	digestOIDs = map[crypto.Hash]asn1.ObjectIdentifier{
+		crypto.SHA256: {2, 16, 840, 1, 101, 3, 4, 2, 1},
+		crypto.SHA384: {2, 16, 840, 1, 101, 3, 4, 2, 2},
+		crypto.SHA512: {2, 16, 840, 1, 101, 3, 4, 2, 3},
+	}
+)
+
+// testSigner is a certificate and the key it certifies, used to sign test modules.
+type testSigner struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// signOptions selects how signModule builds the PKCS#7 signature.
+type signOptions struct {
+	hash           crypto.Hash
+	useKeyID       bool
+	withAttributes bool
+}
+
+func TestVerifyModuleSignature(t *testing.T) {
+	rsaSigner := newTestSigner(t, x509.RSA, "rsa-signer")
+	ecdsaSigner := newTestSigner(t, x509.ECDSA, "ecdsa-signer")
+	otherSigner := newTestSigner(t, x509.RSA, "other-signer")
+	content := []byte("\x7fELF kernel module content")
+
+	testCases := []struct {
+		name          string
+		signer        testSigner
+		options       signOptions
+		verifyWith    testSigner
+		tamper        func(module []byte) []byte
+		expectedError string
+	}{
+		{
+			name:       "rsa sha256 issuer and serial",
+			signer:     rsaSigner,
+			options:    signOptions{hash: crypto.SHA256},
+			verifyWith: rsaSigner,
+		},
+		{
+			name:       "rsa sha512 key id with attributes",
+			signer:     rsaSigner,
+			options:    signOptions{hash: crypto.SHA512, useKeyID: true, withAttributes: true},
+			verifyWith: rsaSigner,
+		},
+		{
+			name:       "ecdsa sha384 key id",
+			signer:     ecdsaSigner,
+			options:    signOptions{hash: crypto.SHA384, useKeyID: true},
+			verifyWith: ecdsaSigner,
+		},
+		{
+			name:       "ecdsa sha256 issuer and serial with attributes",
+			signer:     ecdsaSigner,
+			options:    signOptions{hash: crypto.SHA256, withAttributes: true},
+			verifyWith: ecdsaSigner,
+		},
+		{
+			name:          "other certificate by issuer and serial",
+			signer:        rsaSigner,
+			options:       signOptions{hash: crypto.SHA256},
+			verifyWith:    otherSigner,
+			expectedError: "not other-signer",
+		},
+		{
+			name:          "other certificate by key id",
+			signer:        rsaSigner,
+			options:       signOptions{hash: crypto.SHA256, useKeyID: true},
+			verifyWith:    otherSigner,
+			expectedError: "not the key",
+		},
+		{
+			name:          "tampered content",
+			signer:        rsaSigner,
+			options:       signOptions{hash: crypto.SHA256},
+			verifyWith:    rsaSigner,
+			tamper:        flipFirstByte,
+			expectedError: "signature does not verify",
+		},
+		{
+			name:          "tampered content with attributes",
+			signer:        ecdsaSigner,
+			options:       signOptions{hash: crypto.SHA256, withAttributes: true},
+			verifyWith:    ecdsaSigner,
+			tamper:        flipFirstByte,
+			expectedError: "message digest does not match",
+		},
+		{
+			name:          "unsigned",
+			signer:        rsaSigner,
+			options:       signOptions{hash: crypto.SHA256},
+			verifyWith:    rsaSigner,
+			tamper:        func([]byte) []byte { return slices.Clone(content) },
+			expectedError: "module is not signed",
+		},
+		{
+			name:          "truncated",
+			signer:        rsaSigner,
+			options:       signOptions{hash: crypto.SHA256},
+			verifyWith:    rsaSigner,
+			tamper:        func([]byte) []byte { return []byte("sig" + moduleSignatureMagic) },
+			expectedError: "module signature is truncated",
+		},
+		{
+			name:       "unsupported signature type",
+			signer:     rsaSigner,
+			options:    signOptions{hash: crypto.SHA256},
+			verifyWith: rsaSigner,
+			tamper: func(module []byte) []byte {
+				module[len(module)-len(moduleSignatureMagic)-moduleSignatureInfoSize+2] = 1
+
+				return module
+			},
+			expectedError: "unsupported module signature type 1",
+		},
+		{
+			name:       "signature length exceeds module",
+			signer:     rsaSigner,
+			options:    signOptions{hash: crypto.SHA256},
+			verifyWith: rsaSigner,
+			tamper: func(module []byte) []byte {
+				infoEnd := len(module) - len(moduleSignatureMagic)
+				binary.BigEndian.PutUint32(module[infoEnd-4:infoEnd], uint32(len(module)))
+
+				return module
+			},
+			expectedError: "exceeds module size",
+		},
+	}
+
+	for _, testCase := range testCases {
+		module := signModule(t, testCase.signer, content, testCase.options)
+
+		if testCase.tamper != nil {
+			module = testCase.tamper(module)
+		}
+
+		err := verifyModuleSignature(module, testCase.verifyWith.cert)
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError, testCase.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, testCase.name)
+	}
+}
+
+// TestVerifyModuleSignatureOpenSSL verifies signatures produced by openssl cms the way the kernel sign-file tool
+// produces them, so that the parser is checked against an independent implementation.
+func TestVerifyModuleSignatureOpenSSL(t *testing.T) {
+	if _, err := exec.LookPath("openssl"); err != nil {
+		t.Skip("openssl is not available")
+	}
+
+	directory := t.TempDir()
+	keyPath := filepath.Join(directory, "key.pem")
+	certPath := filepath.Join(directory, "cert.pem")
+	modulePath := filepath.Join(directory, "module.ko")
+	content := []byte("\x7fELF kernel module content signed by openssl")
+
+	runOpenSSL(t, "req", "-new", "-x509", "-newkey", "rsa:2048", "-nodes", "-days", "1", "-subj", "/CN=openssl-signer",
+		"-keyout", keyPath, "-out", certPath)
+
+	err := os.WriteFile(modulePath, content, 0o600)
+	assert.NoError(t, err)
+
+	certPEM, err := os.ReadFile(certPath)
+	assert.NoError(t, err)
+
+	block, _ := pem.Decode(certPEM)
+	if !assert.NotNil(t, block) {
+		return
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "sign-file defaults", args: []string{"-noattr"}},
+		{name: "sign-file key id", args: []string{"-noattr", "-keyid"}},
+		{name: "signed attributes"},
+	}
+
+	for index, testCase := range testCases {
+		signaturePath := filepath.Join(directory, string(rune('a'+index)))
+
+		runOpenSSL(t, append([]string{"cms", "-sign", "-binary", "-nocerts", "-outform", "DER", "-md", "sha256",
+			"-signer", certPath, "-inkey", keyPath, "-in", modulePath, "-out", signaturePath}, testCase.args...)...)
+
+		signature, err := os.ReadFile(signaturePath)
+		assert.NoError(t, err, testCase.name)
+
+		module := appendModuleSignature(content, signature)
+
+		assert.NoError(t, verifyModuleSignature(module, cert), testCase.name)
+
+		module[0] ^= 0xff
+		assert.Error(t, verifyModuleSignature(module, cert), testCase.name)
+	}
+}
+
+func TestFindModule(t *testing.T) {
+	modules := map[string][]byte{
+		"opt/lib/modules/5.14.0/kmm_ci_a.ko": []byte("a"),
+		"opt/lib/modules/5.14.0/kmm_ci_b.ko": []byte("b"),
+	}
+
+	modulePath, module, found := findModule(modules, "kmm_ci_b")
+	assert.True(t, found)
+	assert.Equal(t, "opt/lib/modules/5.14.0/kmm_ci_b.ko", modulePath)
+	assert.Equal(t, []byte("b"), module)
+
+	_, _, found = findModule(modules, "kmm_ci")
+	assert.False(t, found)
+}
+
+// newTestSigner returns a self-signed certificate with a subject key identifier for a new key of keyAlgorithm.
+func newTestSigner(t *testing.T, keyAlgorithm x509.PublicKeyAlgorithm, commonName string) testSigner {
+	t.Helper()
+
+	var (
+		key crypto.Signer
+		err error
+	)
+
+	switch keyAlgorithm {
+	case x509.RSA:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		key, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	}
+
+	assert.NoError(t, err)
+
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.NoError(t, err)
+
+	subjectKeyID := make([]byte, 20)
+	_, err = rand.Read(subjectKeyID)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		SubjectKeyId: subjectKeyID,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	assert.NoError(t, err)
+
+	return testSigner{cert: cert, key: key}
+}
+
+// signModule returns content with a detached PKCS#7 signature appended in the layout of the kernel sign-file tool.
+func signModule(t *testing.T, signer testSigner, content []byte, options signOptions) []byte {
+	t.Helper()
+
+	digest := options.hash.New()
+	digest.Write(content)
+
+	signerInfo := pkcs7SignerInfo{
+		Version:            1,
+		DigestAlgorithm:    pkcs7AlgorithmIdentifier{Algorithm: digestOIDs[options.hash]},
+		SignatureAlgorithm: pkcs7AlgorithmIdentifier{Algorithm: oidRSA},
+	}
+
+	if signer.cert.PublicKeyAlgorithm == x509.ECDSA {
+		signerInfo.SignatureAlgorithm.Algorithm = oidECDSA
+	}
+
+	if options.useKeyID {
+		signerInfo.Version = 3
+		signerInfo.SignerIdentifier = asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0,
+			Bytes: signer.cert.SubjectKeyId}
+	} else {
+		issuerAndSerial, err := asn1.Marshal(pkcs7IssuerAndSerial{
+			Issuer: asn1.RawValue{FullBytes: signer.cert.RawIssuer}, SerialNumber: signer.cert.SerialNumber})
+		assert.NoError(t, err)
+
+		signerInfo.SignerIdentifier = asn1.RawValue{FullBytes: issuerAndSerial}
+	}
+
+	signed := content
+
+	if options.withAttributes {
+		attributes := []pkcs7Attribute{
+			{Type: oidContentType, Values: setOf(t, oidData)},
+			{Type: oidMessageDigest, Values: setOf(t, digest.Sum(nil))},
+		}
+
+		encoded, err := asn1.MarshalWithParams(attributes, "set")
+		assert.NoError(t, err)
+
+		signed = encoded
+		signerInfo.SignedAttributes = asn1.RawValue{FullBytes: slices.Clone(encoded)}
+		signerInfo.SignedAttributes.FullBytes[0] = 0xa0
+	}
+
+	signedDigest := options.hash.New()
+	signedDigest.Write(signed)
+
+	signature, err := signer.key.Sign(rand.Reader, signedDigest.Sum(nil), options.hash)
+	assert.NoError(t, err)
+
+	signerInfo.Signature = signature
+
+	digestAlgorithms, err := asn1.MarshalWithParams([]pkcs7AlgorithmIdentifier{signerInfo.DigestAlgorithm}, "set")
+	assert.NoError(t, err)
+
+	contentInfo, err := asn1.Marshal(struct{ ContentType asn1.ObjectIdentifier }{oidData})
+	assert.NoError(t, err)
+
+	signedData, err := asn1.Marshal(pkcs7SignedData{
+		Version:          signerInfo.Version,
+		DigestAlgorithms: asn1.RawValue{FullBytes: digestAlgorithms},
+		ContentInfo:      asn1.RawValue{FullBytes: contentInfo},
+		SignerInfos:      []pkcs7SignerInfo{signerInfo},
+	})
+	assert.NoError(t, err)
+
+	message, err := asn1.Marshal(pkcs7ContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedData},
+	})
+	assert.NoError(t, err)
+
+	return appendModuleSignature(content, message)
+}
+
+// appendModuleSignature appends signature, struct module_signature and the magic to content.
+func appendModuleSignature(content, signature []byte) []byte {
+	info := make([]byte, moduleSignatureInfoSize)
+	info[2] = moduleSignatureIDTypePKCS7
+	binary.BigEndian.PutUint32(info[8:], uint32(len(signature)))
+
+	module := slices.Concat(content, signature, info, []byte(moduleSignatureMagic))
+
+	return module
+}
+
+// flipFirstByte corrupts the content of module.
+func flipFirstByte(module []byte) []byte {
+	module[0] ^= 0xff
+
+	return module
+}
+
+// setOf returns the DER encoding of a SET holding value.
+func setOf(t *testing.T, value any) asn1.RawValue {
+	t.Helper()
+
+	encoded, err := asn1.Marshal(value)
+	assert.NoError(t, err)
+
+	return asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: encoded}
+}
+
+func runOpenSSL(t *testing.T, args ...string) {
+	t.Helper()
+
+	output, err := exec.Command("openssl", args...).CombinedOutput()
+	assert.NoError(t, err, "openssl %v: %s", args, output)
+}
@@ -19,6 +19,7 @@ type ModulesConfig struct {
 	CatalogSourceName    string `envconfig:"ECO_HWACCEL_KMM_CATALOG_SOURCE_NAME"`
 	CatalogSourceChannel string `envconfig:"ECO_HWACCEL_KMM_CATALOG_SOURCE_CHANNEL"`
 	UpgradeTargetVersion string `envconfig:"ECO_HWACCEL_KMM_UPGRADE_TARGET_VERSION"`
+	LocalRegistryImage   string `envconfig:"ECO_HWACCEL_KMM_LOCAL_REGISTRY_IMAGE"`
 	SpokeKubeConfig      string `envconfig:"ECO_HWACCEL_KMM_SPOKE_KUBECONFIG"`
 	SpokeClusterName     string `envconfig:"ECO_HWACCEL_KMM_SPOKE_CLUSTER_NAME"`
 	SpokeAPIClient       *clients.Settings
@@ -37,6 +38,10 @@ func NewModulesConfig() *ModulesConfig {
 		return nil
 	}
 
+	if modulesConfig.LocalRegistryImage == "" {
+		modulesConfig.LocalRegistryImage = kmmparams.LocalRegistryImage
+	}
+
 	if modulesConfig.SpokeKubeConfig != "" {
 		klog.V(kmmparams.KmmLogLevel).Infof("Creating spoke api client from %s", modulesConfig.SpokeKubeConfig)
 
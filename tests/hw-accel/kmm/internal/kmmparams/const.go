@@ -249,6 +249,10 @@ const (
 	KmmOperatorNamespace = "openshift-kmm"
 	// KmmHubOperatorNamespace represents namespace of the operator.
 	KmmHubOperatorNamespace = "openshift-kmm-hub"
+	// KmmOperatorPackageName represents the package name of the KMM operator.
+	KmmOperatorPackageName = "kernel-module-management"
+	// KmmHubOperatorPackageName represents the package name of the KMM HUB operator.
+	KmmHubOperatorPackageName = "kernel-module-management-hub"
 	// DeploymentName represents the name of the KMM operator deployment.
 	DeploymentName = "kmm-operator-controller"
 	// WebhookDeploymentName represents the name of the Webhook server deployment.
@@ -303,6 +307,18 @@ const (
 	AutomountSATokenTestNamespace = "automount-satoken"
 	// FilesToSignGlobTestNamespace represents test case namespace name for filesToSign glob tests.
 	FilesToSignGlobTestNamespace = "filestosign-glob"
+	// LocalRegistryBuildNamespace represents test case namespace name for builds pushed to the local registry.
+	LocalRegistryBuildNamespace = "local-registry-build"
+	// LocalRegistryNamespace represents the namespace of the in-cluster test registry.
+	LocalRegistryNamespace = "kmm-local-registry"
+	// LocalRegistryName represents the name of the in-cluster test registry resources.
+	LocalRegistryName = "kmm-registry"
+	// LocalRegistryImage represents the default image of the in-cluster test registry.
+	LocalRegistryImage = "quay.io/libpod/registry:2.8.2"
+	// LocalRegistryUser represents the user allowed to push to and pull from the in-cluster test registry.
+	LocalRegistryUser = "kmm"
+	// LocalRegistryPort represents the port the in-cluster test registry listens on.
+	LocalRegistryPort = 5000
 	// DefaultNodesNamespace represents namespace of the nodes events.
 	DefaultNodesNamespace = "default"
 	// SimpleKmodImage represents the pre-built simple-kmod kernel module image.
@@ -0,0 +1,291 @@
+package registry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
+	"k8s.io/klog/v2"
+)
+
+const (
+	mediaTypeOCIIndex             = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest          = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList           = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest       = "application/vnd.docker.distribution.manifest.v2+json"
+	whiteoutPrefix                = ".wh."
+	opaqueWhiteout                = ".wh..wh..opq"
+	kernelModuleExtension         = ".ko"
+	maxKernelModuleSize     int64 = 64 << 20
+)
+
+// manifestMediaTypes are the manifest media types accepted from the registry.
+var manifestMediaTypes = []string{mediaTypeOCIIndex, mediaTypeDockerList, mediaTypeOCIManifest, mediaTypeDockerManifest}
+
+// Platform is the platform an image manifest is built for.
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// String returns the platform as os/architecture[/variant].
+func (platform Platform) String() string {
+	if platform.Variant == "" {
+		return platform.OS + "/" + platform.Architecture
+	}
+
+	return platform.OS + "/" + platform.Architecture + "/" + platform.Variant
+}
+
+// Descriptor references a manifest, config or layer blob.
+type Descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Digest    string    `json:"digest"`
+	Size      int64     `json:"size"`
+	Platform  *Platform `json:"platform,omitempty"`
+}
+
+// Manifest is either an image manifest or, for multi-arch images, an index listing a manifest per platform. It covers
+// both the OCI and the Docker v2 media types.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+	Manifests     []Descriptor `json:"manifests"`
+}
+
+// IsIndex returns true if the manifest lists a manifest per platform.
+func (manifest *Manifest) IsIndex() bool {
+	return manifest.MediaType == mediaTypeOCIIndex || manifest.MediaType == mediaTypeDockerList ||
+		len(manifest.Manifests) > 0
+}
+
+// Manifest returns the manifest of repository at reference, a tag or a digest.
+func (registry *Registry) Manifest(repository, reference string) (*Manifest, error) {
+	response, err := registry.get(context.TODO(), fmt.Sprintf("/v2/%s/manifests/%s", repository, reference),
+		strings.Join(manifestMediaTypes, ","))
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get manifest %s:%s: %s", repository, reference, response.Status)
+	}
+
+	manifest := &Manifest{}
+
+	err = json.NewDecoder(response.Body).Decode(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %s:%s: %w", repository, reference, err)
+	}
+
+	if manifest.MediaType == "" {
+		manifest.MediaType = response.Header.Get("Content-Type")
+	}
+
+	return manifest, nil
+}
+
+// Platforms returns the platforms repository:reference is available for. For a single manifest, the platform is read
+// from the image config. Entries of an index without a platform, such as attestations, are skipped.
+func (registry *Registry) Platforms(repository, reference string) ([]Platform, error) {
+	manifest, err := registry.Manifest(repository, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.IsIndex() {
+		var platforms []Platform
+
+		for _, descriptor := range manifest.Manifests {
+			if descriptor.Platform == nil || descriptor.Platform.Architecture == "unknown" {
+				continue
+			}
+
+			platforms = append(platforms, *descriptor.Platform)
+		}
+
+		return platforms, nil
+	}
+
+	platform := Platform{}
+
+	err = registry.getJSONBlob(repository, manifest.Config.Digest, &platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image config of %s:%s: %w", repository, reference, err)
+	}
+
+	return []Platform{platform}, nil
+}
+
+// KernelModules returns the content of every kernel module file in repository:reference, keyed by absolute path. The
+// layers are applied in order, so files deleted by a later layer are not returned. For a multi-arch image, the
+// manifest of architecture is used.
+func (registry *Registry) KernelModules(repository, reference, architecture string) (map[string][]byte, error) {
+	manifest, err := registry.Manifest(repository, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.IsIndex() {
+		manifest, err = registry.platformManifest(repository, manifest, architecture)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	modules := map[string][]byte{}
+
+	for _, layer := range manifest.Layers {
+		klog.V(kmmparams.KmmLogLevel).Infof("Inspecting layer %s of %s:%s (%d bytes)",
+			layer.Digest, repository, reference, layer.Size)
+
+		err = registry.readLayerModules(repository, layer, modules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modules, nil
+}
+
+// platformManifest returns the manifest of architecture listed in index.
+func (registry *Registry) platformManifest(repository string, index *Manifest, architecture string) (*Manifest, error) {
+	for _, descriptor := range index.Manifests {
+		if descriptor.Platform != nil && descriptor.Platform.Architecture == architecture {
+			return registry.Manifest(repository, descriptor.Digest)
+		}
+	}
+
+	return nil, fmt.Errorf("image %s has no manifest for architecture %s", repository, architecture)
+}
+
+// readLayerModules adds the kernel modules found in layer to modules and removes the files the layer deletes.
+func (registry *Registry) readLayerModules(repository string, layer Descriptor, modules map[string][]byte) error {
+	blob, err := registry.getBlob(repository, layer.Digest)
+	if err != nil {
+		return err
+	}
+
+	defer blob.Close()
+
+	var content io.Reader = blob
+
+	switch {
+	case strings.HasSuffix(layer.MediaType, "gzip"):
+		gzipReader, err := gzip.NewReader(blob)
+		if err != nil {
+			return fmt.Errorf("failed to decompress layer %s: %w", layer.Digest, err)
+		}
+
+		defer gzipReader.Close()
+
+		content = gzipReader
+	case strings.HasSuffix(layer.MediaType, "zstd"):
+		return fmt.Errorf("layer %s is zstd compressed, which is not supported", layer.Digest)
+	}
+
+	tarReader := tar.NewReader(content)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read layer %s: %w", layer.Digest, err)
+		}
+
+		name := path.Join("/", header.Name)
+		dir, base := path.Split(name)
+
+		if base == opaqueWhiteout {
+			for modulePath := range modules {
+				if strings.HasPrefix(modulePath, dir) {
+					delete(modules, modulePath)
+				}
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			delete(modules, path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)))
+
+			continue
+		}
+
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(name, kernelModuleExtension) {
+			continue
+		}
+
+		module, err := io.ReadAll(io.LimitReader(tarReader, maxKernelModuleSize))
+		if err != nil {
+			return fmt.Errorf("failed to read %s from layer %s: %w", name, layer.Digest, err)
+		}
+
+		modules[name] = module
+	}
+}
+
+// getJSONBlob decodes the blob digest of repository into object.
+func (registry *Registry) getJSONBlob(repository, digest string, object any) error {
+	blob, err := registry.getBlob(repository, digest)
+	if err != nil {
+		return err
+	}
+
+	defer blob.Close()
+
+	return json.NewDecoder(blob).Decode(object)
+}
+
+// getBlob returns the content of the blob digest of repository. The caller must close it.
+func (registry *Registry) getBlob(repository, digest string) (io.ReadCloser, error) {
+	response, err := registry.get(context.TODO(), fmt.Sprintf("/v2/%s/blobs/%s", repository, digest), "")
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		_ = response.Body.Close()
+
+		return nil, fmt.Errorf("failed to get blob %s of %s: %s", digest, repository, response.Status)
+	}
+
+	return response.Body, nil
+}
+
+// get sends an authenticated GET request for path to the registry.
+func (registry *Registry) get(ctx context.Context, urlPath, accept string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+registry.Host+urlPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", urlPath, err)
+	}
+
+	request.SetBasicAuth(registry.Username, registry.Password)
+
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	response, err := registry.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s%s failed: %w", registry.Host, urlPath, err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,267 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRepository = "kmm/module"
+	testUsername   = "user"
+	testPassword   = "password"
+)
+
+// testBlob is a manifest or blob served by the test registry.
+type testBlob struct {
+	mediaType string
+	content   []byte
+}
+
+// testLayerFile is a file of a test image layer. A nil content adds a directory.
+type testLayerFile struct {
+	name    string
+	content []byte
+}
+
+func TestPlatformString(t *testing.T) {
+	assert.Equal(t, "linux/amd64", Platform{OS: "linux", Architecture: "amd64"}.String())
+	assert.Equal(t, "linux/arm64/v8", Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}.String())
+}
+
+func TestManifestIsIndex(t *testing.T) {
+	testCases := []struct {
+		manifest Manifest
+		expected bool
+	}{
+		{manifest: Manifest{MediaType: mediaTypeOCIIndex}, expected: true},
+		{manifest: Manifest{MediaType: mediaTypeDockerList}, expected: true},
+		{manifest: Manifest{Manifests: []Descriptor{{Digest: "sha256:amd64"}}}, expected: true},
+		{manifest: Manifest{MediaType: mediaTypeOCIManifest}, expected: false},
+		{manifest: Manifest{MediaType: mediaTypeDockerManifest}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.manifest.IsIndex(), testCase.manifest.MediaType)
+	}
+}
+
+func TestPlatforms(t *testing.T) {
+	registry := newTestRegistry(t, testImages(t))
+
+	platforms, err := registry.Platforms(testRepository, "single")
+	assert.NoError(t, err)
+	assert.Equal(t, []Platform{{OS: "linux", Architecture: "amd64"}}, platforms)
+
+	platforms, err = registry.Platforms(testRepository, "multi")
+	assert.NoError(t, err)
+	assert.Equal(t, []Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}}, platforms)
+
+	_, err = registry.Platforms(testRepository, "missing")
+	assert.ErrorContains(t, err, "failed to get manifest kmm/module:missing: 404")
+}
+
+func TestKernelModules(t *testing.T) {
+	registry := newTestRegistry(t, testImages(t))
+
+	testCases := []struct {
+		name          string
+		reference     string
+		architecture  string
+		expected      map[string][]byte
+		expectedError string
+	}{
+		{
+			name:      "whiteouts applied",
+			reference: "single",
+			expected: map[string][]byte{
+				"/opt/lib/modules/5.14.0/kmm_ci_a.ko":       []byte("module a"),
+				"/opt/lib/modules/5.14.0/extra/kmm_ci_e.ko": []byte("module e"),
+			},
+		},
+		{
+			name:         "index architecture selected",
+			reference:    "multi",
+			architecture: "arm64",
+			expected: map[string][]byte{
+				"/opt/lib/modules/5.14.0/kmm_ci_arm64.ko": []byte("module arm64"),
+			},
+		},
+		{
+			name:          "index architecture missing",
+			reference:     "multi",
+			architecture:  "s390x",
+			expectedError: "has no manifest for architecture s390x",
+		},
+		{
+			name:          "zstd layer",
+			reference:     "zstd",
+			expectedError: "zstd compressed, which is not supported",
+		},
+	}
+
+	for _, testCase := range testCases {
+		modules, err := registry.KernelModules(testRepository, testCase.reference, testCase.architecture)
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError, testCase.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expected, modules, testCase.name)
+	}
+}
+
+func TestRegistryAuthentication(t *testing.T) {
+	registry := newTestRegistry(t, testImages(t))
+	registry.Password = "wrong"
+
+	_, err := registry.Manifest(testRepository, "single")
+	assert.ErrorContains(t, err, "401")
+}
+
+// newTestRegistry serves blobs, keyed by manifest or blob path, over TLS and returns a Registry that uses it.
+func newTestRegistry(t *testing.T, blobs map[string]testBlob) *Registry {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		username, password, ok := request.BasicAuth()
+		if !ok || username != testUsername || password != testPassword {
+			writer.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		blob, found := blobs[request.URL.Path]
+		if !found {
+			writer.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		writer.Header().Set("Content-Type", blob.mediaType)
+		_, _ = writer.Write(blob.content)
+	}))
+	t.Cleanup(server.Close)
+
+	return &Registry{
+		Host:       strings.TrimPrefix(server.URL, "https://"),
+		Username:   testUsername,
+		Password:   testPassword,
+		httpClient: server.Client(),
+	}
+}
+
+// testImages returns the manifests and blobs of a single-arch image with two layers, a multi-arch image and an image
+// with a zstd layer.
+func testImages(t *testing.T) map[string]testBlob {
+	t.Helper()
+
+	blobs := map[string]testBlob{}
+
+	addBlob := func(digest, mediaType string, content []byte) Descriptor {
+		blobs["/v2/"+testRepository+"/blobs/"+digest] = testBlob{mediaType: mediaType, content: content}
+
+		return Descriptor{MediaType: mediaType, Digest: digest, Size: int64(len(content))}
+	}
+
+	addManifest := func(reference string, manifest Manifest) {
+		content, err := json.Marshal(manifest)
+		assert.NoError(t, err)
+
+		// Docker v2 manifests may omit the media type, which is then taken from the response.
+		mediaType := manifest.MediaType
+		if mediaType == "" {
+			mediaType = mediaTypeDockerManifest
+		}
+
+		blobs["/v2/"+testRepository+"/manifests/"+reference] = testBlob{mediaType: mediaType, content: content}
+	}
+
+	amd64Config := addBlob("sha256:config-amd64", "application/vnd.oci.image.config.v1+json",
+		[]byte(`{"architecture":"amd64","os":"linux","rootfs":{"type":"layers"}}`))
+
+	baseLayer := addBlob("sha256:base", "application/vnd.oci.image.layer.v1.tar+gzip", gzipped(t, layerTar(t,
+		testLayerFile{name: "opt/lib/modules/5.14.0/"},
+		testLayerFile{name: "opt/lib/modules/5.14.0/kmm_ci_a.ko", content: []byte("module a")},
+		testLayerFile{name: "opt/lib/modules/5.14.0/kmm_ci_b.ko", content: []byte("module b")},
+		testLayerFile{name: "opt/lib/modules/5.14.0/extra/kmm_ci_c.ko", content: []byte("module c")},
+		testLayerFile{name: "opt/lib/modules/5.14.0/modules.dep", content: []byte("kmm_ci_a.ko:")},
+	)))
+
+	topLayer := addBlob("sha256:top", "application/vnd.docker.image.rootfs.diff.tar", layerTar(t,
+		testLayerFile{name: "opt/lib/modules/5.14.0/.wh.kmm_ci_b.ko", content: []byte{}},
+		testLayerFile{name: "opt/lib/modules/5.14.0/extra/.wh..wh..opq", content: []byte{}},
+		testLayerFile{name: "./opt/lib/modules/5.14.0/extra/kmm_ci_e.ko", content: []byte("module e")},
+	))
+
+	addManifest("single", Manifest{SchemaVersion: 2, Config: amd64Config, Layers: []Descriptor{baseLayer, topLayer}})
+
+	arm64Layer := addBlob("sha256:arm64", "application/vnd.oci.image.layer.v1.tar+gzip", gzipped(t, layerTar(t,
+		testLayerFile{name: "opt/lib/modules/5.14.0/kmm_ci_arm64.ko", content: []byte("module arm64")},
+	)))
+
+	addManifest("sha256:manifest-arm64", Manifest{SchemaVersion: 2, MediaType: mediaTypeOCIManifest,
+		Layers: []Descriptor{arm64Layer}})
+	addManifest("multi", Manifest{SchemaVersion: 2, MediaType: mediaTypeOCIIndex, Manifests: []Descriptor{
+		{Digest: "sha256:manifest-amd64", Platform: &Platform{OS: "linux", Architecture: "amd64"}},
+		{Digest: "sha256:manifest-arm64", Platform: &Platform{OS: "linux", Architecture: "arm64"}},
+		{Digest: "sha256:attestation", Platform: &Platform{OS: "unknown", Architecture: "unknown"}},
+		{Digest: "sha256:no-platform"},
+	}})
+
+	zstdLayer := addBlob("sha256:zstd", "application/vnd.oci.image.layer.v1.tar+zstd", []byte("zstd"))
+	addManifest("zstd", Manifest{SchemaVersion: 2, MediaType: mediaTypeOCIManifest, Layers: []Descriptor{zstdLayer}})
+
+	return blobs
+}
+
+// layerTar returns an uncompressed layer holding files.
+func layerTar(t *testing.T, files ...testLayerFile) []byte {
+	t.Helper()
+
+	buffer := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(buffer)
+
+	for _, file := range files {
+		header := &tar.Header{Name: file.name, Mode: 0o644, Typeflag: tar.TypeReg, Size: int64(len(file.content))}
+
+		if file.content == nil {
+			header.Typeflag = tar.TypeDir
+			header.Mode = 0o755
+		}
+
+		err := tarWriter.WriteHeader(header)
+		assert.NoError(t, err)
+
+		_, err = tarWriter.Write(file.content)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, tarWriter.Close())
+
+	return buffer.Bytes()
+}
+
+// gzipped returns content compressed with gzip.
+func gzipped(t *testing.T, content []byte) []byte {
+	t.Helper()
+
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+
+	_, err := gzipWriter.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, gzipWriter.Close())
+
+	return buffer.Bytes()
+}
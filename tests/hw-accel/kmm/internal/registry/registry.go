@@ -0,0 +1,302 @@
+// Package registry deploys an in-cluster OCI registry with TLS and htpasswd authentication for KMM build and sign
+// tests and inspects the images pushed to it. The registry is exposed with a passthrough route and its CA is added to
+// the cluster image configuration, so that builds, nodes and the test runner all trust it. This lets disconnected labs
+// run the build and sign tests without the internal OpenShift registry or an external one.
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/route"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/secret"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/service"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/define"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	authMountPath    = "/auth"
+	certsMountPath   = "/certs"
+	storageMountPath = "/var/lib/registry"
+)
+
+// Registry is an OCI registry deployed in the cluster by Deploy.
+type Registry struct {
+	// Host is the host name of the registry route. Images pushed to the registry are referenced with it.
+	Host string
+	// Username is the user allowed to push to and pull from the registry.
+	Username string
+	// Password is the password of Username, generated for each deployment.
+	Password string
+
+	apiClient  *clients.Settings
+	namespace  string
+	httpClient *http.Client
+	trust      *trust
+}
+
+// Deploy creates the registry in kmmparams.LocalRegistryNamespace using image, trusts its CA cluster-wide and waits up
+// to timeout for it to serve requests through its route. If any step fails, the changes already made to the cluster,
+// including the trusted CA, are reverted before the error is returned.
+func Deploy(apiClient *clients.Settings, image string, timeout time.Duration) (*Registry, error) {
+	klog.V(kmmparams.KmmLogLevel).Infof("Deploying local registry %s in namespace %s",
+		kmmparams.LocalRegistryName, kmmparams.LocalRegistryNamespace)
+
+	_, err := namespace.NewBuilder(apiClient, kmmparams.LocalRegistryNamespace).Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create namespace %s: %w", kmmparams.LocalRegistryNamespace, err)
+	}
+
+	registry := &Registry{
+		Username:  kmmparams.LocalRegistryUser,
+		apiClient: apiClient,
+		namespace: kmmparams.LocalRegistryNamespace,
+	}
+
+	err = registry.deploy(image, timeout)
+	if err != nil {
+		klog.V(kmmparams.KmmLogLevel).Infof("Cleaning up local registry after failed deployment: %v", err)
+
+		cleanupErr := registry.Delete(timeout)
+		if cleanupErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("failed to clean up local registry: %w", cleanupErr))
+		}
+
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// Image returns the reference of repository:tag in the registry. The tag may contain variables expanded by KMM such
+// as $KERNEL_FULL_VERSION.
+func (registry *Registry) Image(repository, tag string) string {
+	return fmt.Sprintf("%s/%s:%s", registry.Host, repository, tag)
+}
+
+// CreatePullSecret creates a docker config secret named name in nsname with the registry credentials. It can be used
+// both as the Module image repo secret and as the build push secret.
+func (registry *Registry) CreatePullSecret(name, nsname string) (*secret.Builder, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(registry.Username + ":" + registry.Password))
+
+	pullSecret, err := secret.NewBuilder(registry.apiClient, name, nsname, corev1.SecretTypeDockerConfigJson).
+		WithData(define.SecretContent(registry.Host, auth)).Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry pull secret %s/%s: %w", nsname, name, err)
+	}
+
+	return pullSecret, nil
+}
+
+// Delete removes the registry CA from the cluster trust and deletes the registry namespace, waiting up to timeout.
+func (registry *Registry) Delete(timeout time.Duration) error {
+	var errs []error
+
+	if registry.trust != nil {
+		err := registry.trust.remove(registry.apiClient)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	err := namespace.NewBuilder(registry.apiClient, registry.namespace).DeleteAndWait(timeout)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete namespace %s: %w", registry.namespace, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// deploy creates the registry resources in the already created namespace and trusts its CA. The fields of registry
+// are set as resources are created, so that Delete can revert a partial deployment.
+func (registry *Registry) deploy(image string, timeout time.Duration) error {
+	host, err := createRoute(registry.apiClient)
+	if err != nil {
+		return err
+	}
+
+	registry.Host = host
+	serviceHost := fmt.Sprintf("%s.%s.svc", kmmparams.LocalRegistryName, kmmparams.LocalRegistryNamespace)
+
+	certs, err := generateCertificates([]string{host, serviceHost, serviceHost + ".cluster.local"})
+	if err != nil {
+		return err
+	}
+
+	registry.Password, err = randomPassword()
+	if err != nil {
+		return err
+	}
+
+	err = createSecrets(registry.apiClient, certs, registry.Password)
+	if err != nil {
+		return err
+	}
+
+	err = createDeployment(registry.apiClient, image, timeout)
+	if err != nil {
+		return err
+	}
+
+	registry.trust, err = trustCA(registry.apiClient, host, certs.caPEM)
+	if err != nil {
+		return err
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(certs.caPEM)
+
+	registry.httpClient = &http.Client{
+		Timeout:   time.Minute,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}},
+	}
+
+	return registry.waitUntilServing(timeout)
+}
+
+// waitUntilServing waits up to timeout for the registry API to answer through the route. An unauthorized response
+// counts as serving since the registry requires authentication.
+func (registry *Registry) waitUntilServing(timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(context.TODO(), 5*time.Second, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			response, err := registry.get(ctx, "/v2/", "")
+			if err != nil {
+				klog.V(kmmparams.KmmLogLevel).Infof("Local registry is not serving yet: %v", err)
+
+				return false, nil
+			}
+
+			_ = response.Body.Close()
+
+			return response.StatusCode == http.StatusOK || response.StatusCode == http.StatusUnauthorized, nil
+		})
+	if err != nil {
+		return fmt.Errorf("local registry %s did not serve requests within %s: %w", registry.Host, timeout, err)
+	}
+
+	return nil
+}
+
+// createRoute creates the passthrough route of the registry and returns the host assigned to it.
+func createRoute(apiClient *clients.Settings) (string, error) {
+	registryRoute := route.NewBuilder(apiClient, kmmparams.LocalRegistryName, kmmparams.LocalRegistryNamespace,
+		kmmparams.LocalRegistryName).WithTargetPortNumber(kmmparams.LocalRegistryPort)
+	registryRoute.Definition.Spec.TLS = &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationPassthrough,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyNone,
+	}
+
+	_, err := registryRoute.Create()
+	if err != nil {
+		return "", fmt.Errorf("failed to create route %s: %w", kmmparams.LocalRegistryName, err)
+	}
+
+	registryRoute, err = route.Pull(apiClient, kmmparams.LocalRegistryName, kmmparams.LocalRegistryNamespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull route %s: %w", kmmparams.LocalRegistryName, err)
+	}
+
+	if registryRoute.Object.Spec.Host == "" {
+		return "", fmt.Errorf("route %s was not assigned a host", kmmparams.LocalRegistryName)
+	}
+
+	return registryRoute.Object.Spec.Host, nil
+}
+
+// createSecrets creates the serving certificate and htpasswd secrets of the registry.
+func createSecrets(apiClient *clients.Settings, certs *certificates, password string) error {
+	_, err := secret.NewBuilder(apiClient, kmmparams.LocalRegistryName+"-tls", kmmparams.LocalRegistryNamespace,
+		corev1.SecretTypeTLS).WithData(map[string][]byte{
+		corev1.TLSCertKey:       certs.certPEM,
+		corev1.TLSPrivateKeyKey: certs.keyPEM,
+	}).Create()
+	if err != nil {
+		return fmt.Errorf("failed to create registry TLS secret: %w", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash registry password: %w", err)
+	}
+
+	_, err = secret.NewBuilder(apiClient, kmmparams.LocalRegistryName+"-auth", kmmparams.LocalRegistryNamespace,
+		corev1.SecretTypeOpaque).WithData(map[string][]byte{
+		"htpasswd": fmt.Appendf(nil, "%s:%s\n", kmmparams.LocalRegistryUser, hash),
+	}).Create()
+	if err != nil {
+		return fmt.Errorf("failed to create registry htpasswd secret: %w", err)
+	}
+
+	return nil
+}
+
+// createDeployment creates the registry deployment and its service and waits up to timeout for it to be ready.
+func createDeployment(apiClient *clients.Settings, image string, timeout time.Duration) error {
+	labels := map[string]string{"app": kmmparams.LocalRegistryName}
+
+	container, err := pod.NewContainerBuilder(kmmparams.LocalRegistryName, image,
+		[]string{"/bin/registry", "serve", "/etc/docker/registry/config.yml"}).
+		WithEnvVar("REGISTRY_HTTP_ADDR", fmt.Sprintf(":%d", kmmparams.LocalRegistryPort)).
+		WithEnvVar("REGISTRY_HTTP_TLS_CERTIFICATE", certsMountPath+"/"+corev1.TLSCertKey).
+		WithEnvVar("REGISTRY_HTTP_TLS_KEY", certsMountPath+"/"+corev1.TLSPrivateKeyKey).
+		WithEnvVar("REGISTRY_AUTH", "htpasswd").
+		WithEnvVar("REGISTRY_AUTH_HTPASSWD_REALM", kmmparams.LocalRegistryName).
+		WithEnvVar("REGISTRY_AUTH_HTPASSWD_PATH", authMountPath+"/htpasswd").
+		WithVolumeMount(corev1.VolumeMount{Name: "auth", MountPath: authMountPath, ReadOnly: true}).
+		WithVolumeMount(corev1.VolumeMount{Name: "certs", MountPath: certsMountPath, ReadOnly: true}).
+		WithVolumeMount(corev1.VolumeMount{Name: "storage", MountPath: storageMountPath}).
+		WithPorts([]corev1.ContainerPort{{ContainerPort: kmmparams.LocalRegistryPort, Protocol: corev1.ProtocolTCP}}).
+		GetContainerCfg()
+	if err != nil {
+		return fmt.Errorf("failed to define registry container: %w", err)
+	}
+
+	_, err = deployment.NewBuilder(apiClient, kmmparams.LocalRegistryName, kmmparams.LocalRegistryNamespace,
+		labels, *container).
+		WithVolume(secretVolume("auth", kmmparams.LocalRegistryName+"-auth")).
+		WithVolume(secretVolume("certs", kmmparams.LocalRegistryName+"-tls")).
+		WithVolume(corev1.Volume{
+			Name: "storage", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}).
+		CreateAndWaitUntilReady(timeout)
+	if err != nil {
+		return fmt.Errorf("failed to deploy registry: %w", err)
+	}
+
+	_, err = service.NewBuilder(apiClient, kmmparams.LocalRegistryName, kmmparams.LocalRegistryNamespace, labels,
+		corev1.ServicePort{
+			Name:       "registry",
+			Port:       kmmparams.LocalRegistryPort,
+			TargetPort: intstr.FromInt32(kmmparams.LocalRegistryPort),
+			Protocol:   corev1.ProtocolTCP,
+		}).Create()
+	if err != nil {
+		return fmt.Errorf("failed to create registry service: %w", err)
+	}
+
+	return nil
+}
+
+// secretVolume returns a volume named name backed by the secret secretName.
+func secretVolume(name, secretName string) corev1.Volume {
+	return corev1.Volume{
+		Name:         name,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}},
+	}
+}
@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// imageConfigName is the name of the cluster image configuration.
+	imageConfigName = "cluster"
+	// trustedCANamespace is the namespace of the config map referenced by additionalTrustedCA.
+	trustedCANamespace = "openshift-config"
+	// trustedCAConfigMapName is the config map created when the cluster does not trust any additional CA yet.
+	trustedCAConfigMapName = "kmm-local-registry-ca"
+)
+
+// trust records how the registry CA was added to the cluster so that it can be removed.
+type trust struct {
+	host             string
+	configMapName    string
+	createdConfigMap bool
+}
+
+// trustCA adds caPEM as trusted for host to the cluster image configuration. Builds and nodes then trust the
+// registry without a reboot. An existing additionalTrustedCA config map is extended rather than replaced.
+func trustCA(apiClient *clients.Settings, host string, caPEM []byte) (*trust, error) {
+	imageConfig, err := apiClient.ConfigV1Interface.Images().Get(context.TODO(), imageConfigName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster image config: %w", err)
+	}
+
+	configMapName := imageConfig.Spec.AdditionalTrustedCA.Name
+	if configMapName != "" {
+		klog.V(kmmparams.KmmLogLevel).Infof("Adding registry CA for %s to config map %s", host, configMapName)
+
+		caConfigMap, err := configmap.Pull(apiClient, configMapName, trustedCANamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pull trusted CA config map %s: %w", configMapName, err)
+		}
+
+		if caConfigMap.Definition.Data == nil {
+			caConfigMap.Definition.Data = map[string]string{}
+		}
+
+		caConfigMap.Definition.Data[host] = string(caPEM)
+
+		_, err = caConfigMap.Update()
+		if err != nil {
+			return nil, fmt.Errorf("failed to update trusted CA config map %s: %w", configMapName, err)
+		}
+
+		return &trust{host: host, configMapName: configMapName}, nil
+	}
+
+	klog.V(kmmparams.KmmLogLevel).Infof("Creating config map %s to trust registry CA for %s",
+		trustedCAConfigMapName, host)
+
+	_, err = configmap.NewBuilder(apiClient, trustedCAConfigMapName, trustedCANamespace).
+		WithData(map[string]string{host: string(caPEM)}).Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trusted CA config map %s: %w", trustedCAConfigMapName, err)
+	}
+
+	imageConfig.Spec.AdditionalTrustedCA.Name = trustedCAConfigMapName
+
+	_, err = apiClient.ConfigV1Interface.Images().Update(context.TODO(), imageConfig, metav1.UpdateOptions{})
+	if err != nil {
+		err = fmt.Errorf("failed to set additionalTrustedCA on cluster image config: %w", err)
+
+		deleteErr := configmap.NewBuilder(apiClient, trustedCAConfigMapName, trustedCANamespace).Delete()
+		if deleteErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("failed to delete trusted CA config map %s: %w",
+				trustedCAConfigMapName, deleteErr))
+		}
+
+		return nil, err
+	}
+
+	return &trust{host: host, configMapName: trustedCAConfigMapName, createdConfigMap: true}, nil
+}
+
+// remove reverts trustCA.
+func (registryTrust *trust) remove(apiClient *clients.Settings) error {
+	if registryTrust.createdConfigMap {
+		imageConfig, err := apiClient.ConfigV1Interface.Images().Get(
+			context.TODO(), imageConfigName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get cluster image config: %w", err)
+		}
+
+		if imageConfig.Spec.AdditionalTrustedCA.Name == registryTrust.configMapName {
+			imageConfig.Spec.AdditionalTrustedCA.Name = ""
+
+			_, err = apiClient.ConfigV1Interface.Images().Update(context.TODO(), imageConfig, metav1.UpdateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to unset additionalTrustedCA on cluster image config: %w", err)
+			}
+		}
+
+		return configmap.NewBuilder(apiClient, registryTrust.configMapName, trustedCANamespace).Delete()
+	}
+
+	caConfigMap, err := configmap.Pull(apiClient, registryTrust.configMapName, trustedCANamespace)
+	if err != nil {
+		return fmt.Errorf("failed to pull trusted CA config map %s: %w", registryTrust.configMapName, err)
+	}
+
+	delete(caConfigMap.Definition.Data, registryTrust.host)
+
+	_, err = caConfigMap.Update()
+	if err != nil {
+		return fmt.Errorf("failed to update trusted CA config map %s: %w", registryTrust.configMapName, err)
+	}
+
+	return nil
+}
@@ -34,6 +34,8 @@ var (
 		kmmparams.RebuildTriggerNoopNamespace:     "module",
 		kmmparams.AutomountSATokenTestNamespace:   "module",
 		kmmparams.FilesToSignGlobTestNamespace:    "module",
+		kmmparams.LocalRegistryBuildNamespace:     "module",
+		kmmparams.LocalRegistryNamespace:          "module",
 		kmmparams.DefaultNodesNamespace:           "nodes",
 	}
 
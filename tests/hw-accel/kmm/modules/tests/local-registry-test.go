@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/kmm"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/secret"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/serviceaccount"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/await"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/check"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/define"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/get"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmminittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/registry"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+)
+
+var _ = Describe("KMM", Ordered, Label(kmmparams.LabelSuite), func() {
+	Context("Module", Label("local-registry"), func() {
+		moduleName := kmmparams.LocalRegistryBuildNamespace
+		kmodName := "local-registry"
+		serviceAccountName := "local-registry-sa"
+		pullSecretName := "local-registry-pull-secret"
+		repository := fmt.Sprintf("%s/%s", kmmparams.LocalRegistryBuildNamespace, kmodName)
+		buildArgValue := fmt.Sprintf("%s.o", kmodName)
+		filesToSign := []string{fmt.Sprintf("/opt/lib/modules/$KERNEL_FULL_VERSION/%s.ko", kmodName)}
+
+		var localRegistry *registry.Registry
+
+		BeforeAll(func() {
+			By("Deploy local registry")
+
+			var err error
+
+			localRegistry, err = registry.Deploy(APIClient, ModulesConfig.LocalRegistryImage, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred(), "error deploying local registry")
+
+			By("Create Namespace")
+
+			_, err = namespace.NewBuilder(APIClient, kmmparams.LocalRegistryBuildNamespace).Create()
+			Expect(err).ToNot(HaveOccurred(), "error creating test namespace")
+
+			By("Create registry pull secret")
+
+			_, err = localRegistry.CreatePullSecret(pullSecretName, kmmparams.LocalRegistryBuildNamespace)
+			Expect(err).ToNot(HaveOccurred(), "error creating registry pull secret")
+		})
+
+		AfterAll(func() {
+			By("Delete Module")
+
+			_, err := kmm.NewModuleBuilder(APIClient, moduleName, kmmparams.LocalRegistryBuildNamespace).Delete()
+			Expect(err).ToNot(HaveOccurred(), "error deleting module")
+
+			By("Await module to be deleted")
+
+			err = await.ModuleObjectDeleted(APIClient, moduleName, kmmparams.LocalRegistryBuildNamespace, time.Minute)
+			Expect(err).ToNot(HaveOccurred(), "error while waiting module to be deleted")
+
+			svcAccount := serviceaccount.NewBuilder(APIClient, serviceAccountName,
+				kmmparams.LocalRegistryBuildNamespace)
+			svcAccount.Exists()
+
+			By("Delete ClusterRoleBinding")
+
+			crb := define.ModuleCRB(*svcAccount, kmodName)
+			err = crb.Delete()
+			Expect(err).ToNot(HaveOccurred(), "error deleting clusterrolebinding")
+
+			By("Delete Namespace")
+
+			err = namespace.NewBuilder(APIClient, kmmparams.LocalRegistryBuildNamespace).Delete()
+			Expect(err).ToNot(HaveOccurred(), "error deleting test namespace")
+
+			By("Delete local registry")
+
+			if localRegistry != nil {
+				err = localRegistry.Delete(5 * time.Minute)
+				Expect(err).ToNot(HaveOccurred(), "error deleting local registry")
+			}
+		})
+
+		It("should build and sign a module pushed to the local registry", reportxml.ID("local-registry-sign"), func() {
+			By("Creating my-signing-key-pub")
+
+			signKey := get.SigningData("cert", kmmparams.SigningCertBase64)
+
+			_, err := secret.NewBuilder(APIClient, "my-signing-key-pub",
+				kmmparams.LocalRegistryBuildNamespace, corev1.SecretTypeOpaque).WithData(signKey).Create()
+			Expect(err).ToNot(HaveOccurred(), "failed creating secret")
+
+			By("Creating my-signing-key")
+
+			signCert := get.SigningData("key", kmmparams.SigningKeyBase64)
+
+			_, err = secret.NewBuilder(APIClient, "my-signing-key",
+				kmmparams.LocalRegistryBuildNamespace, corev1.SecretTypeOpaque).WithData(signCert).Create()
+			Expect(err).ToNot(HaveOccurred(), "failed creating secret")
+
+			By("Create ConfigMap")
+
+			dockerfileConfigMap, err := configmap.
+				NewBuilder(APIClient, kmodName, kmmparams.LocalRegistryBuildNamespace).
+				WithData(define.MultiStageConfigMapContent(kmodName)).Create()
+			Expect(err).ToNot(HaveOccurred(), "error creating configmap")
+
+			By("Create ServiceAccount")
+
+			svcAccount, err := serviceaccount.
+				NewBuilder(APIClient, serviceAccountName, kmmparams.LocalRegistryBuildNamespace).Create()
+			Expect(err).ToNot(HaveOccurred(), "error creating serviceaccount")
+
+			By("Create ClusterRoleBinding")
+
+			crb := define.ModuleCRB(*svcAccount, kmodName)
+			_, err = crb.Create()
+			Expect(err).ToNot(HaveOccurred(), "error creating clusterrolebinding")
+
+			By("Create KernelMapping")
+
+			kernelMapping := kmm.NewRegExKernelMappingBuilder("^.+$")
+
+			kernelMapping.WithContainerImage(localRegistry.Image(repository, "$KERNEL_FULL_VERSION")).
+				WithBuildArg(kmmparams.BuildArgName, buildArgValue).
+				WithBuildDockerCfgFile(dockerfileConfigMap.Object.Name).
+				WithSign("my-signing-key-pub", "my-signing-key", filesToSign)
+			kerMapOne, err := kernelMapping.BuildKernelMappingConfig()
+			Expect(err).ToNot(HaveOccurred(), "error creating kernel mapping")
+
+			By("Create ModuleLoaderContainer")
+
+			moduleLoaderContainer := kmm.NewModLoaderContainerBuilder(kmodName)
+			moduleLoaderContainer.WithKernelMapping(kerMapOne)
+			moduleLoaderContainer.WithImagePullPolicy("Always")
+			moduleLoaderContainerCfg, err := moduleLoaderContainer.BuildModuleLoaderContainerCfg()
+			Expect(err).ToNot(HaveOccurred(), "error creating moduleloadercontainer")
+
+			By("Create Module")
+
+			module := kmm.NewModuleBuilder(APIClient, moduleName, kmmparams.LocalRegistryBuildNamespace).
+				WithNodeSelector(GeneralConfig.WorkerLabelMap).
+				WithImageRepoSecret(pullSecretName)
+			module = module.WithModuleLoaderContainer(moduleLoaderContainerCfg).
+				WithLoadServiceAccount(svcAccount.Object.Name)
+			_, err = module.Create()
+			Expect(err).ToNot(HaveOccurred(), "error creating module")
+
+			By("Await build pod to complete build")
+
+			err = await.BuildPodCompleted(APIClient, kmmparams.LocalRegistryBuildNamespace, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred(), "error while building module")
+
+			By("Await driver container deployment")
+
+			err = await.ModuleDeployment(APIClient, moduleName, kmmparams.LocalRegistryBuildNamespace, 5*time.Minute,
+				GeneralConfig.WorkerLabelMap)
+			Expect(err).ToNot(HaveOccurred(), "error while waiting on driver deployment")
+
+			By("Check module is loaded on node")
+
+			err = check.ModuleLoaded(APIClient, kmodName, time.Minute)
+			Expect(err).ToNot(HaveOccurred(), "error while checking the module is loaded")
+		})
+
+		It("should push a signed image for every worker kernel and architecture",
+			reportxml.ID("local-registry-multi-arch"), func() {
+				By("Collect kernel versions and architectures of worker nodes")
+
+				workerNodes, err := nodes.List(APIClient,
+					metav1.ListOptions{LabelSelector: labels.Set(GeneralConfig.WorkerLabelMap).String()})
+				Expect(err).ToNot(HaveOccurred(), "error listing worker nodes")
+				Expect(workerNodes).ToNot(BeEmpty(), "no worker nodes found")
+
+				kernelArchitectures := map[string]string{}
+
+				for _, node := range workerNodes {
+					nodeInfo := node.Object.Status.NodeInfo
+					kernelArchitectures[nodeInfo.KernelVersion] = nodeInfo.Architecture
+				}
+
+				certDER := get.SigningData("cert", kmmparams.SigningCertBase64)["cert"]
+
+				for kernelVersion, architecture := range kernelArchitectures {
+					klog.V(kmmparams.KmmLogLevel).Infof("Checking image %s:%s for %s",
+						repository, kernelVersion, architecture)
+
+					By(fmt.Sprintf("Check image for kernel %s is available for %s", kernelVersion, architecture))
+
+					err = check.LocalImageArchitectures(localRegistry, repository, kernelVersion, []string{architecture})
+					Expect(err).ToNot(HaveOccurred(), "error while checking the image architecture")
+
+					By(fmt.Sprintf("Check module in image for kernel %s is signed with the signing cert", kernelVersion))
+
+					err = check.LocalImageModulesSigned(localRegistry, repository, kernelVersion, architecture,
+						[]string{kmodName}, certDER)
+					Expect(err).ToNot(HaveOccurred(), "error while verifying the module signature")
+				}
+			})
+	})
+})
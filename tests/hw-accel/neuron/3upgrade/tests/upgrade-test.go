@@ -10,7 +10,6 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/neuron"
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	operatorsV1alpha1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/olm/operators/v1alpha1"
@@ -60,14 +59,7 @@ var _ = Describe("Neuron Rolling Upgrade Tests", Ordered, Label(params.Label), L
 
 			By("Patching KMM subscription with Neuron upgrade toleration")
 
-			kmmSub, err := olm.PullSubscription(APIClient, "kmm-subscription", "openshift-kmm")
-			if err != nil {
-				klog.V(params.NeuronLogLevel).Infof(
-					"Subscription 'kmm-subscription' not found, trying 'kernel-module-management'")
-
-				kmmSub, err = olm.PullSubscription(APIClient, "kernel-module-management", "openshift-kmm")
-			}
-
+			kmmSub, err := neuronhelpers.KMMSubscription(APIClient)
 			Expect(err).ToNot(HaveOccurred(), "Failed to pull KMM subscription")
 
 			if kmmSub.Definition.Spec.Config == nil {
@@ -3,11 +3,14 @@ package check
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/daemonset"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/do"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -275,3 +278,55 @@ func IsDevicePluginPod(podName string) bool {
 
 	return len(podName) >= len(prefix) && podName[:len(prefix)] == prefix
 }
+
+// InferenceSLOsMet checks a benchmark result against the SLO thresholds and returns an error listing every violated
+// threshold. Zero latency, throughput and utilization thresholds are skipped.
+func InferenceSLOsMet(result *do.BenchmarkResult, slo neuronconfig.InferenceSLO) error {
+	var violations []string
+
+	if result.ErrorRate > slo.MaxErrorRate {
+		violations = append(violations, fmt.Sprintf("error rate %.3f exceeds %.3f (%d/%d failed)",
+			result.ErrorRate, slo.MaxErrorRate, result.Failed, result.Requests))
+	}
+
+	latencies := []struct {
+		name      string
+		value     float64
+		threshold time.Duration
+	}{
+		{"TTFT p95", result.TimeToFirstToken.P95, slo.MaxTTFTP95},
+		{"latency p50", result.Latency.P50, slo.MaxLatencyP50},
+		{"latency p95", result.Latency.P95, slo.MaxLatencyP95},
+		{"latency p99", result.Latency.P99, slo.MaxLatencyP99},
+	}
+
+	for _, latency := range latencies {
+		if latency.threshold > 0 && latency.value > latency.threshold.Seconds() {
+			violations = append(violations, fmt.Sprintf("%s %.3fs exceeds %v",
+				latency.name, latency.value, latency.threshold))
+		}
+	}
+
+	if slo.MinTokensPerSecond > 0 && result.TokensPerSecond < slo.MinTokensPerSecond {
+		violations = append(violations, fmt.Sprintf("throughput %.2f tokens/s is below %.2f",
+			result.TokensPerSecond, slo.MinTokensPerSecond))
+	}
+
+	if slo.MinNeuroncoreUtilization > 0 {
+		switch {
+		case result.NeuroncoreUtilization.Samples == 0:
+			violations = append(violations, "no neuroncore utilization samples were collected")
+		case result.NeuroncoreUtilization.Mean < slo.MinNeuroncoreUtilization:
+			violations = append(violations, fmt.Sprintf("neuroncore utilization %.3f is below %.3f",
+				result.NeuroncoreUtilization.Mean, slo.MinNeuroncoreUtilization))
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("inference SLOs not met: %s", strings.Join(violations, "; "))
+	}
+
+	klog.V(params.NeuronLogLevel).Infof("Inference SLOs met for %s", result.Model)
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/do"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferenceSLOsMet(t *testing.T) {
+	result := &do.BenchmarkResult{
+		Model:                 "model",
+		Requests:              20,
+		Failed:                1,
+		ErrorRate:             0.05,
+		TokensPerSecond:       150,
+		TimeToFirstToken:      do.LatencyStats{P95: 0.4},
+		Latency:               do.LatencyStats{P50: 1, P95: 2, P99: 3},
+		NeuroncoreUtilization: do.UtilizationStats{Samples: 3, Mean: 0.6},
+	}
+
+	testCases := []struct {
+		name               string
+		result             *do.BenchmarkResult
+		slo                neuronconfig.InferenceSLO
+		expectedViolations []string
+	}{
+		{
+			name:   "all thresholds met",
+			result: result,
+			slo: neuronconfig.InferenceSLO{
+				MaxTTFTP95:               500 * time.Millisecond,
+				MaxLatencyP50:            time.Second,
+				MaxLatencyP95:            2 * time.Second,
+				MaxLatencyP99:            3 * time.Second,
+				MinTokensPerSecond:       150,
+				MaxErrorRate:             0.05,
+				MinNeuroncoreUtilization: 0.6,
+			},
+		},
+		{
+			name:               "zero thresholds skipped except error rate",
+			result:             result,
+			slo:                neuronconfig.InferenceSLO{},
+			expectedViolations: []string{"error rate 0.050 exceeds 0.000 (1/20 failed)"},
+		},
+		{
+			name:   "latencies exceeded",
+			result: result,
+			slo: neuronconfig.InferenceSLO{
+				MaxTTFTP95:    300 * time.Millisecond,
+				MaxLatencyP50: 500 * time.Millisecond,
+				MaxLatencyP95: 2 * time.Second,
+				MaxLatencyP99: 2500 * time.Millisecond,
+				MaxErrorRate:  0.1,
+			},
+			expectedViolations: []string{
+				"TTFT p95 0.400s exceeds 300ms",
+				"latency p50 1.000s exceeds 500ms",
+				"latency p99 3.000s exceeds 2.5s",
+			},
+		},
+		{
+			name:               "throughput below minimum",
+			result:             result,
+			slo:                neuronconfig.InferenceSLO{MinTokensPerSecond: 200, MaxErrorRate: 0.1},
+			expectedViolations: []string{"throughput 150.00 tokens/s is below 200.00"},
+		},
+		{
+			name:               "utilization below minimum",
+			result:             result,
+			slo:                neuronconfig.InferenceSLO{MinNeuroncoreUtilization: 0.8, MaxErrorRate: 0.1},
+			expectedViolations: []string{"neuroncore utilization 0.600 is below 0.800"},
+		},
+		{
+			name:               "utilization not sampled",
+			result:             &do.BenchmarkResult{Model: "model"},
+			slo:                neuronconfig.InferenceSLO{MinNeuroncoreUtilization: 0.1},
+			expectedViolations: []string{"no neuroncore utilization samples were collected"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := InferenceSLOsMet(testCase.result, testCase.slo)
+
+		if len(testCase.expectedViolations) == 0 {
+			assert.NoError(t, err, testCase.name)
+
+			continue
+		}
+
+		assert.ErrorContains(t, err, "inference SLOs not met", testCase.name)
+
+		for _, violation := range testCase.expectedViolations {
+			assert.ErrorContains(t, err, violation, testCase.name)
+		}
+
+		assert.Equal(t, len(testCase.expectedViolations)-1, strings.Count(err.Error(), "; "), testCase.name)
+	}
+}
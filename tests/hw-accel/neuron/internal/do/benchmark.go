@@ -0,0 +1,635 @@
+package do
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronmetrics"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// benchmarkPodName is the name of the load generator pod created in the benchmark namespace.
+	benchmarkPodName = "neuron-inference-benchmark"
+	// curlTimingMarker prefixes the line curl writes after the response with the status code and total time.
+	curlTimingMarker = "__CURL_TIMING__"
+	// maxRecordedErrors is the number of request errors kept in the benchmark result.
+	maxRecordedErrors = 10
+)
+
+// DefaultBenchmarkPrompts is the prompt set used when no prompts file is configured. The prompts differ in length so
+// that prefill and decode are both exercised.
+var DefaultBenchmarkPrompts = []string{
+	"Hello, how are you?",
+	"Explain in one paragraph what a kernel module is.",
+	"Write a short poem about accelerators in the cloud.",
+	"List five common use cases for large language models and describe each of them in a sentence.",
+	"Summarize the differences between training and inference for machine learning models, " +
+		"covering hardware requirements, latency expectations and typical batch sizes.",
+}
+
+// BenchmarkConfig holds configuration for an inference benchmark against an OpenAI compatible endpoint.
+type BenchmarkConfig struct {
+	// Endpoint is the base URL of the server as reachable from within the cluster, without /v1/chat/completions.
+	Endpoint string
+	// Namespace is the namespace of the load generator pod.
+	Namespace string
+	// ModelName is the served model name sent with each request.
+	ModelName string
+	// Prompts are sent round robin. DefaultBenchmarkPrompts is used when empty.
+	Prompts []string
+	// MaxTokens is the maximum number of tokens generated per request.
+	MaxTokens int
+	// Concurrency is the number of requests in flight at the same time.
+	Concurrency int
+	// NumRequests is the total number of requests sent.
+	NumRequests int
+	// RequestRate is the number of requests started per second. Zero starts them as fast as Concurrency allows.
+	RequestRate float64
+	// RequestTimeout is the timeout of a single request.
+	RequestTimeout time.Duration
+	// Prometheus, when set, is used to sample neuroncore utilization during the run.
+	Prometheus prometheusv1.API
+	// SampleInterval is the interval between neuroncore utilization samples.
+	SampleInterval time.Duration
+}
+
+// NewBenchmarkConfig returns the benchmark configuration for modelName served at endpoint, with the load settings of
+// neuronConfig. The prompt set is read from neuronConfig.BenchmarkPromptsFile when it is set.
+func NewBenchmarkConfig(neuronConfig *neuronconfig.NeuronConfig,
+	endpoint, namespace, modelName string) (BenchmarkConfig, error) {
+	config := BenchmarkConfig{
+		Endpoint:    endpoint,
+		Namespace:   namespace,
+		ModelName:   modelName,
+		MaxTokens:   neuronConfig.BenchmarkMaxTokens,
+		Concurrency: neuronConfig.BenchmarkConcurrency,
+		NumRequests: neuronConfig.BenchmarkRequests,
+		RequestRate: neuronConfig.BenchmarkRequestRate,
+	}
+
+	if neuronConfig.BenchmarkPromptsFile != "" {
+		prompts, err := LoadBenchmarkPrompts(neuronConfig.BenchmarkPromptsFile)
+		if err != nil {
+			return config, err
+		}
+
+		config.Prompts = prompts
+	}
+
+	return config, nil
+}
+
+// LatencyStats holds the distribution of a per-request duration in seconds.
+type LatencyStats struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// UtilizationStats holds neuroncore utilization ratios sampled during a benchmark. Each sample is the utilization
+// of the busiest neuroncore, which is the one serving the model.
+type UtilizationStats struct {
+	Samples int     `json:"samples"`
+	Mean    float64 `json:"mean"`
+	Max     float64 `json:"max"`
+}
+
+// BenchmarkResult is the outcome of an inference benchmark. Durations are in seconds.
+type BenchmarkResult struct {
+	Model                 string           `json:"model"`
+	Endpoint              string           `json:"endpoint"`
+	Concurrency           int              `json:"concurrency"`
+	RequestRate           float64          `json:"requestRate"`
+	Requests              int              `json:"requests"`
+	Succeeded             int              `json:"succeeded"`
+	Failed                int              `json:"failed"`
+	ErrorRate             float64          `json:"errorRate"`
+	Duration              float64          `json:"duration"`
+	RequestsPerSecond     float64          `json:"requestsPerSecond"`
+	OutputTokens          int              `json:"outputTokens"`
+	TokensPerSecond       float64          `json:"tokensPerSecond"`
+	TimeToFirstToken      LatencyStats     `json:"timeToFirstToken"`
+	Latency               LatencyStats     `json:"latency"`
+	RequestTokensPerSec   LatencyStats     `json:"requestTokensPerSecond"`
+	NeuroncoreUtilization UtilizationStats `json:"neuroncoreUtilization"`
+	Errors                []string         `json:"errors,omitempty"`
+}
+
+// JSON returns the indented JSON encoding of the result.
+func (result *BenchmarkResult) JSON() ([]byte, error) {
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// WriteJSON writes the JSON encoding of the result to path.
+func (result *BenchmarkResult) WriteJSON(path string) error {
+	content, err := result.JSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal benchmark result: %w", err)
+	}
+
+	err = os.WriteFile(path, content, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write benchmark result to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// requestResult holds the measurements of a single streamed request.
+type requestResult struct {
+	ttft    time.Duration
+	latency time.Duration
+	tokens  int
+	err     error
+}
+
+// RunInferenceBenchmark sends config.NumRequests streamed chat completion requests to config.Endpoint from a curl
+// pod, config.Concurrency at a time and paced at config.RequestRate, and returns the aggregated measurements.
+// Neuroncore utilization is sampled during the run when config.Prometheus is set.
+func RunInferenceBenchmark(apiClient *clients.Settings, config BenchmarkConfig) (*BenchmarkResult, error) {
+	config = withBenchmarkDefaults(config)
+
+	if config.Endpoint == "" || config.Namespace == "" || config.ModelName == "" {
+		return nil, fmt.Errorf("benchmark endpoint, namespace and model name are required")
+	}
+
+	ctx := context.Background()
+
+	createdLocally, err := ensureCurlPod(ctx, apiClient, benchmarkPodName, config.Namespace)
+	if createdLocally {
+		defer cleanupCurlPod(apiClient, benchmarkPodName, config.Namespace)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create load generator pod: %w", err)
+	}
+
+	klog.V(params.NeuronLogLevel).Infof(
+		"Starting benchmark of %s at %s: %d requests, concurrency %d, rate %.2f/s",
+		config.ModelName, config.Endpoint, config.NumRequests, config.Concurrency, config.RequestRate)
+
+	samplerCtx, stopSampler := context.WithCancel(ctx)
+	samples := make(chan []float64, 1)
+
+	go func() {
+		samples <- sampleNeuroncoreUtilization(samplerCtx, config.Prometheus, config.SampleInterval)
+	}()
+
+	start := time.Now()
+	results := sendBenchmarkRequests(ctx, apiClient, config)
+	duration := time.Since(start)
+
+	stopSampler()
+
+	result := aggregateBenchmarkResults(config, results, duration, <-samples)
+
+	klog.V(params.NeuronLogLevel).Infof(
+		"Benchmark finished: %d/%d succeeded, %.2f tokens/s, TTFT p95 %.3fs, latency p95 %.3fs",
+		result.Succeeded, result.Requests, result.TokensPerSecond, result.TimeToFirstToken.P95, result.Latency.P95)
+
+	return result, nil
+}
+
+// withBenchmarkDefaults fills the unset fields of config.
+func withBenchmarkDefaults(config BenchmarkConfig) BenchmarkConfig {
+	if len(config.Prompts) == 0 {
+		config.Prompts = DefaultBenchmarkPrompts
+	}
+
+	if config.MaxTokens <= 0 {
+		config.MaxTokens = 128
+	}
+
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+
+	if config.NumRequests <= 0 {
+		config.NumRequests = config.Concurrency
+	}
+
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = 2 * time.Minute
+	}
+
+	if config.SampleInterval <= 0 {
+		config.SampleInterval = 15 * time.Second
+	}
+
+	return config
+}
+
+// sendBenchmarkRequests sends the requests of the benchmark and returns their measurements in order.
+func sendBenchmarkRequests(
+	ctx context.Context, apiClient *clients.Settings, config BenchmarkConfig) []requestResult {
+	results := make([]requestResult, config.NumRequests)
+	requests := make(chan int)
+
+	var waitGroup sync.WaitGroup
+
+	for range config.Concurrency {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for index := range requests {
+				prompt := config.Prompts[index%len(config.Prompts)]
+				results[index] = sendStreamedRequest(ctx, apiClient, config, prompt)
+
+				if results[index].err != nil {
+					klog.V(params.NeuronLogLevel).Infof("Benchmark request %d failed: %v", index, results[index].err)
+				}
+			}
+		}()
+	}
+
+	start := time.Now()
+
+	for index := range config.NumRequests {
+		if config.RequestRate > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(float64(index) / config.RequestRate * float64(time.Second)))))
+		}
+
+		requests <- index
+	}
+
+	close(requests)
+	waitGroup.Wait()
+
+	return results
+}
+
+// sendStreamedRequest sends a single streamed chat completion request with curl and measures it.
+func sendStreamedRequest(
+	ctx context.Context, apiClient *clients.Settings, config BenchmarkConfig, prompt string) requestResult {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":          config.ModelName,
+		"messages":       []map[string]string{{"role": "user", "content": prompt}},
+		"max_tokens":     config.MaxTokens,
+		"temperature":    0,
+		"stream":         true,
+		"stream_options": map[string]bool{"include_usage": true},
+	})
+	if err != nil {
+		return requestResult{err: fmt.Errorf("failed to marshal request: %w", err)}
+	}
+
+	curlCmd := []string{
+		"curl", "-sk", "-N",
+		"-X", "POST",
+		fmt.Sprintf("%s/v1/chat/completions", strings.TrimSuffix(config.Endpoint, "/")),
+		"-H", "Content-Type: application/json",
+		"-d", string(body),
+		"--max-time", strconv.Itoa(int(config.RequestTimeout.Seconds())),
+		"-w", fmt.Sprintf("\n%s %%{http_code} %%{time_total}\n", curlTimingMarker),
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, config.RequestTimeout+30*time.Second)
+	defer cancel()
+
+	recorder := &streamRecorder{}
+
+	var stderr bytes.Buffer
+
+	err = streamInPod(execCtx, apiClient, benchmarkPodName, config.Namespace, "curl", curlCmd, recorder, &stderr)
+	if err != nil {
+		return requestResult{err: err}
+	}
+
+	return recorder.result()
+}
+
+// streamRecorder parses the server-sent events of a streamed chat completion as curl writes them and records when
+// the first token and the end of the response arrive.
+type streamRecorder struct {
+	pending    []byte
+	firstToken time.Time
+	end        time.Time
+	chunks     int
+	usage      int
+	statusCode int
+	total      time.Duration
+	raw        strings.Builder
+	parseErr   error
+}
+
+// Write implements io.Writer.
+func (recorder *streamRecorder) Write(data []byte) (int, error) {
+	now := time.Now()
+
+	recorder.pending = append(recorder.pending, data...)
+
+	for {
+		newline := bytes.IndexByte(recorder.pending, '\n')
+		if newline < 0 {
+			break
+		}
+
+		recorder.recordLine(strings.TrimSpace(string(recorder.pending[:newline])), now)
+		recorder.pending = recorder.pending[newline+1:]
+	}
+
+	return len(data), nil
+}
+
+// recordLine records a complete line of the response.
+func (recorder *streamRecorder) recordLine(line string, now time.Time) {
+	switch {
+	case line == "" || line == "data: [DONE]":
+		return
+	case strings.HasPrefix(line, curlTimingMarker):
+		recorder.end = now
+		recorder.parseErr = recorder.recordTiming(strings.Fields(line))
+	case strings.HasPrefix(line, "data:"):
+		recorder.recordChunk(strings.TrimSpace(strings.TrimPrefix(line, "data:")), now)
+	default:
+		if recorder.raw.Len() < 512 {
+			recorder.raw.WriteString(line)
+		}
+	}
+}
+
+// recordTiming records the status code and total time written by curl after the response.
+func (recorder *streamRecorder) recordTiming(fields []string) error {
+	if len(fields) != 3 {
+		return fmt.Errorf("unexpected curl timing %v", fields)
+	}
+
+	statusCode, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return fmt.Errorf("failed to parse status code %q: %w", fields[1], err)
+	}
+
+	seconds, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse total time %q: %w", fields[2], err)
+	}
+
+	recorder.statusCode = statusCode
+	recorder.total = time.Duration(seconds * float64(time.Second))
+
+	return nil
+}
+
+// recordChunk records a streamed chat completion chunk.
+func (recorder *streamRecorder) recordChunk(data string, now time.Time) {
+	var chunk struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+		Usage *struct {
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return
+	}
+
+	if chunk.Usage != nil {
+		recorder.usage = chunk.Usage.CompletionTokens
+	}
+
+	if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+		return
+	}
+
+	if recorder.firstToken.IsZero() {
+		recorder.firstToken = now
+	}
+
+	recorder.chunks++
+}
+
+// result returns the measurements of the request. The total time is measured by curl inside the cluster. The time to
+// first token is derived from it by subtracting the time between the first token and the end of the response as seen
+// through the exec stream, so that the exec round trip is not counted.
+func (recorder *streamRecorder) result() requestResult {
+	if recorder.end.IsZero() {
+		return requestResult{err: fmt.Errorf("incomplete response: %s", recorder.raw.String())}
+	}
+
+	if recorder.parseErr != nil {
+		return requestResult{err: recorder.parseErr}
+	}
+
+	if recorder.statusCode != 200 {
+		return requestResult{err: fmt.Errorf("status %d: %s", recorder.statusCode, recorder.raw.String())}
+	}
+
+	if recorder.firstToken.IsZero() {
+		return requestResult{err: fmt.Errorf("no token generated")}
+	}
+
+	tokens := recorder.usage
+	if tokens == 0 {
+		tokens = recorder.chunks
+	}
+
+	ttft := recorder.total - recorder.end.Sub(recorder.firstToken)
+	if ttft < 0 {
+		ttft = 0
+	}
+
+	return requestResult{ttft: ttft, latency: recorder.total, tokens: tokens}
+}
+
+// sampleNeuroncoreUtilization samples the utilization of the busiest neuroncore every interval until ctx is done,
+// and once more when it is done so that short runs get at least one sample.
+func sampleNeuroncoreUtilization(ctx context.Context, client prometheusv1.API, interval time.Duration) []float64 {
+	if client == nil {
+		return nil
+	}
+
+	var samples []float64
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if utilization, ok := busiestNeuroncoreUtilization(client); ok {
+				samples = append(samples, utilization)
+			}
+
+			return samples
+		case <-ticker.C:
+			if utilization, ok := busiestNeuroncoreUtilization(client); ok {
+				samples = append(samples, utilization)
+			}
+		}
+	}
+}
+
+// busiestNeuroncoreUtilization returns the highest neuroncore utilization ratio currently reported.
+func busiestNeuroncoreUtilization(client prometheusv1.API) (float64, bool) {
+	values, err := neuronmetrics.GetNeuroncoreUtilization(client)
+	if err != nil {
+		klog.V(params.NeuronLogLevel).Infof("Failed to sample neuroncore utilization: %v", err)
+
+		return 0, false
+	}
+
+	busiest, found := 0.0, false
+
+	for _, value := range values {
+		raw, ok := value["value"].(string)
+		if !ok {
+			continue
+		}
+
+		utilization, err := strconv.ParseFloat(raw, 64)
+		if err == nil && (!found || utilization > busiest) {
+			busiest, found = utilization, true
+		}
+	}
+
+	return busiest, found
+}
+
+// aggregateBenchmarkResults computes the benchmark result from the request measurements.
+func aggregateBenchmarkResults(config BenchmarkConfig, results []requestResult,
+	duration time.Duration, utilization []float64) *BenchmarkResult {
+	result := &BenchmarkResult{
+		Model:       config.ModelName,
+		Endpoint:    config.Endpoint,
+		Concurrency: config.Concurrency,
+		RequestRate: config.RequestRate,
+		Requests:    len(results),
+		Duration:    duration.Seconds(),
+	}
+
+	var ttfts, latencies, tokenRates []float64
+
+	for _, request := range results {
+		if request.err != nil {
+			result.Failed++
+
+			if len(result.Errors) < maxRecordedErrors {
+				result.Errors = append(result.Errors, request.err.Error())
+			}
+
+			continue
+		}
+
+		result.Succeeded++
+		result.OutputTokens += request.tokens
+		ttfts = append(ttfts, request.ttft.Seconds())
+		latencies = append(latencies, request.latency.Seconds())
+
+		if decode := request.latency - request.ttft; decode > 0 {
+			tokenRates = append(tokenRates, float64(request.tokens)/decode.Seconds())
+		}
+	}
+
+	if result.Requests > 0 {
+		result.ErrorRate = float64(result.Failed) / float64(result.Requests)
+	}
+
+	if duration > 0 {
+		result.RequestsPerSecond = float64(result.Succeeded) / duration.Seconds()
+		result.TokensPerSecond = float64(result.OutputTokens) / duration.Seconds()
+	}
+
+	result.TimeToFirstToken = latencyStats(ttfts)
+	result.Latency = latencyStats(latencies)
+	result.RequestTokensPerSec = latencyStats(tokenRates)
+	result.NeuroncoreUtilization = utilizationStats(utilization)
+
+	return result
+}
+
+// latencyStats returns the distribution of values.
+func latencyStats(values []float64) LatencyStats {
+	if len(values) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+
+	return LatencyStats{
+		Mean: sum / float64(len(sorted)),
+		P50:  percentile(sorted, 50),
+		P95:  percentile(sorted, 95),
+		P99:  percentile(sorted, 99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted.
+func percentile(sorted []float64, rank float64) float64 {
+	index := int(math.Ceil(rank/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+
+	return sorted[index]
+}
+
+// utilizationStats returns the summary of the utilization samples.
+func utilizationStats(samples []float64) UtilizationStats {
+	stats := UtilizationStats{Samples: len(samples)}
+
+	for _, sample := range samples {
+		stats.Mean += sample / float64(len(samples))
+		stats.Max = math.Max(stats.Max, sample)
+	}
+
+	return stats
+}
+
+// LoadBenchmarkPrompts reads a prompt set from path, one prompt per non-empty line.
+func LoadBenchmarkPrompts(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open prompts file %s: %w", path, err)
+	}
+
+	defer file.Close()
+
+	var prompts []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if prompt := strings.TrimSpace(scanner.Text()); prompt != "" {
+			prompts = append(prompts, prompt)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read prompts file %s: %w", path, err)
+	}
+
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("prompts file %s has no prompts", path)
+	}
+
+	return prompts, nil
+}
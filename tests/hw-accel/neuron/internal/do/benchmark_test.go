@@ -0,0 +1,310 @@
+package do
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamRecorderRecordLine(t *testing.T) {
+	start := time.Now()
+
+	type timedLine struct {
+		line   string
+		offset time.Duration
+	}
+
+	testCases := []struct {
+		name          string
+		lines         []timedLine
+		expectedError string
+		expected      requestResult
+	}{
+		{
+			name: "usage reported",
+			lines: []timedLine{
+				{`data: {"choices":[{"delta":{"content":"Hello"}}]}`, 100 * time.Millisecond},
+				{`data: {"choices":[{"delta":{"content":" world"}}]}`, 200 * time.Millisecond},
+				{`data: {"choices":[],"usage":{"completion_tokens":7}}`, 300 * time.Millisecond},
+				{"data: [DONE]", 300 * time.Millisecond},
+				{"", 300 * time.Millisecond},
+				{curlTimingMarker + " 200 1.5", 400 * time.Millisecond},
+			},
+			expected: requestResult{ttft: 1200 * time.Millisecond, latency: 1500 * time.Millisecond, tokens: 7},
+		},
+		{
+			name: "chunks counted without usage",
+			lines: []timedLine{
+				{`data: {"choices":[{"delta":{"role":"assistant"}}]}`, 50 * time.Millisecond},
+				{`data: {"choices":[{"delta":{"content":"a"}}]}`, 100 * time.Millisecond},
+				{`data: {"choices":[{"delta":{"content":"b"}}]}`, 150 * time.Millisecond},
+				{`data: {"choices":[{"delta":{"content":"c"}}]}`, 200 * time.Millisecond},
+				{curlTimingMarker + " 200 0.5", 200 * time.Millisecond},
+			},
+			expected: requestResult{ttft: 400 * time.Millisecond, latency: 500 * time.Millisecond, tokens: 3},
+		},
+		{
+			name: "time to first token not negative",
+			lines: []timedLine{
+				{`data: {"choices":[{"delta":{"content":"a"}}]}`, 0},
+				{curlTimingMarker + " 200 0.1", time.Second},
+			},
+			expected: requestResult{ttft: 0, latency: 100 * time.Millisecond, tokens: 1},
+		},
+		{
+			name: "error status",
+			lines: []timedLine{
+				{`{"error":"model not found"}`, 100 * time.Millisecond},
+				{curlTimingMarker + " 404 0.2", 200 * time.Millisecond},
+			},
+			expectedError: `status 404: {"error":"model not found"}`,
+		},
+		{
+			name: "incomplete response",
+			lines: []timedLine{
+				{`data: {"choices":[{"delta":{"content":"a"}}]}`, 100 * time.Millisecond},
+			},
+			expectedError: "incomplete response",
+		},
+		{
+			name: "no token generated",
+			lines: []timedLine{
+				{`data: {"choices":[],"usage":{"completion_tokens":0}}`, 100 * time.Millisecond},
+				{curlTimingMarker + " 200 0.2", 200 * time.Millisecond},
+			},
+			expectedError: "no token generated",
+		},
+		{
+			name: "invalid timing",
+			lines: []timedLine{
+				{`data: {"choices":[{"delta":{"content":"a"}}]}`, 100 * time.Millisecond},
+				{curlTimingMarker + " 200", 200 * time.Millisecond},
+			},
+			expectedError: "unexpected curl timing",
+		},
+		{
+			name: "invalid status code",
+			lines: []timedLine{
+				{`data: {"choices":[{"delta":{"content":"a"}}]}`, 100 * time.Millisecond},
+				{curlTimingMarker + " 000x 0.2", 200 * time.Millisecond},
+			},
+			expectedError: "failed to parse status code",
+		},
+	}
+
+	for _, testCase := range testCases {
+		recorder := &streamRecorder{}
+
+		for _, line := range testCase.lines {
+			recorder.recordLine(line.line, start.Add(line.offset))
+		}
+
+		result := recorder.result()
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, result.err, testCase.expectedError, testCase.name)
+
+			continue
+		}
+
+		assert.Equal(t, testCase.expected, result, testCase.name)
+	}
+}
+
+func TestStreamRecorderWrite(t *testing.T) {
+	recorder := &streamRecorder{}
+
+	writes := []string{
+		`data: {"choices":[{"delta":{"con`,
+		`tent":"a"}}]}` + "\n" + `data: {"choices":[{"delta":{"content":"b"}}]}` + "\n\n",
+		curlTimingMarker + " 200 ",
+		"0.25\n",
+	}
+
+	for _, data := range writes {
+		written, err := recorder.Write([]byte(data))
+		assert.NoError(t, err)
+		assert.Equal(t, len(data), written)
+	}
+
+	result := recorder.result()
+	assert.NoError(t, result.err)
+	assert.Equal(t, 2, result.tokens)
+	assert.Equal(t, 250*time.Millisecond, result.latency)
+	assert.Empty(t, recorder.pending)
+}
+
+func TestLatencyStats(t *testing.T) {
+	hundred := make([]float64, 100)
+	for index := range hundred {
+		hundred[index] = float64(100 - index)
+	}
+
+	testCases := []struct {
+		name     string
+		values   []float64
+		expected LatencyStats
+	}{
+		{
+			name:     "empty",
+			values:   nil,
+			expected: LatencyStats{},
+		},
+		{
+			name:     "single value",
+			values:   []float64{2},
+			expected: LatencyStats{Mean: 2, P50: 2, P95: 2, P99: 2, Max: 2},
+		},
+		{
+			name:     "unsorted values",
+			values:   []float64{5, 1, 4, 2, 3},
+			expected: LatencyStats{Mean: 3, P50: 3, P95: 5, P99: 5, Max: 5},
+		},
+		{
+			name:     "nearest rank",
+			values:   hundred,
+			expected: LatencyStats{Mean: 50.5, P50: 50, P95: 95, P99: 99, Max: 100},
+		},
+	}
+
+	for _, testCase := range testCases {
+		original := append([]float64{}, testCase.values...)
+
+		assert.Equal(t, testCase.expected, latencyStats(testCase.values), testCase.name)
+		assert.Equal(t, original, append([]float64{}, testCase.values...), "%s: input modified", testCase.name)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	testCases := []struct {
+		sorted   []float64
+		rank     float64
+		expected float64
+	}{
+		{sorted: []float64{1, 2, 3, 4}, rank: 0, expected: 1},
+		{sorted: []float64{1, 2, 3, 4}, rank: 25, expected: 1},
+		{sorted: []float64{1, 2, 3, 4}, rank: 26, expected: 2},
+		{sorted: []float64{1, 2, 3, 4}, rank: 50, expected: 2},
+		{sorted: []float64{1, 2, 3, 4}, rank: 99, expected: 4},
+		{sorted: []float64{1, 2, 3, 4}, rank: 100, expected: 4},
+		{sorted: []float64{7}, rank: 50, expected: 7},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, percentile(testCase.sorted, testCase.rank),
+			"rank %v of %v", testCase.rank, testCase.sorted)
+	}
+}
+
+func TestAggregateBenchmarkResults(t *testing.T) {
+	config := BenchmarkConfig{Endpoint: "http://vllm:8000", ModelName: "model", Concurrency: 2, RequestRate: 1.5}
+
+	results := []requestResult{
+		{ttft: time.Second, latency: 3 * time.Second, tokens: 10},
+		{err: errors.New("status 500: internal error")},
+		{ttft: 500 * time.Millisecond, latency: 1500 * time.Millisecond, tokens: 4},
+	}
+
+	result := aggregateBenchmarkResults(config, results, 2*time.Second, []float64{0.5, 0.7})
+
+	assert.Equal(t, "model", result.Model)
+	assert.Equal(t, "http://vllm:8000", result.Endpoint)
+	assert.Equal(t, 2, result.Concurrency)
+	assert.Equal(t, 1.5, result.RequestRate)
+	assert.Equal(t, 3, result.Requests)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.InDelta(t, 1.0/3, result.ErrorRate, 1e-9)
+	assert.Equal(t, 2.0, result.Duration)
+	assert.Equal(t, 1.0, result.RequestsPerSecond)
+	assert.Equal(t, 14, result.OutputTokens)
+	assert.Equal(t, 7.0, result.TokensPerSecond)
+	assert.Equal(t, LatencyStats{Mean: 0.75, P50: 0.5, P95: 1, P99: 1, Max: 1}, result.TimeToFirstToken)
+	assert.Equal(t, LatencyStats{Mean: 2.25, P50: 1.5, P95: 3, P99: 3, Max: 3}, result.Latency)
+	assert.Equal(t, LatencyStats{Mean: 4.5, P50: 4, P95: 5, P99: 5, Max: 5}, result.RequestTokensPerSec)
+	assert.Equal(t, 2, result.NeuroncoreUtilization.Samples)
+	assert.InDelta(t, 0.6, result.NeuroncoreUtilization.Mean, 1e-9)
+	assert.Equal(t, 0.7, result.NeuroncoreUtilization.Max)
+	assert.Equal(t, []string{"status 500: internal error"}, result.Errors)
+}
+
+func TestAggregateBenchmarkResultsAllFailed(t *testing.T) {
+	results := make([]requestResult, maxRecordedErrors+2)
+	for index := range results {
+		results[index] = requestResult{err: errors.New("no token generated")}
+	}
+
+	result := aggregateBenchmarkResults(BenchmarkConfig{}, results, 0, nil)
+
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, len(results), result.Failed)
+	assert.Equal(t, 1.0, result.ErrorRate)
+	assert.Zero(t, result.RequestsPerSecond)
+	assert.Zero(t, result.TokensPerSecond)
+	assert.Equal(t, LatencyStats{}, result.TimeToFirstToken)
+	assert.Equal(t, UtilizationStats{}, result.NeuroncoreUtilization)
+	assert.Len(t, result.Errors, maxRecordedErrors)
+
+	empty := aggregateBenchmarkResults(BenchmarkConfig{}, nil, time.Second, nil)
+	assert.Zero(t, empty.ErrorRate)
+	assert.Zero(t, empty.TokensPerSecond)
+}
+
+func TestLoadBenchmarkPrompts(t *testing.T) {
+	directory := t.TempDir()
+
+	testCases := []struct {
+		name          string
+		content       *string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "prompts trimmed and blank lines skipped",
+			content:  stringPtr("  first prompt \n\n\tsecond prompt\n   \nthird\n"),
+			expected: []string{"first prompt", "second prompt", "third"},
+		},
+		{
+			name:     "no trailing newline",
+			content:  stringPtr("only prompt"),
+			expected: []string{"only prompt"},
+		},
+		{
+			name:          "no prompts",
+			content:       stringPtr("\n  \n"),
+			expectedError: "has no prompts",
+		},
+		{
+			name:          "missing file",
+			expectedError: "failed to open prompts file",
+		},
+	}
+
+	for index, testCase := range testCases {
+		path := filepath.Join(directory, string(rune('a'+index)))
+
+		if testCase.content != nil {
+			err := os.WriteFile(path, []byte(*testCase.content), 0o600)
+			assert.NoError(t, err)
+		}
+
+		prompts, err := LoadBenchmarkPrompts(path)
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError, testCase.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expected, prompts, testCase.name)
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
@@ -48,16 +48,7 @@ func ExecuteKServeInference(apiClient *clients.Settings, config KServeInferenceC
 		return "", fmt.Errorf("failed to create curl pod: %w", err)
 	}
 
-	serviceURL := config.InferenceServiceURL
-
-	parsed, parseErr := url.Parse(serviceURL)
-	if parseErr == nil && parsed.Port() == "" &&
-		strings.Contains(parsed.Hostname(), "svc.cluster.local") {
-		parsed.Host = parsed.Hostname() + ":8080"
-		serviceURL = parsed.String()
-	}
-
-	endpoint := fmt.Sprintf("%s/v1/chat/completions", serviceURL)
+	endpoint := fmt.Sprintf("%s/v1/chat/completions", KServeServiceURL(config.InferenceServiceURL))
 
 	curlCmd := []string{
 		"curl", "-sk",
@@ -105,6 +96,19 @@ func ExecuteKServeInference(apiClient *clients.Settings, config KServeInferenceC
 	return inferenceResult, nil
 }
 
+// KServeServiceURL returns the URL to reach an InferenceService at from within the cluster. Cluster local URLs
+// without a port are served by the predictor on port 8080.
+func KServeServiceURL(inferenceServiceURL string) string {
+	parsed, err := url.Parse(inferenceServiceURL)
+	if err != nil || parsed.Port() != "" || !strings.Contains(parsed.Hostname(), "svc.cluster.local") {
+		return inferenceServiceURL
+	}
+
+	parsed.Host = parsed.Hostname() + ":8080"
+
+	return parsed.String()
+}
+
 // ParseInferenceResponse parses a raw chat completions JSON response.
 func ParseInferenceResponse(response string) (string, error) {
 	var result map[string]interface{}
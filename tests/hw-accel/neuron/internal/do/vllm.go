@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
@@ -312,6 +313,23 @@ func findRunningVLLMPod(ctx context.Context, apiClient *clients.Settings,
 // executeInPod runs a command inside a pod and returns stdout.
 func executeInPod(ctx context.Context, apiClient *clients.Settings,
 	podName, namespace, container string, command []string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	err := streamInPod(ctx, apiClient, podName, namespace, container, command, &stdout, &stderr)
+	if err != nil {
+		return "", err
+	}
+
+	if stdout.String() == "" {
+		return "", fmt.Errorf("empty response, stderr: %s", stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// streamInPod runs a command inside a pod and copies its output to stdout and stderr as it is produced.
+func streamInPod(ctx context.Context, apiClient *clients.Settings,
+	podName, namespace, container string, command []string, stdout io.Writer, stderr *bytes.Buffer) error {
 	execReq := apiClient.CoreV1Interface.RESTClient().Post().
 		Resource("pods").
 		Name(podName).
@@ -326,24 +344,18 @@ func executeInPod(ctx context.Context, apiClient *clients.Settings,
 
 	exec, err := remotecommand.NewSPDYExecutor(apiClient.Config, "POST", execReq.URL())
 	if err != nil {
-		return "", fmt.Errorf("failed to create executor: %w", err)
+		return fmt.Errorf("failed to create executor: %w", err)
 	}
 
-	var stdout, stderr bytes.Buffer
-
 	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
+		Stdout: stdout,
+		Stderr: stderr,
 	})
 	if err != nil {
-		return "", fmt.Errorf("exec failed: %w, stderr: %s", err, stderr.String())
+		return fmt.Errorf("exec failed: %w, stderr: %s", err, stderr.String())
 	}
 
-	if stdout.String() == "" {
-		return "", fmt.Errorf("empty response, stderr: %s", stderr.String())
-	}
-
-	return stdout.String(), nil
+	return nil
 }
 
 // extractInferenceContent parses the chat completions response and extracts content.
@@ -1,7 +1,11 @@
 package neuronconfig
 
 import (
+	"fmt"
 	"os"
+	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
 	"k8s.io/klog/v2"
@@ -10,113 +14,166 @@ import (
 // NeuronConfig holds the configuration for Neuron tests.
 type NeuronConfig struct {
 	// VLLMImage is the vLLM container image with Neuron support.
-	VLLMImage string
+	//nolint:lll
+	VLLMImage string `envconfig:"ECO_HWACCEL_NEURON_VLLM_IMAGE" default:"public.ecr.aws/neuron/pytorch-inference-vllm-neuronx:0.7.2-neuronx-py310-sdk2.24.1-ubuntu22.04"`
 	// ModelName is the model to load for inference tests.
-	ModelName string
+	ModelName string `envconfig:"ECO_HWACCEL_NEURON_MODEL_NAME" default:"TinyLlama/TinyLlama-1.1B-Chat-v1.0"`
 	// HuggingFaceToken is the token for downloading models from Hugging Face.
-	HuggingFaceToken string
+	HuggingFaceToken string `envconfig:"ECO_HWACCEL_NEURON_HF_TOKEN"`
 	// DriversImage is the Neuron kernel module driver image.
-	DriversImage string
+	DriversImage string `envconfig:"ECO_HWACCEL_NEURON_DRIVERS_IMAGE"`
 	// DriverVersion is the Neuron driver version (required for DeviceConfig creation).
-	DriverVersion string
+	DriverVersion string `envconfig:"ECO_HWACCEL_NEURON_DRIVER_VERSION"`
 	// DevicePluginImage is the Neuron device plugin image.
-	DevicePluginImage string
+	DevicePluginImage string `envconfig:"ECO_HWACCEL_NEURON_DEVICE_PLUGIN_IMAGE"`
 	// SchedulerImage is the custom kube-scheduler image for Neuron.
-	SchedulerImage string
+	SchedulerImage string `envconfig:"ECO_HWACCEL_NEURON_SCHEDULER_IMAGE"`
 	// SchedulerExtensionImage is the Neuron scheduler extension image.
-	SchedulerExtensionImage string
+	SchedulerExtensionImage string `envconfig:"ECO_HWACCEL_NEURON_SCHEDULER_EXTENSION_IMAGE"`
 	// NodeMetricsImage is the Neuron node metrics exporter image.
-	NodeMetricsImage string
+	NodeMetricsImage string `envconfig:"ECO_HWACCEL_NEURON_NODE_METRICS_IMAGE"`
 	// CatalogSource is the catalog source for the operator.
-	CatalogSource string
+	CatalogSource string `envconfig:"ECO_HWACCEL_NEURON_CATALOG_SOURCE"`
 	// CatalogSourceNamespace is the namespace for the catalog source.
-	CatalogSourceNamespace string
+	CatalogSourceNamespace string `envconfig:"ECO_HWACCEL_NEURON_CATALOG_SOURCE_NAMESPACE" default:"openshift-marketplace"` //nolint:lll
 	// SubscriptionName is the name of the operator subscription.
-	SubscriptionName string
+	SubscriptionName string `envconfig:"ECO_HWACCEL_NEURON_SUBSCRIPTION_NAME" default:"aws-neuron-operator"`
 	// UpgradeTargetVersion is the target driver version for upgrade tests.
-	UpgradeTargetVersion string
+	UpgradeTargetVersion string `envconfig:"ECO_HWACCEL_NEURON_UPGRADE_TARGET_VERSION"`
 	// UpgradeTargetDriversImage is the target drivers image for upgrade tests.
-	UpgradeTargetDriversImage string
+	UpgradeTargetDriversImage string `envconfig:"ECO_HWACCEL_NEURON_UPGRADE_TARGET_DRIVERS_IMAGE"`
 	// ImageRepoSecretName is the name of the secret for pulling images.
-	ImageRepoSecretName string
+	ImageRepoSecretName string `envconfig:"ECO_HWACCEL_NEURON_IMAGE_REPO_SECRET"`
 	// InstanceType is the AWS instance type for scaling tests (e.g., inf2.xlarge).
-	InstanceType string
+	InstanceType string `envconfig:"ECO_HWACCEL_NEURON_INSTANCE_TYPE"`
 	// StorageClassName is the storage class for model PVC (default: gp3-csi).
-	StorageClassName string
+	StorageClassName string `envconfig:"ECO_HWACCEL_NEURON_STORAGE_CLASS" default:"gp3-csi"`
 	// KServeModelName is the HuggingFace model for KServe inference tests.
-	KServeModelName string
+	KServeModelName string `envconfig:"ECO_HWACCEL_NEURON_KSERVE_MODEL_NAME" default:"TinyLlama/TinyLlama-1.1B-Chat-v1.0"` //nolint:lll
 	// KServeVLLMImage is the vLLM Neuron image for the KServe ServingRuntime.
-	KServeVLLMImage string
+	KServeVLLMImage string `envconfig:"ECO_HWACCEL_NEURON_KSERVE_VLLM_IMAGE"`
 	// KServeNamespace is the namespace where KServe resources are deployed.
-	KServeNamespace string
+	KServeNamespace string `envconfig:"ECO_HWACCEL_NEURON_KSERVE_NAMESPACE" default:"neuron-inference"`
 	// KServeTensorParallelSize is the tensor parallel size for KServe vLLM.
-	KServeTensorParallelSize string
+	KServeTensorParallelSize string `envconfig:"ECO_HWACCEL_NEURON_KSERVE_TENSOR_PARALLEL_SIZE" default:"1"`
+	// BenchmarkConcurrency is the number of inference requests in flight during benchmarks.
+	BenchmarkConcurrency int `envconfig:"ECO_HWACCEL_NEURON_BENCHMARK_CONCURRENCY" default:"4"`
+	// BenchmarkRequests is the number of inference requests sent by a benchmark.
+	BenchmarkRequests int `envconfig:"ECO_HWACCEL_NEURON_BENCHMARK_REQUESTS" default:"32"`
+	// BenchmarkRequestRate is the number of requests started per second, zero for no pacing.
+	BenchmarkRequestRate float64 `envconfig:"ECO_HWACCEL_NEURON_BENCHMARK_REQUEST_RATE" default:"0"`
+	// BenchmarkMaxTokens is the maximum number of tokens generated per benchmark request.
+	BenchmarkMaxTokens int `envconfig:"ECO_HWACCEL_NEURON_BENCHMARK_MAX_TOKENS" default:"128"`
+	// BenchmarkPromptsFile is a file with one benchmark prompt per line. Built-in prompts are used when empty.
+	BenchmarkPromptsFile string `envconfig:"ECO_HWACCEL_NEURON_BENCHMARK_PROMPTS_FILE"`
+	// InferenceSLO holds the thresholds benchmarks are asserted against.
+	InferenceSLO InferenceSLO
 }
 
-// NewNeuronConfig creates a new NeuronConfig from environment variables.
+// InferenceSLO holds the inference benchmark thresholds. Zero thresholds are not asserted, except MaxErrorRate
+// which defaults to no failed request. Durations are given as Go durations such as 500ms or 2s.
+type InferenceSLO struct {
+	// MaxTTFTP95 is the maximum 95th percentile time to first token.
+	MaxTTFTP95 time.Duration `envconfig:"ECO_HWACCEL_NEURON_SLO_TTFT_P95"`
+	// MaxLatencyP50 is the maximum median request latency.
+	MaxLatencyP50 time.Duration `envconfig:"ECO_HWACCEL_NEURON_SLO_LATENCY_P50"`
+	// MaxLatencyP95 is the maximum 95th percentile request latency.
+	MaxLatencyP95 time.Duration `envconfig:"ECO_HWACCEL_NEURON_SLO_LATENCY_P95"`
+	// MaxLatencyP99 is the maximum 99th percentile request latency.
+	MaxLatencyP99 time.Duration `envconfig:"ECO_HWACCEL_NEURON_SLO_LATENCY_P99"`
+	// MinTokensPerSecond is the minimum aggregate output tokens per second.
+	MinTokensPerSecond float64 `envconfig:"ECO_HWACCEL_NEURON_SLO_MIN_TOKENS_PER_SECOND"`
+	// MaxErrorRate is the maximum ratio of failed requests.
+	MaxErrorRate float64 `envconfig:"ECO_HWACCEL_NEURON_SLO_MAX_ERROR_RATE"`
+	// MinNeuroncoreUtilization is the minimum mean utilization ratio of the busiest neuroncore during the run.
+	MinNeuroncoreUtilization float64 `envconfig:"ECO_HWACCEL_NEURON_SLO_MIN_NEURONCORE_UTILIZATION"`
+}
+
+// NewNeuronConfig creates a new NeuronConfig from environment variables. A variable that is unset or empty leaves its
+// field at the default, and a variable that cannot be parsed is logged and leaves its field at the default as well.
 func NewNeuronConfig() *NeuronConfig {
-	config := &NeuronConfig{
-		VLLMImage:                 os.Getenv("ECO_HWACCEL_NEURON_VLLM_IMAGE"),
-		ModelName:                 os.Getenv("ECO_HWACCEL_NEURON_MODEL_NAME"),
-		HuggingFaceToken:          os.Getenv("ECO_HWACCEL_NEURON_HF_TOKEN"),
-		DriversImage:              os.Getenv("ECO_HWACCEL_NEURON_DRIVERS_IMAGE"),
-		DriverVersion:             os.Getenv("ECO_HWACCEL_NEURON_DRIVER_VERSION"),
-		DevicePluginImage:         os.Getenv("ECO_HWACCEL_NEURON_DEVICE_PLUGIN_IMAGE"),
-		SchedulerImage:            os.Getenv("ECO_HWACCEL_NEURON_SCHEDULER_IMAGE"),
-		SchedulerExtensionImage:   os.Getenv("ECO_HWACCEL_NEURON_SCHEDULER_EXTENSION_IMAGE"),
-		NodeMetricsImage:          os.Getenv("ECO_HWACCEL_NEURON_NODE_METRICS_IMAGE"),
-		CatalogSource:             os.Getenv("ECO_HWACCEL_NEURON_CATALOG_SOURCE"),
-		CatalogSourceNamespace:    os.Getenv("ECO_HWACCEL_NEURON_CATALOG_SOURCE_NAMESPACE"),
-		SubscriptionName:          os.Getenv("ECO_HWACCEL_NEURON_SUBSCRIPTION_NAME"),
-		UpgradeTargetVersion:      os.Getenv("ECO_HWACCEL_NEURON_UPGRADE_TARGET_VERSION"),
-		UpgradeTargetDriversImage: os.Getenv("ECO_HWACCEL_NEURON_UPGRADE_TARGET_DRIVERS_IMAGE"),
-		ImageRepoSecretName:       os.Getenv("ECO_HWACCEL_NEURON_IMAGE_REPO_SECRET"),
-		InstanceType:              os.Getenv("ECO_HWACCEL_NEURON_INSTANCE_TYPE"),
-		StorageClassName:          os.Getenv("ECO_HWACCEL_NEURON_STORAGE_CLASS"),
-		KServeModelName:           os.Getenv("ECO_HWACCEL_NEURON_KSERVE_MODEL_NAME"),
-		KServeVLLMImage:           os.Getenv("ECO_HWACCEL_NEURON_KSERVE_VLLM_IMAGE"),
-		KServeNamespace:           os.Getenv("ECO_HWACCEL_NEURON_KSERVE_NAMESPACE"),
-		KServeTensorParallelSize:  os.Getenv("ECO_HWACCEL_NEURON_KSERVE_TENSOR_PARALLEL_SIZE"),
-	}
+	config := new(NeuronConfig)
 
-	// Set defaults
-	if config.CatalogSourceNamespace == "" {
-		config.CatalogSourceNamespace = "openshift-marketplace"
-	}
+	loadFields(reflect.ValueOf(config).Elem())
 
-	if config.SubscriptionName == "" {
-		config.SubscriptionName = "aws-neuron-operator"
-	}
+	klog.V(params.NeuronLogLevel).Infof(
+		"NeuronConfig loaded: DriversImage=%s, DevicePluginImage=%s, NodeMetricsImage=%s",
+		config.DriversImage, config.DevicePluginImage, config.NodeMetricsImage)
 
-	if config.ModelName == "" {
-		config.ModelName = "TinyLlama/TinyLlama-1.1B-Chat-v1.0"
-	}
+	return config
+}
 
-	if config.VLLMImage == "" {
-		// Default vLLM image with Neuron support
-		config.VLLMImage = "public.ecr.aws/neuron/pytorch-inference-vllm-neuronx:0.7.2-neuronx-py310-sdk2.24.1-ubuntu22.04"
-	}
+// loadFields sets each field of spec from the variable named in its envconfig tag, falling back to its default tag.
+// Nested structs are loaded recursively.
+func loadFields(spec reflect.Value) {
+	for index := range spec.NumField() {
+		field := spec.Field(index)
+		structField := spec.Type().Field(index)
 
-	if config.StorageClassName == "" {
-		config.StorageClassName = "gp3-csi"
-	}
+		if field.Kind() == reflect.Struct {
+			loadFields(field)
 
-	if config.KServeModelName == "" {
-		config.KServeModelName = "TinyLlama/TinyLlama-1.1B-Chat-v1.0"
-	}
+			continue
+		}
 
-	if config.KServeNamespace == "" {
-		config.KServeNamespace = "neuron-inference"
-	}
+		key := structField.Tag.Get("envconfig")
+		defaultValue := structField.Tag.Get("default")
+
+		value := os.Getenv(key)
+		if value == "" {
+			value = defaultValue
+		}
+
+		if value == "" {
+			continue
+		}
+
+		err := setField(field, value)
+		if err == nil {
+			continue
+		}
+
+		klog.Errorf("Failed to parse %s value %q, using default %q: %v", key, value, defaultValue, err)
 
-	if config.KServeTensorParallelSize == "" {
-		config.KServeTensorParallelSize = "1"
+		field.SetZero()
+
+		if defaultValue != "" {
+			_ = setField(field, defaultValue)
+		}
 	}
+}
 
-	klog.V(params.NeuronLogLevel).Infof("NeuronConfig loaded: DriversImage=%s, DevicePluginImage=%s, NodeMetricsImage=%s",
-		config.DriversImage, config.DevicePluginImage, config.NodeMetricsImage)
+// setField parses value into field based on its type.
+func setField(field reflect.Value, value string) error {
+	switch {
+	case field.Type() == reflect.TypeOf(time.Duration(0)):
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(duration))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(parsed))
+	case field.Kind() == reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
 
-	return config
+	return nil
 }
 
 // IsValid checks if the minimum required configuration is present.
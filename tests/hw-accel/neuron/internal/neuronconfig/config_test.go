@@ -0,0 +1,91 @@
+package neuronconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNeuronConfig(t *testing.T) {
+	config := NewNeuronConfig()
+	assert.NotNil(t, config)
+	assert.Equal(t, "openshift-marketplace", config.CatalogSourceNamespace)
+	assert.Equal(t, 4, config.BenchmarkConcurrency)
+	assert.Equal(t, 128, config.BenchmarkMaxTokens)
+	assert.Zero(t, config.InferenceSLO.MaxTTFTP95)
+
+	t.Setenv("ECO_HWACCEL_NEURON_BENCHMARK_CONCURRENCY", "8")
+	t.Setenv("ECO_HWACCEL_NEURON_BENCHMARK_REQUEST_RATE", "2.5")
+	t.Setenv("ECO_HWACCEL_NEURON_SLO_TTFT_P95", "750ms")
+	t.Setenv("ECO_HWACCEL_NEURON_SLO_MAX_ERROR_RATE", "0.01")
+
+	config = NewNeuronConfig()
+	assert.NotNil(t, config)
+	assert.Equal(t, 8, config.BenchmarkConcurrency)
+	assert.Equal(t, 2.5, config.BenchmarkRequestRate)
+	assert.Equal(t, 750*time.Millisecond, config.InferenceSLO.MaxTTFTP95)
+	assert.Equal(t, 0.01, config.InferenceSLO.MaxErrorRate)
+}
+
+func TestNewNeuronConfigInvalidValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected func(config *NeuronConfig) any
+		fallback any
+	}{
+		{
+			name:     "ECO_HWACCEL_NEURON_BENCHMARK_CONCURRENCY",
+			value:    "four",
+			expected: func(config *NeuronConfig) any { return config.BenchmarkConcurrency },
+			fallback: 4,
+		},
+		{
+			name:     "ECO_HWACCEL_NEURON_BENCHMARK_REQUEST_RATE",
+			value:    "fast",
+			expected: func(config *NeuronConfig) any { return config.BenchmarkRequestRate },
+			fallback: float64(0),
+		},
+		{
+			name:     "ECO_HWACCEL_NEURON_SLO_LATENCY_P95",
+			value:    "2",
+			expected: func(config *NeuronConfig) any { return config.InferenceSLO.MaxLatencyP95 },
+			fallback: time.Duration(0),
+		},
+		{
+			name:     "ECO_HWACCEL_NEURON_SLO_MIN_TOKENS_PER_SECOND",
+			value:    "many",
+			expected: func(config *NeuronConfig) any { return config.InferenceSLO.MinTokensPerSecond },
+			fallback: float64(0),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv(testCase.name, testCase.value)
+			t.Setenv("ECO_HWACCEL_NEURON_MODEL_NAME", "example/model")
+
+			config := NewNeuronConfig()
+			assert.NotNil(t, config)
+			assert.Equal(t, testCase.fallback, testCase.expected(config))
+			assert.Equal(t, "example/model", config.ModelName)
+		})
+	}
+}
+
+func TestNewNeuronConfigEmptyValues(t *testing.T) {
+	t.Setenv("ECO_HWACCEL_NEURON_CATALOG_SOURCE_NAMESPACE", "")
+	t.Setenv("ECO_HWACCEL_NEURON_STORAGE_CLASS", "")
+	t.Setenv("ECO_HWACCEL_NEURON_BENCHMARK_CONCURRENCY", "")
+	t.Setenv("ECO_HWACCEL_NEURON_SLO_TTFT_P95", "")
+	t.Setenv("ECO_HWACCEL_NEURON_DRIVERS_IMAGE", "quay.io/example/neuron-drivers:2.24")
+
+	config := NewNeuronConfig()
+	assert.NotNil(t, config)
+	assert.Equal(t, "openshift-marketplace", config.CatalogSourceNamespace)
+	assert.Equal(t, "gp3-csi", config.StorageClassName)
+	assert.Equal(t, 4, config.BenchmarkConcurrency)
+	assert.Zero(t, config.InferenceSLO.MaxTTFTP95)
+	assert.Equal(t, "quay.io/example/neuron-drivers:2.24", config.DriversImage)
+}
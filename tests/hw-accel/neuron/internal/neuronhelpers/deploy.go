@@ -1,11 +1,13 @@
 package neuronhelpers
 
 import (
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/internal/deploy"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
 	"k8s.io/klog/v2"
@@ -112,6 +114,34 @@ func GetDefaultKMMInstallConfig(apiClient *clients.Settings) deploy.OperatorInst
 	}
 }
 
+// KMMSubscription returns the subscription of the KMM operator. It is named kmm-subscription when installed by the
+// tests and after the package when installed from the console, so both names are tried.
+func KMMSubscription(apiClient *clients.Settings) (*olm.SubscriptionBuilder, error) {
+	kmmInstallConfig := GetDefaultKMMInstallConfig(apiClient)
+
+	var errs []error
+
+	for _, subscriptionName := range []string{kmmInstallConfig.SubscriptionName, kmmInstallConfig.PackageName} {
+		kmmInstallConfig.SubscriptionName = subscriptionName
+
+		kmmManager, err := deploy.NewOperatorInstaller(kmmInstallConfig).Manager()
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, err := kmmManager.Subscription()
+		if err == nil {
+			return subscription, nil
+		}
+
+		klog.V(params.NeuronLogLevel).Infof("KMM subscription %s not found: %v", subscriptionName, err)
+
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
 // AreAllOperatorsReady checks if NFD, KMM, and Neuron operators are already deployed and ready.
 func AreAllOperatorsReady(apiClient *clients.Settings, neuronOptions *NeuronInstallConfigOptions) bool {
 	klog.V(params.NeuronLogLevel).Info("Checking if all operators are already ready")
@@ -1,36 +1,24 @@
 package neuronmetrics
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/url"
 	"time"
 
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
-	corev1 "k8s.io/api/core/v1"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
 )
 
-// PrometheusQueryResult represents the result from a Prometheus query.
-type PrometheusQueryResult struct {
-	Status string `json:"status"`
-	Data   struct {
-		ResultType string `json:"resultType"`
-		Result     []struct {
-			Metric map[string]string `json:"metric"`
-			Value  []interface{}     `json:"value"`
-		} `json:"result"`
-	} `json:"data"`
-}
+// queryTimeout is the timeout for a single Prometheus query.
+const queryTimeout = 60 * time.Second
 
 // ServiceMonitorExists checks if a ServiceMonitor exists.
 func ServiceMonitorExists(apiClient *clients.Settings, name, namespace string) (bool, error) {
@@ -66,131 +54,71 @@ func ListServiceMonitors(apiClient *clients.Settings, namespace string) (*unstru
 		List(context.Background(), metav1.ListOptions{})
 }
 
-// QueryPrometheus queries Prometheus for a specific metric by executing a query inside a cluster pod.
-func QueryPrometheus(apiClient *clients.Settings, query string) (*PrometheusQueryResult, error) {
-	klog.V(params.NeuronLogLevel).Infof("Querying Prometheus for: %s", query)
-
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
-	// Find a running pod in the monitoring namespace to execute the query from
-	podInfo, err := findMonitoringPod(ctx, apiClient)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find monitoring pod: %w", err)
-	}
-
-	klog.V(params.NeuronLogLevel).Infof("Using monitoring pod: %s (container: %s)", podInfo.Name, podInfo.Container)
-
-	encodedQuery := url.QueryEscape(query)
-
-	// Try localhost endpoints inside the thanos-query container
-	endpoints := []struct {
-		name string
-		url  string
-	}{
-		{"localhost:9090", fmt.Sprintf("http://localhost:9090/api/v1/query?query=%s", encodedQuery)},
-		{"localhost:9095", fmt.Sprintf("http://localhost:9095/api/v1/query?query=%s", encodedQuery)},
-		{"localhost:10902", fmt.Sprintf("http://localhost:10902/api/v1/query?query=%s", encodedQuery)},
-	}
-
-	for _, endpoint := range endpoints {
-		queryCmd := []string{"sh", "-c", fmt.Sprintf("curl -sf '%s' 2>/dev/null", endpoint.url)}
-		resp, err := executeInPod(ctx, apiClient, podInfo.Name, neuronparams.PrometheusNamespace, podInfo.Container, queryCmd)
-
-		if err == nil && resp != "" && !isUnauthorized(resp) {
-			result, parseErr := parsePrometheusResponse(resp)
-			if parseErr == nil && len(result.Data.Result) > 0 {
-				klog.V(params.NeuronLogLevel).Infof("Endpoint %s returned %d results", endpoint.name, len(result.Data.Result))
-
-				return result, nil
-			}
-
-			klog.V(params.NeuronLogLevel).Infof("Endpoint %s returned 0 results, trying next", endpoint.name)
-
-			continue
-		}
-
-		klog.V(params.NeuronLogLevel).Infof("Endpoint %s failed: err=%v, empty=%t", endpoint.name, err, resp == "")
-	}
-
-	result, authErr := queryThanosAuth(ctx, apiClient, podInfo, encodedQuery)
-	if authErr == nil {
-		return result, nil
-	}
-
-	return nil, fmt.Errorf("all prometheus endpoints failed, thanos auth: %w", authErr)
+// NewPrometheusClient creates a client for the cluster Thanos Querier using the shared prometheus package.
+func NewPrometheusClient(apiClient *clients.Settings) (*prometheus.Client, error) {
+	return prometheus.NewClient(apiClient, PrometheusClientOptions()...)
 }
 
-func queryThanosAuth(ctx context.Context, apiClient *clients.Settings,
-	podInfo *monitoringPodInfo, encodedQuery string) (*PrometheusQueryResult, error) {
-	authURL := fmt.Sprintf("https://%s.%s.svc:9091/api/v1/query?query=%s",
-		neuronparams.ThanosQuerierServiceName, neuronparams.PrometheusNamespace, encodedQuery)
-	authCmd := []string{"sh", "-c", fmt.Sprintf(
-		`curl -sf -k -H "Authorization: Bearer $(cat /var/run/secrets/kubernetes.io/serviceaccount/token)" '%s' 2>/dev/null`,
-		authURL)}
-
-	resp, err := executeInPod(ctx, apiClient, podInfo.Name, neuronparams.PrometheusNamespace, podInfo.Container, authCmd)
-	if err != nil || resp == "" || isUnauthorized(resp) {
-		return nil, fmt.Errorf("thanos auth endpoint failed")
-	}
-
-	result, parseErr := parsePrometheusResponse(resp)
-	if parseErr != nil {
-		return nil, fmt.Errorf("thanos auth endpoint parse error: %w", parseErr)
+// PrometheusClientOptions returns the options used for the Neuron prometheus client so that the querier resources can
+// be cleaned up with the same names.
+func PrometheusClientOptions() []prometheus.ClientOption {
+	return []prometheus.ClientOption{
+		prometheus.WithNamespace(neuronparams.PrometheusNamespace),
+		prometheus.WithServiceAccount(
+			neuronparams.PrometheusServiceAccountName, neuronparams.PrometheusClusterRoleBindingName),
 	}
+}
 
-	if len(result.Data.Result) == 0 {
-		return nil, fmt.Errorf("thanos auth endpoint: no results")
-	}
+// QueryPrometheus queries Prometheus for a specific metric at the current time.
+func QueryPrometheus(client prometheusv1.API, query string) (model.Vector, error) {
+	klog.V(params.NeuronLogLevel).Infof("Querying Prometheus for: %s", query)
 
-	klog.V(params.NeuronLogLevel).Infof("Thanos auth endpoint returned %d results", len(result.Data.Result))
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
 
-	return result, nil
+	return prometheus.QueryVector(ctx, client, query, time.Time{})
 }
 
 // MetricExists checks if a metric exists in Prometheus.
-func MetricExists(apiClient *clients.Settings, metricName string) (bool, error) {
-	result, err := QueryPrometheus(apiClient, metricName)
+func MetricExists(client prometheusv1.API, metricName string) (bool, error) {
+	result, err := QueryPrometheus(client, metricName)
 	if err != nil {
 		return false, err
 	}
 
-	return result.Status == "success" && len(result.Data.Result) > 0, nil
+	return len(result) > 0, nil
 }
 
 // GetMetricValue gets the value of a metric from Prometheus.
-func GetMetricValue(apiClient *clients.Settings, metricName string) ([]map[string]interface{}, error) {
-	result, err := QueryPrometheus(apiClient, metricName)
+func GetMetricValue(client prometheusv1.API, metricName string) ([]map[string]interface{}, error) {
+	result, err := QueryPrometheus(client, metricName)
 	if err != nil {
 		return nil, err
 	}
 
-	if result.Status != "success" {
-		return nil, fmt.Errorf("query failed with status: %s", result.Status)
-	}
-
 	var values []map[string]interface{}
 
-	for _, r := range result.Data.Result {
-		value := map[string]interface{}{
-			"metric": r.Metric,
-		}
-		if len(r.Value) >= 2 {
-			value["value"] = r.Value[1]
+	for _, sample := range result {
+		labels := make(map[string]string, len(sample.Metric))
+		for name, value := range sample.Metric {
+			labels[string(name)] = string(value)
 		}
 
-		values = append(values, value)
+		values = append(values, map[string]interface{}{
+			"metric": labels,
+			"value":  sample.Value.String(),
+		})
 	}
 
 	return values, nil
 }
 
 // VerifyNeuronMetricsAvailable checks if all expected Neuron metrics are available.
-func VerifyNeuronMetricsAvailable(apiClient *clients.Settings) ([]string, []string, error) {
+func VerifyNeuronMetricsAvailable(client prometheusv1.API) ([]string, []string, error) {
 	var availableMetrics, missingMetrics []string
 
 	for _, metric := range params.NeuronMetrics {
-		exists, err := MetricExists(apiClient, metric)
+		exists, err := MetricExists(client, metric)
 		if err != nil {
 			klog.V(params.NeuronLogLevel).Infof("Error checking metric %s: %v", metric, err)
 			missingMetrics = append(missingMetrics, metric)
@@ -211,108 +139,16 @@ func VerifyNeuronMetricsAvailable(apiClient *clients.Settings) ([]string, []stri
 }
 
 // GetNeuronHardwareInfo retrieves the neuron_hardware_info metric.
-func GetNeuronHardwareInfo(apiClient *clients.Settings) ([]map[string]interface{}, error) {
-	return GetMetricValue(apiClient, "neuron_hardware_info")
+func GetNeuronHardwareInfo(client prometheusv1.API) ([]map[string]interface{}, error) {
+	return GetMetricValue(client, "neuron_hardware_info")
 }
 
 // GetNeuroncoreUtilization retrieves the neuroncore utilization metric.
-func GetNeuroncoreUtilization(apiClient *clients.Settings) ([]map[string]interface{}, error) {
-	return GetMetricValue(apiClient, "neuroncore_utilization_ratio")
+func GetNeuroncoreUtilization(client prometheusv1.API) ([]map[string]interface{}, error) {
+	return GetMetricValue(client, "neuroncore_utilization_ratio")
 }
 
 // GetNeuronMemoryUsed retrieves the neuron runtime memory used metric.
-func GetNeuronMemoryUsed(apiClient *clients.Settings) ([]map[string]interface{}, error) {
-	return GetMetricValue(apiClient, "neuron_runtime_memory_used_bytes")
-}
-
-// monitoringPodInfo contains information about a pod to use for queries.
-type monitoringPodInfo struct {
-	Name      string
-	Container string
-}
-
-// findMonitoringPod finds a running pod in the monitoring namespace to execute queries from.
-func findMonitoringPod(ctx context.Context, apiClient *clients.Settings) (*monitoringPodInfo, error) {
-	thanosLabels := []string{
-		"app.kubernetes.io/name=thanos-query",
-		"app.kubernetes.io/name=thanos-querier",
-	}
-
-	for _, label := range thanosLabels {
-		podList, err := apiClient.CoreV1Interface.Pods(neuronparams.PrometheusNamespace).List(ctx, metav1.ListOptions{
-			LabelSelector: label,
-		})
-		if err != nil {
-			continue
-		}
-
-		for _, p := range podList.Items {
-			if p.Status.Phase == corev1.PodRunning {
-				klog.V(params.NeuronLogLevel).Infof("Found thanos-querier pod %s via label %s", p.Name, label)
-
-				return &monitoringPodInfo{Name: p.Name, Container: "thanos-query"}, nil
-			}
-		}
-	}
-
-	promPodList, err := apiClient.CoreV1Interface.Pods(neuronparams.PrometheusNamespace).List(ctx, metav1.ListOptions{
-		LabelSelector: "app.kubernetes.io/name=prometheus",
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list monitoring pods: %w", err)
-	}
-
-	for _, p := range promPodList.Items {
-		if p.Status.Phase == corev1.PodRunning {
-			return &monitoringPodInfo{Name: p.Name, Container: "prometheus"}, nil
-		}
-	}
-
-	return nil, fmt.Errorf("no running monitoring pods found")
-}
-
-// executeInPod runs a command inside a pod and returns stdout.
-func executeInPod(ctx context.Context, apiClient *clients.Settings,
-	podName, namespace, container string, command []string) (string, error) {
-	execReq := apiClient.CoreV1Interface.RESTClient().Post().
-		Resource("pods").
-		Name(podName).
-		Namespace(namespace).
-		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Container: container,
-			Command:   command,
-			Stdout:    true,
-			Stderr:    true,
-		}, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(apiClient.Config, "POST", execReq.URL())
-	if err != nil {
-		return "", fmt.Errorf("failed to create executor: %w", err)
-	}
-
-	var stdout, stderr bytes.Buffer
-
-	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
-	if err != nil {
-		return "", fmt.Errorf("exec failed: %w, stderr: %s", err, stderr.String())
-	}
-
-	return stdout.String(), nil
-}
-
-func parsePrometheusResponse(raw string) (*PrometheusQueryResult, error) {
-	var result PrometheusQueryResult
-	if err := json.Unmarshal([]byte(raw), &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return &result, nil
-}
-
-func isUnauthorized(resp string) bool {
-	return resp == "Unauthorized" || resp == "Unauthorized\n"
+func GetNeuronMemoryUsed(client prometheusv1.API) ([]map[string]interface{}, error) {
+	return GetMetricValue(client, "neuron_runtime_memory_used_bytes")
 }
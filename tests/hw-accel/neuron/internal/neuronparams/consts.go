@@ -7,8 +7,10 @@ import (
 const (
 	// PrometheusNamespace represents the namespace for Prometheus.
 	PrometheusNamespace = "openshift-monitoring"
-	// ThanosQuerierServiceName represents the Thanos querier service name.
-	ThanosQuerierServiceName = "thanos-querier"
+	// PrometheusServiceAccountName is the ServiceAccount used to query the Thanos querier.
+	PrometheusServiceAccountName = "neuron-prometheus-querier"
+	// PrometheusClusterRoleBindingName is the ClusterRoleBinding that grants the ServiceAccount monitoring access.
+	PrometheusClusterRoleBindingName = "neuron-prometheus-querier-crb"
 )
 
 // ServiceMonitorGVR is the GroupVersionResource for ServiceMonitor.
@@ -19,4 +19,6 @@ const (
 	InferenceRequestTimeout = 5 * time.Minute
 
 	CurlPodName = "kserve-inference-test-curl"
+
+	BenchmarkResultFile = "neuron-kserve-benchmark.json"
 )
@@ -2,13 +2,16 @@ package tests
 
 import (
 	"fmt"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/kserve"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/check"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/do"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronmetrics"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/kserve/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
@@ -127,5 +130,50 @@ var _ = Describe("Neuron KServe Inference Tests", Ordered, Label(params.Label),
 				Expect(result).ToNot(BeEmpty(), "Inference response should not be empty")
 				klog.V(params.NeuronLogLevel).Infof("Inference result: %s", result)
 			})
+
+		It("Should meet inference SLOs under concurrent load",
+			Label("kserve", "kserve-benchmark"), reportxml.ID("neuron-kserve-003"), func() {
+				By("Getting InferenceService URL")
+
+				isvc, err := kserve.PullInferenceService(
+					APIClient, isvcName, neuronConfig.KServeNamespace)
+				Expect(err).ToNot(HaveOccurred(), "InferenceService must exist")
+
+				isvcURL, err := isvc.GetURL()
+				Expect(err).ToNot(HaveOccurred(), "InferenceService must have a URL")
+
+				benchmarkConfig, err := do.NewBenchmarkConfig(neuronConfig,
+					do.KServeServiceURL(isvcURL), neuronConfig.KServeNamespace, isvcName)
+				Expect(err).ToNot(HaveOccurred(), "Failed to configure benchmark")
+
+				By("Creating Prometheus client for neuroncore utilization sampling")
+
+				prometheusClient, err := neuronmetrics.NewPrometheusClient(APIClient)
+				if err != nil {
+					klog.V(params.NeuronLogLevel).Infof("Benchmarking without utilization sampling: %v", err)
+				} else {
+					benchmarkConfig.Prometheus = prometheusClient
+				}
+
+				By("Running inference benchmark")
+
+				benchmarkResult, err := do.RunInferenceBenchmark(APIClient, benchmarkConfig)
+				Expect(err).ToNot(HaveOccurred(), "Failed to run inference benchmark")
+
+				resultJSON, err := benchmarkResult.JSON()
+				Expect(err).ToNot(HaveOccurred(), "Failed to encode benchmark result")
+				klog.V(params.NeuronLogLevel).Infof("Benchmark result: %s", resultJSON)
+
+				if GeneralConfig.ReportsDirAbsPath != "" {
+					err = benchmarkResult.WriteJSON(
+						filepath.Join(GeneralConfig.ReportsDirAbsPath, tsparams.BenchmarkResultFile))
+					Expect(err).ToNot(HaveOccurred(), "Failed to write benchmark result")
+				}
+
+				By("Asserting inference SLOs")
+
+				err = check.InferenceSLOsMet(benchmarkResult, neuronConfig.InferenceSLO)
+				Expect(err).ToNot(HaveOccurred(), "Inference performance regressed")
+			})
 	})
 })
@@ -7,6 +7,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/neuron"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
@@ -19,6 +20,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/metrics/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -50,6 +52,8 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 	Context("Metrics Provisioning", Label(tsparams.LabelSuite), func() {
 		neuronConfig := neuronconfig.NewNeuronConfig()
 
+		var prometheusAPI prometheusv1.API
+
 		BeforeAll(func() {
 			By("Verifying configuration")
 
@@ -127,6 +131,11 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 				Expect(err).ToNot(HaveOccurred(), "Failed to create metrics test namespace")
 			}
 
+			By("Creating Prometheus API client")
+
+			prometheusAPI, err = neuronmetrics.NewPrometheusClient(APIClient)
+			Expect(err).ToNot(HaveOccurred(), "Failed to create Prometheus API client")
+
 			if os.Getenv("ECO_SKIP_VLLM_CLEANUP") == "true" {
 				klog.V(params.NeuronLogLevel).Info(
 					"vLLM workload kept alive (ECO_SKIP_VLLM_CLEANUP=true), skipping helper deployment")
@@ -171,6 +180,11 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 					klog.V(params.NeuronLogLevel).Infof("Failed to delete metrics test namespace: %v", err)
 				}
 			}
+
+			err := prometheus.CleanupServiceAccount(APIClient, neuronmetrics.PrometheusClientOptions()...)
+			if err != nil {
+				klog.V(params.NeuronLogLevel).Infof("Failed to delete Prometheus querier resources: %v", err)
+			}
 		})
 
 		It("Should verify metrics DaemonSet is created",
@@ -220,7 +234,7 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 				var available, missing []string
 
 				Eventually(func() int {
-					avail, miss, err := neuronmetrics.VerifyNeuronMetricsAvailable(APIClient)
+					avail, miss, err := neuronmetrics.VerifyNeuronMetricsAvailable(prometheusAPI)
 					if err != nil {
 						klog.V(params.NeuronLogLevel).Infof("Error checking metrics: %v", err)
 
@@ -246,7 +260,7 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 
 				hardwareInfo := pollForMetric(
 					func() ([]map[string]interface{}, error) {
-						return neuronmetrics.GetNeuronHardwareInfo(APIClient)
+						return neuronmetrics.GetNeuronHardwareInfo(prometheusAPI)
 					},
 					"neuron_hardware_info",
 				)
@@ -260,7 +274,7 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 
 				utilization := pollForMetric(
 					func() ([]map[string]interface{}, error) {
-						return neuronmetrics.GetNeuroncoreUtilization(APIClient)
+						return neuronmetrics.GetNeuroncoreUtilization(prometheusAPI)
 					},
 					"neuroncore_utilization_ratio",
 				)
@@ -299,7 +313,7 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 
 				memoryUsed := pollForMetric(
 					func() ([]map[string]interface{}, error) {
-						return neuronmetrics.GetNeuronMemoryUsed(APIClient)
+						return neuronmetrics.GetNeuronMemoryUsed(prometheusAPI)
 					},
 					"neuron_runtime_memory_used_bytes",
 				)
@@ -315,7 +329,7 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 
 				hardwareInfo := pollForMetric(
 					func() ([]map[string]interface{}, error) {
-						return neuronmetrics.GetNeuronHardwareInfo(APIClient)
+						return neuronmetrics.GetNeuronHardwareInfo(prometheusAPI)
 					},
 					"neuron_hardware_info",
 				)
@@ -326,7 +340,7 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 
 				utilization := pollForMetric(
 					func() ([]map[string]interface{}, error) {
-						return neuronmetrics.GetNeuroncoreUtilization(APIClient)
+						return neuronmetrics.GetNeuroncoreUtilization(prometheusAPI)
 					},
 					"neuroncore_utilization_ratio",
 				)
@@ -17,4 +17,6 @@ const (
 	OperatorDeployTimeout = 10 * time.Minute
 	// DevicePluginReadyTimeout represents the timeout for device plugin readiness.
 	DevicePluginReadyTimeout = 10 * time.Minute
+	// BenchmarkResultFile represents the file the vLLM benchmark result is written to in the reports directory.
+	BenchmarkResultFile = "neuron-vllm-benchmark.json"
 )
@@ -2,7 +2,9 @@ package tests
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,9 +14,11 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/neuron"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/await"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/check"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/do"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronhelpers"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronmetrics"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/vllm/internal/tsparams"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
@@ -236,5 +240,51 @@ var _ = Describe("Neuron vLLM Inference Tests", Ordered, Label(params.Label), La
 			Expect(inferenceResult).ToNot(BeEmpty(), "Inference should return a result")
 			klog.V(params.NeuronLogLevel).Infof("Inference result: %s", inferenceResult)
 		})
+
+		It("Should meet inference SLOs under concurrent load",
+			Label("neuron-vllm-benchmark"), reportxml.ID("neuron-vllm-002"), func() {
+				vllmConfig := do.DefaultVLLMConfig(tsparams.VLLMTestNamespace)
+
+				By("Verifying vLLM deployment is ready")
+
+				dep, err := deployment.Pull(APIClient, vllmConfig.Name, tsparams.VLLMTestNamespace)
+				if err != nil || !dep.IsReady(time.Minute) {
+					Skip("vLLM deployment is not ready - benchmark requires the inference test to pass")
+				}
+
+				benchmarkConfig, err := do.NewBenchmarkConfig(neuronConfig,
+					fmt.Sprintf("http://%s.%s.svc.cluster.local:%d",
+						vllmConfig.Name, tsparams.VLLMTestNamespace, vllmConfig.Port),
+					tsparams.VLLMTestNamespace, neuronConfig.ModelName)
+				Expect(err).ToNot(HaveOccurred(), "Failed to configure benchmark")
+
+				By("Creating Prometheus client for neuroncore utilization sampling")
+
+				prometheusClient, err := neuronmetrics.NewPrometheusClient(APIClient)
+				if err != nil {
+					klog.V(params.NeuronLogLevel).Infof("Benchmarking without utilization sampling: %v", err)
+				} else {
+					benchmarkConfig.Prometheus = prometheusClient
+				}
+
+				By("Running inference benchmark")
+
+				result, err := do.RunInferenceBenchmark(APIClient, benchmarkConfig)
+				Expect(err).ToNot(HaveOccurred(), "Failed to run inference benchmark")
+
+				resultJSON, err := result.JSON()
+				Expect(err).ToNot(HaveOccurred(), "Failed to encode benchmark result")
+				klog.V(params.NeuronLogLevel).Infof("Benchmark result: %s", resultJSON)
+
+				if GeneralConfig.ReportsDirAbsPath != "" {
+					err = result.WriteJSON(filepath.Join(GeneralConfig.ReportsDirAbsPath, tsparams.BenchmarkResultFile))
+					Expect(err).ToNot(HaveOccurred(), "Failed to write benchmark result")
+				}
+
+				By("Asserting inference SLOs")
+
+				err = check.InferenceSLOsMet(result, neuronConfig.InferenceSLO)
+				Expect(err).ToNot(HaveOccurred(), "Inference performance regressed")
+			})
 	})
 })
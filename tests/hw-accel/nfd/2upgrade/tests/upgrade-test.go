@@ -6,15 +6,14 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/internal/deploy"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nfd/2upgrade/internal/await"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nfd/2upgrade/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nfd/internal/nfdconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nfd/internal/nfdhelpers"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nfd/nfdparams"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/olmlifecycle"
 	"k8s.io/klog/v2"
 )
 
@@ -23,8 +22,8 @@ var _ = Describe("NFD", Ordered, Label(nfdparams.Label), func() {
 		nfdConfig := nfdconfig.NewNfdConfig()
 
 		var (
-			nfdInstaller *deploy.OperatorInstaller
-			nfdCRUtils   *deploy.NFDCRUtils
+			nfdManager *olmlifecycle.Manager
+			nfdCRUtils *deploy.NFDCRUtils
 		)
 
 		BeforeAll(func() {
@@ -40,19 +39,24 @@ var _ = Describe("NFD", Ordered, Label(nfdparams.Label), func() {
 			}
 
 			installConfig := nfdhelpers.GetDefaultNFDInstallConfig(APIClient, options)
-			nfdInstaller = deploy.NewOperatorInstaller(installConfig)
+			nfdInstaller := deploy.NewOperatorInstaller(installConfig)
 			nfdCRUtils = deploy.NewNFDCRUtils(APIClient, installConfig.Namespace, nfdparams.NfdInstance)
 
+			var err error
+
+			nfdManager, err = nfdInstaller.Manager()
+			Expect(err).ToNot(HaveOccurred(), "error creating NFD lifecycle manager")
+
 			By("Installing NFD operator")
 
-			err := nfdInstaller.Install()
+			err = nfdInstaller.Install()
 			Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("error installing NFD operator: %s", err))
 
 			By("Waiting for NFD operator CSV to be ready")
 
-			ready, err := nfdhelpers.WaitForNFDOperatorReady(APIClient, 5*time.Minute)
+			csvName, err := nfdManager.WaitForInstalledCSV(5 * time.Minute)
 			Expect(err).ToNot(HaveOccurred(), "error waiting for NFD operator CSV")
-			Expect(ready).To(BeTrue(), "NFD operator CSV not ready")
+			klog.V(nfdparams.LogLevel).Infof("NFD operator installed with CSV %s", csvName)
 
 			By("Creating NFD CR")
 
@@ -77,12 +81,7 @@ var _ = Describe("NFD", Ordered, Label(nfdparams.Label), func() {
 
 			By("Uninstalling NFD operator")
 
-			uninstallConfig := nfdhelpers.GetDefaultNFDUninstallConfig(
-				APIClient,
-				"nfd-operator-group",
-				"nfd-subscription")
-			nfdUninstaller := deploy.NewOperatorUninstaller(uninstallConfig)
-			err = nfdUninstaller.Uninstall()
+			err = nfdManager.Uninstall(5 * time.Minute)
 			Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("error uninstalling NFD: %s", err))
 		})
 
@@ -95,21 +94,17 @@ var _ = Describe("NFD", Ordered, Label(nfdparams.Label), func() {
 				Skip("No CustomCatalogSource defined. Skipping test")
 			}
 
-			By("Getting NFD subscription")
-
-			sub, err := olm.PullSubscription(APIClient, "nfd-subscription", nfdparams.NFDNamespace)
-			Expect(err).ToNot(HaveOccurred(), "failed getting subscription")
-
 			By("Update subscription to use new catalog source")
-			klog.V(nfdparams.LogLevel).Infof("Current CatalogSource: %s", sub.Object.Spec.CatalogSource)
-			sub.Definition.Spec.CatalogSource = nfdConfig.CustomCatalogSource
-			_, err = sub.Update()
+			klog.V(nfdparams.LogLevel).Infof("Current CatalogSource: %s", nfdManager.Config().CatalogSource)
+
+			err := nfdManager.SwitchChannel("", nfdConfig.CustomCatalogSource)
 			Expect(err).ToNot(HaveOccurred(), "failed updating subscription")
 
 			By("Await operator to be upgraded")
 
-			err = await.OperatorUpgrade(APIClient, nfdConfig.UpgradeTargetVersion, 10*time.Minute)
+			csvName, err := nfdManager.WaitForInstalledVersion(nfdConfig.UpgradeTargetVersion, 10*time.Minute)
 			Expect(err).ToNot(HaveOccurred(), "failed awaiting subscription upgrade")
+			klog.V(nfdparams.LogLevel).Infof("NFD operator upgraded to CSV %s", csvName)
 		})
 	})
 })
@@ -19,6 +19,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodeexec"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
@@ -63,8 +64,21 @@ func PullTestImageOnNodes(apiClient *clients.Settings, nodeSelector, image strin
 	return nil
 }
 
+// sharedNodeExecutor returns the NodeExecutor used by ExecCmd and ExecCmdWithStdout when the general config selects a
+// transport other than the machine-config-daemon. It is a variable so unit tests can replace it with a mock.
+var sharedNodeExecutor = func(apiClient *clients.Settings) (nodeexec.NodeExecutor, error) {
+	return nodeexec.Shared(apiClient, GeneralConfig)
+}
+
 // ExecCmd runc cmd on all nodes that match nodeSelector.
 func ExecCmd(apiClient *clients.Settings, nodeSelector string, shellCmd string) error {
+	if !usesMCDTransport() {
+		_, err := execCmdOnNodes(apiClient, shellCmd,
+			metav1.ListOptions{LabelSelector: labels.Set(map[string]string{nodeSelector: ""}).String()})
+
+		return err
+	}
+
 	klog.V(90).Infof("Executing cmd: %v on nodes based on label: %v using mcp pods", shellCmd, nodeSelector)
 
 	nodeList, err := nodes.List(
@@ -113,6 +127,14 @@ func ExecCmdWithStdout(
 	apiClient *clients.Settings, shellCmd string, options ...metav1.ListOptions) (map[string]string, error) {
 	klog.V(90).Infof("Executing command '%s' with stdout and options ('%v')", shellCmd, options)
 
+	if !usesMCDTransport() {
+		if len(options) > 1 {
+			return nil, fmt.Errorf("error: more than one ListOptions was passed")
+		}
+
+		return execCmdOnNodes(apiClient, shellCmd, options...)
+	}
+
 	if GeneralConfig.MCOConfigDaemonName == "" {
 		return nil, fmt.Errorf("error: mco config daemon pod name cannot be empty")
 	}
@@ -193,6 +215,61 @@ func ExecCmdWithStdout(
 	return outputMap, nil
 }
 
+// usesMCDTransport returns true when commands run on the nodes by exec'ing into the machine-config-daemon pods, which
+// is the case unless the general config selects another node executor transport.
+func usesMCDTransport() bool {
+	return GeneralConfig == nil || GeneralConfig.NodeExecTransport == "" ||
+		nodeexec.Transport(GeneralConfig.NodeExecTransport) == nodeexec.TransportMCD
+}
+
+// execCmdOnNodes runs shellCmd on every node matching listOptions using the shared node executor and returns the
+// stdout keyed by the hostname reported by each node. When the command fails, its output is included in the error.
+func execCmdOnNodes(
+	apiClient *clients.Settings, shellCmd string, listOptions ...metav1.ListOptions) (map[string]string, error) {
+	passedOptions := metav1.ListOptions{}
+	if len(listOptions) == 1 {
+		passedOptions = listOptions[0]
+	}
+
+	nodeList, err := nodes.List(apiClient, passedOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	executor, err := sharedNodeExecutor(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	outputMap := make(map[string]string)
+
+	for _, node := range nodeList {
+		nodeName := node.Definition.Name
+
+		hostnameResult, err := executor.Execute(context.TODO(), nodeName, "printf $(hostname)")
+		if err != nil {
+			return nil, fmt.Errorf("failed gathering node hostname: %w", err)
+		}
+
+		klog.V(90).Infof("Exec cmd %v on node %s using the %s transport",
+			shellCmd, nodeName, GeneralConfig.NodeExecTransport)
+
+		result, err := executor.Execute(context.TODO(), nodeName, shellCmd)
+		if err != nil {
+			if result != nil {
+				return nil, fmt.Errorf("failed executing command '%s' on node %s: %w\n%s",
+					shellCmd, hostnameResult.Stdout, err, result.Stdout)
+			}
+
+			return nil, fmt.Errorf("failed executing command '%s' on node %s: %w", shellCmd, hostnameResult.Stdout, err)
+		}
+
+		outputMap[hostnameResult.Stdout] = result.Stdout
+	}
+
+	return outputMap, nil
+}
+
 // ExecCmdWithRetries executes a command on the provided client on each node matching nodeSelector,
 // retrying on internal errors
 // "retries" times with a "interval" duration between retries, and ignores the stdout.
@@ -358,12 +435,14 @@ func waitForReachable(client *clients.Settings, timeout time.Duration) error {
 		})
 }
 
-// isErrorExecuting matches errors that contain the message "error executing command in container".
+// isErrorExecuting matches errors that contain the message "error executing command in container" or show that the
+// pod running the command was not available, such as while the node reboots.
 func isErrorExecuting(err error) bool {
 	if err == nil {
 		return false
 	}
 
 	return strings.Contains(err.Error(), "error executing command in container") ||
-		strings.Contains(err.Error(), "container not found")
+		strings.Contains(err.Error(), "container not found") ||
+		strings.Contains(err.Error(), "no running pods")
 }
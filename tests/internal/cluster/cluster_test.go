@@ -1 +1,166 @@
 package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodeexec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const hostnameCmd = "printf $(hostname)"
+
+func TestExecCmdWithStdout(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       []metav1.ListOptions
+		setup         func(mock *nodeexec.MockExecutor)
+		expected      map[string]string
+		expectedError string
+	}{
+		{
+			name: "all nodes",
+			setup: func(mock *nodeexec.MockExecutor) {
+				mock.SetStdout("worker-0", "uptime", "up 1 day")
+				mock.SetStdout("worker-1", "uptime", "up 2 days")
+			},
+			expected: map[string]string{"worker-0.example.com": "up 1 day", "worker-1.example.com": "up 2 days"},
+		},
+		{
+			name:    "selected nodes",
+			options: []metav1.ListOptions{{LabelSelector: "node-role.kubernetes.io/worker-cnf"}},
+			setup: func(mock *nodeexec.MockExecutor) {
+				mock.SetStdout("", "uptime", "up")
+			},
+			expected: map[string]string{"worker-1.example.com": "up"},
+		},
+		{
+			name:          "multiple options",
+			options:       []metav1.ListOptions{{}, {}},
+			expectedError: "more than one ListOptions was passed",
+		},
+		{
+			name: "command fails",
+			setup: func(mock *nodeexec.MockExecutor) {
+				mock.SetResponse("", "uptime", &nodeexec.Result{Stdout: "partial output", ExitCode: 1},
+					&nodeexec.ExitError{NodeName: "worker-0", Command: "uptime", ExitCode: 1})
+			},
+			expectedError: "partial output",
+		},
+		{
+			name: "hostname fails",
+			setup: func(mock *nodeexec.MockExecutor) {
+				mock.SetResponse("worker-0", hostnameCmd, nil, errors.New("container not found"))
+			},
+			expectedError: "failed gathering node hostname: container not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock := setMockNodeExecutor(t)
+
+			if testCase.setup != nil {
+				testCase.setup(mock)
+			}
+
+			outputs, err := ExecCmdWithStdout(buildTestNodesClient(), "uptime", testCase.options...)
+
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, outputs)
+			assert.Equal(t, 0, mock.CleanupCount())
+		})
+	}
+}
+
+func TestExecCmd(t *testing.T) {
+	mock := setMockNodeExecutor(t)
+
+	err := ExecCmd(buildTestNodesClient(), "node-role.kubernetes.io/worker-cnf", "systemctl restart kubelet")
+	assert.Nil(t, err)
+	assert.Equal(t, []nodeexec.MockCall{
+		{NodeName: "worker-1", Command: hostnameCmd},
+		{NodeName: "worker-1", Command: "systemctl restart kubelet"},
+	}, mock.Calls())
+}
+
+func TestUsesMCDTransport(t *testing.T) {
+	originalGeneralConfig := inittools.GeneralConfig
+
+	t.Cleanup(func() {
+		inittools.GeneralConfig = originalGeneralConfig
+	})
+
+	testCases := []struct {
+		generalConfig *config.GeneralConfig
+		expected      bool
+	}{
+		{generalConfig: nil, expected: true},
+		{generalConfig: &config.GeneralConfig{}, expected: true},
+		{generalConfig: &config.GeneralConfig{NodeExecTransport: "mcd"}, expected: true},
+		{generalConfig: &config.GeneralConfig{NodeExecTransport: "daemonset"}, expected: false},
+		{generalConfig: &config.GeneralConfig{NodeExecTransport: "ssh"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		inittools.GeneralConfig = testCase.generalConfig
+
+		assert.Equal(t, testCase.expected, usesMCDTransport())
+	}
+}
+
+func TestIsErrorExecuting(t *testing.T) {
+	assert.False(t, isErrorExecuting(nil))
+	assert.True(t, isErrorExecuting(errors.New("error executing command in container: EOF")))
+	assert.True(t, isErrorExecuting(errors.New("no running pods with label k8s-app=machine-config-daemon found")))
+	assert.False(t, isErrorExecuting(&nodeexec.ExitError{NodeName: "worker-0", Command: "false", ExitCode: 1}))
+}
+
+// setMockNodeExecutor selects the daemonset transport and replaces sharedNodeExecutor with one returning a
+// MockExecutor that reports the hostname of each node as its name under example.com. The originals are restored when
+// the test finishes.
+func setMockNodeExecutor(t *testing.T) *nodeexec.MockExecutor {
+	t.Helper()
+
+	mock := nodeexec.NewMockExecutor()
+	mock.SetStdout("worker-0", hostnameCmd, "worker-0.example.com")
+	mock.SetStdout("worker-1", hostnameCmd, "worker-1.example.com")
+
+	originalSharedNodeExecutor := sharedNodeExecutor
+	sharedNodeExecutor = func(*clients.Settings) (nodeexec.NodeExecutor, error) {
+		return mock, nil
+	}
+
+	originalGeneralConfig := inittools.GeneralConfig
+	inittools.GeneralConfig = &config.GeneralConfig{NodeExecTransport: "daemonset"}
+
+	t.Cleanup(func() {
+		sharedNodeExecutor = originalSharedNodeExecutor
+		inittools.GeneralConfig = originalGeneralConfig
+	})
+
+	return mock
+}
+
+// buildTestNodesClient returns a fake client with two worker nodes, of which only worker-1 is a worker-cnf node.
+func buildTestNodesClient() *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-1", Labels: map[string]string{"node-role.kubernetes.io/worker-cnf": ""}}},
+		},
+	})
+}
@@ -6,10 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
-
-	"github.com/kelseyhightower/envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -17,6 +16,10 @@ const (
 	PathToDefaultParamsFile = "./default.yaml"
 )
 
+// nodeExecTransports are the transports supported by the nodeexec package. They are listed here rather than imported
+// since nodeexec depends on this package.
+var nodeExecTransports = []string{"mcd", "debugpod", "daemonset", "ssh"}
+
 // GeneralConfig type keeps general configuration.
 type GeneralConfig struct {
 	ReportsDirAbsPath         string `yaml:"reports_dump_dir" envconfig:"ECO_REPORTS_DUMP_DIR"`
@@ -25,6 +28,7 @@ type GeneralConfig struct {
 	EnableReport              bool   `yaml:"enable_report" envconfig:"ECO_ENABLE_REPORT"`
 	DryRun                    bool   `yaml:"dry_run" envconfig:"ECO_DRY_RUN"`
 	SSHKeyPath                string `envconfig:"ECO_SSH_KEY_PATH"`
+	SSHKnownHostsPath         string `envconfig:"ECO_SSH_KNOWN_HOSTS_PATH"`
 	SSHUser                   string `yaml:"ssh_user" envconfig:"ECO_SSH_USER"`
 	KubernetesRolePrefix      string `yaml:"kubernetes_role_prefix" envconfig:"ECO_KUBERNETES_ROLE_PREFIX"`
 	WorkerLabelEnvVar         string `yaml:"worker_label" envconfig:"ECO_WORKER_LABEL"`
@@ -37,6 +41,8 @@ type GeneralConfig struct {
 	SriovOperatorNamespace    string `yaml:"sriov_operator_namespace" envconfig:"ECO_SRIOV_OPERATOR_NAMESPACE"`
 	NMStateOperatorNamespace  string `yaml:"nmstate_operator_namespace" envconfig:"ECO_NMSTATE_OPERATOR_NAMESPACE"`
 	SriovFecOperatorNamespace string `yaml:"sriov_fec_operator_namespace" envconfig:"ECO_SRIOV_FEC_OPERATOR_NAMESPACE"`
+	NodeExecTransport         string `yaml:"node_exec_transport" envconfig:"ECO_NODE_EXEC_TRANSPORT"`
+	NodeExecImage             string `yaml:"node_exec_image" envconfig:"ECO_NODE_EXEC_IMAGE"`
 	WorkerLabelMap            map[string]string
 	ControlPlaneLabelMap      map[string]string
 }
@@ -51,20 +57,25 @@ func NewConfig() *GeneralConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultParamsFile)
 
-	err := readFile(&conf, confFile)
+	err := newGeneralLoader(confFile).Load(&conf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
+		log.Printf("Error to load GeneralConfig: %v", err)
 
 		return nil
 	}
 
-	err = readEnv(&conf)
+	err = conf.Validate()
 	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to validate GeneralConfig: %v", err)
 
 		return nil
 	}
 
+	conf.WorkerLabel = fmt.Sprintf("%s/%s", conf.KubernetesRolePrefix, conf.WorkerLabelEnvVar)
+	conf.ControlPlaneLabel = fmt.Sprintf("%s/%s", conf.KubernetesRolePrefix, conf.ControlPlaneLabel)
+	conf.WorkerLabelMap = map[string]string{conf.WorkerLabel: ""}
+	conf.ControlPlaneLabelMap = map[string]string{conf.ControlPlaneLabel: ""}
+
 	err = deployReportDir(conf.ReportsDirAbsPath)
 	if err != nil {
 		log.Printf("Error to deploy report directory %s due to %s", conf.ReportsDirAbsPath, err.Error())
@@ -111,38 +122,33 @@ func (cfg *GeneralConfig) GetDumpFailedTestReportLocation(file string) string {
 	return ""
 }
 
-func readFile(cfg *GeneralConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
+// Validate checks the values of the config after it has been loaded, returning a FieldError for the first invalid
+// field.
+func (cfg *GeneralConfig) Validate() error {
+	if level, err := strconv.Atoi(cfg.VerboseLevel); err != nil || level < 0 {
+		return &FieldError{Field: "VerboseLevel", Err: fmt.Errorf("%q is not a non-negative integer", cfg.VerboseLevel)}
 	}
 
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
+	if !filepath.IsAbs(cfg.ReportsDirAbsPath) {
+		return &FieldError{Field: "ReportsDirAbsPath", Err: fmt.Errorf("%q is not an absolute path", cfg.ReportsDirAbsPath)}
+	}
 
-	decoder := yaml.NewDecoder(openedCfgFile)
+	if cfg.KubernetesRolePrefix == "" {
+		return &FieldError{Field: "KubernetesRolePrefix", Err: fmt.Errorf("value must not be empty")}
+	}
 
-	err = decoder.Decode(&cfg)
-	if err != nil {
-		return err
+	if cfg.NodeExecTransport != "" && !slices.Contains(nodeExecTransports, cfg.NodeExecTransport) {
+		return &FieldError{Field: "NodeExecTransport", Err: fmt.Errorf(
+			"%q is not one of %s", cfg.NodeExecTransport, strings.Join(nodeExecTransports, ", "))}
 	}
 
 	return nil
 }
 
-func readEnv(cfg *GeneralConfig) error {
-	err := envconfig.Process("", cfg)
-	if err != nil {
-		return err
-	}
-
-	cfg.WorkerLabel = fmt.Sprintf("%s/%s", cfg.KubernetesRolePrefix, cfg.WorkerLabelEnvVar)
-	cfg.ControlPlaneLabel = fmt.Sprintf("%s/%s", cfg.KubernetesRolePrefix, cfg.ControlPlaneLabel)
-	cfg.WorkerLabelMap = map[string]string{cfg.WorkerLabel: ""}
-	cfg.ControlPlaneLabelMap = map[string]string{cfg.ControlPlaneLabel: ""}
-
-	return nil
+// newGeneralLoader returns the Loader for GeneralConfig. Since the ECO_ prefix is shared with every suite config, ECO_
+// variables that do not match a field are logged rather than rejected.
+func newGeneralLoader(defaultFile string) Loader {
+	return Loader{Section: "general", DefaultFile: defaultFile, EnvPrefix: "ECO_", WarnUnknownEnv: true}
 }
 
 func deployReportDir(dirName string) error {
@@ -1 +1,236 @@
 package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testWorkload struct {
+	Image string `yaml:"image" envconfig:"ECO_TEST_WORKLOAD_IMAGE"`
+}
+
+type testConfig struct {
+	Name     string            `yaml:"name" envconfig:"ECO_TEST_NAME"`
+	Count    int               `yaml:"count" envconfig:"ECO_TEST_COUNT"`
+	Enabled  bool              `yaml:"enabled"`
+	Workload testWorkload      `yaml:"workload"`
+	Labels   map[string]string `yaml:"labels"`
+}
+
+func TestLoaderLoad(t *testing.T) {
+	testCases := []struct {
+		name           string
+		defaults       string
+		overrides      string
+		env            map[string]string
+		partial        bool
+		expectedConfig testConfig
+		expectedError  error
+	}{
+		{
+			name:           "defaults only",
+			defaults:       "name: default\ncount: 1\n",
+			expectedConfig: testConfig{Name: "default", Count: 1},
+		},
+		{
+			name:           "override section",
+			defaults:       "name: default\ncount: 1\n",
+			overrides:      "test:\n  count: 2\n  enabled: true\nother:\n  unknown: true\n",
+			expectedConfig: testConfig{Name: "default", Count: 2, Enabled: true},
+		},
+		{
+			name:           "environment over override",
+			defaults:       "name: default\ncount: 1\n",
+			overrides:      "test:\n  count: 2\n",
+			env:            map[string]string{"ECO_TEST_COUNT": "3"},
+			expectedConfig: testConfig{Name: "default", Count: 3},
+		},
+		{
+			name:          "unknown default key",
+			defaults:      "name: default\ncuont: 1\n",
+			expectedError: &UnknownKeyError{Key: "cuont", Source: "default.yaml"},
+		},
+		{
+			name:           "unknown key in partial config",
+			defaults:       "name: default\ncuont: 1\n",
+			partial:        true,
+			expectedConfig: testConfig{Name: "default"},
+		},
+		{
+			name:          "unknown override key",
+			defaults:      "name: default\n",
+			overrides:     "test:\n  nmae: override\n",
+			expectedError: &UnknownKeyError{Key: "nmae", Source: "override.yaml"},
+		},
+		{
+			name:          "unknown environment variable",
+			defaults:      "name: default\n",
+			env:           map[string]string{"ECO_TEST_CUONT": "3"},
+			expectedError: &UnknownKeyError{Key: "ECO_TEST_CUONT", Source: envSource},
+		},
+		{
+			name:           "nested default key",
+			defaults:       "name: default\nworkload:\n  image: default\n",
+			overrides:      "test:\n  workload:\n    image: override\n",
+			expectedConfig: testConfig{Name: "default", Workload: testWorkload{Image: "override"}},
+		},
+		{
+			name:           "override replaces map",
+			defaults:       "name: default\nlabels:\n  role: worker\n  zone: a\n",
+			overrides:      "test:\n  labels:\n    role: master\n",
+			expectedConfig: testConfig{Name: "default", Labels: map[string]string{"role": "master"}},
+		},
+		{
+			name:           "override replaces nested struct",
+			defaults:       "name: default\nworkload:\n  image: default\n",
+			overrides:      "test:\n  workload: {}\n",
+			expectedConfig: testConfig{Name: "default"},
+		},
+		{
+			name:           "nested environment variable",
+			defaults:       "name: default\nworkload:\n  image: default\n",
+			env:            map[string]string{"ECO_TEST_WORKLOAD_IMAGE": "environment"},
+			expectedConfig: testConfig{Name: "default", Workload: testWorkload{Image: "environment"}},
+		},
+		{
+			name:          "nested key at top level",
+			defaults:      "name: default\nimage: default\n",
+			expectedError: &UnknownKeyError{Key: "image", Source: "default.yaml"},
+		},
+		{
+			name:           "config file path and ignored environment variables",
+			defaults:       "name: default\n",
+			env:            map[string]string{"ECO_TEST_CONFIG_FILE_PATH": "/tmp/test.yaml", "ECO_TEST_OTHER_NAME": "other"},
+			expectedConfig: testConfig{Name: "default"},
+		},
+		{
+			name:          "invalid default value",
+			defaults:      "name: default\ncount: many\n",
+			expectedError: &FieldError{Field: "Count", Key: "count", Source: "default.yaml"},
+		},
+		{
+			name:          "invalid environment value",
+			defaults:      "name: default\n",
+			env:           map[string]string{"ECO_TEST_COUNT": "many"},
+			expectedError: &FieldError{Field: "Count", Key: "ECO_TEST_COUNT", Source: envSource},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			defaultFile := filepath.Join(tempDir, "default.yaml")
+			overrideFile := filepath.Join(tempDir, "override.yaml")
+
+			assert.Nil(t, os.WriteFile(defaultFile, []byte(testCase.defaults), 0644))
+			assert.Nil(t, os.WriteFile(overrideFile, []byte(testCase.overrides), 0644))
+
+			t.Setenv(ConfigFileEnvVar, overrideFile)
+
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			var config testConfig
+
+			loader := Loader{
+				Section:           "test",
+				DefaultFile:       defaultFile,
+				EnvPrefix:         "ECO_TEST_",
+				EnvIgnorePrefixes: []string{"ECO_TEST_OTHER_"},
+				Partial:           testCase.partial,
+			}
+			err := loader.Load(&config)
+
+			switch expectedError := testCase.expectedError.(type) {
+			case nil:
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedConfig, config)
+			case *UnknownKeyError:
+				var unknownKeyErr *UnknownKeyError
+
+				assert.ErrorAs(t, err, &unknownKeyErr)
+				assert.Equal(t, expectedError.Key, unknownKeyErr.Key)
+				assert.Equal(t, expectedError.Source, filepath.Base(unknownKeyErr.Source))
+			case *FieldError:
+				var fieldErr *FieldError
+
+				assert.ErrorAs(t, err, &fieldErr)
+				assert.Equal(t, expectedError.Field, fieldErr.Field)
+				assert.Equal(t, expectedError.Key, fieldErr.Key)
+				assert.Equal(t, expectedError.Source, filepath.Base(fieldErr.Source))
+			}
+		})
+	}
+}
+
+func TestGeneralLoaderEnv(t *testing.T) {
+	t.Setenv(ConfigFileEnvVar, "")
+	t.Setenv("ECO_VERBOSE_LEVEL", "90")
+	t.Setenv("ECO_CNF_RAN_KUBECONFIG_HUB", "/tmp/kubeconfig")
+	t.Setenv("ECO_RDS_CORE_CONFIG_FILE_PATH", "/tmp/rdscore.yaml")
+
+	var config GeneralConfig
+
+	assert.Nil(t, newGeneralLoader(PathToDefaultParamsFile).Load(&config))
+	assert.Equal(t, "90", config.VerboseLevel)
+
+	t.Setenv("ECO_VERBOSE_LEVL", "10")
+	t.Setenv("ECO_UNLISTED_SUITE_IMAGE", "quay.io/example/image:latest")
+
+	assert.Nil(t, newGeneralLoader(PathToDefaultParamsFile).Load(&config))
+	assert.Equal(t, "90", config.VerboseLevel)
+}
+
+func TestGeneralConfigValidate(t *testing.T) {
+	testCases := []struct {
+		config        GeneralConfig
+		expectedField string
+	}{
+		{
+			config: GeneralConfig{VerboseLevel: "0", ReportsDirAbsPath: "/tmp/reports", KubernetesRolePrefix: "prefix"},
+		},
+		{
+			config: GeneralConfig{
+				VerboseLevel: "high", ReportsDirAbsPath: "/tmp/reports", KubernetesRolePrefix: "prefix"},
+			expectedField: "VerboseLevel",
+		},
+		{
+			config:        GeneralConfig{VerboseLevel: "0", ReportsDirAbsPath: "reports", KubernetesRolePrefix: "prefix"},
+			expectedField: "ReportsDirAbsPath",
+		},
+		{
+			config:        GeneralConfig{VerboseLevel: "0", ReportsDirAbsPath: "/tmp/reports"},
+			expectedField: "KubernetesRolePrefix",
+		},
+		{
+			config: GeneralConfig{
+				VerboseLevel: "0", ReportsDirAbsPath: "/tmp/reports", KubernetesRolePrefix: "prefix",
+				NodeExecTransport: "ssh"},
+		},
+		{
+			config: GeneralConfig{
+				VerboseLevel: "0", ReportsDirAbsPath: "/tmp/reports", KubernetesRolePrefix: "prefix",
+				NodeExecTransport: "telnet"},
+			expectedField: "NodeExecTransport",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.config.Validate()
+
+		if testCase.expectedField == "" {
+			assert.Nil(t, err)
+
+			continue
+		}
+
+		var fieldErr *FieldError
+
+		assert.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, testCase.expectedField, fieldErr.Field)
+	}
+}
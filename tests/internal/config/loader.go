@@ -0,0 +1,344 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ConfigFileEnvVar is the environment variable containing a comma separated list of YAML files layered over the
+	// defaults of every config, in order. Each file contains a section for every config it overrides, keyed by the
+	// Section of the Loader for that config.
+	ConfigFileEnvVar = "ECO_CONFIG_FILE"
+	// ConfigFilePathEnvSuffix is the suffix of the environment variables some suites use to replace the path of their
+	// default file. Since these are read before loading, they are never reported as unknown keys.
+	ConfigFilePathEnvSuffix = "_CONFIG_FILE_PATH"
+
+	envSource = "environment"
+)
+
+// UnknownKeyError is returned when a YAML file or the environment contains a key that does not match any field of the
+// config being loaded.
+type UnknownKeyError struct {
+	// Key is the YAML key or environment variable name.
+	Key string
+	// Source is the path of the YAML file or environment.
+	Source string
+}
+
+// Error returns the error message for the unknown key.
+func (err *UnknownKeyError) Error() string {
+	return fmt.Sprintf("unknown key %s in %s", err.Key, err.Source)
+}
+
+// FieldError is returned when a value cannot be decoded into a field or when the field fails validation.
+type FieldError struct {
+	// Field is the name of the struct field.
+	Field string
+	// Key is the YAML key or environment variable name the value came from. It is empty for validation errors.
+	Key string
+	// Source is the path of the YAML file or environment the value came from. It is empty for validation errors.
+	Source string
+	Err    error
+}
+
+// Error returns the error message naming the field and, if known, where its value came from.
+func (err *FieldError) Error() string {
+	if err.Source == "" {
+		return fmt.Sprintf("invalid value for field %s: %v", err.Field, err.Err)
+	}
+
+	return fmt.Sprintf("invalid value for field %s from key %s in %s: %v", err.Field, err.Key, err.Source, err.Err)
+}
+
+// Unwrap returns the underlying error.
+func (err *FieldError) Unwrap() error {
+	return err.Err
+}
+
+// Loader loads a config struct from three layers, each overriding the last: the default YAML file, the section of each
+// file in ECO_CONFIG_FILE, and then environment variables. Each YAML key replaces the whole value of its field, so a
+// map or nested struct given in an override file does not keep any entries from the defaults.
+type Loader struct {
+	// Section is the top level key of the files in ECO_CONFIG_FILE containing the overrides for this config.
+	Section string
+	// DefaultFile is the path to the YAML file with the default values.
+	DefaultFile string
+	// EnvPrefix, when set, causes any environment variable starting with it that does not match a field to be
+	// reported as an UnknownKeyError.
+	EnvPrefix string
+	// EnvIgnorePrefixes lists prefixes of environment variables that start with EnvPrefix but are read by other
+	// configs or directly from the environment, so they are not reported as unknown keys.
+	EnvIgnorePrefixes []string
+	// WarnUnknownEnv logs the environment variables starting with EnvPrefix that do not match a field instead of
+	// returning an UnknownKeyError. It is meant for configs whose EnvPrefix is shared with other configs.
+	WarnUnknownEnv bool
+	// Partial allows the YAML files to contain keys that do not match a field. It is meant for configs split across
+	// multiple structs that share a single file, where another Loader has already checked for unknown keys.
+	Partial bool
+}
+
+// Load fills config, which must be a pointer to a struct, from each layer in order. It returns an UnknownKeyError for
+// keys that do not match a field and a FieldError for values that cannot be decoded.
+func (loader Loader) Load(config any) error {
+	configValue := reflect.ValueOf(config)
+	if configValue.Kind() != reflect.Pointer || configValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to a struct, got %T", config)
+	}
+
+	fields := collectFields(configValue.Elem().Type())
+
+	contents, err := os.ReadFile(loader.DefaultFile)
+	if err != nil {
+		return fmt.Errorf("failed to read default file %s: %w", loader.DefaultFile, err)
+	}
+
+	var defaults yaml.MapSlice
+
+	err = yaml.Unmarshal(contents, &defaults)
+	if err != nil {
+		return fmt.Errorf("failed to parse default file %s: %w", loader.DefaultFile, err)
+	}
+
+	err = loader.decodeYAML(config, fields, defaults, loader.DefaultFile)
+	if err != nil {
+		return err
+	}
+
+	err = loader.loadOverrides(config, fields)
+	if err != nil {
+		return err
+	}
+
+	return loader.loadEnv(config, fields)
+}
+
+// loadOverrides decodes the section for this config from every file in ECO_CONFIG_FILE.
+func (loader Loader) loadOverrides(config any, fields []field) error {
+	configFiles := os.Getenv(ConfigFileEnvVar)
+	if configFiles == "" {
+		return nil
+	}
+
+	for _, configFile := range strings.Split(configFiles, ",") {
+		configFile = strings.TrimSpace(configFile)
+		if configFile == "" {
+			continue
+		}
+
+		contents, err := os.ReadFile(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to read override file %s from %s: %w", configFile, ConfigFileEnvVar, err)
+		}
+
+		var sections yaml.MapSlice
+
+		err = yaml.Unmarshal(contents, &sections)
+		if err != nil {
+			return fmt.Errorf("failed to parse override file %s: %w", configFile, err)
+		}
+
+		for _, section := range sections {
+			if fmt.Sprint(section.Key) != loader.Section {
+				continue
+			}
+
+			values, ok := section.Value.(yaml.MapSlice)
+			if !ok {
+				return fmt.Errorf("section %s in override file %s must be a mapping", loader.Section, configFile)
+			}
+
+			log.Printf("Applying section %s from override file %s", loader.Section, configFile)
+
+			err = loader.decodeYAML(config, fields, values, configFile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// decodeYAML decodes each key in values into config separately so errors can be attributed to a single field.
+func (loader Loader) decodeYAML(config any, fields []field, values yaml.MapSlice, source string) error {
+	for _, item := range values {
+		key := fmt.Sprint(item.Key)
+
+		index := slices.IndexFunc(fields, func(field field) bool {
+			return field.yamlKey == key
+		})
+
+		if index < 0 {
+			if loader.Partial {
+				continue
+			}
+
+			return &UnknownKeyError{Key: key, Source: source}
+		}
+
+		contents, err := yaml.Marshal(yaml.MapSlice{item})
+		if err != nil {
+			return &FieldError{Field: fields[index].name, Key: key, Source: source, Err: err}
+		}
+
+		// The YAML decoder merges maps and structs into their existing value, so the field is cleared first to have
+		// the key replace it.
+		if fieldValue := reflect.ValueOf(config).Elem().FieldByName(fields[index].name); fieldValue.CanSet() {
+			fieldValue.SetZero()
+		}
+
+		err = yaml.Unmarshal(contents, config)
+		if err != nil {
+			return &FieldError{Field: fields[index].name, Key: key, Source: source, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// loadEnv checks for unknown environment variables with the EnvPrefix and then processes the environment into config.
+func (loader Loader) loadEnv(config any, fields []field) error {
+	if loader.EnvPrefix != "" {
+		environ := os.Environ()
+		slices.Sort(environ)
+
+		var unknown []string
+
+		for _, variable := range environ {
+			name, _, _ := strings.Cut(variable, "=")
+			if !strings.HasPrefix(name, loader.EnvPrefix) || loader.isIgnoredEnv(name) {
+				continue
+			}
+
+			known := slices.ContainsFunc(fields, func(field field) bool {
+				return field.envKey == name
+			})
+
+			if known {
+				continue
+			}
+
+			if !loader.WarnUnknownEnv {
+				return &UnknownKeyError{Key: name, Source: envSource}
+			}
+
+			unknown = append(unknown, name)
+		}
+
+		if len(unknown) > 0 {
+			log.Printf("Environment variables not used by config %s: %s", loader.Section, strings.Join(unknown, ", "))
+		}
+	}
+
+	err := envconfig.Process("", config)
+
+	var parseErr *envconfig.ParseError
+	if errors.As(err, &parseErr) {
+		return &FieldError{Field: parseErr.FieldName, Key: parseErr.KeyName, Source: envSource, Err: parseErr.Err}
+	}
+
+	return err
+}
+
+// isIgnoredEnv returns whether the environment variable name is never reported as unknown, either since it is read
+// before loading or since it matches one of the EnvIgnorePrefixes.
+func (loader Loader) isIgnoredEnv(name string) bool {
+	if name == ConfigFileEnvVar || strings.HasSuffix(name, ConfigFilePathEnvSuffix) {
+		return true
+	}
+
+	return slices.ContainsFunc(loader.EnvIgnorePrefixes, func(prefix string) bool {
+		return strings.HasPrefix(name, prefix)
+	})
+}
+
+// field is a single field of a config struct along with the keys used to set it.
+type field struct {
+	name    string
+	yamlKey string
+	envKey  string
+}
+
+// collectFields returns the fields of configType, including those of embedded and nested structs. Fields of embedded
+// structs are accepted as known keys even though the YAML decoder only sets them when the embedded struct is loaded
+// itself. Fields of nested structs are only accepted as environment variables, since their YAML keys are decoded as
+// part of the nested struct.
+func collectFields(configType reflect.Type) []field {
+	return collectFieldsOf(configType, map[reflect.Type]bool{})
+}
+
+// collectFieldsOf returns the fields of configType, skipping types in visited to avoid infinite recursion on
+// self-referencing types.
+func collectFieldsOf(configType reflect.Type, visited map[reflect.Type]bool) []field {
+	if visited[configType] {
+		return nil
+	}
+
+	visited[configType] = true
+	defer delete(visited, configType)
+
+	var fields []field
+
+	for i := range configType.NumField() {
+		structField := configType.Field(i)
+
+		if structField.Anonymous {
+			if embeddedType := structType(structField.Type); embeddedType != nil {
+				fields = append(fields, collectFieldsOf(embeddedType, visited)...)
+			}
+
+			continue
+		}
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		newField := field{name: structField.Name}
+
+		if yamlTag := structField.Tag.Get("yaml"); yamlTag != "-" {
+			newField.yamlKey, _, _ = strings.Cut(yamlTag, ",")
+			if newField.yamlKey == "" {
+				newField.yamlKey = strings.ToLower(structField.Name)
+			}
+		}
+
+		if structField.Tag.Get("ignored") != "true" {
+			newField.envKey = strings.ToUpper(structField.Tag.Get("envconfig"))
+		}
+
+		fields = append(fields, newField)
+
+		if nestedType := structType(structField.Type); nestedType != nil && structField.Tag.Get("ignored") != "true" {
+			for _, nestedField := range collectFieldsOf(nestedType, visited) {
+				nestedField.name = structField.Name + "." + nestedField.name
+				nestedField.yamlKey = ""
+				fields = append(fields, nestedField)
+			}
+		}
+	}
+
+	return fields
+}
+
+// structType returns fieldType, or the type it points to, if it is a struct. Otherwise, it returns nil.
+func structType(fieldType reflect.Type) reflect.Type {
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return fieldType
+}
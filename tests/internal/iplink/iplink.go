@@ -0,0 +1,203 @@
+package iplink
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Link is an entry of the JSON output of ip -s -d link and ip addr. Fields that are missing from the output, such as
+// stats without -s or linkinfo without -d, are left to their zero value.
+type Link struct {
+	Ifindex     int           `json:"ifindex,omitempty"`
+	Link        string        `json:"link,omitempty"`
+	Ifname      string        `json:"ifname,omitempty"`
+	Flags       []string      `json:"flags,omitempty"`
+	Mtu         int           `json:"mtu,omitempty"`
+	MinMtu      int           `json:"min_mtu,omitempty"`
+	MaxMtu      int           `json:"max_mtu,omitempty"`
+	Qdisc       string        `json:"qdisc,omitempty"`
+	Master      string        `json:"master,omitempty"`
+	Operstate   string        `json:"operstate,omitempty"`
+	Linkmode    string        `json:"linkmode,omitempty"`
+	Group       string        `json:"group,omitempty"`
+	Txqlen      int           `json:"txqlen,omitempty"`
+	LinkType    string        `json:"link_type,omitempty"`
+	Address     string        `json:"address,omitempty"`
+	Broadcast   string        `json:"broadcast,omitempty"`
+	PermAddress string        `json:"permaddr,omitempty"`
+	AltNames    []string      `json:"altnames,omitempty"`
+	ParentBus   string        `json:"parentbus,omitempty"`
+	ParentDev   string        `json:"parentdev,omitempty"`
+	LinkInfo    *LinkInfo     `json:"linkinfo,omitempty"`
+	VFInfoList  []VFInfo      `json:"vfinfo_list,omitempty"`
+	AddrInfo    []AddressInfo `json:"addr_info,omitempty"`
+	Stats64     Stats64       `json:"stats64,omitempty"`
+}
+
+// LinkInfo holds the details of a link printed with -d. InfoData is set for links of InfoKind vlan or bond, and
+// InfoSlaveData for links enslaved to a bond.
+type LinkInfo struct {
+	InfoKind      string         `json:"info_kind,omitempty"`
+	InfoData      *InfoData      `json:"info_data,omitempty"`
+	InfoSlaveKind string         `json:"info_slave_kind,omitempty"`
+	InfoSlaveData *InfoSlaveData `json:"info_slave_data,omitempty"`
+}
+
+// InfoData holds the vlan and bond attributes of a link.
+type InfoData struct {
+	// vlan attributes.
+	Protocol string   `json:"protocol,omitempty"`
+	ID       int      `json:"id,omitempty"`
+	Flags    []string `json:"flags,omitempty"`
+	// bond attributes.
+	Mode           string `json:"mode,omitempty"`
+	ActiveSlave    string `json:"active_slave,omitempty"`
+	Miimon         int    `json:"miimon,omitempty"`
+	Updelay        int    `json:"updelay,omitempty"`
+	Downdelay      int    `json:"downdelay,omitempty"`
+	FailOverMac    string `json:"fail_over_mac,omitempty"`
+	XmitHashPolicy string `json:"xmit_hash_policy,omitempty"`
+	LacpRate       string `json:"lacp_rate,omitempty"`
+	AdSelect       string `json:"ad_select,omitempty"`
+}
+
+// InfoSlaveData holds the attributes of a link enslaved to a bond.
+type InfoSlaveData struct {
+	State            string `json:"state,omitempty"`
+	MiiStatus        string `json:"mii_status,omitempty"`
+	LinkFailureCount int    `json:"link_failure_count,omitempty"`
+	PermHwaddr       string `json:"perm_hwaddr,omitempty"`
+	QueueID          int    `json:"queue_id,omitempty"`
+}
+
+// VFInfo is the configuration of a VF as reported on its PF.
+type VFInfo struct {
+	VF       int    `json:"vf"`
+	Address  string `json:"address,omitempty"`
+	VlanList []struct {
+		Vlan     int    `json:"vlan,omitempty"`
+		Qos      int    `json:"qos,omitempty"`
+		Protocol string `json:"protocol,omitempty"`
+	} `json:"vlan_list,omitempty"`
+	Rate struct {
+		MaxTx int `json:"max_tx"`
+		MinTx int `json:"min_tx"`
+	} `json:"rate,omitempty"`
+	SpoofCheck bool   `json:"spoofchk,omitempty"`
+	LinkState  string `json:"link_state,omitempty"`
+	Trust      bool   `json:"trust,omitempty"`
+}
+
+// AddressInfo is an address of a link as reported by ip addr.
+type AddressInfo struct {
+	Family            string `json:"family,omitempty"`
+	Local             string `json:"local,omitempty"`
+	Prefixlen         int    `json:"prefixlen,omitempty"`
+	Broadcast         string `json:"broadcast,omitempty"`
+	Scope             string `json:"scope,omitempty"`
+	Label             string `json:"label,omitempty"`
+	Dynamic           bool   `json:"dynamic,omitempty"`
+	Tentative         bool   `json:"tentative,omitempty"`
+	DadFailed         bool   `json:"dadfailed,omitempty"`
+	ValidLifeTime     int64  `json:"valid_life_time,omitempty"`
+	PreferredLifeTime int64  `json:"preferred_life_time,omitempty"`
+}
+
+// Parse parses the JSON output of ip link or ip addr listing one or more links.
+func Parse(jsonOutput []byte) ([]Link, error) {
+	if len(strings.TrimSpace(string(jsonOutput))) == 0 {
+		return nil, fmt.Errorf("empty json output")
+	}
+
+	var links []Link
+
+	err := json.Unmarshal(jsonOutput, &links)
+	if err != nil {
+		return nil, fmt.Errorf("json unmarshalling failed: %w", err)
+	}
+
+	if len(links) < 1 {
+		return nil, fmt.Errorf("no links to process found")
+	}
+
+	return links, nil
+}
+
+// ParseSingle parses the JSON output of ip link or ip addr for a single device and fails if it lists more links.
+func ParseSingle(jsonOutput []byte) (*Link, error) {
+	links, err := Parse(jsonOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(links) > 1 {
+		return nil, fmt.Errorf("failed to process more than 1 link")
+	}
+
+	return &links[0], nil
+}
+
+// Find returns the link named name, matching alternative names too.
+func Find(links []Link, name string) (*Link, error) {
+	for index := range links {
+		if links[index].Ifname == name || slices.Contains(links[index].AltNames, name) {
+			return &links[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("link %s not found", name)
+}
+
+// GetRxByte returns number of unicast bytes received on link.
+func (l *Link) GetRxByte() int {
+	return l.Stats64.Rx.Bytes
+}
+
+// HasFlag returns true when the link has flag set, e.g. UP or LOWER_UP.
+func (l *Link) HasFlag(flag string) bool {
+	return slices.Contains(l.Flags, flag)
+}
+
+// IsUp returns true when the link is administratively up and has a carrier.
+func (l *Link) IsUp() bool {
+	return l.HasFlag("UP") && l.HasFlag("LOWER_UP")
+}
+
+// Kind returns the kind of the link, such as vlan or bond, or an empty string for physical links or when the output
+// was collected without -d.
+func (l *Link) Kind() string {
+	if l.LinkInfo == nil {
+		return ""
+	}
+
+	return l.LinkInfo.InfoKind
+}
+
+// VF returns the configuration of the VF with the given ID from the link of its PF.
+func (l *Link) VF(vfID int) (*VFInfo, error) {
+	for index := range l.VFInfoList {
+		if l.VFInfoList[index].VF == vfID {
+			return &l.VFInfoList[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("vf %d not found on link %s", vfID, l.Ifname)
+}
+
+// Addresses returns the addresses of the link in CIDR notation. If family is set to inet or inet6, only addresses of
+// that family are returned.
+func (l *Link) Addresses(family string) []string {
+	var addresses []string
+
+	for _, addrInfo := range l.AddrInfo {
+		if family != "" && addrInfo.Family != family {
+			continue
+		}
+
+		addresses = append(addresses, fmt.Sprintf("%s/%d", addrInfo.Local, addrInfo.Prefixlen))
+	}
+
+	return addresses
+}
@@ -0,0 +1,109 @@
+package iplink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testLinkOutput = `[{"ifindex":4,"ifname":"ens1f0","flags":["BROADCAST","MULTICAST","SLAVE","UP","LOWER_UP"],` +
+	`"mtu":9000,"qdisc":"mq","master":"bond0","operstate":"UP","linkmode":"DEFAULT","group":"default",` +
+	`"txqlen":1000,"link_type":"ether","address":"b4:96:91:00:00:01","broadcast":"ff:ff:ff:ff:ff:ff",` +
+	`"min_mtu":68,"max_mtu":9702,"linkinfo":{"info_slave_kind":"bond","info_slave_data":{"state":"ACTIVE",` +
+	`"mii_status":"UP","link_failure_count":1,"perm_hwaddr":"b4:96:91:00:00:01","queue_id":0}},` +
+	`"altnames":["enp59s0f0"],"parentbus":"pci","parentdev":"0000:3b:00.0",` +
+	`"vfinfo_list":[{"vf":0,"address":"02:00:00:00:00:01","vlan_list":[{"vlan":100,"qos":2}],` +
+	`"rate":{"max_tx":0,"min_tx":0},"spoofchk":true,"link_state":"auto","trust":false},` +
+	`{"vf":1,"address":"00:00:00:00:00:00","rate":{"max_tx":0,"min_tx":0},"spoofchk":false,` +
+	`"link_state":"disable","trust":true}],` +
+	`"stats64":{"rx":{"bytes":1000,"packets":10,"errors":0,"dropped":1,"over_errors":0,"multicast":2},` +
+	`"tx":{"bytes":2000,"packets":20,"errors":0,"dropped":0,"carrier_errors":0,"collisions":0}}},` +
+	`{"ifindex":10,"ifname":"bond0.100","flags":["BROADCAST","MULTICAST","UP"],"mtu":9000,` +
+	`"operstate":"LOWERLAYERDOWN","link":"bond0","linkinfo":{"info_kind":"vlan",` +
+	`"info_data":{"protocol":"802.1Q","id":100,"flags":["REORDER_HDR"]}},` +
+	`"stats64":{"rx":{"bytes":1500,"packets":15,"errors":0,"dropped":1,"over_errors":0,"multicast":2},` +
+	`"tx":{"bytes":2000,"packets":20,"errors":0,"dropped":0,"carrier_errors":0,"collisions":0}}}]`
+
+const testAddrOutput = `[{"ifindex":5,"ifname":"net1","flags":["BROADCAST","MULTICAST","UP","LOWER_UP"],"mtu":1500,` +
+	`"operstate":"UP","address":"02:00:00:00:00:02","addr_info":[{"family":"inet","local":"192.168.0.1",` +
+	`"prefixlen":24,"broadcast":"192.168.0.255","scope":"global","label":"net1","valid_life_time":4294967295,` +
+	`"preferred_life_time":4294967295},{"family":"inet6","local":"2001::1","prefixlen":64,"scope":"global",` +
+	`"tentative":true}]}]`
+
+func TestParse(t *testing.T) {
+	links, err := Parse([]byte(testLinkOutput))
+	assert.NoError(t, err)
+	assert.Len(t, links, 2)
+
+	pfLink := links[0]
+	assert.Equal(t, "ens1f0", pfLink.Ifname)
+	assert.Equal(t, 9000, pfLink.Mtu)
+	assert.Equal(t, "bond0", pfLink.Master)
+	assert.Equal(t, []string{"enp59s0f0"}, pfLink.AltNames)
+	assert.Equal(t, "0000:3b:00.0", pfLink.ParentDev)
+	assert.True(t, pfLink.IsUp())
+	assert.Equal(t, 1000, pfLink.GetRxByte())
+	assert.Empty(t, pfLink.Kind())
+	assert.Equal(t, "ACTIVE", pfLink.LinkInfo.InfoSlaveData.State)
+	assert.Equal(t, "UP", pfLink.LinkInfo.InfoSlaveData.MiiStatus)
+
+	vfInfo, err := pfLink.VF(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "disable", vfInfo.LinkState)
+	assert.True(t, vfInfo.Trust)
+
+	vfInfo, err = pfLink.VF(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, vfInfo.VlanList[0].Vlan)
+
+	_, err = pfLink.VF(2)
+	assert.Error(t, err)
+
+	vlanLink := links[1]
+	assert.False(t, vlanLink.IsUp())
+	assert.Equal(t, "vlan", vlanLink.Kind())
+	assert.Equal(t, 100, vlanLink.LinkInfo.InfoData.ID)
+
+	for _, output := range []string{"", " \n", "{", "[]"} {
+		_, err = Parse([]byte(output))
+		assert.Error(t, err, output)
+	}
+}
+
+func TestParseSingle(t *testing.T) {
+	_, err := ParseSingle([]byte(testLinkOutput))
+	assert.Error(t, err)
+
+	addrLink, err := ParseSingle([]byte(testAddrOutput))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.0.1/24", "2001::1/64"}, addrLink.Addresses(""))
+	assert.Equal(t, []string{"2001::1/64"}, addrLink.Addresses("inet6"))
+	assert.True(t, addrLink.AddrInfo[1].Tentative)
+}
+
+func TestFind(t *testing.T) {
+	links, err := Parse([]byte(testLinkOutput))
+	assert.NoError(t, err)
+
+	link, err := Find(links, "enp59s0f0")
+	assert.NoError(t, err)
+	assert.Equal(t, "ens1f0", link.Ifname)
+
+	_, err = Find(links, "ens2f0")
+	assert.Error(t, err)
+}
+
+func TestDelta(t *testing.T) {
+	before := []Link{
+		{Ifname: "net1", Stats64: Stats64{Rx: RxStats{Bytes: 100, Packets: 1}, Tx: TxStats{Bytes: 50}}},
+		{Ifname: "net2", Stats64: Stats64{Rx: RxStats{Bytes: 100}}},
+	}
+	after := []Link{
+		{Ifname: "net1", Stats64: Stats64{Rx: RxStats{Bytes: 400, Packets: 4}, Tx: TxStats{Bytes: 50}}},
+		{Ifname: "net3", Stats64: Stats64{Rx: RxStats{Bytes: 100}}},
+	}
+
+	delta := Delta(before, after)
+	assert.Len(t, delta, 1)
+	assert.Equal(t, Stats64{Rx: RxStats{Bytes: 300, Packets: 3}}, delta["net1"])
+}
@@ -0,0 +1,94 @@
+package iplink
+
+// RxStats are the receive counters of a link.
+type RxStats struct {
+	Bytes        int `json:"bytes"`
+	Packets      int `json:"packets"`
+	Errors       int `json:"errors"`
+	Dropped      int `json:"dropped"`
+	OverErrors   int `json:"over_errors"`
+	Multicast    int `json:"multicast"`
+	LengthErrors int `json:"length_errors,omitempty"`
+	CrcErrors    int `json:"crc_errors,omitempty"`
+	FrameErrors  int `json:"frame_errors,omitempty"`
+	FifoErrors   int `json:"fifo_errors,omitempty"`
+	MissedErrors int `json:"missed_errors,omitempty"`
+	Nohandler    int `json:"nohandler,omitempty"`
+}
+
+// TxStats are the transmit counters of a link.
+type TxStats struct {
+	Bytes           int `json:"bytes"`
+	Packets         int `json:"packets"`
+	Errors          int `json:"errors"`
+	Dropped         int `json:"dropped"`
+	CarrierErrors   int `json:"carrier_errors"`
+	Collisions      int `json:"collisions"`
+	AbortedErrors   int `json:"aborted_errors,omitempty"`
+	FifoErrors      int `json:"fifo_errors,omitempty"`
+	WindowErrors    int `json:"window_errors,omitempty"`
+	HeartbeatErrors int `json:"heartbeat_errors,omitempty"`
+	CarrierChanges  int `json:"carrier_changes,omitempty"`
+}
+
+// Stats64 are the 64-bit counters of a link printed with -s.
+type Stats64 struct {
+	Rx RxStats `json:"rx"`
+	Tx TxStats `json:"tx"`
+}
+
+// Sub returns the counters accumulated since the previous snapshot.
+func (stats Stats64) Sub(previous Stats64) Stats64 {
+	return Stats64{
+		Rx: RxStats{
+			Bytes:        stats.Rx.Bytes - previous.Rx.Bytes,
+			Packets:      stats.Rx.Packets - previous.Rx.Packets,
+			Errors:       stats.Rx.Errors - previous.Rx.Errors,
+			Dropped:      stats.Rx.Dropped - previous.Rx.Dropped,
+			OverErrors:   stats.Rx.OverErrors - previous.Rx.OverErrors,
+			Multicast:    stats.Rx.Multicast - previous.Rx.Multicast,
+			LengthErrors: stats.Rx.LengthErrors - previous.Rx.LengthErrors,
+			CrcErrors:    stats.Rx.CrcErrors - previous.Rx.CrcErrors,
+			FrameErrors:  stats.Rx.FrameErrors - previous.Rx.FrameErrors,
+			FifoErrors:   stats.Rx.FifoErrors - previous.Rx.FifoErrors,
+			MissedErrors: stats.Rx.MissedErrors - previous.Rx.MissedErrors,
+			Nohandler:    stats.Rx.Nohandler - previous.Rx.Nohandler,
+		},
+		Tx: TxStats{
+			Bytes:           stats.Tx.Bytes - previous.Tx.Bytes,
+			Packets:         stats.Tx.Packets - previous.Tx.Packets,
+			Errors:          stats.Tx.Errors - previous.Tx.Errors,
+			Dropped:         stats.Tx.Dropped - previous.Tx.Dropped,
+			CarrierErrors:   stats.Tx.CarrierErrors - previous.Tx.CarrierErrors,
+			Collisions:      stats.Tx.Collisions - previous.Tx.Collisions,
+			AbortedErrors:   stats.Tx.AbortedErrors - previous.Tx.AbortedErrors,
+			FifoErrors:      stats.Tx.FifoErrors - previous.Tx.FifoErrors,
+			WindowErrors:    stats.Tx.WindowErrors - previous.Tx.WindowErrors,
+			HeartbeatErrors: stats.Tx.HeartbeatErrors - previous.Tx.HeartbeatErrors,
+			CarrierChanges:  stats.Tx.CarrierChanges - previous.Tx.CarrierChanges,
+		},
+	}
+}
+
+// Delta returns, for each link present in both snapshots, the counters accumulated between before and after, keyed
+// by link name. Links that appeared or disappeared between the snapshots are skipped.
+func Delta(before, after []Link) map[string]Stats64 {
+	previousStats := make(map[string]Stats64, len(before))
+
+	for _, link := range before {
+		previousStats[link.Ifname] = link.Stats64
+	}
+
+	delta := make(map[string]Stats64, len(after))
+
+	for _, link := range after {
+		previous, found := previousStats[link.Ifname]
+		if !found {
+			continue
+		}
+
+		delta[link.Ifname] = link.Stats64.Sub(previous)
+	}
+
+	return delta
+}
@@ -0,0 +1,304 @@
+package loganalysis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	initialLineBufferSize = 64 * 1024
+	maxLineSize           = 1024 * 1024
+)
+
+// StateTransition describes a state change between adjacent entries of a process.
+type StateTransition struct {
+	// From is the state of the previous entry.
+	From string `json:"from"`
+	// To is the state of the current entry.
+	To string `json:"to"`
+	// Raw is the full raw log line of the current entry.
+	Raw string `json:"raw"`
+}
+
+// ProcessResult groups the per-process output of an analysis.
+type ProcessResult struct {
+	// Stats is the descriptive statistics for the values of the process.
+	Stats Statistics `json:"stats"`
+	// StateTransitions is the state transitions between adjacent entries of the process.
+	StateTransitions []StateTransition `json:"stateTransitions,omitempty"`
+	// CandidateLines is the number of lines that belonged to the process, including dropped lines.
+	CandidateLines int `json:"candidateLines"`
+	// DroppedLines is the number of lines that belonged to the process but could not be parsed.
+	DroppedLines int `json:"droppedLines"`
+
+	prevState string
+}
+
+// AnalysisResult is the structured output of an analysis. It can be serialized to JSON.
+type AnalysisResult struct {
+	// Passed is true if no rules were violated.
+	Passed bool `json:"passed"`
+	// Violations is the list of violated rules, in the order the rules were added.
+	Violations []Violation `json:"violations"`
+	// Processes maps each process name to its result.
+	Processes map[string]*ProcessResult `json:"processes"`
+	// Counters maps each counter name to the number of lines it matched.
+	Counters map[string]int `json:"counters"`
+	// LineCount is the total number of lines read.
+	LineCount int `json:"lineCount"`
+	// ParseWarnings is a list of warnings for lines that belonged to a process but could not be parsed.
+	ParseWarnings []string `json:"parseWarnings,omitempty"`
+
+	processOrder []string
+	counterOrder []string
+	percentiles  []float64
+}
+
+// Process returns the result for the named process. It returns an empty result if the process is unknown.
+func (result *AnalysisResult) Process(name string) *ProcessResult {
+	if processResult, ok := result.Processes[name]; ok {
+		return processResult
+	}
+
+	return &ProcessResult{}
+}
+
+// DiagnosticMessage builds a concise multi-line summary for assertions and report entries.
+func (result *AnalysisResult) DiagnosticMessage() string {
+	var builder strings.Builder
+
+	if len(result.Violations) == 0 {
+		builder.WriteString("No anomalies detected.")
+	} else {
+		builder.WriteString("Anomalies detected:")
+
+		for _, violation := range result.Violations {
+			builder.WriteString("\n- ")
+			builder.WriteString(violation.Message)
+		}
+	}
+
+	if len(result.ParseWarnings) > 0 {
+		builder.WriteString("\nParse warnings:")
+
+		for _, warning := range result.ParseWarnings {
+			builder.WriteString("\n- ")
+			builder.WriteString(warning)
+		}
+	}
+
+	for _, process := range result.processOrder {
+		stats := result.Processes[process].Stats
+
+		fmt.Fprintf(&builder, "\n%s: samples=%d min=%g max=%g mean=%.3f",
+			process, stats.Count, stats.Min, stats.Max, stats.Mean)
+
+		for _, percentile := range result.percentiles {
+			name := PercentileName(percentile)
+			if value, ok := stats.Percentiles[name]; ok {
+				fmt.Fprintf(&builder, " %s=%g", name, value)
+			}
+		}
+	}
+
+	for _, counter := range result.counterOrder {
+		fmt.Fprintf(&builder, "\n%s=%d", counter, result.Counters[counter])
+	}
+
+	return builder.String()
+}
+
+// counter counts the lines matched by a function.
+type counter struct {
+	name    string
+	matches func(line string) bool
+}
+
+// Analyzer performs a single-pass streaming analysis of a log. Lines are parsed by the registered parsers, counted by
+// the registered counters, and checked by the registered rules. Memory use is bounded by the number of parsed values
+// rather than the size of the log.
+type Analyzer struct {
+	parsers     []Parser
+	counters    []counter
+	rules       []Rule
+	percentiles []float64
+	absolute    bool
+}
+
+// NewAnalyzer creates an Analyzer with no parsers, counters, or rules that computes the DefaultPercentiles.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{percentiles: DefaultPercentiles}
+}
+
+// WithParser adds a parser for the lines of a single process. Each line is given to every parser.
+func (analyzer *Analyzer) WithParser(parser Parser) *Analyzer {
+	analyzer.parsers = append(analyzer.parsers, parser)
+
+	return analyzer
+}
+
+// WithCounter adds a counter with the provided name that counts the lines for which matches returns true.
+func (analyzer *Analyzer) WithCounter(name string, matches func(line string) bool) *Analyzer {
+	analyzer.counters = append(analyzer.counters, counter{name: name, matches: matches})
+
+	return analyzer
+}
+
+// WithRule adds a rule that is checked after the log has been read.
+func (analyzer *Analyzer) WithRule(rule Rule) *Analyzer {
+	analyzer.rules = append(analyzer.rules, rule)
+
+	return analyzer
+}
+
+// WithPercentiles sets the percentiles, between 0 and 100, computed for each process.
+func (analyzer *Analyzer) WithPercentiles(percentiles ...float64) *Analyzer {
+	analyzer.percentiles = percentiles
+
+	return analyzer
+}
+
+// WithAbsoluteValues makes the statistics of each process use the absolute values of entries, such as for offsets
+// where the sign does not matter. Rules still observe the original values.
+func (analyzer *Analyzer) WithAbsoluteValues() *Analyzer {
+	analyzer.absolute = true
+
+	return analyzer
+}
+
+// AnalyzeFile analyzes the log file at filePath.
+func (analyzer *Analyzer) AnalyzeFile(filePath string) (*AnalysisResult, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %s: %w", filePath, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	result, err := analyzer.Analyze(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze log file %s: %w", filePath, err)
+	}
+
+	return result, nil
+}
+
+// Analyze reads the log from reader line by line and returns the analysis result.
+func (analyzer *Analyzer) Analyze(reader io.Reader) (*AnalysisResult, error) {
+	result := &AnalysisResult{
+		Violations:  []Violation{},
+		Processes:   make(map[string]*ProcessResult, len(analyzer.parsers)),
+		Counters:    make(map[string]int, len(analyzer.counters)),
+		percentiles: analyzer.percentiles,
+	}
+
+	for _, parser := range analyzer.parsers {
+		result.Processes[parser.Process()] = &ProcessResult{}
+		result.processOrder = append(result.processOrder, parser.Process())
+	}
+
+	for _, counter := range analyzer.counters {
+		result.Counters[counter.name] = 0
+		result.counterOrder = append(result.counterOrder, counter.name)
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, initialLineBufferSize), maxLineSize)
+
+	for scanner.Scan() {
+		analyzer.processLine(result, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading log: %w", err)
+	}
+
+	analyzer.finalize(result)
+
+	return result, nil
+}
+
+// processLine parses, counts, and observes a single log line.
+func (analyzer *Analyzer) processLine(result *AnalysisResult, line string) {
+	result.LineCount++
+
+	for _, counter := range analyzer.counters {
+		if counter.matches(line) {
+			result.Counters[counter.name]++
+		}
+	}
+
+	for _, parser := range analyzer.parsers {
+		entry, ok, err := parser.Parse(line)
+		if !ok && err == nil {
+			continue
+		}
+
+		processResult := result.Processes[parser.Process()]
+		processResult.CandidateLines++
+
+		if err != nil {
+			klog.V(100).Infof("%s: dropping line %q: %v", parser.Process(), line, err)
+
+			processResult.DroppedLines++
+
+			continue
+		}
+
+		analyzer.observe(processResult, entry)
+	}
+}
+
+// observe adds entry to the result of its process and passes it to every rule.
+func (analyzer *Analyzer) observe(processResult *ProcessResult, entry Entry) {
+	value := entry.Value
+	if analyzer.absolute {
+		value = math.Abs(value)
+	}
+
+	processResult.Stats.observe(value)
+
+	if processResult.prevState != "" && processResult.prevState != entry.State {
+		processResult.StateTransitions = append(processResult.StateTransitions, StateTransition{
+			From: processResult.prevState,
+			To:   entry.State,
+			Raw:  entry.Raw,
+		})
+	}
+
+	processResult.prevState = entry.State
+
+	for _, rule := range analyzer.rules {
+		rule.Observe(entry)
+	}
+}
+
+// finalize computes the statistics and parse warnings, then evaluates every rule to determine the pass/fail decision.
+func (analyzer *Analyzer) finalize(result *AnalysisResult) {
+	for _, process := range result.processOrder {
+		processResult := result.Processes[process]
+		processResult.Stats.finalize(analyzer.percentiles)
+
+		if processResult.DroppedLines > 0 {
+			result.ParseWarnings = append(result.ParseWarnings, fmt.Sprintf(
+				"%s dropped %d/%d candidate lines during parsing",
+				process, processResult.DroppedLines, processResult.CandidateLines))
+		}
+	}
+
+	for _, rule := range analyzer.rules {
+		if violation := rule.Evaluate(result); violation != nil {
+			result.Violations = append(result.Violations, *violation)
+		}
+	}
+
+	result.Passed = len(result.Violations) == 0
+}
@@ -0,0 +1,124 @@
+package loganalysis
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPattern = regexp.MustCompile(`^daemon\[.*?\boffset\s+(?P<value>\S+)\s+(?P<state>s\d+)`)
+
+func TestAnalyzerAnalyze(t *testing.T) {
+	testCases := []struct {
+		name               string
+		lines              []string
+		rules              []Rule
+		expectedViolations []string
+	}{
+		{
+			name:  "no rules",
+			lines: []string{"daemon[1]: offset 5 s2"},
+		},
+		{
+			name:               "min samples",
+			lines:              []string{"other line"},
+			rules:              []Rule{&MinSamples{Process: "daemon", Min: 1}},
+			expectedViolations: []string{"min-samples"},
+		},
+		{
+			name:               "value threshold only in state",
+			lines:              []string{"daemon[1]: offset 500 s1", "daemon[2]: offset -150 s2", "daemon[3]: offset 50 s2"},
+			rules:              []Rule{&ValueThreshold{Process: "daemon", State: "s2", Threshold: 100}},
+			expectedViolations: []string{"value-threshold"},
+		},
+		{
+			name:  "percentile threshold tolerates outliers",
+			lines: append(repeatLine("daemon[1]: offset 10 s2", 99), "daemon[2]: offset 1000 s2"),
+			rules: []Rule{&PercentileThreshold{Process: "daemon", Percentile: 99, Threshold: 100}},
+		},
+		{
+			name:               "state transitions",
+			lines:              []string{"daemon[1]: offset 5 s1", "daemon[2]: offset 5 s2"},
+			rules:              []Rule{&MaxStateTransitions{Process: "daemon"}},
+			expectedViolations: []string{"max-state-transitions"},
+		},
+		{
+			name:               "max count",
+			lines:              []string{"port FAULTY", "faulty again"},
+			rules:              []Rule{&MaxCount{Counter: "faulty", Description: "faulty lines", Max: 1}},
+			expectedViolations: []string{"max-count"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			analyzer := NewAnalyzer().
+				WithParser(NewRegexParser("daemon", testPattern)).
+				WithCounter("faulty", ContainsFold("faulty"))
+
+			for _, rule := range testCase.rules {
+				analyzer.WithRule(rule)
+			}
+
+			result, err := analyzer.Analyze(strings.NewReader(strings.Join(testCase.lines, "\n")))
+			assert.Nil(t, err)
+
+			var violations []string
+			for _, violation := range result.Violations {
+				violations = append(violations, violation.Rule)
+			}
+
+			assert.Equal(t, testCase.expectedViolations, violations)
+			assert.Equal(t, len(testCase.expectedViolations) == 0, result.Passed)
+			assert.Equal(t, len(testCase.lines), result.LineCount)
+		})
+	}
+}
+
+func TestAnalyzerStatistics(t *testing.T) {
+	lines := []string{
+		"daemon[1]: offset -4 s1",
+		"daemon[2]: offset 2 s2",
+		"daemon[3]: offset not-a-number s2",
+		"daemon[4]: offset 1 s2",
+		"daemon[5]: offset 3 s2",
+	}
+
+	result, err := NewAnalyzer().
+		WithAbsoluteValues().
+		WithPercentiles(50, 99.9).
+		WithParser(NewRegexParser("daemon", testPattern)).
+		Analyze(strings.NewReader(strings.Join(lines, "\n")))
+	assert.Nil(t, err)
+
+	processResult := result.Process("daemon")
+	assert.Equal(t, 4, processResult.Stats.Count)
+	assert.Equal(t, 1.0, processResult.Stats.Min)
+	assert.Equal(t, 4.0, processResult.Stats.Max)
+	assert.Equal(t, 2.5, processResult.Stats.Mean)
+	assert.Equal(t, map[string]float64{"p50": 2, "p99.9": 4}, processResult.Stats.Percentiles)
+	assert.Equal(t, []StateTransition{{From: "s1", To: "s2", Raw: lines[1]}}, processResult.StateTransitions)
+	assert.Equal(t, 5, processResult.CandidateLines)
+	assert.Equal(t, 1, processResult.DroppedLines)
+	assert.Len(t, result.ParseWarnings, 1)
+
+	contents, err := json.Marshal(result)
+	assert.Nil(t, err)
+
+	var decoded AnalysisResult
+
+	assert.Nil(t, json.Unmarshal(contents, &decoded))
+	assert.Equal(t, processResult.Stats.Percentiles, decoded.Process("daemon").Stats.Percentiles)
+}
+
+func repeatLine(line string, count int) []string {
+	lines := make([]string, count)
+	for index := range lines {
+		lines[index] = line
+	}
+
+	return lines
+}
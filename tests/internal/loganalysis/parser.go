@@ -0,0 +1,102 @@
+package loganalysis
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ValueGroup is the name of the regular expression group containing the numeric value of an entry.
+	ValueGroup = "value"
+	// StateGroup is the name of the optional regular expression group containing the state of an entry.
+	StateGroup = "state"
+)
+
+// Entry is a single log line parsed by a Parser.
+type Entry struct {
+	// Process is the name of the process that logged the line.
+	Process string `json:"process"`
+	// Raw is the full raw log line that was matched.
+	Raw string `json:"raw"`
+	// Value is the numeric value parsed from the line, such as a clock offset.
+	Value float64 `json:"value"`
+	// State is the state parsed from the line, such as a servo state. It is empty if the parser has no state.
+	State string `json:"state,omitempty"`
+}
+
+// Parser parses the log lines for a single process.
+type Parser interface {
+	// Process returns the name of the process this parser matches lines for. It must be unique within an analyzer.
+	Process() string
+	// Parse returns the entry for line and true if the line belongs to this process. If the line belongs to this
+	// process but cannot be parsed, it returns false and an error so the line is counted as dropped.
+	Parse(line string) (Entry, bool, error)
+}
+
+// RegexParser is a Parser that matches lines using a regular expression. The expression must contain a group named
+// value and may contain a group named state.
+type RegexParser struct {
+	process    string
+	pattern    *regexp.Regexp
+	valueIndex int
+	stateIndex int
+}
+
+// NewRegexParser creates a RegexParser for process using pattern. It panics if pattern has no value group, since the
+// patterns are expected to be constants.
+func NewRegexParser(process string, pattern *regexp.Regexp) *RegexParser {
+	valueIndex := pattern.SubexpIndex(ValueGroup)
+	if valueIndex < 0 {
+		panic(fmt.Sprintf("pattern for process %s has no %s group: %s", process, ValueGroup, pattern))
+	}
+
+	return &RegexParser{
+		process:    process,
+		pattern:    pattern,
+		valueIndex: valueIndex,
+		stateIndex: pattern.SubexpIndex(StateGroup),
+	}
+}
+
+// Process returns the name of the process this parser matches lines for.
+func (parser *RegexParser) Process() string {
+	return parser.process
+}
+
+// Parse returns the entry for line if it matches the pattern of this parser.
+func (parser *RegexParser) Parse(line string) (Entry, bool, error) {
+	match := parser.pattern.FindStringSubmatch(line)
+	if match == nil {
+		return Entry{}, false, nil
+	}
+
+	value, err := strconv.ParseFloat(match[parser.valueIndex], 64)
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("failed to parse %s value %q: %w", parser.process, match[parser.valueIndex], err)
+	}
+
+	entry := Entry{Process: parser.process, Raw: line, Value: value}
+	if parser.stateIndex >= 0 {
+		entry.State = match[parser.stateIndex]
+	}
+
+	return entry, true, nil
+}
+
+// Contains returns a line matcher for counters that matches lines containing substring.
+func Contains(substring string) func(line string) bool {
+	return func(line string) bool {
+		return strings.Contains(line, substring)
+	}
+}
+
+// ContainsFold returns a line matcher for counters that matches lines containing substring, ignoring case.
+func ContainsFold(substring string) func(line string) bool {
+	substring = strings.ToLower(substring)
+
+	return func(line string) bool {
+		return strings.Contains(strings.ToLower(line), substring)
+	}
+}
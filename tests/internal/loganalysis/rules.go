@@ -0,0 +1,173 @@
+package loganalysis
+
+import (
+	"fmt"
+	"math"
+)
+
+// Violation is a single failed rule in an AnalysisResult.
+type Violation struct {
+	// Rule is the name of the rule that failed.
+	Rule string `json:"rule"`
+	// Process is the process the rule applies to. It is empty for rules that do not apply to a single process.
+	Process string `json:"process,omitempty"`
+	// Count is the number of occurrences that caused the violation, such as the number of values over a threshold.
+	Count int `json:"count"`
+	// Message is a human-readable description of the violation.
+	Message string `json:"message"`
+}
+
+// Rule is a check applied to a log during analysis. Rules may keep state between calls to Observe, so a rule should
+// only be added to a single analyzer and that analyzer should only be used once.
+type Rule interface {
+	// Observe is called with every entry parsed from the log, in order.
+	Observe(entry Entry)
+	// Evaluate returns a violation if the rule failed for the completed result, or nil otherwise.
+	Evaluate(result *AnalysisResult) *Violation
+}
+
+// MinSamples is a Rule that fails if a process has fewer than Min parsed entries.
+type MinSamples struct {
+	Process string
+	Min     int
+}
+
+// Observe does nothing since MinSamples only uses the final statistics.
+func (rule *MinSamples) Observe(Entry) {}
+
+// Evaluate returns a violation if the process has fewer than Min parsed entries.
+func (rule *MinSamples) Evaluate(result *AnalysisResult) *Violation {
+	count := result.Process(rule.Process).Stats.Count
+	if count >= rule.Min {
+		return nil
+	}
+
+	message := fmt.Sprintf("found %d %s entries, expected at least %d", count, rule.Process, rule.Min)
+	if count == 0 {
+		message = fmt.Sprintf("no %s entries parsed", rule.Process)
+	}
+
+	return &Violation{Rule: "min-samples", Process: rule.Process, Count: count, Message: message}
+}
+
+// MaxCount is a Rule that fails if the counter named Counter exceeds Max. Description describes what the counter
+// counts, such as "lines containing FAULTY".
+type MaxCount struct {
+	Counter     string
+	Description string
+	Max         int
+}
+
+// Observe does nothing since MaxCount only uses the final counters.
+func (rule *MaxCount) Observe(Entry) {}
+
+// Evaluate returns a violation if the counter exceeds Max.
+func (rule *MaxCount) Evaluate(result *AnalysisResult) *Violation {
+	count := result.Counters[rule.Counter]
+	if count <= rule.Max {
+		return nil
+	}
+
+	message := fmt.Sprintf("found %d %s", count, rule.Description)
+	if rule.Max > 0 {
+		message += fmt.Sprintf(", expected at most %d", rule.Max)
+	}
+
+	return &Violation{Rule: "max-count", Count: count, Message: message}
+}
+
+// ValueThreshold is a Rule that fails if any entry of a process has an absolute value over Threshold. If State is not
+// empty, only entries in that state are checked.
+type ValueThreshold struct {
+	Process   string
+	State     string
+	Threshold float64
+
+	count int
+}
+
+// Observe counts the entries whose absolute value exceeds the threshold.
+func (rule *ValueThreshold) Observe(entry Entry) {
+	if entry.Process != rule.Process || (rule.State != "" && entry.State != rule.State) {
+		return
+	}
+
+	if math.Abs(entry.Value) > rule.Threshold {
+		rule.count++
+	}
+}
+
+// Evaluate returns a violation if any entries exceeded the threshold.
+func (rule *ValueThreshold) Evaluate(*AnalysisResult) *Violation {
+	if rule.count == 0 {
+		return nil
+	}
+
+	entries := rule.Process
+	if rule.State != "" {
+		entries += " " + rule.State
+	}
+
+	return &Violation{
+		Rule:    "value-threshold",
+		Process: rule.Process,
+		Count:   rule.count,
+		Message: fmt.Sprintf("found %d %s values over threshold %g", rule.count, entries, rule.Threshold),
+	}
+}
+
+// PercentileThreshold is a Rule that fails if the value at Percentile for a process exceeds Threshold. Unlike
+// ValueThreshold, it tolerates a small fraction of outliers.
+type PercentileThreshold struct {
+	Process    string
+	Percentile float64
+	Threshold  float64
+}
+
+// Observe does nothing since PercentileThreshold only uses the final statistics.
+func (rule *PercentileThreshold) Observe(Entry) {}
+
+// Evaluate returns a violation if the value at Percentile exceeds Threshold.
+func (rule *PercentileThreshold) Evaluate(result *AnalysisResult) *Violation {
+	processResult := result.Process(rule.Process)
+	if processResult.Stats.Count == 0 {
+		return nil
+	}
+
+	value := processResult.Stats.Percentile(rule.Percentile)
+	if value <= rule.Threshold {
+		return nil
+	}
+
+	return &Violation{
+		Rule:    "percentile-threshold",
+		Process: rule.Process,
+		Count:   1,
+		Message: fmt.Sprintf("%s %s value %g is over threshold %g",
+			rule.Process, PercentileName(rule.Percentile), value, rule.Threshold),
+	}
+}
+
+// MaxStateTransitions is a Rule that fails if a process changes state more than Max times.
+type MaxStateTransitions struct {
+	Process string
+	Max     int
+}
+
+// Observe does nothing since MaxStateTransitions only uses the final state transitions.
+func (rule *MaxStateTransitions) Observe(Entry) {}
+
+// Evaluate returns a violation if the process changed state more than Max times.
+func (rule *MaxStateTransitions) Evaluate(result *AnalysisResult) *Violation {
+	count := len(result.Process(rule.Process).StateTransitions)
+	if count <= rule.Max {
+		return nil
+	}
+
+	return &Violation{
+		Rule:    "max-state-transitions",
+		Process: rule.Process,
+		Count:   count,
+		Message: fmt.Sprintf("found %d %s state transitions", count, rule.Process),
+	}
+}
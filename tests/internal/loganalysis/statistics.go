@@ -0,0 +1,83 @@
+package loganalysis
+
+import (
+	"math"
+	"slices"
+	"strconv"
+)
+
+// DefaultPercentiles are the percentiles computed when an analyzer does not specify any.
+var DefaultPercentiles = []float64{50, 90, 99}
+
+// Statistics captures descriptive statistics about the values of a process. If the analyzer uses absolute values,
+// all statistics are of the absolute values.
+type Statistics struct {
+	// Count is the number of values observed.
+	Count int `json:"count"`
+	// Min is the minimum value.
+	Min float64 `json:"min"`
+	// Max is the maximum value.
+	Max float64 `json:"max"`
+	// Mean is the arithmetic mean of the values.
+	Mean float64 `json:"mean"`
+	// Percentiles maps the name of each computed percentile, such as p99, to its value.
+	Percentiles map[string]float64 `json:"percentiles,omitempty"`
+
+	total  float64
+	values []float64
+	sorted bool
+}
+
+// observe adds a value to the statistics. All values are kept so that exact percentiles can be computed, which costs
+// 8 bytes per value.
+func (stats *Statistics) observe(value float64) {
+	if stats.Count == 0 || value < stats.Min {
+		stats.Min = value
+	}
+
+	if stats.Count == 0 || value > stats.Max {
+		stats.Max = value
+	}
+
+	stats.total += value
+	stats.Count++
+	stats.Mean = stats.total / float64(stats.Count)
+	stats.values = append(stats.values, value)
+	stats.sorted = false
+}
+
+// Percentile returns the value at percentile, between 0 and 100, using the nearest-rank method. It returns 0 if no
+// values have been observed.
+func (stats *Statistics) Percentile(percentile float64) float64 {
+	if len(stats.values) == 0 {
+		return 0
+	}
+
+	if !stats.sorted {
+		slices.Sort(stats.values)
+		stats.sorted = true
+	}
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(stats.values))))
+	rank = min(max(rank, 1), len(stats.values))
+
+	return stats.values[rank-1]
+}
+
+// finalize computes the provided percentiles.
+func (stats *Statistics) finalize(percentiles []float64) {
+	if stats.Count == 0 {
+		return
+	}
+
+	stats.Percentiles = make(map[string]float64, len(percentiles))
+
+	for _, percentile := range percentiles {
+		stats.Percentiles[PercentileName(percentile)] = stats.Percentile(percentile)
+	}
+}
+
+// PercentileName returns the name used for percentile in Statistics.Percentiles, such as p99 or p99.9.
+func PercentileName(percentile float64) string {
+	return "p" + strconv.FormatFloat(percentile, 'f', -1, 64)
+}
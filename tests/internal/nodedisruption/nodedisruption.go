@@ -0,0 +1,174 @@
+// Package nodedisruption provides helpers to disrupt cluster nodes, such as stopping the kubelet or cutting the node
+// off from the API server, and to wait for nodes to go down and recover. The disruptions are scheduled on the node as
+// transient systemd units that undo themselves, since commands executed through the kubelet or the API server are no
+// longer possible once the node is disrupted.
+package nodedisruption
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodeexec"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// APIServerPort is the port of the API server that is blocked by BlockAPIServer.
+	APIServerPort = 6443
+
+	notReadyPollInterval = 5 * time.Second
+	readyPollInterval    = 10 * time.Second
+)
+
+// StopKubelet stops the kubelet on nodeName and starts it again after duration. A node that is rebooted in the
+// meantime starts the kubelet on boot.
+func StopKubelet(executor nodeexec.NodeExecutor, nodeName string, duration time.Duration) error {
+	klog.V(90).Infof("Stopping kubelet on node %s for %s", nodeName, duration)
+
+	err := runTransient(executor, nodeName, "kubelet-stop", duration,
+		"systemctl stop kubelet", "systemctl start kubelet")
+	if err != nil {
+		return fmt.Errorf("failed to stop kubelet on node %s: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// BlockAPIServer drops the traffic from nodeName to the API server port and allows it again after duration. The rule
+// is not persisted, so a node that is rebooted in the meantime can reach the API server again after boot.
+func BlockAPIServer(executor nodeexec.NodeExecutor, nodeName string, duration time.Duration) error {
+	klog.V(90).Infof("Blocking API server traffic from node %s for %s", nodeName, duration)
+
+	rule := fmt.Sprintf("OUTPUT -p tcp --dport %d -j DROP", APIServerPort)
+
+	err := runTransient(executor, nodeName, "apiserver-block", duration, "iptables -I "+rule, "iptables -D "+rule)
+	if err != nil {
+		return fmt.Errorf("failed to block API server traffic from node %s: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// runTransient runs start on nodeName and then stop after duration in a transient systemd unit, returning once the
+// unit is started.
+func runTransient(
+	executor nodeexec.NodeExecutor, nodeName, unitSuffix string, duration time.Duration, start, stop string) error {
+	if executor == nil {
+		return fmt.Errorf("node executor cannot be nil")
+	}
+
+	if duration <= 0 {
+		return fmt.Errorf("disruption duration must be positive, got %s", duration)
+	}
+
+	command := fmt.Sprintf("systemd-run --unit=eco-%s-%d --collect sh -c '%s; sleep %d; %s'",
+		unitSuffix, time.Now().Unix(), start, int(duration.Seconds()), stop)
+
+	_, err := executor.Execute(context.TODO(), nodeName, command)
+
+	return err
+}
+
+// GetBootID returns the boot ID reported by the kubelet of nodeName. It changes every time the node reboots.
+func GetBootID(apiClient *clients.Settings, nodeName string) (string, error) {
+	node, err := nodes.Pull(apiClient, nodeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull node %s: %w", nodeName, err)
+	}
+
+	return node.Object.Status.NodeInfo.BootID, nil
+}
+
+// WaitForNodeNotReady waits up to timeout for the Ready condition of nodeName to be False or Unknown. Errors getting
+// the node are ignored since the API server may be unavailable while a node goes down.
+func WaitForNodeNotReady(apiClient *clients.Settings, nodeName string, timeout time.Duration) error {
+	klog.V(90).Infof("Waiting for node %s to become NotReady", nodeName)
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), notReadyPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			ready, found := nodeReadyStatus(apiClient, nodeName)
+
+			return found && !ready, nil
+		})
+	if err != nil {
+		return fmt.Errorf("node %s did not become NotReady within %s: %w", nodeName, timeout, err)
+	}
+
+	klog.V(90).Infof("Node %s is NotReady", nodeName)
+
+	return nil
+}
+
+// WaitForNodeReady waits up to timeout for the Ready condition of nodeName to be True. Errors getting the node are
+// ignored since the API server may be unavailable while a node reboots.
+func WaitForNodeReady(apiClient *clients.Settings, nodeName string, timeout time.Duration) error {
+	klog.V(90).Infof("Waiting for node %s to become Ready", nodeName)
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), readyPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			ready, found := nodeReadyStatus(apiClient, nodeName)
+
+			return found && ready, nil
+		})
+	if err != nil {
+		return fmt.Errorf("node %s did not become Ready within %s: %w", nodeName, timeout, err)
+	}
+
+	klog.V(90).Infof("Node %s is Ready", nodeName)
+
+	return nil
+}
+
+// WaitForNodeReboot waits up to timeout for nodeName to report a boot ID other than previousBootID and be Ready.
+func WaitForNodeReboot(apiClient *clients.Settings, nodeName, previousBootID string, timeout time.Duration) error {
+	klog.V(90).Infof("Waiting for node %s to reboot", nodeName)
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), readyPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			bootID, err := GetBootID(apiClient, nodeName)
+			if err != nil {
+				klog.V(90).Infof("Error getting boot ID of node %s (expected during reboot): %v", nodeName, err)
+
+				return false, nil
+			}
+
+			if bootID == previousBootID {
+				return false, nil
+			}
+
+			ready, found := nodeReadyStatus(apiClient, nodeName)
+
+			return found && ready, nil
+		})
+	if err != nil {
+		return fmt.Errorf("node %s did not reboot within %s: %w", nodeName, timeout, err)
+	}
+
+	klog.V(90).Infof("Node %s rebooted", nodeName)
+
+	return nil
+}
+
+// nodeReadyStatus returns whether the Ready condition of nodeName is True and whether the condition was found. It
+// returns false for both if the node cannot be retrieved.
+func nodeReadyStatus(apiClient *clients.Settings, nodeName string) (ready, found bool) {
+	node, err := nodes.Pull(apiClient, nodeName)
+	if err != nil {
+		klog.V(90).Infof("Error pulling node %s: %v", nodeName, err)
+
+		return false, false
+	}
+
+	for _, condition := range node.Object.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue, true
+		}
+	}
+
+	return false, false
+}
@@ -0,0 +1,154 @@
+package nodedisruption
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodeexec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testNodeName = "worker-0"
+
+func TestDisruptions(t *testing.T) {
+	testCases := []struct {
+		name             string
+		disrupt          func(nodeexec.NodeExecutor, string, time.Duration) error
+		duration         time.Duration
+		executeErr       error
+		expectedCommands []string
+		expectedError    bool
+	}{
+		{
+			name:     "stop kubelet",
+			disrupt:  StopKubelet,
+			duration: 2 * time.Minute,
+			expectedCommands: []string{
+				"systemd-run --unit=eco-kubelet-stop-",
+				"sh -c 'systemctl stop kubelet; sleep 120; systemctl start kubelet'",
+			},
+		},
+		{
+			name:     "block api server",
+			disrupt:  BlockAPIServer,
+			duration: time.Minute,
+			expectedCommands: []string{
+				"systemd-run --unit=eco-apiserver-block-",
+				"sh -c 'iptables -I OUTPUT -p tcp --dport 6443 -j DROP; sleep 60; " +
+					"iptables -D OUTPUT -p tcp --dport 6443 -j DROP'",
+			},
+		},
+		{
+			name:          "zero duration",
+			disrupt:       StopKubelet,
+			expectedError: true,
+		},
+		{
+			name:          "execute error",
+			disrupt:       BlockAPIServer,
+			duration:      time.Minute,
+			executeErr:    errors.New("connection refused"),
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			executor := nodeexec.NewMockExecutor()
+
+			if testCase.executeErr != nil {
+				executor.ExecuteFunc = func(_ context.Context, _, _ string) (*nodeexec.Result, error) {
+					return nil, testCase.executeErr
+				}
+			}
+
+			err := testCase.disrupt(executor, testNodeName, testCase.duration)
+			assert.Equal(t, testCase.expectedError, err != nil)
+
+			if testCase.expectedError {
+				return
+			}
+
+			calls := executor.Calls()
+			assert.Len(t, calls, 1)
+			assert.Equal(t, testNodeName, calls[0].NodeName)
+
+			for _, expected := range testCase.expectedCommands {
+				assert.True(t, strings.Contains(calls[0].Command, expected),
+					"command %q does not contain %q", calls[0].Command, expected)
+			}
+		})
+	}
+}
+
+func TestNilExecutor(t *testing.T) {
+	assert.NotNil(t, StopKubelet(nil, testNodeName, time.Minute))
+}
+
+func TestNodeStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		node          *corev1.Node
+		expectedReady bool
+		expectedFound bool
+	}{
+		{
+			name:          "ready",
+			node:          buildNode(corev1.ConditionTrue),
+			expectedReady: true,
+			expectedFound: true,
+		},
+		{
+			name:          "unknown",
+			node:          buildNode(corev1.ConditionUnknown),
+			expectedFound: true,
+		},
+		{
+			name: "no ready condition",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: testNodeName}},
+		},
+		{
+			name: "missing node",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var objects []runtime.Object
+
+			if testCase.node != nil {
+				objects = append(objects, testCase.node)
+			}
+
+			apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: objects})
+
+			ready, found := nodeReadyStatus(apiClient, testNodeName)
+			assert.Equal(t, testCase.expectedReady, ready)
+			assert.Equal(t, testCase.expectedFound, found)
+
+			bootID, err := GetBootID(apiClient, testNodeName)
+			assert.Equal(t, testCase.node == nil, err != nil)
+
+			if testCase.node != nil {
+				assert.Equal(t, testCase.node.Status.NodeInfo.BootID, bootID)
+			}
+		})
+	}
+}
+
+func buildNode(readyStatus corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: testNodeName},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: readyStatus}},
+			NodeInfo:   corev1.NodeSystemInfo{BootID: "boot-" + string(readyStatus)},
+		},
+	}
+}
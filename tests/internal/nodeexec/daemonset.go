@@ -0,0 +1,128 @@
+package nodeexec
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/daemonset"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	daemonSetName      = "eco-nodeexec-helper"
+	daemonSetContainer = "helper"
+)
+
+// daemonSetExecutor runs commands in the pods of a privileged helper DaemonSet using the host PID and network
+// namespaces. The DaemonSet is deployed the first time a command is run and removed by Cleanup.
+type daemonSetExecutor struct {
+	apiClient *clients.Settings
+	namespace string
+	image     string
+
+	mutex     sync.Mutex
+	daemonSet *daemonset.Builder
+}
+
+// newDaemonSetExecutor creates a daemonSetExecutor using the namespace and image from opts.
+func newDaemonSetExecutor(apiClient *clients.Settings, opts *options) *daemonSetExecutor {
+	return &daemonSetExecutor{apiClient: apiClient, namespace: opts.namespace, image: opts.image}
+}
+
+// Execute runs command in all of the host namespaces from the helper pod on nodeName, deploying the helper DaemonSet
+// if needed.
+func (executor *daemonSetExecutor) Execute(ctx context.Context, nodeName, command string) (*Result, error) {
+	err := executor.ensureDaemonSet()
+	if err != nil {
+		return nil, err
+	}
+
+	podName, err := findRunningPod(
+		ctx, executor.apiClient, executor.namespace, fmt.Sprintf("%s=daemonset", helperLabel), nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return execInPod(ctx, executor.apiClient, podTarget{
+		namespace: executor.namespace,
+		podName:   podName,
+		container: daemonSetContainer,
+	}, nodeName, command, hostCommand(command))
+}
+
+// Cleanup deletes the helper DaemonSet if it was deployed by the executor.
+func (executor *daemonSetExecutor) Cleanup() error {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+
+	if executor.daemonSet == nil {
+		return nil
+	}
+
+	klog.V(90).Infof("Deleting helper daemonset %s in namespace %s", daemonSetName, executor.namespace)
+
+	err := executor.daemonSet.DeleteAndWait(helperDeleteTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to delete helper daemonset %s: %w", daemonSetName, err)
+	}
+
+	executor.daemonSet = nil
+
+	return nil
+}
+
+// ensureDaemonSet deploys the helper DaemonSet and waits for it to be ready if it has not already been deployed. Any
+// DaemonSet left over with the same name is deleted first.
+func (executor *daemonSetExecutor) ensureDaemonSet() error {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+
+	if executor.daemonSet != nil {
+		return nil
+	}
+
+	if staleDaemonSet, err := daemonset.Pull(executor.apiClient, daemonSetName, executor.namespace); err == nil {
+		klog.V(90).Infof("Deleting stale helper daemonset %s in namespace %s", daemonSetName, executor.namespace)
+
+		err = staleDaemonSet.DeleteAndWait(helperDeleteTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to delete stale helper daemonset %s: %w", daemonSetName, err)
+		}
+	}
+
+	klog.V(90).Infof("Deploying helper daemonset %s in namespace %s", daemonSetName, executor.namespace)
+
+	container := corev1.Container{
+		Name:            daemonSetContainer,
+		Image:           executor.image,
+		Command:         []string{"/bin/bash", "-c", "sleep INF"},
+		SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+	}
+
+	helperDaemonSet, err := daemonset.NewBuilder(
+		executor.apiClient, daemonSetName, executor.namespace, map[string]string{helperLabel: "daemonset"}, container).
+		WithHostNetwork().
+		WithOptions(func(builder *daemonset.Builder) (*daemonset.Builder, error) {
+			builder.Definition.Spec.Template.Spec.HostPID = true
+			builder.Definition.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			}
+
+			return builder, nil
+		}).
+		CreateAndWaitUntilReady(helperStartTimeout)
+	if helperDaemonSet != nil && helperDaemonSet.Exists() {
+		// Keep track of the DaemonSet even if it did not become ready so Cleanup still removes it.
+		executor.daemonSet = helperDaemonSet
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to deploy helper daemonset %s: %w", daemonSetName, err)
+	}
+
+	return nil
+}
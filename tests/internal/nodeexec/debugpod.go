@@ -0,0 +1,128 @@
+package nodeexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	debugPodPrefix = "eco-nodeexec-"
+	// debugPodContainer is the name of the default container created by pod.NewBuilder.
+	debugPodContainer = "test"
+	// helperLabel is the label applied to the debug pods and helper DaemonSet pods so stale ones can be found.
+	helperLabel = "eco-gotests.io/nodeexec"
+	// helperStartTimeout is how long to wait for a debug pod or the helper DaemonSet to become ready.
+	helperStartTimeout = 3 * time.Minute
+	// helperDeleteTimeout is how long to wait for a debug pod or the helper DaemonSet to be deleted.
+	helperDeleteTimeout = time.Minute
+)
+
+// debugPodExecutor runs commands in privileged pods using the host PID and network namespaces. A pod is created the
+// first time a command is run on a node and reused until Cleanup is called.
+type debugPodExecutor struct {
+	apiClient *clients.Settings
+	namespace string
+	image     string
+
+	mutex sync.Mutex
+	pods  map[string]*pod.Builder
+}
+
+// newDebugPodExecutor creates a debugPodExecutor using the namespace and image from opts.
+func newDebugPodExecutor(apiClient *clients.Settings, opts *options) *debugPodExecutor {
+	return &debugPodExecutor{
+		apiClient: apiClient,
+		namespace: opts.namespace,
+		image:     opts.image,
+		pods:      make(map[string]*pod.Builder),
+	}
+}
+
+// Execute runs command in all of the host namespaces from the debug pod on nodeName, creating the pod if needed.
+func (executor *debugPodExecutor) Execute(ctx context.Context, nodeName, command string) (*Result, error) {
+	debugPod, err := executor.ensurePod(nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return execInPod(ctx, executor.apiClient, podTarget{
+		namespace: executor.namespace,
+		podName:   debugPod.Definition.Name,
+		container: debugPodContainer,
+	}, nodeName, command, hostCommand(command))
+}
+
+// Cleanup deletes every debug pod created by the executor.
+func (executor *debugPodExecutor) Cleanup() error {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+
+	var errs []error
+
+	for nodeName, debugPod := range executor.pods {
+		klog.V(90).Infof("Deleting debug pod %s for node %s", debugPod.Definition.Name, nodeName)
+
+		_, err := debugPod.DeleteAndWait(helperDeleteTimeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete debug pod for node %s: %w", nodeName, err))
+
+			continue
+		}
+
+		delete(executor.pods, nodeName)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ensurePod returns the debug pod for nodeName, creating it if it has not already been created. Any pod left over
+// with the same name is deleted first.
+func (executor *debugPodExecutor) ensurePod(nodeName string) (*pod.Builder, error) {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+
+	if debugPod, ok := executor.pods[nodeName]; ok {
+		return debugPod, nil
+	}
+
+	podName := debugPodPrefix + nodeName
+
+	if stalePod, err := pod.Pull(executor.apiClient, podName, executor.namespace); err == nil {
+		klog.V(90).Infof("Deleting stale debug pod %s in namespace %s", podName, executor.namespace)
+
+		_, err = stalePod.DeleteAndWait(helperDeleteTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete stale debug pod %s: %w", podName, err)
+		}
+	}
+
+	klog.V(90).Infof("Creating debug pod %s on node %s", podName, nodeName)
+
+	debugPod, err := pod.NewBuilder(executor.apiClient, podName, executor.namespace, executor.image).
+		WithPrivilegedFlag().
+		WithHostNetwork().
+		WithHostPid(true).
+		WithLabel(helperLabel, "debugpod").
+		WithToleration(corev1.Toleration{Operator: corev1.TolerationOpExists}).
+		WithOptions(func(builder *pod.Builder) (*pod.Builder, error) {
+			builder.Definition.Spec.NodeName = nodeName
+
+			return builder, nil
+		}).
+		CreateAndWaitUntilRunning(helperStartTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug pod on node %s: %w", nodeName, err)
+	}
+
+	executor.pods[nodeName] = debugPod
+
+	return debugPod, nil
+}
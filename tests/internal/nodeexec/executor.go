@@ -0,0 +1,267 @@
+package nodeexec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+)
+
+const (
+	// DefaultRetries is the number of times a command is retried after a transient error when no retries are
+	// provided.
+	DefaultRetries = 3
+	// DefaultRetryInterval is the time waited between retries when no retry interval is provided.
+	DefaultRetryInterval = 5 * time.Second
+	// DefaultTimeout is the timeout applied to each attempt when no timeout is provided.
+	DefaultTimeout = 2 * time.Minute
+	// DefaultNamespace is the namespace used for the MCD pods and the privileged helper pods when no namespace is
+	// provided.
+	DefaultNamespace = "openshift-machine-config-operator"
+	// DefaultMCDName is the value of the k8s-app label on the machine-config-daemon pods.
+	DefaultMCDName = "machine-config-daemon"
+	// DefaultImage is the image used for the debug pod and helper DaemonSet when no image is provided.
+	DefaultImage = "registry.redhat.io/rhel9/support-tools:latest"
+	// DefaultSSHUser is the user used for the SSH transport when no user is provided.
+	DefaultSSHUser = "core"
+	// DefaultSSHPort is the port used for the SSH transport.
+	DefaultSSHPort = "22"
+)
+
+// Transport is the mechanism used to run a command on a node.
+type Transport string
+
+const (
+	// TransportMCD runs commands in the machine-config-daemon pod on the node, entering the host mount namespace.
+	TransportMCD Transport = "mcd"
+	// TransportDebugPod runs commands in a privileged pod created on demand on the node and reused for later
+	// commands until Cleanup is called.
+	TransportDebugPod Transport = "debugpod"
+	// TransportDaemonSet runs commands in a privileged helper DaemonSet that is deployed on the first command and
+	// removed by Cleanup.
+	TransportDaemonSet Transport = "daemonset"
+	// TransportSSH runs commands over SSH to the InternalIP of the node.
+	TransportSSH Transport = "ssh"
+)
+
+// Result is the outcome of a command that ran to completion on a node.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// ExitError is returned along with the Result when a command ran but exited with a non-zero exit code.
+type ExitError struct {
+	NodeName string
+	Command  string
+	ExitCode int
+	Stderr   string
+}
+
+// Error returns a message with the node, command, exit code, and stderr of the command.
+func (exitError *ExitError) Error() string {
+	return fmt.Sprintf("command %q on node %s exited with code %d: %s",
+		exitError.Command, exitError.NodeName, exitError.ExitCode, exitError.Stderr)
+}
+
+// NodeExecutor runs shell commands in the host context of cluster nodes.
+type NodeExecutor interface {
+	// Execute runs command using sh on the node named nodeName. If the command runs but exits with a non-zero
+	// code, both the Result and an *ExitError are returned. If the command could not be run, the Result is nil.
+	Execute(ctx context.Context, nodeName, command string) (*Result, error)
+	// Cleanup removes any resources created on the cluster by the executor. It is safe to call more than once.
+	Cleanup() error
+}
+
+// options is a struct that holds the options for a NodeExecutor. It is unexported since the Option functions should
+// be used to configure it.
+type options struct {
+	retries       uint
+	retryInterval time.Duration
+	timeout       time.Duration
+	namespace     string
+	mcdName       string
+	image         string
+	sshUser       string
+	sshKeyPath    string
+	sshKnownHosts string
+}
+
+// newOptions creates a new options struct with default values. This function should always be used instead of
+// creating a new struct directly to ensure that the default values are set correctly.
+func newOptions() *options {
+	return &options{
+		retries:       DefaultRetries,
+		retryInterval: DefaultRetryInterval,
+		timeout:       DefaultTimeout,
+		namespace:     DefaultNamespace,
+		mcdName:       DefaultMCDName,
+		image:         DefaultImage,
+		sshUser:       DefaultSSHUser,
+	}
+}
+
+// Option is a function that configures a NodeExecutor.
+type Option func(*options)
+
+// noopOption is an Option that does nothing. It is used when the value provided to a function returning an Option is
+// invalid.
+func noopOption(*options) {}
+
+// WithRetries sets how many times a command is retried after a transient error and the time waited between retries.
+// Setting retries to zero disables retries. If interval is less than or equal to zero, it does nothing.
+func WithRetries(retries uint, interval time.Duration) Option {
+	if interval <= 0 {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.retries = retries
+		opts.retryInterval = interval
+	}
+}
+
+// WithTimeout sets the timeout applied to each attempt to run a command. If the timeout is less than or equal to zero,
+// it does nothing.
+func WithTimeout(timeout time.Duration) Option {
+	if timeout <= 0 {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.timeout = timeout
+	}
+}
+
+// WithNamespace sets the namespace of the MCD pods for the MCD transport and the namespace in which the debug pods and
+// helper DaemonSet are created. If the namespace is empty, it does nothing.
+func WithNamespace(namespace string) Option {
+	if namespace == "" {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.namespace = namespace
+	}
+}
+
+// WithMCDName sets the value of the k8s-app label used to find the machine-config-daemon pods. If the name is empty,
+// it does nothing.
+func WithMCDName(mcdName string) Option {
+	if mcdName == "" {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.mcdName = mcdName
+	}
+}
+
+// WithImage sets the image used for the debug pods and helper DaemonSet. The image must provide sh and nsenter. If
+// the image is empty, it does nothing.
+func WithImage(image string) Option {
+	if image == "" {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.image = image
+	}
+}
+
+// WithSSHUser sets the user for the SSH transport. If the user is empty, it does nothing.
+func WithSSHUser(user string) Option {
+	if user == "" {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.sshUser = user
+	}
+}
+
+// WithSSHKeyPath sets the path to the private key for the SSH transport. If the path is empty, it does nothing.
+func WithSSHKeyPath(keyPath string) Option {
+	if keyPath == "" {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.sshKeyPath = keyPath
+	}
+}
+
+// WithSSHKnownHostsPath sets the path to the known_hosts file used to verify node host keys for the SSH transport. If
+// the path is empty, it does nothing and host keys are not verified.
+func WithSSHKnownHostsPath(knownHostsPath string) Option {
+	if knownHostsPath == "" {
+		return noopOption
+	}
+
+	return func(opts *options) {
+		opts.sshKnownHosts = knownHostsPath
+	}
+}
+
+// New returns a NodeExecutor that uses transport to run commands. Every executor retries transient errors and applies
+// a timeout to each attempt according to the options.
+func New(apiClient *clients.Settings, transport Transport, options ...Option) (NodeExecutor, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot create node executor with nil apiClient")
+	}
+
+	opts := newOptions()
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	var base NodeExecutor
+
+	switch transport {
+	case TransportMCD:
+		base = newMCDExecutor(apiClient, opts)
+	case TransportDebugPod:
+		base = newDebugPodExecutor(apiClient, opts)
+	case TransportDaemonSet:
+		base = newDaemonSetExecutor(apiClient, opts)
+	case TransportSSH:
+		if opts.sshKeyPath == "" {
+			return nil, fmt.Errorf("cannot create node executor with %s transport: no ssh key path provided", transport)
+		}
+
+		base = newSSHExecutor(apiClient, opts)
+	default:
+		return nil, fmt.Errorf("unknown node executor transport %q", transport)
+	}
+
+	return newRetryExecutor(base, opts), nil
+}
+
+// NewFromConfig returns a NodeExecutor using the transport, namespaces, image, and SSH settings from generalConfig.
+// Options are applied after the values from generalConfig so they take precedence.
+func NewFromConfig(
+	apiClient *clients.Settings, generalConfig *config.GeneralConfig, options ...Option) (NodeExecutor, error) {
+	if generalConfig == nil {
+		return nil, fmt.Errorf("cannot create node executor with nil general config")
+	}
+
+	configOptions := []Option{
+		WithNamespace(generalConfig.MCONamespace),
+		WithMCDName(generalConfig.MCOConfigDaemonName),
+		WithImage(generalConfig.NodeExecImage),
+		WithSSHUser(generalConfig.SSHUser),
+		WithSSHKeyPath(generalConfig.SSHKeyPath),
+		WithSSHKnownHostsPath(generalConfig.SSHKnownHostsPath),
+	}
+
+	transport := Transport(generalConfig.NodeExecTransport)
+	if transport == "" {
+		transport = TransportMCD
+	}
+
+	return New(apiClient, transport, append(configOptions, options...)...)
+}
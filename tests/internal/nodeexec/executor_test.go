@@ -0,0 +1,290 @@
+package nodeexec
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestRetryExecutor(t *testing.T) {
+	transientError := fmt.Errorf("error executing command in container: container is restarting")
+	exitError := &ExitError{NodeName: "node-0", Command: "false", ExitCode: 1}
+
+	testCases := []struct {
+		name             string
+		errors           []error
+		retries          uint
+		expectedAttempts int
+		expectedExitCode int
+		expectedError    bool
+	}{
+		{
+			name:             "success",
+			errors:           []error{nil},
+			retries:          2,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "transient then success",
+			errors:           []error{transientError, transientError, nil},
+			retries:          2,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "transient exhausts retries",
+			errors:           []error{transientError},
+			retries:          2,
+			expectedAttempts: 3,
+			expectedError:    true,
+		},
+		{
+			name:             "exit error is not retried",
+			errors:           []error{exitError},
+			retries:          2,
+			expectedAttempts: 1,
+			expectedExitCode: 1,
+			expectedError:    true,
+		},
+		{
+			name:             "other error is not retried",
+			errors:           []error{fmt.Errorf("pods is forbidden")},
+			retries:          2,
+			expectedAttempts: 1,
+			expectedError:    true,
+		},
+		{
+			name:             "retries disabled",
+			errors:           []error{transientError, nil},
+			retries:          0,
+			expectedAttempts: 1,
+			expectedError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock := NewMockExecutor()
+			attempts := 0
+			mock.ExecuteFunc = func(context.Context, string, string) (*Result, error) {
+				err := testCase.errors[min(attempts, len(testCase.errors)-1)]
+				attempts++
+
+				var exitErr *ExitError
+				if errors.As(err, &exitErr) {
+					return &Result{ExitCode: exitErr.ExitCode}, err
+				}
+
+				if err != nil {
+					return nil, err
+				}
+
+				return &Result{Stdout: "ok"}, nil
+			}
+
+			opts := newOptions()
+			WithRetries(testCase.retries, time.Millisecond)(opts)
+
+			result, err := newRetryExecutor(mock, opts).Execute(context.TODO(), "node-0", "true")
+			assert.Equal(t, testCase.expectedError, err != nil)
+			assert.Equal(t, testCase.expectedAttempts, attempts)
+
+			if testCase.expectedExitCode != 0 {
+				assert.ErrorAs(t, err, &exitError)
+				assert.Equal(t, testCase.expectedExitCode, result.ExitCode)
+			}
+		})
+	}
+}
+
+func TestRetryExecutorTimeout(t *testing.T) {
+	mock := NewMockExecutor()
+	mock.ExecuteFunc = func(ctx context.Context, _, _ string) (*Result, error) {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	opts := newOptions()
+	WithTimeout(10 * time.Millisecond)(opts)
+
+	_, err := newRetryExecutor(mock, opts).Execute(context.TODO(), "node-0", "sleep 60")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "timed out")
+	assert.Len(t, mock.Calls(), 1)
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name          string
+		apiClient     *clients.Settings
+		transport     Transport
+		options       []Option
+		expectedError bool
+	}{
+		{name: "mcd", apiClient: &clients.Settings{}, transport: TransportMCD},
+		{name: "debug pod", apiClient: &clients.Settings{}, transport: TransportDebugPod},
+		{name: "daemonset", apiClient: &clients.Settings{}, transport: TransportDaemonSet},
+		{
+			name:      "ssh",
+			apiClient: &clients.Settings{},
+			transport: TransportSSH,
+			options:   []Option{WithSSHKeyPath("/tmp/id_rsa")},
+		},
+		{name: "ssh without key", apiClient: &clients.Settings{}, transport: TransportSSH, expectedError: true},
+		{name: "unknown transport", apiClient: &clients.Settings{}, transport: "telnet", expectedError: true},
+		{name: "nil client", transport: TransportMCD, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			executor, err := New(testCase.apiClient, testCase.transport, testCase.options...)
+			assert.Equal(t, testCase.expectedError, err != nil)
+			assert.Equal(t, testCase.expectedError, executor == nil)
+		})
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	executor, err := NewFromConfig(&clients.Settings{}, &config.GeneralConfig{
+		NodeExecTransport: "debugpod",
+		NodeExecImage:     "quay.io/example/tools:latest",
+		MCONamespace:      "test-namespace",
+	}, WithNamespace("override-namespace"))
+	assert.Nil(t, err)
+
+	retrying, ok := executor.(*retryExecutor)
+	assert.True(t, ok)
+
+	debugPod, ok := retrying.base.(*debugPodExecutor)
+	assert.True(t, ok)
+	assert.Equal(t, "override-namespace", debugPod.namespace)
+	assert.Equal(t, "quay.io/example/tools:latest", debugPod.image)
+
+	_, err = NewFromConfig(&clients.Settings{}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestOptions(t *testing.T) {
+	opts := newOptions()
+
+	WithRetries(5, -time.Second)(opts)
+	WithTimeout(0)(opts)
+	WithNamespace("")(opts)
+	WithImage("")(opts)
+
+	assert.Equal(t, uint(DefaultRetries), opts.retries)
+	assert.Equal(t, DefaultRetryInterval, opts.retryInterval)
+	assert.Equal(t, DefaultTimeout, opts.timeout)
+	assert.Equal(t, DefaultNamespace, opts.namespace)
+	assert.Equal(t, DefaultImage, opts.image)
+}
+
+func TestMockExecutor(t *testing.T) {
+	mock := NewMockExecutor()
+	mock.SetStdout("", "hostname", "any-node")
+	mock.SetStdout("node-1", "hostname", "node-1")
+	mock.SetResponse("node-1", "false", &Result{ExitCode: 1}, &ExitError{NodeName: "node-1", Command: "false"})
+
+	result, err := mock.Execute(context.TODO(), "node-0", "hostname")
+	assert.Nil(t, err)
+	assert.Equal(t, "any-node", result.Stdout)
+
+	result, err = mock.Execute(context.TODO(), "node-1", "hostname")
+	assert.Nil(t, err)
+	assert.Equal(t, "node-1", result.Stdout)
+
+	result, err = mock.Execute(context.TODO(), "node-1", "false")
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, result.ExitCode)
+
+	result, err = mock.Execute(context.TODO(), "node-1", "uptime")
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{}, result)
+
+	assert.Nil(t, mock.Cleanup())
+	assert.Equal(t, 1, mock.CleanupCount())
+	assert.Equal(t, []MockCall{
+		{NodeName: "node-0", Command: "hostname"},
+		{NodeName: "node-1", Command: "hostname"},
+		{NodeName: "node-1", Command: "false"},
+		{NodeName: "node-1", Command: "uptime"},
+	}, mock.Calls())
+}
+
+func TestShared(t *testing.T) {
+	t.Cleanup(func() {
+		_ = CleanupShared()
+	})
+
+	generalConfig := &config.GeneralConfig{NodeExecTransport: "mcd"}
+
+	executor, err := Shared(&clients.Settings{}, generalConfig)
+	assert.Nil(t, err)
+
+	retrying, ok := executor.(*retryExecutor)
+	assert.True(t, ok)
+	assert.Equal(t, SharedTimeout, retrying.options.timeout)
+
+	sameExecutor, err := Shared(&clients.Settings{}, &config.GeneralConfig{NodeExecTransport: "debugpod"})
+	assert.Nil(t, err)
+	assert.Same(t, executor, sameExecutor)
+
+	assert.Nil(t, CleanupShared())
+	assert.Nil(t, CleanupShared())
+
+	newExecutor, err := Shared(&clients.Settings{}, generalConfig)
+	assert.Nil(t, err)
+	assert.NotSame(t, executor, newExecutor)
+
+	assert.Nil(t, CleanupShared())
+
+	_, err = Shared(&clients.Settings{}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestSSHHostKeyCallback(t *testing.T) {
+	hostKey := newTestSSHPublicKey(t)
+	otherKey := newTestSSHPublicKey(t)
+	address := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	knownHostsPath := filepath.Join(t.TempDir(), "known_hosts")
+	err := os.WriteFile(knownHostsPath, []byte(knownhosts.Line([]string{"10.0.0.1"}, hostKey)+"\n"), 0o600)
+	assert.Nil(t, err)
+
+	callback, err := (&sshExecutor{knownHostsPath: knownHostsPath}).hostKeyCallback()
+	assert.Nil(t, err)
+	assert.Nil(t, callback("10.0.0.1:22", address, hostKey))
+	assert.NotNil(t, callback("10.0.0.1:22", address, otherKey))
+
+	callback, err = (&sshExecutor{}).hostKeyCallback()
+	assert.Nil(t, err)
+	assert.Nil(t, callback("10.0.0.1:22", address, otherKey))
+
+	_, err = (&sshExecutor{knownHostsPath: filepath.Join(t.TempDir(), "missing")}).hostKeyCallback()
+	assert.NotNil(t, err)
+}
+
+// newTestSSHPublicKey returns a new random ed25519 SSH public key.
+func newTestSSHPublicKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	assert.Nil(t, err)
+
+	sshKey, err := ssh.NewPublicKey(publicKey)
+	assert.Nil(t, err)
+
+	return sshKey
+}
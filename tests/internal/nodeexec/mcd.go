@@ -0,0 +1,46 @@
+package nodeexec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+)
+
+// mcdContainer is the name of the container in the machine-config-daemon pods that has the host filesystem mounted.
+const mcdContainer = "machine-config-daemon"
+
+// mcdExecutor runs commands in the machine-config-daemon pod on each node, entering the host mount namespace. It
+// does not create any resources.
+type mcdExecutor struct {
+	apiClient *clients.Settings
+	namespace string
+	mcdName   string
+}
+
+// newMCDExecutor creates an mcdExecutor using the namespace and MCD name from opts.
+func newMCDExecutor(apiClient *clients.Settings, opts *options) *mcdExecutor {
+	return &mcdExecutor{apiClient: apiClient, namespace: opts.namespace, mcdName: opts.mcdName}
+}
+
+// Execute runs command in the host mount namespace from the machine-config-daemon pod on nodeName.
+func (executor *mcdExecutor) Execute(ctx context.Context, nodeName, command string) (*Result, error) {
+	podName, err := findRunningPod(
+		ctx, executor.apiClient, executor.namespace, fmt.Sprintf("k8s-app=%s", executor.mcdName), nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	argv := []string{"nsenter", "--mount=/proc/1/ns/mnt", "--", "sh", "-c", command}
+
+	return execInPod(ctx, executor.apiClient, podTarget{
+		namespace: executor.namespace,
+		podName:   podName,
+		container: mcdContainer,
+	}, nodeName, command, argv)
+}
+
+// Cleanup does nothing since the machine-config-daemon pods are managed by the cluster.
+func (executor *mcdExecutor) Cleanup() error {
+	return nil
+}
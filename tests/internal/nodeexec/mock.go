@@ -0,0 +1,109 @@
+package nodeexec
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MockCall records a single call to MockExecutor.Execute.
+type MockCall struct {
+	NodeName string
+	Command  string
+}
+
+// MockExecutor is a NodeExecutor for unit tests. By default it returns an empty Result for every command. Responses
+// for specific commands can be set with SetResponse or all commands can be handled by setting ExecuteFunc. It is safe
+// for concurrent use.
+type MockExecutor struct {
+	// ExecuteFunc, if set, is called for every command that does not have a response set with SetResponse.
+	ExecuteFunc func(ctx context.Context, nodeName, command string) (*Result, error)
+
+	mutex     sync.Mutex
+	responses map[string]mockResponse
+	calls     []MockCall
+	cleanups  int
+}
+
+// mockResponse is the Result and error returned by MockExecutor for a command.
+type mockResponse struct {
+	result *Result
+	err    error
+}
+
+// Ensure MockExecutor implements NodeExecutor.
+var _ NodeExecutor = (*MockExecutor)(nil)
+
+// NewMockExecutor returns a MockExecutor with no responses set.
+func NewMockExecutor() *MockExecutor {
+	return &MockExecutor{responses: make(map[string]mockResponse)}
+}
+
+// SetResponse sets the Result and error returned when command is run on nodeName. If nodeName is empty, the response
+// is used for command on any node without a more specific response.
+func (mock *MockExecutor) SetResponse(nodeName, command string, result *Result, err error) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	mock.responses[mockKey(nodeName, command)] = mockResponse{result: result, err: err}
+}
+
+// SetStdout sets a successful response with stdout for command on nodeName. It is shorthand for SetResponse.
+func (mock *MockExecutor) SetStdout(nodeName, command, stdout string) {
+	mock.SetResponse(nodeName, command, &Result{Stdout: stdout}, nil)
+}
+
+// Execute records the call and returns the response for command, checking the node-specific response first.
+func (mock *MockExecutor) Execute(ctx context.Context, nodeName, command string) (*Result, error) {
+	mock.mutex.Lock()
+
+	mock.calls = append(mock.calls, MockCall{NodeName: nodeName, Command: command})
+
+	response, ok := mock.responses[mockKey(nodeName, command)]
+	if !ok {
+		response, ok = mock.responses[mockKey("", command)]
+	}
+
+	mock.mutex.Unlock()
+
+	if ok {
+		return response.result, response.err
+	}
+
+	if mock.ExecuteFunc != nil {
+		return mock.ExecuteFunc(ctx, nodeName, command)
+	}
+
+	return &Result{}, nil
+}
+
+// Cleanup records that Cleanup was called and returns nil.
+func (mock *MockExecutor) Cleanup() error {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	mock.cleanups++
+
+	return nil
+}
+
+// Calls returns a copy of every call made to Execute in order.
+func (mock *MockExecutor) Calls() []MockCall {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	return append([]MockCall(nil), mock.calls...)
+}
+
+// CleanupCount returns the number of times Cleanup has been called.
+func (mock *MockExecutor) CleanupCount() int {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	return mock.cleanups
+}
+
+// mockKey returns the key used to store the response for command on nodeName.
+func mockKey(nodeName, command string) string {
+	return fmt.Sprintf("%s\x00%s", nodeName, command)
+}
@@ -0,0 +1,94 @@
+package nodeexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// hostCommand returns the command that runs command using sh in all of the namespaces of the host. It requires the
+// pod to be privileged and use the host PID namespace.
+func hostCommand(command string) []string {
+	return []string{"nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--", "sh", "-c", command}
+}
+
+// podTarget identifies the container in which a command is executed.
+type podTarget struct {
+	namespace string
+	podName   string
+	container string
+}
+
+// findRunningPod returns the name of a running pod on nodeName in namespace that matches labelSelector.
+func findRunningPod(
+	ctx context.Context, apiClient *clients.Settings, namespace, labelSelector, nodeName string) (string, error) {
+	podList, err := apiClient.CoreV1Interface.Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods with label %s on node %s: %w", labelSelector, nodeName, err)
+	}
+
+	for _, nodePod := range podList.Items {
+		if nodePod.Status.Phase == corev1.PodRunning && nodePod.DeletionTimestamp == nil {
+			return nodePod.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no running pods with label %s found on node %s in namespace %s",
+		labelSelector, nodeName, namespace)
+}
+
+// execInPod runs argv in the target container without a TTY, so stdout and stderr are kept separate and unaltered. A
+// non-zero exit code is returned as an *ExitError along with the Result, using nodeName and command to describe it.
+func execInPod(
+	ctx context.Context,
+	apiClient *clients.Settings,
+	target podTarget,
+	nodeName, command string,
+	argv []string) (*Result, error) {
+	request := apiClient.CoreV1Interface.RESTClient().Post().
+		Resource("pods").
+		Name(target.podName).
+		Namespace(target.namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: target.container,
+			Command:   argv,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(apiClient.Config, "POST", request.URL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create executor for pod %s: %w", target.podName, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+	if err == nil {
+		return &Result{Stdout: stdout.String(), Stderr: stderr.String()}, nil
+	}
+
+	var codeError utilexec.ExitError
+	if errors.As(err, &codeError) {
+		result := &Result{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: codeError.ExitStatus()}
+
+		return result, &ExitError{
+			NodeName: nodeName, Command: command, ExitCode: result.ExitCode, Stderr: result.Stderr}
+	}
+
+	return nil, fmt.Errorf("failed to exec in container %s of pod %s: %w, stderr: %s",
+		target.container, target.podName, err, stderr.String())
+}
@@ -0,0 +1,101 @@
+package nodeexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// transientErrorMessages are substrings of errors that indicate the command could not be started because of a
+// temporary problem with the transport, so the command is safe to retry.
+var transientErrorMessages = []string{
+	"error executing command in container",
+	"container not found",
+	"unable to upgrade connection",
+}
+
+// retryExecutor wraps another NodeExecutor, applying a timeout to each attempt and retrying transient errors.
+type retryExecutor struct {
+	base    NodeExecutor
+	options *options
+}
+
+// newRetryExecutor creates a retryExecutor around base using the retries, retry interval, and timeout from opts.
+func newRetryExecutor(base NodeExecutor, opts *options) *retryExecutor {
+	return &retryExecutor{base: base, options: opts}
+}
+
+// Execute runs command on the node, retrying up to the configured number of times when the error is transient. Exit
+// errors and timeouts are never retried.
+func (executor *retryExecutor) Execute(ctx context.Context, nodeName, command string) (*Result, error) {
+	var (
+		result *Result
+		err    error
+	)
+
+	for attempt := uint(0); attempt <= executor.options.retries; attempt++ {
+		if attempt > 0 {
+			klog.V(90).Infof("Retrying command on node %s after transient error, retry %d (%d max): %v",
+				nodeName, attempt, executor.options.retries, err)
+
+			select {
+			case <-time.After(executor.options.retryInterval):
+			case <-ctx.Done():
+				return nil, fmt.Errorf("failed to execute command on node %s: context finished: %w", nodeName, ctx.Err())
+			}
+		}
+
+		result, err = executor.executeWithTimeout(ctx, nodeName, command)
+		if !isTransientError(err) {
+			return result, err
+		}
+	}
+
+	return result, fmt.Errorf("failed to execute command on node %s after %d retries: %w",
+		nodeName, executor.options.retries, err)
+}
+
+// Cleanup calls Cleanup on the wrapped executor.
+func (executor *retryExecutor) Cleanup() error {
+	return executor.base.Cleanup()
+}
+
+// executeWithTimeout runs a single attempt of command with the configured timeout.
+func (executor *retryExecutor) executeWithTimeout(ctx context.Context, nodeName, command string) (*Result, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, executor.options.timeout)
+	defer cancel()
+
+	klog.V(100).Infof("Executing command on node %s with timeout %s: %s", nodeName, executor.options.timeout, command)
+
+	result, err := executor.base.Execute(attemptCtx, nodeName, command)
+	if err != nil && attemptCtx.Err() != nil && ctx.Err() == nil {
+		return result, fmt.Errorf("command on node %s timed out after %s: %w", nodeName, executor.options.timeout, err)
+	}
+
+	return result, err
+}
+
+// isTransientError returns true if err is not nil, is not an *ExitError, and matches one of the known transient
+// errors.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var exitError *ExitError
+	if errors.As(err, &exitError) {
+		return false
+	}
+
+	for _, message := range transientErrorMessages {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package nodeexec
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+)
+
+// SharedTimeout is the timeout applied to each attempt by the shared NodeExecutor. It is long enough for the image
+// pulls and file copies some tests run on the nodes; callers needing a shorter timeout should use a context deadline.
+const SharedTimeout = 15 * time.Minute
+
+var (
+	sharedMutex    sync.Mutex
+	sharedExecutor NodeExecutor
+)
+
+// Shared returns the NodeExecutor shared by the whole process, creating it from generalConfig on the first call. Later
+// calls return the same executor regardless of their arguments, so helpers that run many commands do not create and
+// remove a debug pod or DaemonSet for each of them. Suites using those transports should call CleanupShared once they
+// are done, for example in AfterSuite.
+func Shared(apiClient *clients.Settings, generalConfig *config.GeneralConfig) (NodeExecutor, error) {
+	sharedMutex.Lock()
+	defer sharedMutex.Unlock()
+
+	if sharedExecutor != nil {
+		return sharedExecutor, nil
+	}
+
+	executor, err := NewFromConfig(apiClient, generalConfig, WithTimeout(SharedTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	sharedExecutor = executor
+
+	return sharedExecutor, nil
+}
+
+// CleanupShared cleans up the shared NodeExecutor if it was created. The next call to Shared creates a new executor.
+func CleanupShared() error {
+	sharedMutex.Lock()
+	defer sharedMutex.Unlock()
+
+	if sharedExecutor == nil {
+		return nil
+	}
+
+	err := sharedExecutor.Cleanup()
+	sharedExecutor = nil
+
+	return err
+}
@@ -0,0 +1,164 @@
+package nodeexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// sshExecutor runs commands over SSH to the InternalIP of each node. It does not create any resources.
+type sshExecutor struct {
+	apiClient      *clients.Settings
+	user           string
+	keyPath        string
+	knownHostsPath string
+}
+
+// newSSHExecutor creates an sshExecutor using the SSH user, key path, and known_hosts path from opts.
+func newSSHExecutor(apiClient *clients.Settings, opts *options) *sshExecutor {
+	return &sshExecutor{
+		apiClient: apiClient, user: opts.sshUser, keyPath: opts.sshKeyPath, knownHostsPath: opts.sshKnownHosts}
+}
+
+// Execute runs command over SSH on nodeName. The connection is closed if ctx finishes before the command does.
+func (executor *sshExecutor) Execute(ctx context.Context, nodeName, command string) (*Result, error) {
+	address, err := getNodeInternalIP(executor.apiClient, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig, err := executor.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	hostPort := net.JoinHostPort(address, DefaultSSHPort)
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", hostPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial node %s at %s: %w", nodeName, hostPort, err)
+	}
+
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, hostPort, clientConfig)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("failed to establish ssh connection to node %s: %w", nodeName, err)
+	}
+
+	client := ssh.NewClient(sshConn, channels, requests)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssh session on node %s: %w", nodeName, err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- session.Run(command)
+	}()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		_ = client.Close()
+
+		return nil, fmt.Errorf("ssh command on node %s interrupted: %w", nodeName, ctx.Err())
+	}
+
+	result := &Result{Stdout: stdout.String(), Stderr: stderr.String()}
+
+	var exitError *ssh.ExitError
+	if errors.As(err, &exitError) {
+		result.ExitCode = exitError.ExitStatus()
+
+		return result, &ExitError{NodeName: nodeName, Command: command, ExitCode: result.ExitCode, Stderr: result.Stderr}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to run ssh command on node %s: %w", nodeName, err)
+	}
+
+	return result, nil
+}
+
+// Cleanup does nothing since the SSH transport does not create any resources.
+func (executor *sshExecutor) Cleanup() error {
+	return nil
+}
+
+// clientConfig reads the private key and returns the SSH client config for the executor. Host keys are verified
+// against the known_hosts file when one is configured.
+func (executor *sshExecutor) clientConfig() (*ssh.ClientConfig, error) {
+	keyBytes, err := os.ReadFile(executor.keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ssh private key %s: %w", executor.keyPath, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ssh private key %s: %w", executor.keyPath, err)
+	}
+
+	hostKeyCallback, err := executor.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User:            executor.user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
+
+// hostKeyCallback returns a callback checking host keys against the known_hosts file of the executor. Without a
+// known_hosts file, host keys are not verified.
+func (executor *sshExecutor) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	if executor.knownHostsPath == "" {
+		klog.V(90).Infof("No ssh known_hosts file configured, not verifying node host keys")
+
+		return ssh.InsecureIgnoreHostKey(), nil //nolint:gosec
+	}
+
+	callback, err := knownhosts.New(executor.knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ssh known_hosts file %s: %w", executor.knownHostsPath, err)
+	}
+
+	return callback, nil
+}
+
+// getNodeInternalIP returns the first InternalIP address of nodeName.
+func getNodeInternalIP(apiClient *clients.Settings, nodeName string) (string, error) {
+	node, err := nodes.Pull(apiClient, nodeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull node %s: %w", nodeName, err)
+	}
+
+	for _, address := range node.Object.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			return address.Address, nil
+		}
+	}
+
+	return "", fmt.Errorf("node %s has no InternalIP address", nodeName)
+}
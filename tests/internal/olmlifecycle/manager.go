@@ -0,0 +1,260 @@
+// Package olmlifecycle manages the OLM lifecycle of an operator for tests: installing it at a starting CSV, approving
+// manual InstallPlans one step at a time, switching channels, waiting for CSVs to succeed with the expected version,
+// and uninstalling it while detecting resources OLM left behind. It is shared by every suite that tests operator
+// upgrades so that each does not re-implement the same flows.
+package olmlifecycle
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	operatorsV1alpha1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/olm/operators/v1alpha1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultCatalogSourceNamespace is the namespace of the catalog sources shipped with OpenShift.
+	DefaultCatalogSourceNamespace = "openshift-marketplace"
+	// DefaultPollInterval is the interval between checks while waiting on OLM resources.
+	DefaultPollInterval = 5 * time.Second
+
+	logLevel = 90
+)
+
+// Config describes the operator whose lifecycle is managed. Only Namespace and PackageName are required, the other
+// fields have defaults or are only needed by some operations.
+type Config struct {
+	// Namespace is the namespace the operator is installed in.
+	Namespace string
+	// PackageName is the name of the operator package in the catalog.
+	PackageName string
+	// SubscriptionName defaults to PackageName.
+	SubscriptionName string
+	// OperatorGroupName defaults to PackageName.
+	OperatorGroupName string
+	// CatalogSource is the catalog the operator is installed from. It is required by Install.
+	CatalogSource string
+	// CatalogSourceNamespace defaults to DefaultCatalogSourceNamespace.
+	CatalogSourceNamespace string
+	// Channel is the subscription channel. If empty, the default channel of the package is used.
+	Channel string
+	// StartingCSV, if set, is the CSV installed first instead of the head of the channel.
+	StartingCSV string
+	// InstallPlanApproval defaults to Automatic. With Manual, each InstallPlan must be approved by the test.
+	InstallPlanApproval operatorsV1alpha1.Approval
+	// TargetNamespaces are watched by the operator. If empty, the operator watches all namespaces.
+	TargetNamespaces []string
+	// SharedNamespace means the namespace is neither created by Install nor deleted by Uninstall.
+	SharedNamespace bool
+	// SharedOperatorGroup means the operator group is neither created by Install nor deleted by Uninstall.
+	SharedOperatorGroup bool
+	// DeleteCRDs makes Uninstall also delete the CRDs owned by the operator, which OLM leaves in place.
+	DeleteCRDs bool
+	// PollInterval defaults to DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// Manager manages the OLM lifecycle of a single operator. It tracks every CSV it sees succeed and the CRDs they own,
+// so that Uninstall and Leftovers cover every version installed during an upgrade.
+type Manager struct {
+	apiClient *clients.Settings
+	config    Config
+	csvNames  []string
+	crdNames  []string
+}
+
+// NewManager returns a Manager for the operator described by config after applying the defaults.
+func NewManager(apiClient *clients.Settings, config Config) (*Manager, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("apiClient cannot be nil")
+	}
+
+	if config.Namespace == "" {
+		return nil, fmt.Errorf("operator namespace cannot be empty")
+	}
+
+	if config.PackageName == "" {
+		return nil, fmt.Errorf("operator package name cannot be empty")
+	}
+
+	if config.SubscriptionName == "" {
+		config.SubscriptionName = config.PackageName
+	}
+
+	if config.OperatorGroupName == "" {
+		config.OperatorGroupName = config.PackageName
+	}
+
+	if config.CatalogSourceNamespace == "" {
+		config.CatalogSourceNamespace = DefaultCatalogSourceNamespace
+	}
+
+	if config.InstallPlanApproval == "" {
+		config.InstallPlanApproval = operatorsV1alpha1.ApprovalAutomatic
+	}
+
+	if config.InstallPlanApproval != operatorsV1alpha1.ApprovalAutomatic &&
+		config.InstallPlanApproval != operatorsV1alpha1.ApprovalManual {
+		return nil, fmt.Errorf("invalid InstallPlan approval %q", config.InstallPlanApproval)
+	}
+
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	return &Manager{apiClient: apiClient, config: config}, nil
+}
+
+// Config returns the configuration of the manager with the defaults applied.
+func (manager *Manager) Config() Config {
+	return manager.config
+}
+
+// CSVNames returns the names of every CSV the manager has seen succeed, in order.
+func (manager *Manager) CSVNames() []string {
+	return slices.Clone(manager.csvNames)
+}
+
+// Install creates the namespace, operator group and subscription, skipping any that already exist. It does not wait
+// for the operator to be installed, use WaitForInstalledCSV or ApproveNextInstallPlan for that.
+func (manager *Manager) Install() error {
+	if manager.config.CatalogSource == "" {
+		return fmt.Errorf("catalog source cannot be empty when installing %s", manager.config.PackageName)
+	}
+
+	klog.V(logLevel).Infof("Installing operator %s in namespace %s from catalog %s",
+		manager.config.PackageName, manager.config.Namespace, manager.config.CatalogSource)
+
+	if !manager.config.SharedNamespace {
+		err := manager.createNamespace()
+		if err != nil {
+			return err
+		}
+	}
+
+	if !manager.config.SharedOperatorGroup {
+		err := manager.createOperatorGroup()
+		if err != nil {
+			return err
+		}
+	}
+
+	return manager.createSubscription()
+}
+
+// Subscription pulls the subscription of the operator.
+func (manager *Manager) Subscription() (*olm.SubscriptionBuilder, error) {
+	subscription, err := olm.PullSubscription(
+		manager.apiClient, manager.config.SubscriptionName, manager.config.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull subscription %s in namespace %s: %w",
+			manager.config.SubscriptionName, manager.config.Namespace, err)
+	}
+
+	return subscription, nil
+}
+
+// SwitchChannel updates the subscription to follow channel and to use catalogSource, leaving either unchanged when it
+// is empty. OLM then resolves the upgrade path to the head of the channel in the catalog.
+func (manager *Manager) SwitchChannel(channel, catalogSource string) error {
+	if channel == "" && catalogSource == "" {
+		return fmt.Errorf("channel and catalog source cannot both be empty")
+	}
+
+	subscription, err := manager.Subscription()
+	if err != nil {
+		return err
+	}
+
+	channel = cmp.Or(channel, subscription.Definition.Spec.Channel)
+	catalogSource = cmp.Or(catalogSource, subscription.Definition.Spec.CatalogSource)
+
+	klog.V(logLevel).Infof("Switching subscription %s from channel %s of %s to channel %s of %s",
+		manager.config.SubscriptionName, subscription.Definition.Spec.Channel,
+		subscription.Definition.Spec.CatalogSource, channel, catalogSource)
+
+	subscription.Definition.Spec.Channel = channel
+	subscription.Definition.Spec.CatalogSource = catalogSource
+
+	_, err = subscription.Update()
+	if err != nil {
+		return fmt.Errorf("failed to switch subscription %s to channel %s: %w",
+			manager.config.SubscriptionName, channel, err)
+	}
+
+	manager.config.Channel = channel
+	manager.config.CatalogSource = catalogSource
+
+	return nil
+}
+
+// createNamespace creates the operator namespace if it does not exist.
+func (manager *Manager) createNamespace() error {
+	nsBuilder := namespace.NewBuilder(manager.apiClient, manager.config.Namespace)
+	if nsBuilder.Exists() {
+		return nil
+	}
+
+	_, err := nsBuilder.Create()
+	if err != nil {
+		return fmt.Errorf("failed to create namespace %s: %w", manager.config.Namespace, err)
+	}
+
+	return nil
+}
+
+// createOperatorGroup creates the operator group if it does not exist.
+func (manager *Manager) createOperatorGroup() error {
+	operatorGroup := olm.NewOperatorGroupBuilder(
+		manager.apiClient, manager.config.OperatorGroupName, manager.config.Namespace)
+	if operatorGroup.Exists() {
+		return nil
+	}
+
+	operatorGroup.Definition.Spec.TargetNamespaces = manager.config.TargetNamespaces
+
+	_, err := operatorGroup.Create()
+	if err != nil {
+		return fmt.Errorf("failed to create operator group %s: %w", manager.config.OperatorGroupName, err)
+	}
+
+	return nil
+}
+
+// createSubscription creates the subscription if it does not exist.
+func (manager *Manager) createSubscription() error {
+	subscription := olm.NewSubscriptionBuilder(
+		manager.apiClient,
+		manager.config.SubscriptionName,
+		manager.config.Namespace,
+		manager.config.CatalogSource,
+		manager.config.CatalogSourceNamespace,
+		manager.config.PackageName).
+		WithInstallPlanApproval(manager.config.InstallPlanApproval)
+
+	if manager.config.Channel != "" {
+		subscription.WithChannel(manager.config.Channel)
+	}
+
+	if manager.config.StartingCSV != "" {
+		subscription.WithStartingCSV(manager.config.StartingCSV)
+	}
+
+	if subscription.Exists() {
+		klog.V(logLevel).Infof("Subscription %s already exists", manager.config.SubscriptionName)
+
+		return nil
+	}
+
+	_, err := subscription.Create()
+	if err != nil {
+		return fmt.Errorf("failed to create subscription %s: %w", manager.config.SubscriptionName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,438 @@
+package olmlifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	operatorsV1alpha1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/olm/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testNamespace   = "test-operator"
+	testPackage     = "test-operator"
+	testInstallPlan = "install-abcde"
+	testCSV         = "test-operator.v1.1.0"
+	testCRD         = "widgets.example.com"
+	testTimeout     = 100 * time.Millisecond
+)
+
+func TestNewManager(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        Config
+		nilClient     bool
+		expectedError bool
+	}{
+		{
+			name:   "defaults",
+			config: Config{Namespace: testNamespace, PackageName: testPackage},
+		},
+		{
+			name:          "nil client",
+			config:        Config{Namespace: testNamespace, PackageName: testPackage},
+			nilClient:     true,
+			expectedError: true,
+		},
+		{
+			name:          "empty namespace",
+			config:        Config{PackageName: testPackage},
+			expectedError: true,
+		},
+		{
+			name:          "empty package",
+			config:        Config{Namespace: testNamespace},
+			expectedError: true,
+		},
+		{
+			name: "invalid approval",
+			config: Config{
+				Namespace: testNamespace, PackageName: testPackage, InstallPlanApproval: "Sometimes"},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var apiClient *clients.Settings
+
+			if !testCase.nilClient {
+				apiClient = clients.GetTestClients(clients.TestClientParams{})
+			}
+
+			manager, err := NewManager(apiClient, testCase.config)
+			assert.Equal(t, testCase.expectedError, err != nil)
+
+			if testCase.expectedError {
+				return
+			}
+
+			config := manager.Config()
+			assert.Equal(t, testPackage, config.SubscriptionName)
+			assert.Equal(t, testPackage, config.OperatorGroupName)
+			assert.Equal(t, DefaultCatalogSourceNamespace, config.CatalogSourceNamespace)
+			assert.Equal(t, operatorsV1alpha1.ApprovalAutomatic, config.InstallPlanApproval)
+			assert.Equal(t, DefaultPollInterval, config.PollInterval)
+		})
+	}
+}
+
+func TestApproveNextInstallPlan(t *testing.T) {
+	testCases := []struct {
+		name          string
+		approved      bool
+		csvNames      []string
+		currentCSV    string
+		expectedCSV   string
+		expectedError bool
+	}{
+		{
+			name:        "pending install plan",
+			csvNames:    []string{testCSV},
+			currentCSV:  testCSV,
+			expectedCSV: testCSV,
+		},
+		{
+			name:        "current csv listed after a dependency",
+			csvNames:    []string{"dependency.v2.0.0", testCSV},
+			currentCSV:  testCSV,
+			expectedCSV: testCSV,
+		},
+		{
+			name:          "already approved",
+			approved:      true,
+			csvNames:      []string{testCSV},
+			currentCSV:    testCSV,
+			expectedError: true,
+		},
+		{
+			name:          "no csv",
+			currentCSV:    testCSV,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := buildTestClient(
+				buildSubscription(testCase.currentCSV),
+				buildInstallPlan(testCase.csvNames, testCase.approved))
+			manager := buildManager(t, apiClient, Config{InstallPlanApproval: operatorsV1alpha1.ApprovalManual})
+
+			csvName, err := manager.ApproveNextInstallPlan(testTimeout)
+			assert.Equal(t, testCase.expectedError, err != nil)
+			assert.Equal(t, testCase.expectedCSV, csvName)
+
+			if testCase.expectedError {
+				return
+			}
+
+			installPlan, err := olm.PullInstallPlan(apiClient, testInstallPlan, testNamespace)
+			assert.Nil(t, err)
+			assert.True(t, installPlan.Object.Spec.Approved)
+		})
+	}
+}
+
+func TestWaitForCSV(t *testing.T) {
+	testCases := []struct {
+		name          string
+		phase         operatorsV1alpha1.ClusterServiceVersionPhase
+		expectedError bool
+	}{
+		{
+			name:  "succeeded",
+			phase: operatorsV1alpha1.CSVPhaseSucceeded,
+		},
+		{
+			name:          "installing",
+			phase:         operatorsV1alpha1.CSVPhaseInstalling,
+			expectedError: true,
+		},
+		{
+			name:          "failed",
+			phase:         operatorsV1alpha1.CSVPhaseFailed,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			manager := buildManager(t, buildTestClient(buildCSV(testCase.phase)), Config{})
+
+			_, err := manager.WaitForCSV(testCSV, testTimeout)
+			assert.Equal(t, testCase.expectedError, err != nil)
+
+			if testCase.expectedError {
+				assert.Empty(t, manager.CSVNames())
+
+				return
+			}
+
+			assert.Equal(t, []string{testCSV}, manager.CSVNames())
+			assert.Nil(t, manager.VerifyCSVVersion(testCSV, "1.1.0"))
+			assert.NotNil(t, manager.VerifyCSVVersion(testCSV, "1.0.0"))
+		})
+	}
+}
+
+func TestLeftovers(t *testing.T) {
+	testCases := []struct {
+		name              string
+		objects           []runtime.Object
+		deleteCRDs        bool
+		expectedLeftovers Leftovers
+	}{
+		{
+			name: "clean uninstall",
+		},
+		{
+			name:       "crd left over",
+			objects:    []runtime.Object{buildCRD()},
+			deleteCRDs: true,
+			expectedLeftovers: Leftovers{
+				CRDs: []string{testCRD},
+			},
+		},
+		{
+			name:    "crd kept without deleteCRDs",
+			objects: []runtime.Object{buildCRD()},
+			expectedLeftovers: Leftovers{
+				CRDs: []string{testCRD},
+			},
+		},
+		{
+			name: "rbac left over",
+			objects: []runtime.Object{
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+					Name: "test-operator-role", Labels: map[string]string{OwnerLabel: testCSV}}},
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+					Name: "unrelated-role", Labels: map[string]string{OwnerLabel: "other.v1.0.0"}}},
+			},
+			expectedLeftovers: Leftovers{
+				ClusterRoles: []string{"test-operator-role"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			objects := append([]runtime.Object{buildCSV(operatorsV1alpha1.CSVPhaseSucceeded)}, testCase.objects...)
+			manager := buildManager(t, buildTestClient(objects...), Config{DeleteCRDs: testCase.deleteCRDs})
+
+			_, err := manager.WaitForCSV(testCSV, testTimeout)
+			assert.Nil(t, err)
+
+			leftovers, err := manager.Leftovers()
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, testCase.expectedLeftovers.CRDs, leftovers.CRDs)
+			assert.ElementsMatch(t, testCase.expectedLeftovers.ClusterRoles, leftovers.ClusterRoles)
+			assert.Empty(t, leftovers.ValidatingWebhooks)
+			assert.Empty(t, leftovers.MutatingWebhooks)
+			assert.Empty(t, leftovers.ClusterRoleBindings)
+			assert.Equal(t, testCase.expectedLeftovers.Empty(), leftovers.Err() == nil)
+		})
+	}
+}
+
+func TestUninstallDeletesOwnedCRDs(t *testing.T) {
+	apiClient := buildTestClient(
+		buildSubscription(testCSV), buildCSV(operatorsV1alpha1.CSVPhaseSucceeded), buildCRD())
+	manager := buildManager(t, apiClient, Config{DeleteCRDs: true, SharedNamespace: true})
+
+	err := manager.Uninstall(testTimeout)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{testCSV}, manager.CSVNames())
+
+	_, err = olm.PullClusterServiceVersion(apiClient, testCSV, testNamespace)
+	assert.NotNil(t, err)
+
+	leftovers, err := manager.Leftovers()
+	assert.Nil(t, err)
+	assert.True(t, leftovers.Empty())
+}
+
+func TestSwitchChannel(t *testing.T) {
+	testCases := []struct {
+		name            string
+		channel         string
+		catalogSource   string
+		expectedChannel string
+		expectedCatalog string
+		expectedError   bool
+	}{
+		{
+			name:            "channel and catalog",
+			channel:         "fast",
+			catalogSource:   "custom-catalog",
+			expectedChannel: "fast",
+			expectedCatalog: "custom-catalog",
+		},
+		{
+			name:            "catalog only",
+			catalogSource:   "custom-catalog",
+			expectedChannel: "stable",
+			expectedCatalog: "custom-catalog",
+		},
+		{
+			name:            "channel only",
+			channel:         "fast",
+			expectedChannel: "fast",
+			expectedCatalog: "redhat-operators",
+		},
+		{
+			name:          "nothing to switch",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			subscription := buildSubscription(testCSV)
+			subscription.Spec = &operatorsV1alpha1.SubscriptionSpec{
+				Package: testPackage, Channel: "stable", CatalogSource: "redhat-operators"}
+
+			manager := buildManager(t, buildTestClient(subscription), Config{})
+
+			err := manager.SwitchChannel(testCase.channel, testCase.catalogSource)
+			assert.Equal(t, testCase.expectedError, err != nil)
+
+			if testCase.expectedError {
+				return
+			}
+
+			updated, err := manager.Subscription()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedChannel, updated.Object.Spec.Channel)
+			assert.Equal(t, testCase.expectedCatalog, updated.Object.Spec.CatalogSource)
+			assert.Equal(t, testCase.expectedChannel, manager.Config().Channel)
+			assert.Equal(t, testCase.expectedCatalog, manager.Config().CatalogSource)
+		})
+	}
+}
+
+func TestWaitForInstalledVersion(t *testing.T) {
+	testCases := []struct {
+		name           string
+		phase          operatorsV1alpha1.ClusterServiceVersionPhase
+		versionPattern string
+		expectedError  bool
+	}{
+		{
+			name:           "matching version succeeded",
+			phase:          operatorsV1alpha1.CSVPhaseSucceeded,
+			versionPattern: `^1\.1\.`,
+		},
+		{
+			name:           "matching version installing",
+			phase:          operatorsV1alpha1.CSVPhaseInstalling,
+			versionPattern: `^1\.1\.`,
+			expectedError:  true,
+		},
+		{
+			name:           "matching version failed",
+			phase:          operatorsV1alpha1.CSVPhaseFailed,
+			versionPattern: `^1\.1\.`,
+			expectedError:  true,
+		},
+		{
+			name:           "other version",
+			phase:          operatorsV1alpha1.CSVPhaseSucceeded,
+			versionPattern: `^2\.0\.`,
+			expectedError:  true,
+		},
+		{
+			name:           "invalid pattern",
+			phase:          operatorsV1alpha1.CSVPhaseSucceeded,
+			versionPattern: "(",
+			expectedError:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			subscription := buildSubscription(testCSV)
+			subscription.Status.InstalledCSV = testCSV
+
+			manager := buildManager(t, buildTestClient(subscription, buildCSV(testCase.phase)), Config{})
+
+			csvName, err := manager.WaitForInstalledVersion(testCase.versionPattern, testTimeout)
+			assert.Equal(t, testCase.expectedError, err != nil)
+
+			if testCase.expectedError {
+				assert.Empty(t, manager.CSVNames())
+
+				return
+			}
+
+			assert.Equal(t, testCSV, csvName)
+			assert.Equal(t, []string{testCSV}, manager.CSVNames())
+		})
+	}
+}
+
+func buildTestClient(objects ...runtime.Object) *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: append(objects,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}),
+		SchemeAttachers: []clients.SchemeAttacher{operatorsV1alpha1.AddToScheme},
+	})
+}
+
+func buildManager(t *testing.T, apiClient *clients.Settings, config Config) *Manager {
+	t.Helper()
+
+	config.Namespace = testNamespace
+	config.PackageName = testPackage
+	config.PollInterval = 10 * time.Millisecond
+
+	manager, err := NewManager(apiClient, config)
+	assert.Nil(t, err)
+
+	return manager
+}
+
+func buildSubscription(currentCSV string) *operatorsV1alpha1.Subscription {
+	return &operatorsV1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: testPackage, Namespace: testNamespace},
+		Status: operatorsV1alpha1.SubscriptionStatus{
+			CurrentCSV:     currentCSV,
+			State:          operatorsV1alpha1.SubscriptionStateUpgradePending,
+			InstallPlanRef: &corev1.ObjectReference{Name: testInstallPlan, Namespace: testNamespace},
+		},
+	}
+}
+
+func buildInstallPlan(csvNames []string, approved bool) *operatorsV1alpha1.InstallPlan {
+	return &operatorsV1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: testInstallPlan, Namespace: testNamespace},
+		Spec: operatorsV1alpha1.InstallPlanSpec{
+			ClusterServiceVersionNames: csvNames,
+			Approval:                   operatorsV1alpha1.ApprovalManual,
+			Approved:                   approved,
+		},
+	}
+}
+
+func buildCSV(phase operatorsV1alpha1.ClusterServiceVersionPhase) *operatorsV1alpha1.ClusterServiceVersion {
+	csv := &operatorsV1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: testCSV, Namespace: testNamespace},
+		Status:     operatorsV1alpha1.ClusterServiceVersionStatus{Phase: phase},
+	}
+
+	csv.Spec.CustomResourceDefinitions.Owned = []operatorsV1alpha1.CRDDescription{{Name: testCRD}}
+	_ = csv.Spec.Version.UnmarshalJSON([]byte(`"1.1.0"`))
+
+	return csv
+}
+
+func buildCRD() *apiextv1.CustomResourceDefinition {
+	return &apiextv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: testCRD}}
+}
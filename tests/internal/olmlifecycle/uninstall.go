@@ -0,0 +1,240 @@
+package olmlifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OwnerLabel is the label OLM sets to the name of the CSV on the cluster scoped resources it creates for it.
+const OwnerLabel = "olm.owner"
+
+// Leftovers lists the resources that remain on the cluster after an operator is uninstalled.
+type Leftovers struct {
+	CRDs                []string
+	ValidatingWebhooks  []string
+	MutatingWebhooks    []string
+	ClusterRoles        []string
+	ClusterRoleBindings []string
+}
+
+// Empty returns true if no resources were left over.
+func (leftovers Leftovers) Empty() bool {
+	return len(leftovers.CRDs) == 0 && len(leftovers.ValidatingWebhooks) == 0 &&
+		len(leftovers.MutatingWebhooks) == 0 && len(leftovers.ClusterRoles) == 0 &&
+		len(leftovers.ClusterRoleBindings) == 0
+}
+
+// Err returns an error describing the leftovers, or nil if there are none.
+func (leftovers Leftovers) Err() error {
+	if leftovers.Empty() {
+		return nil
+	}
+
+	var kinds []string
+
+	for _, kind := range []struct {
+		name  string
+		names []string
+	}{
+		{"CRDs", leftovers.CRDs},
+		{"ValidatingWebhookConfigurations", leftovers.ValidatingWebhooks},
+		{"MutatingWebhookConfigurations", leftovers.MutatingWebhooks},
+		{"ClusterRoles", leftovers.ClusterRoles},
+		{"ClusterRoleBindings", leftovers.ClusterRoleBindings},
+	} {
+		if len(kind.names) > 0 {
+			kinds = append(kinds, fmt.Sprintf("%s %v", kind.name, kind.names))
+		}
+	}
+
+	return fmt.Errorf("resources left over after uninstall: %s", strings.Join(kinds, ", "))
+}
+
+// Uninstall deletes the subscription and every CSV of the operator, then the CRDs if DeleteCRDs is set, the operator
+// group and the namespace unless they are shared. It waits up to timeout for the CSVs and the namespace to be deleted.
+// Every step is attempted even if an earlier one fails and the errors are joined.
+func (manager *Manager) Uninstall(timeout time.Duration) error {
+	klog.V(logLevel).Infof("Uninstalling operator %s from namespace %s",
+		manager.config.PackageName, manager.config.Namespace)
+
+	var errs []error
+
+	subscription, err := olm.PullSubscription(
+		manager.apiClient, manager.config.SubscriptionName, manager.config.Namespace)
+	if err == nil {
+		for _, csvName := range []string{
+			subscription.Object.Status.InstalledCSV, subscription.Object.Status.CurrentCSV} {
+			if csvName != "" && !slices.Contains(manager.csvNames, csvName) {
+				manager.csvNames = append(manager.csvNames, csvName)
+			}
+		}
+
+		err = subscription.Delete()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete subscription %s: %w",
+				manager.config.SubscriptionName, err))
+		}
+	}
+
+	errs = append(errs, manager.deleteCSVs(timeout))
+
+	if manager.config.DeleteCRDs {
+		errs = append(errs, manager.deleteCRDs())
+	}
+
+	if !manager.config.SharedOperatorGroup {
+		operatorGroup, err := olm.PullOperatorGroup(
+			manager.apiClient, manager.config.OperatorGroupName, manager.config.Namespace)
+		if err == nil {
+			err = operatorGroup.Delete()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete operator group %s: %w",
+					manager.config.OperatorGroupName, err))
+			}
+		}
+	}
+
+	if !manager.config.SharedNamespace {
+		err = namespace.NewBuilder(manager.apiClient, manager.config.Namespace).DeleteAndWait(timeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete namespace %s: %w", manager.config.Namespace, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Leftovers returns the resources OLM created for any CSV tracked by the manager that are still on the cluster,
+// including the CRDs they own. OLM keeps the CRDs on purpose, so callers that do not set DeleteCRDs may expect them to
+// be listed.
+func (manager *Manager) Leftovers() (Leftovers, error) {
+	return FindLeftovers(manager.apiClient, manager.csvNames, manager.crdNames)
+}
+
+// FindLeftovers returns the CRDs named in crdNames that still exist, and the webhook configurations, cluster roles
+// and cluster role bindings that OLM labeled as owned by any of csvNames.
+func FindLeftovers(apiClient *clients.Settings, csvNames, crdNames []string) (Leftovers, error) {
+	if apiClient == nil {
+		return Leftovers{}, fmt.Errorf("apiClient cannot be nil")
+	}
+
+	leftovers := Leftovers{}
+
+	for _, crdName := range crdNames {
+		err := apiClient.Client.Get(
+			context.TODO(), runtimeclient.ObjectKey{Name: crdName}, &apiextv1.CustomResourceDefinition{})
+		if err == nil {
+			leftovers.CRDs = append(leftovers.CRDs, crdName)
+
+			continue
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			return leftovers, fmt.Errorf("failed to get CRD %s: %w", crdName, err)
+		}
+	}
+
+	for _, csvName := range csvNames {
+		owned := runtimeclient.MatchingLabels{OwnerLabel: csvName}
+
+		validatingWebhooks := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+		mutatingWebhooks := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+		clusterRoles := &rbacv1.ClusterRoleList{}
+		clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+
+		for _, list := range []runtimeclient.ObjectList{
+			validatingWebhooks, mutatingWebhooks, clusterRoles, clusterRoleBindings} {
+			err := apiClient.Client.List(context.TODO(), list, owned)
+			if err != nil {
+				return leftovers, fmt.Errorf("failed to list resources owned by CSV %s: %w", csvName, err)
+			}
+		}
+
+		leftovers.ValidatingWebhooks = append(leftovers.ValidatingWebhooks, names(validatingWebhooks.Items)...)
+		leftovers.MutatingWebhooks = append(leftovers.MutatingWebhooks, names(mutatingWebhooks.Items)...)
+		leftovers.ClusterRoles = append(leftovers.ClusterRoles, names(clusterRoles.Items)...)
+		leftovers.ClusterRoleBindings = append(leftovers.ClusterRoleBindings, names(clusterRoleBindings.Items)...)
+	}
+
+	return leftovers, nil
+}
+
+// deleteCSVs deletes every CSV tracked by the manager and waits up to timeout for them to be removed. The CRDs each
+// CSV owns are tracked before it is deleted, so that they are known even for CSVs the manager never waited for.
+func (manager *Manager) deleteCSVs(timeout time.Duration) error {
+	var errs []error
+
+	for _, csvName := range manager.csvNames {
+		csv, err := olm.PullClusterServiceVersion(manager.apiClient, csvName, manager.config.Namespace)
+		if err != nil {
+			klog.V(logLevel).Infof("CSV %s not found, skipping deletion: %v", csvName, err)
+
+			continue
+		}
+
+		manager.track(csv.Object)
+
+		err = csv.Delete()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete CSV %s: %w", csvName, err))
+
+			continue
+		}
+
+		err = wait.PollUntilContextTimeout(context.TODO(), manager.config.PollInterval, timeout, true,
+			func(ctx context.Context) (bool, error) {
+				return !csv.Exists(), nil
+			})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CSV %s was not deleted within %s: %w", csvName, timeout, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleteCRDs deletes the CRDs owned by the CSVs tracked by the manager.
+func (manager *Manager) deleteCRDs() error {
+	var errs []error
+
+	for _, crdName := range manager.crdNames {
+		crd := &apiextv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: crdName}}
+
+		err := manager.apiClient.Client.Delete(context.TODO(), crd)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete CRD %s: %w", crdName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// names returns the names of objects.
+func names[T any, PT interface {
+	*T
+	GetName() string
+}](objects []T) []string {
+	result := make([]string, 0, len(objects))
+
+	for index := range objects {
+		result = append(result, PT(&objects[index]).GetName())
+	}
+
+	return result
+}
@@ -0,0 +1,332 @@
+package olmlifecycle
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	operatorsV1alpha1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/olm/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// ApproveNextInstallPlan waits up to timeout for the subscription to reference an InstallPlan that is not approved
+// yet, approves it and returns the name of the CSV it installs. It only approves a single InstallPlan so that tests
+// can verify every step of an upgrade path.
+func (manager *Manager) ApproveNextInstallPlan(timeout time.Duration) (string, error) {
+	var (
+		installPlan *olm.InstallPlanBuilder
+		currentCSV  string
+	)
+
+	err := wait.PollUntilContextTimeout(context.TODO(), manager.config.PollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			subscription, err := manager.Subscription()
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to get subscription, retrying: %v", err)
+
+				return false, nil
+			}
+
+			currentCSV = subscription.Object.Status.CurrentCSV
+
+			reference := subscription.Object.Status.InstallPlanRef
+			if reference == nil {
+				klog.V(logLevel).Infof("Subscription %s does not reference an InstallPlan yet, state %q",
+					manager.config.SubscriptionName, subscription.Object.Status.State)
+
+				return false, nil
+			}
+
+			installPlan, err = olm.PullInstallPlan(manager.apiClient, reference.Name, reference.Namespace)
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to pull InstallPlan %s, retrying: %v", reference.Name, err)
+
+				return false, nil
+			}
+
+			if installPlan.Object.Spec.Approved {
+				klog.V(logLevel).Infof("InstallPlan %s is already approved, waiting for the next one", reference.Name)
+
+				return false, nil
+			}
+
+			return true, nil
+		})
+	if err != nil {
+		return "", fmt.Errorf("no InstallPlan waiting for approval for subscription %s within %s: %w",
+			manager.config.SubscriptionName, timeout, err)
+	}
+
+	csvName, err := installPlanCSV(installPlan.Object, currentCSV)
+	if err != nil {
+		return "", err
+	}
+
+	klog.V(logLevel).Infof("Approving InstallPlan %s for CSV %s", installPlan.Object.Name, csvName)
+
+	installPlan.Definition.Spec.Approved = true
+
+	_, err = installPlan.Update()
+	if err != nil {
+		return "", fmt.Errorf("failed to approve InstallPlan %s: %w", installPlan.Object.Name, err)
+	}
+
+	return csvName, nil
+}
+
+// WaitForCSV waits up to timeout for the CSV named csvName to reach the Succeeded phase. The CSV and the CRDs it owns
+// are then tracked by the manager for Uninstall and Leftovers.
+func (manager *Manager) WaitForCSV(csvName string, timeout time.Duration) (*olm.ClusterServiceVersionBuilder, error) {
+	var csv *olm.ClusterServiceVersionBuilder
+
+	err := wait.PollUntilContextTimeout(context.TODO(), manager.config.PollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			var err error
+
+			csv, err = olm.PullClusterServiceVersion(manager.apiClient, csvName, manager.config.Namespace)
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to pull CSV %s, retrying: %v", csvName, err)
+
+				return false, nil
+			}
+
+			phase := csv.Object.Status.Phase
+			if phase == operatorsV1alpha1.CSVPhaseFailed {
+				return false, fmt.Errorf("CSV %s failed: %s", csvName, csv.Object.Status.Message)
+			}
+
+			if phase != operatorsV1alpha1.CSVPhaseSucceeded {
+				klog.V(logLevel).Infof("CSV %s is in phase %q", csvName, phase)
+
+				return false, nil
+			}
+
+			return true, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("CSV %s did not succeed within %s: %w", csvName, timeout, err)
+	}
+
+	manager.track(csv.Object)
+
+	return csv, nil
+}
+
+// WaitForInstalledCSV waits up to timeout for the subscription to report an installed CSV and for that CSV to
+// succeed. It returns the name of the CSV. With manual approval, ApproveNextInstallPlan must be called first.
+func (manager *Manager) WaitForInstalledCSV(timeout time.Duration) (string, error) {
+	var csvName string
+
+	err := wait.PollUntilContextTimeout(context.TODO(), manager.config.PollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			subscription, err := manager.Subscription()
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to get subscription, retrying: %v", err)
+
+				return false, nil
+			}
+
+			csvName = subscription.Object.Status.InstalledCSV
+
+			return csvName != "", nil
+		})
+	if err != nil {
+		return "", fmt.Errorf("subscription %s did not report an installed CSV within %s: %w",
+			manager.config.SubscriptionName, timeout, err)
+	}
+
+	_, err = manager.WaitForCSV(csvName, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	return csvName, nil
+}
+
+// WaitForInstalledVersion waits up to timeout for the subscription to install a CSV whose version matches
+// versionPattern, a regular expression, and for that CSV to succeed. It returns the name of the CSV, which is then
+// tracked by the manager. It suits automatic approval when only the target version, not its CSV name, is known.
+func (manager *Manager) WaitForInstalledVersion(versionPattern string, timeout time.Duration) (string, error) {
+	versionRegex, err := regexp.Compile(versionPattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid version pattern %q: %w", versionPattern, err)
+	}
+
+	var (
+		csv     *operatorsV1alpha1.ClusterServiceVersion
+		version string
+	)
+
+	err = wait.PollUntilContextTimeout(context.TODO(), manager.config.PollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			subscription, err := manager.Subscription()
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to get subscription, retrying: %v", err)
+
+				return false, nil
+			}
+
+			csvName := subscription.Object.Status.InstalledCSV
+			if csvName == "" {
+				return false, nil
+			}
+
+			csvBuilder, err := olm.PullClusterServiceVersion(manager.apiClient, csvName, manager.config.Namespace)
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to pull CSV %s, retrying: %v", csvName, err)
+
+				return false, nil
+			}
+
+			csv = csvBuilder.Object
+			version = csv.Spec.Version.String()
+			phase := csv.Status.Phase
+
+			klog.V(logLevel).Infof("Subscription %s has installed CSV %s with version %s in phase %q",
+				manager.config.SubscriptionName, csvName, version, phase)
+
+			if !versionRegex.MatchString(version) {
+				return false, nil
+			}
+
+			if phase == operatorsV1alpha1.CSVPhaseFailed {
+				return false, fmt.Errorf("CSV %s failed: %s", csvName, csv.Status.Message)
+			}
+
+			return phase == operatorsV1alpha1.CSVPhaseSucceeded, nil
+		})
+	if err != nil {
+		return "", fmt.Errorf("subscription %s did not install a CSV with version matching %s within %s, "+
+			"last installed version %q: %w", manager.config.SubscriptionName, versionPattern, timeout, version, err)
+	}
+
+	manager.track(csv)
+
+	return csv.Name, nil
+}
+
+// UpgradeTo upgrades the operator until targetCSV is installed and succeeded, and returns the CSVs installed on the
+// way in order. With manual approval every InstallPlan of the upgrade path is approved and waited for one at a time,
+// each step being given up to timeout. With automatic approval it waits up to timeout for targetCSV to be installed.
+func (manager *Manager) UpgradeTo(targetCSV string, timeout time.Duration) ([]string, error) {
+	if targetCSV == "" {
+		return nil, fmt.Errorf("target CSV cannot be empty")
+	}
+
+	if manager.config.InstallPlanApproval == operatorsV1alpha1.ApprovalAutomatic {
+		err := manager.waitForInstalledCSVName(targetCSV, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = manager.WaitForCSV(targetCSV, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{targetCSV}, nil
+	}
+
+	var path []string
+
+	for {
+		csvName, err := manager.ApproveNextInstallPlan(timeout)
+		if err != nil {
+			return path, fmt.Errorf("upgrade to %s stopped after %v: %w", targetCSV, path, err)
+		}
+
+		if slices.Contains(path, csvName) {
+			return path, fmt.Errorf("upgrade to %s is looping, CSV %s was already installed", targetCSV, csvName)
+		}
+
+		_, err = manager.WaitForCSV(csvName, timeout)
+		if err != nil {
+			return path, err
+		}
+
+		path = append(path, csvName)
+
+		if csvName == targetCSV {
+			return path, nil
+		}
+	}
+}
+
+// VerifyCSVVersion returns an error if the version of the CSV named csvName is not expectedVersion.
+func (manager *Manager) VerifyCSVVersion(csvName, expectedVersion string) error {
+	csv, err := olm.PullClusterServiceVersion(manager.apiClient, csvName, manager.config.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to pull CSV %s: %w", csvName, err)
+	}
+
+	version := csv.Object.Spec.Version.String()
+	if version != expectedVersion {
+		return fmt.Errorf("CSV %s has version %s instead of %s", csvName, version, expectedVersion)
+	}
+
+	return nil
+}
+
+// waitForInstalledCSVName waits up to timeout for the subscription to report csvName as installed.
+func (manager *Manager) waitForInstalledCSVName(csvName string, timeout time.Duration) error {
+	var (
+		installedCSV string
+		state        operatorsV1alpha1.SubscriptionState
+	)
+
+	err := wait.PollUntilContextTimeout(context.TODO(), manager.config.PollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			subscription, err := manager.Subscription()
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to get subscription, retrying: %v", err)
+
+				return false, nil
+			}
+
+			installedCSV = subscription.Object.Status.InstalledCSV
+			state = subscription.Object.Status.State
+
+			klog.V(logLevel).Infof("Subscription %s has installed CSV %s in state %q",
+				manager.config.SubscriptionName, installedCSV, state)
+
+			return installedCSV == csvName, nil
+		})
+	if err != nil {
+		return fmt.Errorf("subscription %s did not install %s within %s, installed CSV %s in state %q: %w",
+			manager.config.SubscriptionName, csvName, timeout, installedCSV, state, err)
+	}
+
+	return nil
+}
+
+// track records csv and the CRDs it owns.
+func (manager *Manager) track(csv *operatorsV1alpha1.ClusterServiceVersion) {
+	if !slices.Contains(manager.csvNames, csv.Name) {
+		manager.csvNames = append(manager.csvNames, csv.Name)
+	}
+
+	for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+		if !slices.Contains(manager.crdNames, crd.Name) {
+			manager.crdNames = append(manager.crdNames, crd.Name)
+		}
+	}
+}
+
+// installPlanCSV returns the CSV of the operator installed by installPlan. An InstallPlan may also list the CSVs of
+// dependencies, so currentCSV, the CSV the subscription is upgrading to, is preferred when it is listed.
+func installPlanCSV(installPlan *operatorsV1alpha1.InstallPlan, currentCSV string) (string, error) {
+	names := installPlan.Spec.ClusterServiceVersionNames
+	if len(names) == 0 {
+		return "", fmt.Errorf("InstallPlan %s does not list any CSV", installPlan.Name)
+	}
+
+	if slices.Contains(names, currentCSV) {
+		return currentCSV, nil
+	}
+
+	return names[0], nil
+}
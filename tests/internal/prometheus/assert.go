@@ -0,0 +1,240 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultPollInterval is the poll interval used for a query assert when a timeout is specified but no poll
+	// interval is provided.
+	DefaultPollInterval = 5 * time.Second
+)
+
+// Condition checks the result of a query at a single point in time, returning an error describing why the result
+// does not meet the condition.
+type Condition func(vector model.Vector) error
+
+// NotEmpty returns a Condition that requires the query to return at least one sample.
+func NotEmpty() Condition {
+	return func(vector model.Vector) error {
+		if len(vector) == 0 {
+			return fmt.Errorf("no samples returned")
+		}
+
+		return nil
+	}
+}
+
+// AllMatch returns a Condition that requires the query to return at least one sample and every sample to satisfy
+// matches. The description is used in the error message, such as "greater than 0".
+func AllMatch(description string, matches func(value float64) bool) Condition {
+	return func(vector model.Vector) error {
+		if len(vector) == 0 {
+			return fmt.Errorf("no samples returned")
+		}
+
+		for _, sample := range vector {
+			if sample == nil {
+				continue
+			}
+
+			if !matches(float64(sample.Value)) {
+				return fmt.Errorf("expected all samples to be %s, got sample %s", description, sample)
+			}
+		}
+
+		return nil
+	}
+}
+
+// AllEqual returns a Condition that requires the query to return at least one sample and every sample to equal
+// expected.
+func AllEqual(expected float64) Condition {
+	return AllMatch(fmt.Sprintf("equal to %g", expected), func(value float64) bool {
+		return value == expected
+	})
+}
+
+// assertOptions is a struct that holds the options for the AssertQuery function. It is unexported since the
+// AssertOption functions should be used to configure it.
+type assertOptions struct {
+	timeout        time.Duration
+	pollInterval   time.Duration
+	stableDuration time.Duration
+	startTime      time.Time
+}
+
+// newAssertOptions creates a new assertOptions struct with default values. This function should always be used
+// instead of creating a new struct directly to ensure that the default values are set correctly.
+func newAssertOptions() *assertOptions {
+	return &assertOptions{
+		timeout:        0,
+		pollInterval:   DefaultPollInterval,
+		stableDuration: 0,
+		startTime:      time.Now(),
+	}
+}
+
+// AssertOption is a function that configures assertions for the AssertQuery function.
+type AssertOption func(*assertOptions)
+
+// noopAssertOption is an AssertOption that does nothing. It is used when the value provided to a function returning
+// an AssertOption is invalid.
+func noopAssertOption(options *assertOptions) {}
+
+// AssertWithTimeout sets the timeout for the assertion. If the timeout is less than or equal to zero, it does nothing.
+// Similarly, the timeout cannot be set to less than the stable duration. This upholds the invariant that timeout =
+// max(timeout, stableDuration).
+func AssertWithTimeout(timeout time.Duration) AssertOption {
+	if timeout <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		if options.stableDuration > timeout {
+			return
+		}
+
+		options.timeout = timeout
+	}
+}
+
+// AssertWithPollInterval sets the poll interval for the assertion. If the poll interval is less than or equal to zero,
+// it does nothing. Note that if the poll interval is set to longer than the timeout, the assertion will only run once.
+func AssertWithPollInterval(pollInterval time.Duration) AssertOption {
+	if pollInterval <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.pollInterval = pollInterval
+	}
+}
+
+// AssertWithStableDuration sets the stable duration for the assertion. If the stable duration is less than or equal to
+// zero, it does nothing. If the stable duration is set to longer than the timeout, the timeout is updated to be the
+// stable duration. This upholds the invariant that timeout = max(timeout, stableDuration).
+func AssertWithStableDuration(stableDuration time.Duration) AssertOption {
+	if stableDuration <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		if options.timeout <= stableDuration {
+			options.timeout = stableDuration
+		}
+
+		options.stableDuration = stableDuration
+	}
+}
+
+// AssertWithStartTime sets the start time for the assertion. If the start time is zero or in the future, it does
+// nothing.
+func AssertWithStartTime(startTime time.Time) AssertOption {
+	if startTime.IsZero() || startTime.After(time.Now()) {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.startTime = startTime
+	}
+}
+
+// AssertQuery executes the provided query and checks the result vector against condition. In the base case, the query
+// is executed once at the current time.
+//
+// Options can be provided to specify a timeout, poll interval, stable duration, and start time. In cases where the
+// start time is provided alone, the query will be executed immediately at start time and return the result based on
+// just the start time, which defaults to the current time.
+//
+// Timeout is equal to max(timeout, stableDuration) if at least one of them is provided. The behavior then is to ensure
+// that the assertion succeeds at least once within the period between the start time and call time plus timeout and if
+// stableDuration is provided, the query must succeed for polls over the entire stable duration. If the assertion fails,
+// the running stable duration is reset.
+//
+// SECURITY: This function does not perform any sort of sanitization on the query. It should only be used with trusted
+// queries.
+func AssertQuery(
+	ctx context.Context,
+	client prometheusv1.API,
+	query string,
+	condition Condition,
+	options ...AssertOption) error {
+	if client == nil {
+		return fmt.Errorf("cannot assert query with nil client")
+	}
+
+	if condition == nil {
+		return fmt.Errorf("cannot assert query with nil condition")
+	}
+
+	opts := newAssertOptions()
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	// queryTime is the time at which each query is executed. It begins as the start time and will be incremented by
+	// the poll interval until the timeout is reached.
+	queryTime := opts.startTime
+	// stableTime is the first time at which a query succeeded, reset after a failure. The time in between the
+	// stableTime and the queryTime is the running stable duration.
+	stableTime := queryTime
+	// lastTime is the time at which the query will stop being executed. It is the current time plus the timeout.
+	lastTime := time.Now().Add(opts.timeout)
+
+	// The second condition allows the query to be executed exactly once if the timeout is zero.
+	for queryTime.Before(lastTime) || queryTime.Equal(lastTime) {
+		select {
+		// Wait until the queryTime is no longer in the future. Since the metrics are saved to Prometheus, the
+		// queryTime is allowed to be in the past and is executed immediately in that case.
+		case <-time.After(time.Until(queryTime)):
+			err := assertQueryAtTime(ctx, client, query, condition, queryTime)
+			if err == nil && (opts.stableDuration == 0 || queryTime.Sub(stableTime) >= opts.stableDuration) {
+				return nil
+			}
+
+			if err == nil {
+				// The query succeeded but has not been stable for long enough, so keep the earlier
+				// stableTime.
+				queryTime = queryTime.Add(opts.pollInterval)
+
+				continue
+			}
+
+			klog.V(100).Infof("Query assert failed at time %s: %v", queryTime, err)
+
+			// Since the query failed, the earliest it can start being stable is the next queryTime.
+			queryTime = queryTime.Add(opts.pollInterval)
+			stableTime = queryTime
+		case <-ctx.Done():
+			return fmt.Errorf("failed to assert query eventually: context finished: %w", ctx.Err())
+		}
+	}
+
+	return fmt.Errorf("failed to assert query eventually: timeout of %s exceeded", opts.timeout)
+}
+
+// assertQueryAtTime executes query at assertTime and checks the result against condition.
+func assertQueryAtTime(
+	ctx context.Context, client prometheusv1.API, query string, condition Condition, assertTime time.Time) error {
+	vector, err := QueryVector(ctx, client, query, assertTime)
+	if err != nil {
+		return fmt.Errorf("failed to execute query at time %s: %w", assertTime, err)
+	}
+
+	err = condition(vector)
+	if err != nil {
+		return fmt.Errorf("query assert error at time %s: %w\nquery: %s", assertTime, err, query)
+	}
+
+	klog.V(100).Infof("Query assert passed at time %s: got %s\nquery: %s", assertTime, vector, query)
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package prometheus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAPI is a prometheusv1.API that returns the next value from values for each instant query. Calling any method
+// other than Query panics.
+type fakeAPI struct {
+	prometheusv1.API
+
+	values  []float64
+	queries int
+}
+
+func (api *fakeAPI) Query(
+	context.Context, string, time.Time, ...prometheusv1.Option) (model.Value, prometheusv1.Warnings, error) {
+	value := api.values[min(api.queries, len(api.values)-1)]
+	api.queries++
+
+	return model.Vector{{Metric: model.Metric{}, Value: model.SampleValue(value)}}, nil, nil
+}
+
+func TestAssertQuery(t *testing.T) {
+	testCases := []struct {
+		name            string
+		values          []float64
+		options         []AssertOption
+		expectedError   bool
+		expectedQueries int
+	}{
+		{
+			name:            "single success",
+			values:          []float64{1},
+			expectedQueries: 1,
+		},
+		{
+			name:            "single failure",
+			values:          []float64{0},
+			expectedError:   true,
+			expectedQueries: 1,
+		},
+		{
+			name:   "eventual success",
+			values: []float64{0, 0, 1},
+			options: []AssertOption{
+				AssertWithStartTime(time.Now().Add(-time.Minute)),
+				AssertWithTimeout(time.Minute),
+				AssertWithPollInterval(10 * time.Second),
+			},
+			expectedQueries: 3,
+		},
+		{
+			name:   "stable after failure",
+			values: []float64{1, 0, 1, 1, 1},
+			options: []AssertOption{
+				AssertWithStartTime(time.Now().Add(-time.Minute)),
+				AssertWithStableDuration(20 * time.Second),
+				AssertWithPollInterval(10 * time.Second),
+			},
+			expectedQueries: 5,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			api := &fakeAPI{values: testCase.values}
+
+			err := AssertQuery(context.TODO(), api, "up", AllEqual(1), testCase.options...)
+			assert.Equal(t, testCase.expectedError, err != nil)
+			assert.Equal(t, testCase.expectedQueries, api.queries)
+		})
+	}
+}
+
+func TestAssertOptions(t *testing.T) {
+	options := newAssertOptions()
+
+	AssertWithStableDuration(time.Minute)(options)
+	AssertWithTimeout(time.Second)(options)
+	AssertWithPollInterval(-time.Second)(options)
+
+	assert.Equal(t, time.Minute, options.timeout)
+	assert.Equal(t, time.Minute, options.stableDuration)
+	assert.Equal(t, DefaultPollInterval, options.pollInterval)
+}
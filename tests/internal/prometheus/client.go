@@ -0,0 +1,329 @@
+package prometheus
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	prometheusapi "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/config"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/rbac"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/route"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/secret"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/serviceaccount"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultNamespace is the namespace of the Thanos Querier and the default namespace for the ServiceAccount used
+	// to access it.
+	DefaultNamespace = "openshift-monitoring"
+	// DefaultServiceAccountName is the name of the ServiceAccount created to access the Thanos Querier when no other
+	// name is provided.
+	DefaultServiceAccountName = "eco-prometheus-querier"
+	// DefaultClusterRoleBindingName is the name of the ClusterRoleBinding created to access the Thanos Querier when
+	// no other name is provided.
+	DefaultClusterRoleBindingName = "eco-prometheus-querier-crb"
+	// DefaultTokenDuration is how long the ServiceAccount token is valid for when no other duration is provided.
+	DefaultTokenDuration = 24 * time.Hour
+
+	thanosQuerierName       = "thanos-querier"
+	monitoringViewRole      = "cluster-monitoring-view"
+	routerCASecretName      = "router-certs-default"
+	routerCASecretNamespace = "openshift-ingress"
+	routerCASecretKey       = "tls.crt"
+	defaultDialTimeout      = 10 * time.Second
+	defaultExecTimeout      = 60 * time.Second
+)
+
+// Transport is the way a Client reaches the Thanos Querier.
+type Transport string
+
+const (
+	// TransportRoute connects directly to the Thanos Querier route.
+	TransportRoute Transport = "route"
+	// TransportAPIServer connects to the Thanos Querier route by dialing the API server hostname. This is used when
+	// the route hostname does not resolve but the ingress shares an address with the API server, such as on SNO.
+	TransportAPIServer Transport = "apiserver"
+	// TransportExec sends requests with curl from inside a running Thanos Querier pod. It is used when the route is
+	// not reachable from the test runner at all.
+	TransportExec Transport = "exec"
+)
+
+// Client is a Prometheus API client for the Thanos Querier of a cluster. It embeds the prometheusv1.API so it can be
+// used anywhere the API is accepted.
+type Client struct {
+	prometheusv1.API
+
+	// Address is the hostname of the Thanos Querier route.
+	Address string
+	// Transport is the transport that was selected to reach the Thanos Querier.
+	Transport Transport
+
+	apiClient *clients.Settings
+	options   *clientOptions
+	created   createdResources
+}
+
+// createdResources records which of the ServiceAccount and ClusterRoleBinding were created by NewClient, rather than
+// already existing, so that Cleanup does not delete resources shared with other suites.
+type createdResources struct {
+	serviceAccount     bool
+	clusterRoleBinding bool
+}
+
+// clientOptions holds the options for NewClient. It is unexported since the ClientOption functions should be used to
+// configure it.
+type clientOptions struct {
+	namespace          string
+	serviceAccount     string
+	clusterRoleBinding string
+	tokenDuration      time.Duration
+	dialTimeout        time.Duration
+	transports         []Transport
+}
+
+// newClientOptions creates a new clientOptions struct with default values.
+func newClientOptions() *clientOptions {
+	return &clientOptions{
+		namespace:          DefaultNamespace,
+		serviceAccount:     DefaultServiceAccountName,
+		clusterRoleBinding: DefaultClusterRoleBindingName,
+		tokenDuration:      DefaultTokenDuration,
+		dialTimeout:        defaultDialTimeout,
+		transports:         []Transport{TransportRoute, TransportAPIServer, TransportExec},
+	}
+}
+
+// ClientOption is a function that configures NewClient and CleanupServiceAccount.
+type ClientOption func(*clientOptions)
+
+// WithServiceAccount sets the names of the ServiceAccount and ClusterRoleBinding used to access the Thanos Querier.
+// Suites should use their own names so that cleaning up after one suite does not affect another. Empty names are
+// ignored.
+func WithServiceAccount(serviceAccount, clusterRoleBinding string) ClientOption {
+	return func(options *clientOptions) {
+		if serviceAccount != "" {
+			options.serviceAccount = serviceAccount
+		}
+
+		if clusterRoleBinding != "" {
+			options.clusterRoleBinding = clusterRoleBinding
+		}
+	}
+}
+
+// WithNamespace sets the namespace of the Thanos Querier route and pods, which is also where the ServiceAccount is
+// created. If namespace is empty, it does nothing.
+func WithNamespace(namespace string) ClientOption {
+	return func(options *clientOptions) {
+		if namespace != "" {
+			options.namespace = namespace
+		}
+	}
+}
+
+// WithTokenDuration sets how long the ServiceAccount token is valid for. If duration is less than or equal to zero,
+// it does nothing.
+func WithTokenDuration(duration time.Duration) ClientOption {
+	return func(options *clientOptions) {
+		if duration > 0 {
+			options.tokenDuration = duration
+		}
+	}
+}
+
+// WithTransports restricts the transports NewClient tries, in order. By default, the route is tried first, then the
+// API server hostname, then exec. The last transport provided is used without checking it is reachable.
+func WithTransports(transports ...Transport) ClientOption {
+	return func(options *clientOptions) {
+		if len(transports) > 0 {
+			options.transports = transports
+		}
+	}
+}
+
+// NewClient creates a Client for the Thanos Querier of the cluster. It finds the address of the Thanos Querier
+// route, ensures a ServiceAccount and ClusterRoleBinding to cluster-monitoring-view exist, creates a token for the
+// ServiceAccount, and loads the CA pool of the default ingress router. It then selects the first reachable
+// transport, falling back to executing requests inside a Thanos Querier pod when the route cannot be reached.
+func NewClient(apiClient *clients.Settings, options ...ClientOption) (*Client, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot create prometheus client with nil apiClient")
+	}
+
+	opts := newClientOptions()
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	address, err := findQuerierAddress(apiClient, opts.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	saBuilder, created, err := ensureServiceAccount(apiClient, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := saBuilder.CreateToken(opts.tokenDuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus service account token: %w", err)
+	}
+
+	caPool, err := getRouterCAPool(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, roundTripper, err := selectTransport(apiClient, opts, address, caPool)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Using %s transport for thanos-querier at %s", transport, address)
+
+	client, err := prometheusapi.NewClient(prometheusapi.Config{
+		Address: "https://" + address,
+		RoundTripper: config.NewAuthorizationCredentialsRoundTripper(
+			"Bearer", config.NewInlineSecret(token), roundTripper),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client: %w", err)
+	}
+
+	return &Client{
+		API:       prometheusv1.NewAPI(client),
+		Address:   address,
+		Transport: transport,
+		apiClient: apiClient,
+		options:   opts,
+		created:   created,
+	}, nil
+}
+
+// Cleanup deletes the ServiceAccount and ClusterRoleBinding used by the client if NewClient created them. Resources
+// that already existed, such as those of another suite using the same names, are left in place. The client should
+// not be used after calling this.
+func (client *Client) Cleanup() error {
+	if client == nil || client.apiClient == nil {
+		return fmt.Errorf("cannot cleanup prometheus client that was not created by NewClient")
+	}
+
+	return cleanupServiceAccount(client.apiClient, client.options, client.created)
+}
+
+// CleanupServiceAccount deletes the ServiceAccount and ClusterRoleBinding that NewClient creates with the same
+// options. It is idempotent and will not fail if the resources do not exist. This is useful for suite cleanup when the
+// Client itself is not available.
+func CleanupServiceAccount(apiClient *clients.Settings, options ...ClientOption) error {
+	opts := newClientOptions()
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return cleanupServiceAccount(apiClient, opts, createdResources{serviceAccount: true, clusterRoleBinding: true})
+}
+
+// findQuerierAddress returns the hostname of the Thanos Querier route, without the scheme.
+func findQuerierAddress(apiClient *clients.Settings, namespace string) (string, error) {
+	routeBuilder, err := route.Pull(apiClient, thanosQuerierName, namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get thanos-querier route: %w", err)
+	}
+
+	if len(routeBuilder.Object.Status.Ingress) == 0 {
+		return "", fmt.Errorf("cannot find address for thanos-querier route: no ingresses found")
+	}
+
+	return routeBuilder.Object.Status.Ingress[0].Host, nil
+}
+
+// ensureServiceAccount ensures the ServiceAccount and ClusterRoleBinding exist, then returns the ServiceAccount and
+// which of the resources it created.
+func ensureServiceAccount(
+	apiClient *clients.Settings, opts *clientOptions) (*serviceaccount.Builder, createdResources, error) {
+	klog.V(100).Infof("Ensuring prometheus ServiceAccount %s/%s and ClusterRoleBinding %s exist",
+		opts.namespace, opts.serviceAccount, opts.clusterRoleBinding)
+
+	var created createdResources
+
+	// Create is equivalent to pulling if the resource already exists, so checking existence first is only needed to
+	// know whether cleaning up should delete the resource.
+	saBuilder := serviceaccount.NewBuilder(apiClient, opts.serviceAccount, opts.namespace)
+	created.serviceAccount = !saBuilder.Exists()
+
+	saBuilder, err := saBuilder.Create()
+	if err != nil {
+		return nil, created, fmt.Errorf("failed to create prometheus service account: %w", err)
+	}
+
+	saSubject := rbacv1.Subject{
+		Kind:      "ServiceAccount",
+		Name:      opts.serviceAccount,
+		Namespace: opts.namespace,
+	}
+
+	crbBuilder := rbac.NewClusterRoleBindingBuilder(apiClient, opts.clusterRoleBinding, monitoringViewRole, saSubject)
+	created.clusterRoleBinding = !crbBuilder.Exists()
+
+	_, err = crbBuilder.Create()
+	if err != nil {
+		return nil, created, fmt.Errorf("failed to create prometheus cluster role binding: %w", err)
+	}
+
+	return saBuilder, created, nil
+}
+
+// cleanupServiceAccount deletes the ClusterRoleBinding and ServiceAccount marked in created if they exist.
+func cleanupServiceAccount(apiClient *clients.Settings, opts *clientOptions, created createdResources) error {
+	if created.clusterRoleBinding {
+		crbBuilder, err := rbac.PullClusterRoleBinding(apiClient, opts.clusterRoleBinding)
+		if err == nil {
+			err = crbBuilder.Delete()
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete prometheus cluster role binding: %w", err)
+			}
+		}
+	}
+
+	if created.serviceAccount {
+		saBuilder, err := serviceaccount.Pull(apiClient, opts.serviceAccount, opts.namespace)
+		if err == nil {
+			err = saBuilder.Delete()
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete prometheus service account: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getRouterCAPool returns the system CA pool with the CA of the default ingress router appended.
+func getRouterCAPool(apiClient *clients.Settings) (*x509.CertPool, error) {
+	secretBuilder, err := secret.Pull(apiClient, routerCASecretName, routerCASecretNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull default router CA secret: %w", err)
+	}
+
+	caPool, err := x509.SystemCertPool()
+	if err != nil {
+		klog.V(100).Infof("Failed to load system CA pool, using empty pool: %v", err)
+
+		caPool = x509.NewCertPool()
+	}
+
+	if !caPool.AppendCertsFromPEM(secretBuilder.Object.Data[routerCASecretKey]) {
+		return nil, fmt.Errorf("failed to append default router CA to pool")
+	}
+
+	return caPool, nil
+}
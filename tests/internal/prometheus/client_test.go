@@ -0,0 +1,83 @@
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/rbac"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/serviceaccount"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestServiceAccountCleanup(t *testing.T) {
+	testCases := []struct {
+		name            string
+		existing        []runtime.Object
+		expectedCreated createdResources
+	}{
+		{
+			name:            "created by client",
+			expectedCreated: createdResources{serviceAccount: true, clusterRoleBinding: true},
+		},
+		{
+			name: "shared with another suite",
+			existing: []runtime.Object{
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+					Name: DefaultServiceAccountName, Namespace: DefaultNamespace}},
+				&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: DefaultClusterRoleBindingName}},
+			},
+		},
+		{
+			name: "only service account shared",
+			existing: []runtime.Object{
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+					Name: DefaultServiceAccountName, Namespace: DefaultNamespace}},
+			},
+			expectedCreated: createdResources{clusterRoleBinding: true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := clients.GetTestClients(clients.TestClientParams{
+				K8sMockObjects: append(testCase.existing,
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: DefaultNamespace}}),
+			})
+			opts := newClientOptions()
+
+			_, created, err := ensureServiceAccount(apiClient, opts)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedCreated, created)
+
+			client := &Client{apiClient: apiClient, options: opts, created: created}
+			assert.Nil(t, client.Cleanup())
+
+			assert.Equal(t, !created.serviceAccount,
+				serviceaccount.NewBuilder(apiClient, opts.serviceAccount, opts.namespace).Exists())
+
+			_, err = rbac.PullClusterRoleBinding(apiClient, opts.clusterRoleBinding)
+			assert.Equal(t, !created.clusterRoleBinding, err == nil)
+		})
+	}
+}
+
+func TestCleanupServiceAccount(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "suite-querier", Namespace: DefaultNamespace}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "suite-querier-crb"}},
+		},
+	})
+
+	err := CleanupServiceAccount(apiClient, WithServiceAccount("suite-querier", "suite-querier-crb"))
+	assert.Nil(t, err)
+
+	assert.False(t, serviceaccount.NewBuilder(apiClient, "suite-querier", DefaultNamespace).Exists())
+
+	_, err = rbac.PullClusterRoleBinding(apiClient, "suite-querier-crb")
+	assert.NotNil(t, err)
+}
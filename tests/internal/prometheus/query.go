@@ -0,0 +1,109 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/klog/v2"
+)
+
+// QueryVector executes an instant query at queryTime and returns the result as a model.Vector. If queryTime is zero,
+// the current time is used. Any warnings returned by the query are logged.
+//
+// SECURITY: This function does not perform any sort of sanitization on the query. It should only be used with trusted
+// queries.
+func QueryVector(
+	ctx context.Context, client prometheusv1.API, query string, queryTime time.Time) (model.Vector, error) {
+	if client == nil {
+		return nil, fmt.Errorf("cannot execute query with nil client")
+	}
+
+	if queryTime.IsZero() {
+		queryTime = time.Now()
+	}
+
+	klog.V(100).Infof("Executing query at %s: %s", queryTime, query)
+
+	result, warnings, err := client.Query(ctx, query, queryTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query %s: %w", query, err)
+	}
+
+	logWarnings(warnings)
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type for query %s: %s", query, result.Type())
+	}
+
+	return vector, nil
+}
+
+// QueryMatrix executes a range query over queryRange and returns the result as a model.Matrix. Any warnings returned
+// by the query are logged.
+//
+// SECURITY: This function does not perform any sort of sanitization on the query. It should only be used with trusted
+// queries.
+func QueryMatrix(
+	ctx context.Context, client prometheusv1.API, query string, queryRange prometheusv1.Range) (model.Matrix, error) {
+	if client == nil {
+		return nil, fmt.Errorf("cannot execute query range with nil client")
+	}
+
+	klog.V(100).Infof("Executing query range from %s to %s: %s", queryRange.Start, queryRange.End, query)
+
+	result, warnings, err := client.QueryRange(ctx, query, queryRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query range %s: %w", query, err)
+	}
+
+	logWarnings(warnings)
+
+	matrix, ok := result.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type for query range %s: %s", query, result.Type())
+	}
+
+	return matrix, nil
+}
+
+// QueryScalar executes an instant query at the current time and returns the value of its only sample. It returns an
+// error if the query does not return exactly one sample.
+//
+// SECURITY: This function does not perform any sort of sanitization on the query. It should only be used with trusted
+// queries.
+func QueryScalar(ctx context.Context, client prometheusv1.API, query string) (float64, error) {
+	vector, err := QueryVector(ctx, client, query, time.Time{})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(vector) != 1 {
+		return 0, fmt.Errorf("expected exactly one sample for query %s, got %d", query, len(vector))
+	}
+
+	return float64(vector[0].Value), nil
+}
+
+// MetricExists returns true if the query returns at least one sample at the current time.
+//
+// SECURITY: This function does not perform any sort of sanitization on the query. It should only be used with trusted
+// queries.
+func MetricExists(ctx context.Context, client prometheusv1.API, query string) (bool, error) {
+	vector, err := QueryVector(ctx, client, query, time.Time{})
+	if err != nil {
+		return false, err
+	}
+
+	return len(vector) > 0, nil
+}
+
+// logWarnings logs the warnings returned by a query.
+func logWarnings(warnings prometheusv1.Warnings) {
+	for _, warning := range warnings {
+		klog.V(100).Infof("Query returned warning: %s", warning)
+	}
+}
@@ -0,0 +1,311 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
+)
+
+const (
+	routePort = "443"
+	// thanosQuerierServicePort is the port of the Thanos Querier service that requires a bearer token with the
+	// cluster-monitoring-view role, matching the route.
+	thanosQuerierServicePort = 9091
+	thanosQuerierPodLabel    = "app.kubernetes.io/name=thanos-query"
+	thanosQuerierContainer   = "thanos-query"
+)
+
+// curlCommand runs curl with its options read from stdin, keeping headers such as the bearer token out of argv.
+var curlCommand = []string{"curl", "--config", "-"}
+
+// curlConfigEscaper escapes the characters that have special meaning in a double quoted curl config string.
+var curlConfigEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// selectTransport returns the first transport in opts that can reach the Thanos Querier along with the round tripper
+// for it. The last transport is returned without checking it can be reached.
+func selectTransport(
+	apiClient *clients.Settings,
+	opts *clientOptions,
+	address string,
+	caPool *x509.CertPool) (Transport, http.RoundTripper, error) {
+	for index, transport := range opts.transports {
+		isLast := index == len(opts.transports)-1
+
+		switch transport {
+		case TransportRoute:
+			if isLast || canDial(net.JoinHostPort(address, routePort), opts) {
+				return transport, &http.Transport{TLSClientConfig: &tls.Config{RootCAs: caPool}}, nil
+			}
+		case TransportAPIServer:
+			dialHost, err := getAPIServerHostname(apiClient)
+			if err != nil {
+				klog.V(100).Infof("Skipping %s transport: %v", transport, err)
+
+				continue
+			}
+
+			if isLast || canDial(net.JoinHostPort(dialHost, routePort), opts) {
+				return transport, newAPIServerTransport(dialHost, address, caPool, opts), nil
+			}
+		case TransportExec:
+			return transport, newExecRoundTripper(apiClient, opts.namespace), nil
+		default:
+			return "", nil, fmt.Errorf("unknown prometheus transport %q", transport)
+		}
+
+		klog.V(100).Infof("Thanos-querier is not reachable using %s transport", transport)
+	}
+
+	return "", nil, fmt.Errorf("thanos-querier at %s is not reachable using transports %v", address, opts.transports)
+}
+
+// canDial returns true if a TCP connection can be made to hostPort within the dial timeout.
+func canDial(hostPort string, opts *clientOptions) bool {
+	conn, err := net.DialTimeout("tcp", hostPort, opts.dialTimeout)
+	if err != nil {
+		klog.V(100).Infof("Failed to dial %s: %v", hostPort, err)
+
+		return false
+	}
+
+	_ = conn.Close()
+
+	return true
+}
+
+// getAPIServerHostname returns the hostname or IP of the API server from the client config.
+func getAPIServerHostname(apiClient *clients.Settings) (string, error) {
+	if apiClient.Config == nil {
+		return "", fmt.Errorf("api client has no rest config")
+	}
+
+	apiURL, err := url.Parse(apiClient.Config.Host)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse API server URL %q: %w", apiClient.Config.Host, err)
+	}
+
+	if apiURL.Hostname() == "" {
+		return "", fmt.Errorf("api server URL %q has no hostname", apiClient.Config.Host)
+	}
+
+	return apiURL.Hostname(), nil
+}
+
+// newAPIServerTransport returns a transport that dials dialHost for every request while still verifying the route
+// certificate for address.
+func newAPIServerTransport(
+	dialHost, address string, caPool *x509.CertPool, opts *clientOptions) *http.Transport {
+	dialer := &net.Dialer{Timeout: opts.dialTimeout}
+
+	return &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: caPool, ServerName: address},
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			_, port, err := net.SplitHostPort(addr)
+			if err != nil || port == "" {
+				port = routePort
+			}
+
+			return dialer.DialContext(ctx, network, net.JoinHostPort(dialHost, port))
+		},
+	}
+}
+
+// podExecFunc runs command in the Thanos Querier pod with stdin and returns stdout and stderr.
+type podExecFunc func(ctx context.Context, command []string, stdin io.Reader) (stdout, stderr string, err error)
+
+// execRoundTripper is an http.RoundTripper that sends each request using curl from inside a running Thanos Querier
+// pod, addressing the Thanos Querier service rather than the route.
+type execRoundTripper struct {
+	serviceURL string
+	exec       podExecFunc
+}
+
+// newExecRoundTripper creates an execRoundTripper for the Thanos Querier in namespace.
+func newExecRoundTripper(apiClient *clients.Settings, namespace string) *execRoundTripper {
+	return &execRoundTripper{
+		serviceURL: fmt.Sprintf("https://%s.%s.svc:%d", thanosQuerierName, namespace, thanosQuerierServicePort),
+		exec: func(ctx context.Context, command []string, stdin io.Reader) (string, string, error) {
+			return execInQuerierPod(ctx, apiClient, namespace, command, stdin)
+		},
+	}
+}
+
+// RoundTrip sends the request with curl and converts its output back into a response. The request, including its
+// authorization header and body, is passed to curl as a config on stdin so the token never appears in the pod's
+// process list or in returned errors.
+func (roundTripper *execRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, defaultExecTimeout)
+		defer cancel()
+	}
+
+	var body []byte
+
+	if request.Body != nil {
+		defer func() {
+			_ = request.Body.Close()
+		}()
+
+		var err error
+
+		body, err = io.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s request body: %w", request.Method, err)
+		}
+	}
+
+	curlConfig := buildCurlConfig(request, roundTripper.serviceURL, body)
+
+	stdout, stderr, err := roundTripper.exec(ctx, curlCommand, strings.NewReader(curlConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exec %s request in thanos-querier pod: %w, stderr: %s",
+			request.Method, err, strings.TrimSpace(stderr))
+	}
+
+	statusCode, body, err := parseCurlOutput(stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+// buildCurlConfig returns the curl config, read by curlCommand from stdin, that sends request with body to serviceURL.
+// The status code is written on its own line after the body so it can be split off by parseCurlOutput.
+func buildCurlConfig(request *http.Request, serviceURL string, body []byte) string {
+	lines := []string{
+		"silent",
+		"show-error",
+		"insecure",
+		"request = " + quoteCurlConfig(request.Method),
+		"write-out = " + quoteCurlConfig("\n%{http_code}"),
+	}
+
+	for name, values := range request.Header {
+		for _, value := range values {
+			lines = append(lines, "header = "+quoteCurlConfig(fmt.Sprintf("%s: %s", name, value)))
+		}
+	}
+
+	if len(body) > 0 {
+		lines = append(lines, "data-raw = "+quoteCurlConfig(string(body)))
+	}
+
+	lines = append(lines, "url = "+quoteCurlConfig(serviceURL+request.URL.RequestURI()))
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// quoteCurlConfig returns value as a double quoted curl config string, escaping the characters curl unescapes.
+func quoteCurlConfig(value string) string {
+	return `"` + curlConfigEscaper.Replace(value) + `"`
+}
+
+// parseCurlOutput splits the output of the curl command from buildCurlCommand into the status code and body.
+func parseCurlOutput(output string) (int, []byte, error) {
+	separator := strings.LastIndex(output, "\n")
+	if separator < 0 {
+		return 0, nil, fmt.Errorf("failed to find status code in curl output: %q", output)
+	}
+
+	statusCode, err := strconv.Atoi(strings.TrimSpace(output[separator+1:]))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to parse status code in curl output: %w", err)
+	}
+
+	if statusCode == 0 {
+		return 0, nil, fmt.Errorf("curl did not receive a response from thanos-querier")
+	}
+
+	return statusCode, []byte(output[:separator]), nil
+}
+
+// execInQuerierPod runs command with stdin in the first running Thanos Querier pod in namespace. Unlike
+// pod.Builder.ExecCommand, it does not allocate a TTY, so stdout is not altered and stderr is kept separate.
+func execInQuerierPod(
+	ctx context.Context,
+	apiClient *clients.Settings,
+	namespace string,
+	command []string,
+	stdin io.Reader) (string, string, error) {
+	podList, err := apiClient.CoreV1Interface.Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: thanosQuerierPodLabel,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list thanos-querier pods: %w", err)
+	}
+
+	podName := ""
+
+	for _, querierPod := range podList.Items {
+		if querierPod.Status.Phase == corev1.PodRunning {
+			podName = querierPod.Name
+
+			break
+		}
+	}
+
+	if podName == "" {
+		return "", "", fmt.Errorf("no running thanos-querier pods found in namespace %s", namespace)
+	}
+
+	request := apiClient.CoreV1Interface.RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: thanosQuerierContainer,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(apiClient.Config, "POST", request.URL())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create executor for pod %s: %w", podName, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return stdout.String(), stderr.String(), fmt.Errorf("failed to exec in pod %s: %w", podName, err)
+	}
+
+	return stdout.String(), stderr.String(), nil
+}
@@ -0,0 +1,146 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	prometheusapi "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCurlOutput(t *testing.T) {
+	testCases := []struct {
+		name           string
+		output         string
+		expectedStatus int
+		expectedBody   string
+		expectedError  bool
+	}{
+		{
+			name:           "success",
+			output:         "{\"status\":\"success\"}\n200",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"success"}`,
+		},
+		{
+			name:           "body with newlines",
+			output:         "line one\nline two\n403",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "line one\nline two",
+		},
+		{
+			name:          "no status",
+			output:        "no status code",
+			expectedError: true,
+		},
+		{
+			name:          "no response",
+			output:        "\n000",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			status, body, err := parseCurlOutput(testCase.output)
+
+			assert.Equal(t, testCase.expectedError, err != nil)
+			assert.Equal(t, testCase.expectedStatus, status)
+
+			if !testCase.expectedError {
+				assert.Equal(t, testCase.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestExecRoundTripperQuery(t *testing.T) {
+	var (
+		receivedCommand []string
+		receivedConfig  string
+	)
+
+	roundTripper := &execRoundTripper{
+		serviceURL: "https://thanos-querier.openshift-monitoring.svc:9091",
+		exec: func(ctx context.Context, command []string, stdin io.Reader) (string, string, error) {
+			receivedCommand = command
+
+			body, err := io.ReadAll(stdin)
+			if err != nil {
+				return "", "", err
+			}
+
+			receivedConfig = string(body)
+
+			return `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"__name__":"up"},"value":[1700000000,"1"]}]}}` + "\n200", "", nil
+		},
+	}
+
+	client, err := prometheusapi.NewClient(prometheusapi.Config{
+		Address:      "https://thanos-querier.example.com",
+		RoundTripper: roundTripper,
+	})
+	assert.Nil(t, err)
+
+	vector, err := QueryVector(context.TODO(), prometheusv1.NewAPI(client), "up", time.Unix(1700000000, 0))
+	assert.Nil(t, err)
+	assert.Len(t, vector, 1)
+	assert.Equal(t, model.SampleValue(1), vector[0].Value)
+
+	assert.Equal(t, []string{"curl", "--config", "-"}, receivedCommand)
+	assert.Contains(t, receivedConfig, "data-raw = \"query=up")
+	assert.Contains(t, receivedConfig,
+		"url = \"https://thanos-querier.openshift-monitoring.svc:9091/api/v1/query")
+}
+
+func TestBuildCurlConfig(t *testing.T) {
+	request, err := http.NewRequest(http.MethodPost, "https://thanos-querier.example.com/api/v1/query?dedup=true", nil)
+	assert.Nil(t, err)
+
+	request.Header.Set("Authorization", "Bearer secret-token")
+
+	curlConfig := buildCurlConfig(request, "https://thanos-querier.openshift-monitoring.svc:9091",
+		[]byte("query=sum(up{job=\"a\\b\"})\n"))
+
+	assert.Equal(t, strings.Join([]string{
+		"silent",
+		"show-error",
+		"insecure",
+		`request = "POST"`,
+		`write-out = "\n%{http_code}"`,
+		`header = "Authorization: Bearer secret-token"`,
+		`data-raw = "query=sum(up{job=\"a\\b\"})\n"`,
+		`url = "https://thanos-querier.openshift-monitoring.svc:9091/api/v1/query?dedup=true"`,
+	}, "\n")+"\n", curlConfig)
+}
+
+func TestExecRoundTripperError(t *testing.T) {
+	roundTripper := &execRoundTripper{
+		serviceURL: "https://thanos-querier.openshift-monitoring.svc:9091",
+		exec: func(context.Context, []string, io.Reader) (string, string, error) {
+			return "", "curl: (6) Could not resolve host", fmt.Errorf("command terminated with exit code 6")
+		},
+	}
+
+	request, err := http.NewRequest(http.MethodGet, "https://thanos-querier.example.com/api/v1/query", nil)
+	assert.Nil(t, err)
+
+	request.Header.Set("Authorization", "Bearer secret-token")
+
+	response, err := roundTripper.RoundTrip(request)
+	if response != nil {
+		_ = response.Body.Close()
+	}
+
+	assert.NotNil(t, err)
+	assert.ErrorContains(t, err, "Could not resolve host")
+	assert.NotContains(t, err.Error(), "secret-token")
+}
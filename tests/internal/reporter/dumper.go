@@ -0,0 +1,339 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift-kni/k8sreporter"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// namespaceDumpTimeout is the deadline for dumping a single namespace, or the cluster scoped resources.
+	namespaceDumpTimeout = 5 * time.Minute
+	// logsSinceMargin is how far before the start of the spec to collect pod logs and events from.
+	logsSinceMargin = 5 * time.Second
+
+	fileSeparator = "-----------------------------------\n"
+)
+
+// dumper dumps the cluster resources for a failed spec to a bundle directory, using the same file layout as
+// k8sreporter. Unlike k8sreporter, every request uses the provided context, so dumps are bounded by a deadline and
+// client logging is scoped to the logger in the context rather than the global verbosity.
+type dumper struct {
+	coreClient    corev1client.CoreV1Interface
+	runtimeClient runtimeclient.Client
+	scheme        *runtime.Scheme
+	bundleDir     string
+	since         time.Time
+}
+
+// newDumper creates a dumper for the cluster specified by kubeconfig, falling back to the KUBECONFIG environment
+// variable then the in-cluster config if it is empty. Pod logs and events are dumped starting from since. The bundle
+// directory must already exist.
+func newDumper(kubeconfig, bundleDir string, scheme *runtime.Scheme, since time.Time) (*dumper, error) {
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+	}
+
+	var (
+		config *rest.Config
+		err    error
+	)
+
+	if kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig %q: %w", kubeconfig, err)
+	}
+
+	coreClient, err := corev1client.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create core client: %w", err)
+	}
+
+	runtimeClient, err := runtimeclient.New(config, runtimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runtime client: %w", err)
+	}
+
+	return &dumper{
+		coreClient:    coreClient,
+		runtimeClient: runtimeClient,
+		scheme:        scheme,
+		bundleDir:     bundleDir,
+		since:         since.Add(-logsSinceMargin),
+	}, nil
+}
+
+// Dump concurrently dumps the nodes and cluster scoped CRs, and each of the provided namespaces along with the CRs in
+// it. Each scope has its own deadline. All errors are returned joined together rather than stopping the dump, so one
+// failing scope does not prevent the others from being dumped. Client logging is discarded unless ctx already
+// contains a logger.
+func (dumper *dumper) Dump(ctx context.Context, namespaces []string, cRDs []k8sreporter.CRData) error {
+	if _, err := logr.FromContext(ctx); err != nil {
+		ctx = klog.NewContext(ctx, logr.Discard())
+	}
+
+	scopes := map[string][]k8sreporter.CRData{"": nil}
+
+	for _, namespace := range namespaces {
+		scopes[namespace] = nil
+	}
+
+	for _, crData := range cRDs {
+		namespace := ""
+		if crData.Namespace != nil {
+			namespace = *crData.Namespace
+		}
+
+		scopes[namespace] = append(scopes[namespace], crData)
+	}
+
+	var (
+		waitGroup sync.WaitGroup
+		mutex     sync.Mutex
+		errs      []error
+	)
+
+	for namespace, scopeCRDs := range scopes {
+		waitGroup.Go(func() {
+			scopeCtx, cancel := context.WithTimeout(ctx, namespaceDumpTimeout)
+			defer cancel()
+
+			err := dumper.dumpScope(scopeCtx, namespace, slices.Contains(namespaces, namespace), scopeCRDs)
+			if err != nil {
+				mutex.Lock()
+				errs = append(errs, err)
+				mutex.Unlock()
+			}
+		})
+	}
+
+	waitGroup.Wait()
+
+	return errors.Join(errs...)
+}
+
+// ClusterVersion returns the desired version of the cluster. It returns an error on clusters that are not OpenShift.
+func (dumper *dumper) ClusterVersion(ctx context.Context) (string, error) {
+	if _, err := logr.FromContext(ctx); err != nil {
+		ctx = klog.NewContext(ctx, logr.Discard())
+	}
+
+	clusterVersion := &configv1.ClusterVersion{}
+
+	err := dumper.runtimeClient.Get(ctx, runtimeclient.ObjectKey{Name: "version"}, clusterVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster version: %w", err)
+	}
+
+	return clusterVersion.Status.Desired.Version, nil
+}
+
+// dumpScope dumps a single namespace, or the nodes if namespace is empty. Pods and events are only dumped if
+// includePods is true, which allows CRs to be dumped from namespaces that were not requested.
+func (dumper *dumper) dumpScope(
+	ctx context.Context, namespace string, includePods bool, cRDs []k8sreporter.CRData) error {
+	var errs []error
+
+	if namespace == "" {
+		errs = append(errs, dumper.dumpNodes(ctx))
+	}
+
+	if includePods {
+		errs = append(errs, dumper.dumpPods(ctx, namespace), dumper.dumpEvents(ctx, namespace))
+	}
+
+	for _, crData := range cRDs {
+		errs = append(errs, dumper.dumpCRs(ctx, crData))
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && namespace != "" {
+		return fmt.Errorf("failed to dump namespace %s: %w", namespace, err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to dump cluster scoped resources: %w", err)
+	}
+
+	return nil
+}
+
+// dumpNodes dumps all nodes to the nodes file.
+func (dumper *dumper) dumpNodes(ctx context.Context) error {
+	nodes, err := dumper.coreClient.Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	return dumper.writeJSONFile("nodes", fileSeparator, nodes)
+}
+
+// dumpPods dumps the spec and logs of every pod in namespace, with the logs of each container since the start of the
+// spec. Failures to get logs are ignored since containers that have not started or restarted have no logs.
+func (dumper *dumper) dumpPods(ctx context.Context, namespace string) error {
+	pods, err := dumper.coreClient.Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		filePrefix := pod.Namespace + "_" + pod.Name
+
+		err = dumper.writeJSONFile(filePrefix+"_pods_specs", "", pod)
+		if err != nil {
+			return err
+		}
+
+		err = dumper.writeFile(filePrefix+"_pods_logs", func(writer io.Writer) error {
+			dumper.writePodLogs(ctx, writer, pod)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("stopped dumping pods: %w", ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+// writePodLogs writes the current and previous logs of every container in pod to writer.
+func (dumper *dumper) writePodLogs(ctx context.Context, writer io.Writer, pod corev1.Pod) {
+	logStart := metav1.NewTime(dumper.since)
+	containers := slices.Concat(pod.Spec.Containers, pod.Spec.InitContainers)
+
+	for _, container := range containers {
+		for _, previous := range []bool{false, true} {
+			logOptions := &corev1.PodLogOptions{Container: container.Name, SinceTime: &logStart, Previous: previous}
+
+			logs, err := dumper.coreClient.Pods(pod.Namespace).GetLogs(pod.Name, logOptions).DoRaw(ctx)
+			if err != nil {
+				continue
+			}
+
+			description := "logs"
+			if previous {
+				description = "previous logs"
+			}
+
+			_, _ = fmt.Fprintf(writer, "%sDumping %s for pod %s-%s-%s\n%s\n",
+				fileSeparator, description, pod.Namespace, pod.Name, container.Name, logs)
+		}
+	}
+}
+
+// dumpEvents dumps the events in namespace created since the start of the spec.
+func (dumper *dumper) dumpEvents(ctx context.Context, namespace string) error {
+	events, err := dumper.coreClient.Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return dumper.writeFile(namespace+"_events", func(writer io.Writer) error {
+		_, _ = fmt.Fprintf(writer, "%sDumping events for namespace %s\n", fileSeparator, namespace)
+
+		for _, event := range events.Items {
+			if event.CreationTimestamp.Time.Before(dumper.since) {
+				continue
+			}
+
+			contents, err := json.MarshalIndent(event, "", "    ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal event %s: %w", event.Name, err)
+			}
+
+			_, _ = fmt.Fprintln(writer, string(contents))
+		}
+
+		return nil
+	})
+}
+
+// dumpCRs dumps the CRs in crData to a file named after their kind and namespace. As with k8sreporter, failing to
+// list the CRs is recorded in the file rather than returned since the operator for them may not be installed.
+func (dumper *dumper) dumpCRs(ctx context.Context, crData k8sreporter.CRData) error {
+	fileName := getCRKind(dumper.scheme, crData.Cr)
+
+	var options []runtimeclient.ListOption
+
+	if crData.Namespace != nil {
+		fileName = fmt.Sprintf("%s_%s", fileName, *crData.Namespace)
+		options = append(options, runtimeclient.InNamespace(*crData.Namespace))
+	}
+
+	crList := crData.Cr.DeepCopyObject().(runtimeclient.ObjectList)
+
+	err := dumper.runtimeClient.List(ctx, crList, options...)
+	if err != nil {
+		return dumper.writeFile(fileName, func(writer io.Writer) error {
+			_, _ = fmt.Fprintf(writer, "Failed to fetch %T: %v\n", crList, err)
+
+			return nil
+		})
+	}
+
+	return dumper.writeJSONFile(fileName, "", crList)
+}
+
+// writeJSONFile appends prefix and the indented JSON of object to the named file in the bundle directory.
+func (dumper *dumper) writeJSONFile(name, prefix string, object any) error {
+	contents, err := json.MarshalIndent(object, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+
+	return dumper.writeFile(name, func(writer io.Writer) error {
+		_, err := fmt.Fprintf(writer, "%s%s\n", prefix, contents)
+
+		return err
+	})
+}
+
+// writeFile opens the named log file in the bundle directory for appending and calls write with it.
+func (dumper *dumper) writeFile(name string, write func(writer io.Writer) error) error {
+	path := filepath.Join(dumper.bundleDir, name+".log")
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	err = write(file)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
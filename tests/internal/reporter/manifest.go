@@ -0,0 +1,236 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/openshift-kni/k8sreporter"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	// ManifestFileName is the name of the manifest file written to each failure bundle.
+	ManifestFileName = "manifest.json"
+	// IndexFileName is the name of the index file in the reports directory. Each line is a JSON encoded IndexEntry,
+	// so that specs from parallel processes and separate suites can append to it safely.
+	IndexFileName = "failure_index.jsonl"
+
+	// unknownKind matches the file name k8sreporter uses for CRs whose kind cannot be determined.
+	unknownKind = "lostfound"
+)
+
+// Manifest describes the failure bundle dumped for a single failed spec.
+type Manifest struct {
+	SpecText        string     `json:"specText"`
+	Suite           string     `json:"suite"`
+	Labels          []string   `json:"labels"`
+	State           string     `json:"state"`
+	FailureLocation string     `json:"failureLocation"`
+	FailureMessage  string     `json:"failureMessage"`
+	StartTime       time.Time  `json:"startTime"`
+	EndTime         time.Time  `json:"endTime"`
+	ClusterVersion  string     `json:"clusterVersion,omitempty"`
+	Namespaces      []string   `json:"namespaces"`
+	CRs             []DumpedCR `json:"crs"`
+	Files           []string   `json:"files"`
+}
+
+// DumpedCR is a single CR list dumped to the failure bundle. Namespace is empty for CRs dumped across all namespaces.
+type DumpedCR struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// IndexEntry is a single failed spec in the index file. Paths are relative to the reports directory.
+type IndexEntry struct {
+	SpecText        string    `json:"specText"`
+	Suite           string    `json:"suite"`
+	FailureLocation string    `json:"failureLocation"`
+	FailureMessage  string    `json:"failureMessage"`
+	EndTime         time.Time `json:"endTime"`
+	BundleDir       string    `json:"bundleDir"`
+	Manifest        string    `json:"manifest"`
+}
+
+// newManifest creates a manifest for the failed spec in report, without the cluster version or files.
+func newManifest(
+	report types.SpecReport,
+	testSuite string,
+	nSpaces map[string]string,
+	cRDs []k8sreporter.CRData,
+	scheme *runtime.Scheme) *Manifest {
+	manifest := &Manifest{
+		SpecText:        report.FullText(),
+		Suite:           testSuite,
+		Labels:          report.Labels(),
+		State:           report.State.String(),
+		FailureLocation: report.Failure.Location.String(),
+		FailureMessage:  report.Failure.Message,
+		StartTime:       report.StartTime,
+		EndTime:         report.EndTime,
+		Namespaces:      []string{},
+		CRs:             []DumpedCR{},
+		Files:           []string{},
+	}
+
+	for namespace := range nSpaces {
+		manifest.Namespaces = append(manifest.Namespaces, namespace)
+	}
+
+	slices.Sort(manifest.Namespaces)
+
+	for _, crData := range cRDs {
+		dumpedCR := DumpedCR{Kind: getCRKind(scheme, crData.Cr)}
+		if crData.Namespace != nil {
+			dumpedCR.Namespace = *crData.Namespace
+		}
+
+		manifest.CRs = append(manifest.CRs, dumpedCR)
+	}
+
+	return manifest
+}
+
+// newCRScheme returns the scheme used for dumping CRs and determining their kinds.
+func newCRScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+
+	err := clientgoscheme.AddToScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setReporterSchemes(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheme, nil
+}
+
+// getCRKind returns the kind of the provided CR list, matching the file name used by k8sreporter.
+func getCRKind(scheme *runtime.Scheme, crList runtime.Object) string {
+	if scheme == nil || crList == nil {
+		return unknownKind
+	}
+
+	gvks, _, err := scheme.ObjectKinds(crList)
+	if err != nil {
+		return unknownKind
+	}
+
+	for _, gvk := range gvks {
+		if gvk.Kind != "" && gvk.Version != "" && gvk.Version != runtime.APIVersionInternal {
+			return gvk.Kind
+		}
+	}
+
+	return unknownKind
+}
+
+// listBundleFiles returns the paths of all regular files in bundleDir relative to it, excluding the manifest.
+func listBundleFiles(bundleDir string) ([]string, error) {
+	files := []string{}
+
+	err := filepath.WalkDir(bundleDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(bundleDir, path)
+		if err != nil {
+			return err
+		}
+
+		if relativePath != ManifestFileName {
+			files = append(files, relativePath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in bundle %s: %w", bundleDir, err)
+	}
+
+	return files, nil
+}
+
+// writeManifest fills in the file list of manifest from bundleDir then writes it to the manifest file in bundleDir.
+func writeManifest(bundleDir string, manifest *Manifest) error {
+	files, err := listBundleFiles(bundleDir)
+	if err != nil {
+		return err
+	}
+
+	manifest.Files = files
+
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(bundleDir, ManifestFileName), contents, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest to bundle %s: %w", bundleDir, err)
+	}
+
+	return nil
+}
+
+// appendIndexEntry appends an entry for the bundle in bundleDir to the index file in reportsDir.
+func appendIndexEntry(reportsDir, bundleDir string, manifest *Manifest) error {
+	relativeBundleDir, err := filepath.Rel(reportsDir, bundleDir)
+	if err != nil {
+		return fmt.Errorf("failed to get bundle path relative to %s: %w", reportsDir, err)
+	}
+
+	entry := IndexEntry{
+		SpecText:        manifest.SpecText,
+		Suite:           manifest.Suite,
+		FailureLocation: manifest.FailureLocation,
+		FailureMessage:  manifest.FailureMessage,
+		EndTime:         manifest.EndTime,
+		BundleDir:       relativeBundleDir,
+		Manifest:        filepath.Join(relativeBundleDir, ManifestFileName),
+	}
+
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index entry: %w", err)
+	}
+
+	indexFile, err := os.OpenFile(filepath.Join(reportsDir, IndexFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open index file in %s: %w", reportsDir, err)
+	}
+
+	defer func() {
+		_ = indexFile.Close()
+	}()
+
+	// A single write per entry keeps lines from concurrent writers from interleaving.
+	_, err = indexFile.Write(append(contents, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write index entry: %w", err)
+	}
+
+	return nil
+}
+
+// getBundleDirName returns the name of the failure bundle directory for the spec with the provided text. It matches the
+// directory name used by k8sreporter so bundle paths are unchanged.
+func getBundleDirName(specText string) string {
+	return strings.NewReplacer("/", "-", " ", "_").Replace(specText)
+}
@@ -1,21 +1,24 @@
 package reporter
 
 import (
+	"context"
 	"errors"
-	"flag"
 	"fmt"
 	"io"
 	"os"
-	"path"
-	"strings"
+	"path/filepath"
+	"time"
 
+	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/types"
 	"github.com/openshift-kni/k8sreporter"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 )
 
+// DumpErrorsReportEntryName is the name of the report entry added to the spec report when dumping it fails.
+const DumpErrorsReportEntryName = "Failure dump errors"
+
 var (
 	pathToPodExecLogs = "/tmp/pod_exec_logs.log"
 	// generalCfg holds the configuration for reporter operations.
@@ -37,33 +40,6 @@ func SetGeneralConfig(cfg *config.GeneralConfig) {
 	generalCfg = cfg
 }
 
-func newReporter(
-	reportPath,
-	kubeconfig string,
-	namespacesToDump map[string]string,
-	apiScheme func(scheme *runtime.Scheme) error,
-	cRDs []k8sreporter.CRData) (*k8sreporter.KubernetesReporter, error) {
-	nsToDumpFilter := func(ns string) bool {
-		_, found := namespacesToDump[ns]
-
-		return found
-	}
-
-	if _, err := os.Stat(reportPath); os.IsNotExist(err) {
-		err := os.MkdirAll(reportPath, 0755)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	res, err := k8sreporter.New(kubeconfig, apiScheme, nsToDumpFilter, reportPath, cRDs...)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
 // ReportIfFailed dumps requested cluster CRs if TC is failed to the given directory.
 func ReportIfFailed(
 	report types.SpecReport,
@@ -74,55 +50,106 @@ func ReportIfFailed(
 }
 
 // ReportIfFailedOnCluster dumps the requested cluster CRs on the cluster specified by kubeconfig if TC is failed to the
-// given directory.
+// given directory. Errors while dumping do not stop the test run, instead they are logged and attached to the spec
+// report as a report entry.
 func ReportIfFailedOnCluster(
 	kubeconfig string,
 	report types.SpecReport,
 	testSuite string,
 	nSpaces map[string]string,
 	cRDs []k8sreporter.CRData) {
+	err := DumpIfFailedOnCluster(kubeconfig, report, testSuite, nSpaces, cRDs)
+	if err != nil {
+		klog.Errorf("Failed to dump cluster state for %q: %v", report.FullText(), err)
+
+		ginkgo.AddReportEntry(DumpErrorsReportEntryName, err.Error())
+	}
+}
+
+// DumpIfFailedOnCluster dumps the requested cluster CRs on the cluster specified by kubeconfig if TC is failed to the
+// given directory. Each namespace is dumped concurrently with its own deadline and all errors are returned joined
+// together after dumping as much as possible.
+func DumpIfFailedOnCluster(
+	kubeconfig string,
+	report types.SpecReport,
+	testSuite string,
+	nSpaces map[string]string,
+	cRDs []k8sreporter.CRData) error {
 	if !types.SpecStateFailureStates.Is(report.State) {
-		return
+		return nil
 	}
 
 	// If no config is available, skip dumping
 	if generalCfg == nil {
 		klog.V(100).Infof("No reporter configuration available, skipping dump for test: %s", testSuite)
 
-		return
+		return nil
 	}
 
-	dumpDir := generalCfg.GetDumpFailedTestReportLocation(testSuite)
+	var errs []error
 
+	dumpDir := generalCfg.GetDumpFailedTestReportLocation(testSuite)
 	if dumpDir != "" {
-		reporter, err := newReporter(dumpDir, kubeconfig, nSpaces, setReporterSchemes, cRDs)
-		if err != nil {
-			klog.Fatalf("Failed to create log reporter due to %s", err)
-		}
+		errs = append(errs, dumpBundle(kubeconfig, dumpDir, report, testSuite, nSpaces, cRDs))
+	}
 
-		tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
+	errs = append(errs, removeFile(pathToPodExecLogs))
 
-		// Workaround for the fact we are unable to pass a context to specify a logger for the client used by
-		// the reporter. Otherwise, we get megabytes of verbose logging.
-		_ = flag.Set("v", "0")
+	return errors.Join(errs...)
+}
 
-		reporter.Dump(report.RunTime, tcReportFolderName)
+// dumpBundle dumps the cluster state and pod exec logs for the failed spec in report to its own directory in dumpDir,
+// then writes the bundle manifest and adds it to the index.
+func dumpBundle(
+	kubeconfig string,
+	dumpDir string,
+	report types.SpecReport,
+	testSuite string,
+	nSpaces map[string]string,
+	cRDs []k8sreporter.CRData) error {
+	bundleDir := filepath.Join(dumpDir, getBundleDirName(report.FullText()))
 
-		_ = flag.Set("v", generalCfg.VerboseLevel)
+	err := os.MkdirAll(bundleDir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle directory %s: %w", bundleDir, err)
+	}
+
+	scheme, err := newCRScheme()
+	if err != nil {
+		return fmt.Errorf("failed to create scheme for dumping CRs: %w", err)
+	}
+
+	manifest := newManifest(report, testSuite, nSpaces, cRDs, scheme)
+
+	var errs []error
 
-		_, podExecLogsFName := path.Split(pathToPodExecLogs)
+	dumper, err := newDumper(kubeconfig, bundleDir, scheme, time.Now().Add(-report.RunTime))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create dumper: %w", err))
+	} else {
+		errs = append(errs, dumper.Dump(context.Background(), manifest.Namespaces, cRDs))
 
-		err = moveFile(
-			pathToPodExecLogs, path.Join(generalCfg.ReportsDirAbsPath, tcReportFolderName, podExecLogsFName))
+		manifest.ClusterVersion, err = dumper.ClusterVersion(context.Background())
 		if err != nil {
-			klog.Fatalf("Failed to move pod exec logs %s to report folder: %s", pathToPodExecLogs, err)
+			klog.V(100).Infof("Failed to get cluster version for failure bundle manifest: %v", err)
 		}
 	}
 
-	err := removeFile(pathToPodExecLogs)
+	_, podExecLogsFName := filepath.Split(pathToPodExecLogs)
+
+	err = moveFile(pathToPodExecLogs, filepath.Join(bundleDir, podExecLogsFName))
 	if err != nil {
-		klog.Fatal(err.Error())
+		errs = append(errs, fmt.Errorf("failed to move pod exec logs %s to report folder: %w", pathToPodExecLogs, err))
+	}
+
+	err = writeManifest(bundleDir, manifest)
+	if err == nil {
+		err = appendIndexEntry(generalCfg.ReportsDirAbsPath, bundleDir, manifest)
 	}
+
+	errs = append(errs, err)
+
+	return errors.Join(errs...)
 }
 
 func moveFile(sourcePath, destPath string) error {
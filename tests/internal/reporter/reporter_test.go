@@ -1 +1,138 @@
 package reporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/openshift-kni/k8sreporter"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewManifest(t *testing.T) {
+	scheme, err := newCRScheme()
+	assert.Nil(t, err)
+
+	crNamespace := "test-ns"
+	report := buildFailedSpecReport()
+	cRDs := []k8sreporter.CRData{
+		{Cr: &corev1.PodList{}},
+		{Cr: &corev1.ConfigMapList{}, Namespace: &crNamespace},
+	}
+
+	manifest := newManifest(report, "test_suite_test.go", map[string]string{"ns-b": "", "ns-a": ""}, cRDs, scheme)
+
+	assert.Equal(t, "container spec", manifest.SpecText)
+	assert.Equal(t, "test_suite_test.go", manifest.Suite)
+	assert.Equal(t, []string{"label"}, manifest.Labels)
+	assert.Equal(t, "failed", manifest.State)
+	assert.Equal(t, "spec_test.go:10", manifest.FailureLocation)
+	assert.Equal(t, "expected failure", manifest.FailureMessage)
+	assert.Equal(t, []string{"ns-a", "ns-b"}, manifest.Namespaces)
+	assert.Equal(t, []DumpedCR{{Kind: "PodList"}, {Kind: "ConfigMapList", Namespace: crNamespace}}, manifest.CRs)
+}
+
+func TestWriteManifestAndIndex(t *testing.T) {
+	reportsDir := t.TempDir()
+	bundleDir := filepath.Join(reportsDir, "failed_test_suite_test", getBundleDirName("container spec"))
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(bundleDir, "nested"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(bundleDir, "pods.log"), []byte("pods"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(bundleDir, "nested", "events.log"), []byte("events"), 0644))
+
+	manifest := newManifest(buildFailedSpecReport(), "test_suite_test.go", nil, nil, nil)
+
+	for range 2 {
+		assert.Nil(t, writeManifest(bundleDir, manifest))
+		assert.Nil(t, appendIndexEntry(reportsDir, bundleDir, manifest))
+	}
+
+	contents, err := os.ReadFile(filepath.Join(bundleDir, ManifestFileName))
+	assert.Nil(t, err)
+
+	var writtenManifest Manifest
+
+	assert.Nil(t, json.Unmarshal(contents, &writtenManifest))
+	assert.Equal(t, []string{filepath.Join("nested", "events.log"), "pods.log"}, writtenManifest.Files)
+
+	indexFile, err := os.Open(filepath.Join(reportsDir, IndexFileName))
+	assert.Nil(t, err)
+
+	defer func() {
+		_ = indexFile.Close()
+	}()
+
+	var entries []IndexEntry
+
+	scanner := bufio.NewScanner(indexFile)
+	for scanner.Scan() {
+		var entry IndexEntry
+
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &entry))
+
+		entries = append(entries, entry)
+	}
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, filepath.Join("failed_test_suite_test", "container_spec"), entries[0].BundleDir)
+	assert.Equal(t, filepath.Join("failed_test_suite_test", "container_spec", ManifestFileName), entries[0].Manifest)
+}
+
+func TestDumperDump(t *testing.T) {
+	scheme, err := newCRScheme()
+	assert.Nil(t, err)
+
+	bundleDir := t.TempDir()
+	crNamespace := "ns-b"
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns-a"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "container"}}},
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: crNamespace}}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+
+	testDumper := &dumper{
+		coreClient:    k8sfake.NewClientset(pod, node).CoreV1(),
+		runtimeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build(),
+		scheme:        scheme,
+		bundleDir:     bundleDir,
+		since:         time.Now().Add(-time.Minute),
+	}
+
+	cRDs := []k8sreporter.CRData{{Cr: &corev1.ConfigMapList{}, Namespace: &crNamespace}}
+	assert.Nil(t, testDumper.Dump(context.TODO(), []string{"ns-a"}, cRDs))
+
+	files, err := listBundleFiles(bundleDir)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		"nodes.log", "ns-a_pod_pods_specs.log", "ns-a_pod_pods_logs.log", "ns-a_events.log", "ConfigMapList_ns-b.log",
+	}, files)
+
+	contents, err := os.ReadFile(filepath.Join(bundleDir, "ConfigMapList_ns-b.log"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(contents), "config")
+}
+
+func buildFailedSpecReport() types.SpecReport {
+	return types.SpecReport{
+		ContainerHierarchyTexts: []string{"container"},
+		LeafNodeText:            "spec",
+		LeafNodeLabels:          []string{"label"},
+		State:                   types.SpecStateFailed,
+		StartTime:               time.Unix(0, 0),
+		EndTime:                 time.Unix(60, 0),
+		Failure: types.Failure{
+			Message:  "expected failure",
+			Location: types.CodeLocation{FileName: "spec_test.go", LineNumber: 10},
+		},
+	}
+}
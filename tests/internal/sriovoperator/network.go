@@ -0,0 +1,211 @@
+package sriovoperator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	nadV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// nadPollInterval is the interval between NetworkAttachmentDefinition lookups.
+	nadPollInterval = time.Second
+)
+
+// NetworkOption is a function that modifies a NetworkBuilder.
+type NetworkOption func(*sriov.NetworkBuilder) *sriov.NetworkBuilder
+
+// WithSpoof sets spoof checking on the network.
+func WithSpoof(enabled bool) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithSpoof(enabled)
+	}
+}
+
+// WithTrust sets trust flag on the network.
+func WithTrust(enabled bool) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithTrustFlag(enabled)
+	}
+}
+
+// WithVLAN sets VLAN ID on the network.
+func WithVLAN(vlanID uint16) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithVLAN(vlanID)
+	}
+}
+
+// WithVlanQoS sets VLAN QoS on the network.
+func WithVlanQoS(qos uint16) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithVlanQoS(qos)
+	}
+}
+
+// WithMinTxRate sets minimum TX rate on the network.
+func WithMinTxRate(rate uint16) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithMinTxRate(rate)
+	}
+}
+
+// WithMaxTxRate sets maximum TX rate on the network.
+func WithMaxTxRate(rate uint16) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithMaxTxRate(rate)
+	}
+}
+
+// WithLinkState sets link state on the network.
+func WithLinkState(state string) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithLinkState(state)
+	}
+}
+
+// WithLogLevel sets the SR-IOV CNI log level on the network.
+func WithLogLevel(logLevel string) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithLogLevel(logLevel)
+	}
+}
+
+// WithMacAddressSupport enables the mac capability on the network.
+func WithMacAddressSupport() NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithMacAddressSupport()
+	}
+}
+
+// WithIPAddressSupport enables the ips capability on the network.
+func WithIPAddressSupport() NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithIPAddressSupport()
+	}
+}
+
+// WithStaticIPAM sets static IPAM on the network.
+func WithStaticIPAM() NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithStaticIpam()
+	}
+}
+
+// WithWhereaboutsIPAM sets whereabouts IPAM with a single range on the network.
+func WithWhereaboutsIPAM(ipRange, gateway, networkName string) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		return nb.WithWhereaboutsIPAM(ipRange, gateway, "", networkName)
+	}
+}
+
+// WithIPAM sets the raw IPAM configuration of the network. Used for configurations the builder does not cover,
+// such as dual-stack whereabouts ranges.
+func WithIPAM(ipamConfig string) NetworkOption {
+	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
+		nb.Definition.Spec.IPAM = ipamConfig
+
+		return nb
+	}
+}
+
+// NewNetwork defines a SriovNetwork for resourceName in targetNamespace and applies the given options.
+func NewNetwork(
+	apiClient *clients.Settings,
+	name,
+	sriovOperatorNamespace,
+	targetNamespace,
+	resourceName string,
+	opts ...NetworkOption) *sriov.NetworkBuilder {
+	networkBuilder := sriov.NewNetworkBuilder(apiClient, name, sriovOperatorNamespace, targetNamespace, resourceName)
+
+	for _, opt := range opts {
+		networkBuilder = opt(networkBuilder)
+	}
+
+	return networkBuilder
+}
+
+// CreateSriovNetworkAndWaitForNADCreation creates a SriovNetwork and waits for NAD creation in its target namespace.
+func CreateSriovNetworkAndWaitForNADCreation(
+	apiClient *clients.Settings, networkBuilder *sriov.NetworkBuilder, timeout time.Duration) error {
+	klog.V(90).Infof("Creating SriovNetwork %s and waiting for net-attach-def to be created",
+		networkBuilder.Definition.Name)
+
+	sriovNetwork, err := networkBuilder.Create()
+	if err != nil {
+		return fmt.Errorf("failed to create SriovNetwork %s: %w", networkBuilder.Definition.Name, err)
+	}
+
+	return WaitForNADCreation(apiClient, sriovNetwork.Object.Name, TargetNamespaceOf(sriovNetwork), timeout)
+}
+
+// WaitForNADCreation waits for the NAD to be created.
+func WaitForNADCreation(apiClient *clients.Settings, name, namespace string, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(context.TODO(),
+		nadPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			var testNAD nadV1.NetworkAttachmentDefinition
+
+			err := apiClient.Client.Get(ctx, k8sclient.ObjectKey{Name: name, Namespace: namespace}, &testNAD)
+			if err != nil {
+				klog.V(100).Infof("Failed to get NAD %s in namespace %s: %v", name, namespace, err)
+
+				return false, nil
+			}
+
+			return true, nil
+		})
+}
+
+// WaitForNADDeletion waits for the NAD to be deleted.
+func WaitForNADDeletion(apiClient *clients.Settings, name, namespace string, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(context.TODO(),
+		nadPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			var testNAD nadV1.NetworkAttachmentDefinition
+
+			err := apiClient.Client.Get(ctx, k8sclient.ObjectKey{Name: name, Namespace: namespace}, &testNAD)
+
+			return k8serrors.IsNotFound(err), nil
+		})
+}
+
+// TargetNamespaceOf returns the target namespace of a SriovNetwork.
+// If the target namespace is not set, it returns the namespace of the SriovNetwork.
+func TargetNamespaceOf(sriovNetwork *sriov.NetworkBuilder) string {
+	if sriovNetwork.Object.Spec.NetworkNamespace != "" {
+		return sriovNetwork.Object.Spec.NetworkNamespace
+	}
+
+	return sriovNetwork.Object.Namespace
+}
+
+// RemoveSriovNetwork removes the SriovNetwork if it exists and waits until it is deleted.
+func RemoveSriovNetwork(
+	apiClient *clients.Settings, name, sriovOperatorNamespace string, timeout time.Duration) error {
+	klog.V(90).Infof("Removing SriovNetwork %s", name)
+
+	sriovNetwork, err := sriov.PullNetwork(apiClient, name, sriovOperatorNamespace)
+	if err != nil {
+		if isDoesNotExistError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to pull SriovNetwork %s: %w", name, err)
+	}
+
+	return sriovNetwork.DeleteAndWait(timeout)
+}
+
+// isDoesNotExistError returns true if err is the error eco-goinfra returns when pulling a missing object. These are
+// plain errors rather than Kubernetes NotFound errors.
+func isDoesNotExistError(err error) bool {
+	return k8serrors.IsNotFound(err) || strings.Contains(err.Error(), "does not exist")
+}
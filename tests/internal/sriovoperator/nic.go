@@ -0,0 +1,160 @@
+package sriovoperator
+
+import (
+	"fmt"
+
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
+	"k8s.io/klog/v2"
+)
+
+// DiscoverInterfaceUnderTestVendorID discovers vendor ID for a given SR-IOV interface.
+func DiscoverInterfaceUnderTestVendorID(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace,
+	srIovInterfaceUnderTest,
+	workerNodeName string) (string, error) {
+	srIovInterface, err := discoverUpInterface(
+		apiClient, sriovOperatorNamespace, srIovInterfaceUnderTest, workerNodeName)
+	if err != nil {
+		return "", err
+	}
+
+	return srIovInterface.Vendor, nil
+}
+
+// DiscoverInterfaceUnderTestDeviceID discovers device ID for a given SR-IOV interface.
+func DiscoverInterfaceUnderTestDeviceID(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace,
+	srIovInterfaceUnderTest,
+	workerNodeName string) (string, error) {
+	srIovInterface, err := discoverUpInterface(
+		apiClient, sriovOperatorNamespace, srIovInterfaceUnderTest, workerNodeName)
+	if err != nil {
+		return "", err
+	}
+
+	return srIovInterface.DeviceID, nil
+}
+
+// DiscoverInterfaceByVendorAndDeviceID returns the name of the first SR-IOV interface on the node that matches
+// the given vendor and device IDs.
+func DiscoverInterfaceByVendorAndDeviceID(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace,
+	workerNodeName,
+	vendorID,
+	deviceID string) (string, error) {
+	klog.V(90).Infof("Discovering SR-IOV interface with vendor %s and device %s on node %s",
+		vendorID, deviceID, workerNodeName)
+
+	sriovInterfaces, err := sriov.NewNetworkNodeStateBuilder(
+		apiClient, workerNodeName, sriovOperatorNamespace).GetNICs()
+	if err != nil {
+		return "", err
+	}
+
+	interfaceNames := FilterInterfacesByVendorAndDeviceID(sriovInterfaces, vendorID, deviceID)
+	if len(interfaceNames) == 0 {
+		return "", fmt.Errorf("no interface found matching vendor %q deviceID %q on node %s",
+			vendorID, deviceID, workerNodeName)
+	}
+
+	return interfaceNames[0], nil
+}
+
+// FilterInterfacesByVendorAndDeviceID returns the names of the interfaces that match the given vendor and device
+// IDs. An empty vendor or device ID matches any interface, but at least one of them must be set, otherwise no
+// interface is returned.
+func FilterInterfacesByVendorAndDeviceID(sriovInterfaces srIovV1.InterfaceExts, vendorID, deviceID string) []string {
+	if vendorID == "" && deviceID == "" {
+		klog.V(90).Infof("Neither vendor nor device ID is set, not matching any interface")
+
+		return nil
+	}
+
+	var interfaceNames []string
+
+	for _, srIovInterface := range sriovInterfaces {
+		if vendorID != "" && srIovInterface.Vendor != vendorID {
+			continue
+		}
+
+		if deviceID != "" && srIovInterface.DeviceID != deviceID {
+			continue
+		}
+
+		interfaceNames = append(interfaceNames, srIovInterface.Name)
+	}
+
+	return interfaceNames
+}
+
+// ValidateInterfacesOnNodes checks that all the requested SR-IOV interfaces are up on every given node.
+func ValidateInterfacesOnNodes(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace string,
+	nodeNames,
+	requestedInterfaces []string) error {
+	for _, nodeName := range nodeNames {
+		availableUpSriovInterfaces, err := sriov.NewNetworkNodeStateBuilder(
+			apiClient, nodeName, sriovOperatorNamespace).GetUpNICs()
+		if err != nil {
+			return fmt.Errorf("failed to get SR-IOV devices from node %s: %w", nodeName, err)
+		}
+
+		missing := missingInterfaces(availableUpSriovInterfaces, requestedInterfaces)
+		if len(missing) > 0 {
+			return fmt.Errorf("requested interfaces %v are not all present on node %s (missing %v)",
+				requestedInterfaces, nodeName, missing)
+		}
+	}
+
+	return nil
+}
+
+// missingInterfaces returns the requested interfaces that are not among the available ones.
+func missingInterfaces(availableInterfaces srIovV1.InterfaceExts, requestedInterfaces []string) []string {
+	var missing []string
+
+	for _, requestedInterface := range requestedInterfaces {
+		found := false
+
+		for _, availableInterface := range availableInterfaces {
+			if availableInterface.Name == requestedInterface {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, requestedInterface)
+		}
+	}
+
+	return missing
+}
+
+// discoverUpInterface returns the up SR-IOV interface with the given name on the node.
+func discoverUpInterface(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace,
+	interfaceName,
+	workerNodeName string) (*srIovV1.InterfaceExt, error) {
+	sriovInterfaces, err := sriov.NewNetworkNodeStateBuilder(
+		apiClient, workerNodeName, sriovOperatorNamespace).GetUpNICs()
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range sriovInterfaces {
+		if sriovInterfaces[index].Name == interfaceName {
+			return &sriovInterfaces[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("interface %s not found", interfaceName)
+}
@@ -0,0 +1,180 @@
+package sriovoperator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// NetworkStatusAnnotation is the pod annotation where Multus reports the attached networks.
+	NetworkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+)
+
+// NetworkStatus is an entry of the network-status annotation of a pod.
+type NetworkStatus struct {
+	Name       string   `json:"name"`
+	Interface  string   `json:"interface"`
+	IPs        []string `json:"ips,omitempty"`
+	Mac        string   `json:"mac"`
+	Default    bool     `json:"default,omitempty"`
+	DeviceInfo struct {
+		Type string `json:"type"`
+		Pci  struct {
+			PciAddress string `json:"pci-address"`
+		} `json:"pci"`
+	} `json:"device-info,omitempty"`
+}
+
+// ParseNetworkStatus parses the value of the network-status annotation.
+func ParseNetworkStatus(annotation string) ([]NetworkStatus, error) {
+	if annotation == "" {
+		return nil, fmt.Errorf("empty %s annotation", NetworkStatusAnnotation)
+	}
+
+	var statuses []NetworkStatus
+
+	err := json.Unmarshal([]byte(annotation), &statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", NetworkStatusAnnotation, err)
+	}
+
+	return statuses, nil
+}
+
+// InterfaceNetworkStatus returns the network status of interfaceName in the pod. The pod is pulled to get the
+// annotation set after it started.
+func InterfaceNetworkStatus(apiClient *clients.Settings, podName, podNamespace, interfaceName string) (
+	*NetworkStatus, error) {
+	podObj, err := pod.Pull(apiClient, podName, podNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull pod %s: %w", podName, err)
+	}
+
+	statuses, err := ParseNetworkStatus(podObj.Object.Annotations[NetworkStatusAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("pod %s: %w", podName, err)
+	}
+
+	for index := range statuses {
+		if statuses[index].Interface == interfaceName {
+			return &statuses[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("interface %s not found in pod %s", interfaceName, podName)
+}
+
+// IPsOfFamily returns the addresses of ips that belong to ipFamily, ipv4 or ipv6, without prefix length. IPv6
+// link-local addresses are skipped.
+func IPsOfFamily(ips []string, ipFamily string) []string {
+	var familyIPs []string
+
+	for _, ip := range ips {
+		address := strings.Split(ip, "/")[0]
+		isIPv6 := strings.Contains(address, ":")
+
+		switch {
+		case ipFamily == "ipv4" && !isIPv6:
+			familyIPs = append(familyIPs, address)
+		case ipFamily == "ipv6" && isIPv6 && !strings.HasPrefix(strings.ToLower(address), "fe80"):
+			familyIPs = append(familyIPs, address)
+		}
+	}
+
+	return familyIPs
+}
+
+// TestPodConfig describes a privileged test pod attached to an SR-IOV network.
+type TestPodConfig struct {
+	Name string
+	// NodeName is the node to run the pod on. If empty, the scheduler picks the node.
+	NodeName    string
+	NetworkName string
+	MacAddress  string
+	IPAddresses []string
+	// ContainerName and Command replace the default container when Command is set.
+	ContainerName string
+	Command       []string
+	Labels        map[string]string
+}
+
+// CreateTestPod creates the test pod described by podConfig and waits until it is ready. On a readiness timeout the
+// pod is returned along with the error so it can be cleaned up.
+func CreateTestPod(
+	apiClient *clients.Settings,
+	namespace,
+	image string,
+	podConfig TestPodConfig,
+	timeout time.Duration) (*pod.Builder, error) {
+	klog.V(90).Infof("Creating test pod %s with SR-IOV network %s", podConfig.Name, podConfig.NetworkName)
+
+	secNetwork := []*types.NetworkSelectionElement{{
+		Name:       podConfig.NetworkName,
+		MacRequest: podConfig.MacAddress,
+		IPRequest:  podConfig.IPAddresses,
+	}}
+
+	podBuilder := pod.NewBuilder(apiClient, podConfig.Name, namespace, image).
+		WithPrivilegedFlag().
+		WithSecondaryNetwork(secNetwork)
+
+	if podConfig.NodeName != "" {
+		podBuilder = podBuilder.DefineOnNode(podConfig.NodeName)
+	}
+
+	for key, value := range podConfig.Labels {
+		podBuilder = podBuilder.WithLabel(key, value)
+	}
+
+	if len(podConfig.Command) > 0 {
+		container, err := pod.NewContainerBuilder(podConfig.ContainerName, image, podConfig.Command).GetContainerCfg()
+		if err != nil {
+			return nil, fmt.Errorf("failed to define container of pod %s: %w", podConfig.Name, err)
+		}
+
+		podBuilder = podBuilder.RedefineDefaultContainer(*container)
+	}
+
+	podBuilder, err := podBuilder.Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod %s: %w", podConfig.Name, err)
+	}
+
+	err = podBuilder.WaitUntilReady(timeout)
+	if err != nil {
+		return podBuilder, fmt.Errorf("pod %s is not ready: %w", podConfig.Name, err)
+	}
+
+	return podBuilder, nil
+}
+
+// CreateTestPodPair creates the client and server test pods. If the server pod fails, the client pod is returned
+// along with the error so it can be cleaned up.
+func CreateTestPodPair(
+	apiClient *clients.Settings,
+	namespace,
+	image string,
+	clientConfig,
+	serverConfig TestPodConfig,
+	timeout time.Duration) (clientPod, serverPod *pod.Builder, err error) {
+	klog.V(90).Infof("Creating client pod %s and server pod %s", clientConfig.Name, serverConfig.Name)
+
+	clientPod, err = CreateTestPod(apiClient, namespace, image, clientConfig, timeout)
+	if err != nil {
+		return clientPod, nil, fmt.Errorf("failed to create client pod: %w", err)
+	}
+
+	serverPod, err = CreateTestPod(apiClient, namespace, image, serverConfig, timeout)
+	if err != nil {
+		return clientPod, serverPod, fmt.Errorf("failed to create server pod: %w", err)
+	}
+
+	return clientPod, serverPod, nil
+}
@@ -0,0 +1,182 @@
+package sriovoperator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// PolicyOption is a function that modifies a PolicyBuilder.
+type PolicyOption func(*sriov.PolicyBuilder) *sriov.PolicyBuilder
+
+// WithMTU sets the MTU of the VFs created by the policy.
+func WithMTU(mtu int) PolicyOption {
+	return func(pb *sriov.PolicyBuilder) *sriov.PolicyBuilder {
+		return pb.WithMTU(mtu)
+	}
+}
+
+// WithVFRange restricts the policy to the VFs from firstVF to lastVF of each PF.
+func WithVFRange(firstVF, lastVF int) PolicyOption {
+	return func(pb *sriov.PolicyBuilder) *sriov.PolicyBuilder {
+		return pb.WithVFRange(firstVF, lastVF)
+	}
+}
+
+// WithDevType sets the driver the VFs are bound to, netdevice or vfio-pci.
+func WithDevType(devType string) PolicyOption {
+	return func(pb *sriov.PolicyBuilder) *sriov.PolicyBuilder {
+		return pb.WithDevType(devType)
+	}
+}
+
+// WithRDMA enables RDMA on the VFs created by the policy.
+func WithRDMA(enabled bool) PolicyOption {
+	return func(pb *sriov.PolicyBuilder) *sriov.PolicyBuilder {
+		return pb.WithRDMA(enabled)
+	}
+}
+
+// WithNicVendor restricts the policy to NICs with the given vendor ID.
+func WithNicVendor(vendorID string) PolicyOption {
+	return func(pb *sriov.PolicyBuilder) *sriov.PolicyBuilder {
+		pb.Definition.Spec.NicSelector.Vendor = vendorID
+
+		return pb
+	}
+}
+
+// WithNicDeviceID restricts the policy to NICs with the given device ID.
+func WithNicDeviceID(deviceID string) PolicyOption {
+	return func(pb *sriov.PolicyBuilder) *sriov.PolicyBuilder {
+		pb.Definition.Spec.NicSelector.DeviceID = deviceID
+
+		return pb
+	}
+}
+
+// NewPolicy defines a SriovNetworkNodePolicy creating numVFs VFs on pfNames and applies the given options.
+func NewPolicy(
+	apiClient *clients.Settings,
+	name,
+	sriovOperatorNamespace,
+	resourceName string,
+	numVFs int,
+	pfNames []string,
+	nodeSelector map[string]string,
+	opts ...PolicyOption) *sriov.PolicyBuilder {
+	policyBuilder := sriov.NewPolicyBuilder(
+		apiClient, name, sriovOperatorNamespace, resourceName, numVFs, pfNames, nodeSelector)
+
+	for _, opt := range opts {
+		policyBuilder = opt(policyBuilder)
+	}
+
+	return policyBuilder
+}
+
+// RemoveSriovPolicy removes the SriovNetworkNodePolicy if it exists and waits until it is deleted. It does not wait
+// for SR-IOV and MCP to become stable.
+func RemoveSriovPolicy(
+	apiClient *clients.Settings, name, sriovOperatorNamespace string, timeout time.Duration) error {
+	klog.V(90).Infof("Removing SriovNetworkNodePolicy %s", name)
+
+	policy, err := sriov.PullPolicy(apiClient, name, sriovOperatorNamespace)
+	if err != nil {
+		if isDoesNotExistError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to pull SriovNetworkNodePolicy %s: %w", name, err)
+	}
+
+	err = policy.Delete()
+	if err != nil {
+		return fmt.Errorf("failed to delete SriovNetworkNodePolicy %s: %w", name, err)
+	}
+
+	return wait.PollUntilContextTimeout(context.TODO(), nadPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			return !policy.Exists(), nil
+		})
+}
+
+// VFConfig describes the VFs to create on a NIC selected by vendor and device ID.
+type VFConfig struct {
+	// PolicyName is used as both the policy and the resource name.
+	PolicyName string
+	// InterfaceName is the PF to use. If empty, it is discovered on each node from VendorID and DeviceID, at least one
+	// of which must be set.
+	InterfaceName string
+	VendorID      string
+	DeviceID      string
+	// DevType is the VF driver, netdevice or vfio-pci.
+	DevType string
+	NumVFs  int
+}
+
+// CreatePolicyOnFirstMatchingNode creates a policy for the VFs in vfConfig on the first of nodeNames where the PF
+// is found and the policy is applied, then returns the name of that node. A policy that does not become stable is
+// removed before trying the next node.
+func CreatePolicyOnFirstMatchingNode(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace,
+	mcpName string,
+	vfConfig VFConfig,
+	nodeNames []string,
+	timeout,
+	stableDuration time.Duration) (string, error) {
+	if vfConfig.NumVFs <= 0 {
+		return "", fmt.Errorf("number of VFs must be > 0, got %d", vfConfig.NumVFs)
+	}
+
+	if vfConfig.InterfaceName == "" && vfConfig.VendorID == "" && vfConfig.DeviceID == "" {
+		return "", fmt.Errorf("policy %s must set an interface name, vendor ID or device ID", vfConfig.PolicyName)
+	}
+
+	err := RemoveSriovPolicy(apiClient, vfConfig.PolicyName, sriovOperatorNamespace, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	for _, nodeName := range nodeNames {
+		interfaceName := vfConfig.InterfaceName
+
+		if interfaceName == "" {
+			interfaceName, err = DiscoverInterfaceByVendorAndDeviceID(
+				apiClient, sriovOperatorNamespace, nodeName, vfConfig.VendorID, vfConfig.DeviceID)
+			if err != nil {
+				klog.V(90).Infof("Skipping node %s: %v", nodeName, err)
+
+				continue
+			}
+		}
+
+		policy := NewPolicy(apiClient, vfConfig.PolicyName, sriovOperatorNamespace, vfConfig.PolicyName,
+			vfConfig.NumVFs, []string{interfaceName}, map[string]string{corev1.LabelHostname: nodeName},
+			WithVFRange(0, vfConfig.NumVFs-1), WithDevType(vfConfig.DevType),
+			WithNicVendor(vfConfig.VendorID), WithNicDeviceID(vfConfig.DeviceID))
+
+		err = CreateSriovPolicyAndWaitUntilItsApplied(
+			apiClient, mcpName, sriovOperatorNamespace, policy, timeout, stableDuration)
+		if err != nil {
+			klog.V(90).Infof("Policy %s was not applied on node %s: %v", vfConfig.PolicyName, nodeName, err)
+
+			_ = policy.Delete()
+
+			continue
+		}
+
+		klog.V(90).Infof("Successfully created policy %s on node %s", vfConfig.PolicyName, nodeName)
+
+		return nodeName, nil
+	}
+
+	return "", fmt.Errorf("failed to create policy %s on any node", vfConfig.PolicyName)
+}
@@ -0,0 +1,101 @@
+package sriovoperator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// NodeStateSucceededStatus is the sync status of a SriovNetworkNodeState once its policies are applied.
+	NodeStateSucceededStatus = "Succeeded"
+	// ResourcePrefix is the prefix of the extended resources the SR-IOV device plugin advertises.
+	ResourcePrefix = "openshift.io"
+)
+
+// WaitForNodeStatesSynced waits until the SriovNetworkNodeState of every given node reports the Succeeded sync
+// status. It returns the nodes that were not in sync yet, since their device plugin may still be re-enumerating VFs.
+func WaitForNodeStatesSynced(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace string,
+	nodeNames []string,
+	timeout time.Duration) ([]string, error) {
+	var resyncedNodes []string
+
+	for _, nodeName := range nodeNames {
+		klog.V(90).Infof("Checking SriovNetworkNodeState sync status on node %s", nodeName)
+
+		nodeState := sriov.NewNetworkNodeStateBuilder(apiClient, nodeName, sriovOperatorNamespace)
+		if nodeState == nil {
+			return nil, fmt.Errorf("failed to create SriovNetworkNodeState builder for node %s", nodeName)
+		}
+
+		err := nodeState.Discover()
+		if err != nil {
+			return nil, fmt.Errorf("SriovNetworkNodeState not found for node %s: %w", nodeName, err)
+		}
+
+		if nodeState.Objects.Status.SyncStatus == NodeStateSucceededStatus {
+			continue
+		}
+
+		klog.V(90).Infof("Waiting up to %v for SriovNetworkNodeState on node %s to be in sync", timeout, nodeName)
+
+		err = nodeState.WaitUntilSyncStatus(NodeStateSucceededStatus, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("timeout waiting for SriovNetworkNodeState sync on node %s: %w", nodeName, err)
+		}
+
+		resyncedNodes = append(resyncedNodes, nodeName)
+	}
+
+	return resyncedNodes, nil
+}
+
+// WaitForResourcesAllocatable waits until every given node advertises a non-zero allocatable quantity of each
+// resource, confirming the device plugin has enumerated the VFs. Resource names must include the prefix, e.g.
+// openshift.io/sriovnic.
+func WaitForResourcesAllocatable(
+	apiClient *clients.Settings,
+	nodeNames,
+	resourceNames []string,
+	interval,
+	timeout time.Duration) error {
+	for _, nodeName := range nodeNames {
+		for _, resourceName := range resourceNames {
+			klog.V(90).Infof("Waiting for SR-IOV resource %s to become available on node %s", resourceName, nodeName)
+
+			err := wait.PollUntilContextTimeout(context.TODO(), interval, timeout, true,
+				func(ctx context.Context) (bool, error) {
+					nodeBuilder, err := nodes.Pull(apiClient, nodeName)
+					if err != nil {
+						klog.V(90).Infof("Failed to pull node %s: %v", nodeName, err)
+
+						return false, nil
+					}
+
+					quantity, exists := nodeBuilder.Object.Status.Allocatable[corev1.ResourceName(resourceName)]
+					if !exists || quantity.IsZero() {
+						klog.V(90).Infof("Resource %s is not yet allocatable on node %s", resourceName, nodeName)
+
+						return false, nil
+					}
+
+					return true, nil
+				})
+			if err != nil {
+				return fmt.Errorf("SR-IOV resource %s did not become available on node %s within %v: %w",
+					resourceName, nodeName, timeout, err)
+			}
+		}
+	}
+
+	return nil
+}
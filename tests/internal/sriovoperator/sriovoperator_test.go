@@ -0,0 +1,121 @@
+package sriovoperator
+
+import (
+	"testing"
+	"time"
+
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+var testInterfaces = srIovV1.InterfaceExts{
+	{Name: "ens1f0", Vendor: "8086", DeviceID: "158b"},
+	{Name: "ens1f1", Vendor: "8086", DeviceID: "158b"},
+	{Name: "ens2f0", Vendor: "15b3", DeviceID: "101d"},
+}
+
+func TestFilterInterfacesByVendorAndDeviceID(t *testing.T) {
+	testCases := []struct {
+		name     string
+		vendorID string
+		deviceID string
+		expected []string
+	}{
+		{
+			name:     "vendor and device",
+			vendorID: "8086",
+			deviceID: "158b",
+			expected: []string{"ens1f0", "ens1f1"},
+		},
+		{
+			name:     "vendor only",
+			vendorID: "15b3",
+			expected: []string{"ens2f0"},
+		},
+		{
+			name:     "device only",
+			deviceID: "158b",
+			expected: []string{"ens1f0", "ens1f1"},
+		},
+		{
+			name: "neither vendor nor device",
+		},
+		{
+			name:     "no match",
+			vendorID: "15b3",
+			deviceID: "158b",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected,
+				FilterInterfacesByVendorAndDeviceID(testInterfaces, testCase.vendorID, testCase.deviceID))
+		})
+	}
+}
+
+func TestMissingInterfaces(t *testing.T) {
+	assert.Empty(t, missingInterfaces(testInterfaces, []string{"ens1f0", "ens2f0"}))
+	assert.Equal(t, []string{"ens3f0"}, missingInterfaces(testInterfaces, []string{"ens1f1", "ens3f0"}))
+}
+
+func TestParseNetworkStatus(t *testing.T) {
+	annotation := `[{"name":"ovn-kubernetes","interface":"eth0","ips":["10.128.2.15"],"default":true},` +
+		`{"name":"test/sriov-net","interface":"net1","ips":["192.168.0.1","fe80::1","2001::1"],` +
+		`"mac":"02:04:0f:f1:88:01","device-info":{"type":"pci","pci":{"pci-address":"0000:3b:02.1"}}}]`
+
+	statuses, err := ParseNetworkStatus(annotation)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Default)
+	assert.Equal(t, "test/sriov-net", statuses[1].Name)
+	assert.Equal(t, "0000:3b:02.1", statuses[1].DeviceInfo.Pci.PciAddress)
+
+	_, err = ParseNetworkStatus("")
+	assert.Error(t, err)
+
+	_, err = ParseNetworkStatus("{")
+	assert.Error(t, err)
+}
+
+func TestIPsOfFamily(t *testing.T) {
+	ips := []string{"192.168.0.1/24", "fe80::1", "2001::1/64", "10.0.0.1"}
+
+	assert.Equal(t, []string{"192.168.0.1", "10.0.0.1"}, IPsOfFamily(ips, "ipv4"))
+	assert.Equal(t, []string{"2001::1"}, IPsOfFamily(ips, "ipv6"))
+	assert.Empty(t, IPsOfFamily(ips, "ipv5"))
+}
+
+func TestICMPCheck(t *testing.T) {
+	assert.Equal(t, "ping -I net1 192.168.0.2 -c 5", ICMPCheck("net1", "192.168.0.2/24").Command)
+	assert.Equal(t, "ping -6 -I net1 2001::2 -c 5", ICMPCheck("net1", "2001::2").Command)
+}
+
+func TestTestcmdChecks(t *testing.T) {
+	checks := TestcmdChecks("net1", "192.168.0.2/24", "239.100.100.250", 1500)
+
+	assert.Len(t, checks, 4)
+	assert.Equal(t, "TCP", checks[0].Protocol)
+	assert.Equal(t, "testcmd -protocol tcp -port 5001 -interface net1 -server 192.168.0.2 -mtu 1400", checks[0].Command)
+	assert.Equal(t, "multicast", checks[3].Protocol)
+	assert.Equal(t,
+		"testcmd -multicast -protocol udp -port 5004 -interface net1 -server 239.100.100.250 -mtu 1400",
+		checks[3].Command)
+}
+
+func TestTestcmdListenersCommand(t *testing.T) {
+	assert.Equal(t,
+		"testcmd -listen -protocol tcp -port 5001 -interface net1 -mtu 8900 & "+
+			"testcmd -listen -protocol udp -port 5002 -interface net1 -mtu 8900 & "+
+			"testcmd -listen -protocol sctp -port 5003 -interface net1 -server $SERVER_IP -mtu 8900 & "+
+			"testcmd -listen -multicast -protocol udp -port 5004 -interface net1 -server $MCAST_GROUP -mtu 8900 & "+
+			"sleep infinity",
+		TestcmdListenersCommand("net1", "$SERVER_IP", "$MCAST_GROUP", 9000))
+}
+
+func TestCreatePolicyOnFirstMatchingNodeRequiresInterfaceFilter(t *testing.T) {
+	_, err := CreatePolicyOnFirstMatchingNode(nil, "openshift-sriov-network-operator", "worker",
+		VFConfig{PolicyName: "test-policy", NumVFs: 2}, []string{"worker-0"}, time.Minute, time.Second)
+	assert.ErrorContains(t, err, "must set an interface name, vendor ID or device ID")
+}
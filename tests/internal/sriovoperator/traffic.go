@@ -0,0 +1,148 @@
+package sriovoperator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// TestcmdTCPPort is the port of the testcmd TCP listener.
+	TestcmdTCPPort = 5001
+	// TestcmdUDPPort is the port of the testcmd UDP listener.
+	TestcmdUDPPort = 5002
+	// TestcmdSCTPPort is the port of the testcmd SCTP listener.
+	TestcmdSCTPPort = 5003
+	// TestcmdMulticastPort is the port of the testcmd multicast listener.
+	TestcmdMulticastPort = 5004
+	// TestcmdHeaderOverhead is subtracted from the MTU to get a testcmd payload that fits in a single frame. It
+	// accounts for IP headers, protocol headers and testcmd overhead.
+	TestcmdHeaderOverhead = 100
+)
+
+// TrafficCheck is a command run in the client pod that fails when traffic of Protocol does not pass.
+type TrafficCheck struct {
+	Protocol string
+	Command  string
+}
+
+// ICMPCheck returns a check pinging serverIP from interfaceName. serverIP may have a prefix length.
+func ICMPCheck(interfaceName, serverIP string) TrafficCheck {
+	address := strings.Split(serverIP, "/")[0]
+
+	pingFlags := ""
+	if parsedIP := net.ParseIP(address); parsedIP != nil && parsedIP.To4() == nil {
+		pingFlags = "-6 "
+	}
+
+	return TrafficCheck{
+		Protocol: "ICMP",
+		Command:  fmt.Sprintf("ping %s-I %s %s -c 5", pingFlags, interfaceName, address),
+	}
+}
+
+// TestcmdChecks returns the TCP, UDP, SCTP and multicast checks against the listeners started by
+// TestcmdListenersCommand with the same interface, server IP, multicast group and MTU.
+func TestcmdChecks(interfaceName, serverIP, multicastGroup string, mtu int) []TrafficCheck {
+	packetSize := mtu - TestcmdHeaderOverhead
+	serverIP = strings.Split(serverIP, "/")[0]
+
+	return []TrafficCheck{
+		{
+			Protocol: "TCP",
+			Command: fmt.Sprintf("testcmd -protocol tcp -port %d -interface %s -server %s -mtu %d",
+				TestcmdTCPPort, interfaceName, serverIP, packetSize),
+		},
+		{
+			Protocol: "UDP",
+			Command: fmt.Sprintf("testcmd -protocol udp -port %d -interface %s -server %s -mtu %d",
+				TestcmdUDPPort, interfaceName, serverIP, packetSize),
+		},
+		{
+			Protocol: "SCTP",
+			Command: fmt.Sprintf("testcmd -protocol sctp -port %d -interface %s -server %s -mtu %d",
+				TestcmdSCTPPort, interfaceName, serverIP, packetSize),
+		},
+		{
+			Protocol: "multicast",
+			Command: fmt.Sprintf("testcmd -multicast -protocol udp -port %d -interface %s -server %s -mtu %d",
+				TestcmdMulticastPort, interfaceName, multicastGroup, packetSize),
+		},
+	}
+}
+
+// TestcmdListenersCommand returns the shell command starting the testcmd listeners matching TestcmdChecks and
+// keeping the container running. serverIP and multicastGroup may be shell variables such as $SERVER_IP.
+func TestcmdListenersCommand(interfaceName, serverIP, multicastGroup string, mtu int) string {
+	packetSize := mtu - TestcmdHeaderOverhead
+
+	return fmt.Sprintf(
+		"testcmd -listen -protocol tcp -port %d -interface %s -mtu %d & "+
+			"testcmd -listen -protocol udp -port %d -interface %s -mtu %d & "+
+			"testcmd -listen -protocol sctp -port %d -interface %s -server %s -mtu %d & "+
+			"testcmd -listen -multicast -protocol udp -port %d -interface %s -server %s -mtu %d & "+
+			"sleep infinity",
+		TestcmdTCPPort, interfaceName, packetSize,
+		TestcmdUDPPort, interfaceName, packetSize,
+		TestcmdSCTPPort, interfaceName, serverIP, packetSize,
+		TestcmdMulticastPort, interfaceName, multicastGroup, packetSize)
+}
+
+// WaitForTestcmdListeners waits until the testcmd listeners run in the server pod.
+func WaitForTestcmdListeners(serverPod *pod.Builder, interval, timeout time.Duration) error {
+	klog.V(90).Infof("Waiting for testcmd listeners on pod %s", serverPod.Definition.Name)
+
+	err := wait.PollUntilContextTimeout(context.TODO(), interval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			_, err := serverPod.ExecCommand([]string{"bash", "-c", "pgrep -f testcmd"})
+			if err != nil {
+				klog.V(90).Infof("testcmd not ready on pod %s: %v", serverPod.Definition.Name, err)
+
+				return false, nil
+			}
+
+			return true, nil
+		})
+	if err != nil {
+		return fmt.Errorf("testcmd listeners not ready on pod %s: %w", serverPod.Definition.Name, err)
+	}
+
+	return nil
+}
+
+// RunProtocolTest executes a protocol-specific connectivity test command in the client pod.
+func RunProtocolTest(clientPod *pod.Builder, protocol, command string) error {
+	klog.V(90).Infof("Running %s connectivity test", protocol)
+
+	output, err := clientPod.ExecCommand([]string{"bash", "-c", command})
+	if err != nil {
+		return fmt.Errorf("%s connectivity check failed (output: %s): %w", protocol, output.String(), err)
+	}
+
+	return nil
+}
+
+// RunTrafficChecks runs every check in the client pod. All checks are run, and the error lists each failed
+// protocol.
+func RunTrafficChecks(clientPod *pod.Builder, checks []TrafficCheck) error {
+	var failedProtocols []string
+
+	for _, check := range checks {
+		err := RunProtocolTest(clientPod, check.Protocol, check.Command)
+		if err != nil {
+			failedProtocols = append(failedProtocols, fmt.Sprintf("%s: %v", check.Protocol, err))
+		}
+	}
+
+	if len(failedProtocols) > 0 {
+		return fmt.Errorf("traffic tests failed: %s", strings.Join(failedProtocols, "; "))
+	}
+
+	return nil
+}
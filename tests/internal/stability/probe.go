@@ -0,0 +1,57 @@
+// Package stability records the state of a cluster over a long run as JSON Lines samples and summarizes which
+// observed values changed. It has no cluster dependency so that the recorder and summary can be unit tested; probes
+// that query a cluster are defined by the suites using it.
+package stability
+
+import "context"
+
+// Kind is the type of value recorded by an Observation.
+type Kind string
+
+const (
+	// KindState observations record a state, such as a sync or compliance status, that is expected not to change.
+	KindState Kind = "state"
+	// KindCounter observations record a monotonically increasing count, such as restarts, that is expected not to
+	// increase.
+	KindCounter Kind = "counter"
+)
+
+// Observation is the value of a single key reported by a probe at one point in time.
+type Observation struct {
+	Key   string
+	Kind  Kind
+	State string
+	Count int64
+	// Detail is optional context for the observation that is recorded but not compared between samples.
+	Detail string
+}
+
+// Probe reports the current value of one or more keys each time it is sampled.
+type Probe interface {
+	// Name identifies the probe in the recorded samples and summary. It must be unique within a Recorder.
+	Name() string
+	// Sample returns the current observations. Keys that are missing from a sample but present in others are treated
+	// as a change.
+	Sample(ctx context.Context) ([]Observation, error)
+}
+
+// funcProbe is a Probe backed by a function.
+type funcProbe struct {
+	name   string
+	sample func(ctx context.Context) ([]Observation, error)
+}
+
+// NewProbe returns a Probe named name that calls sample each time it is sampled.
+func NewProbe(name string, sample func(ctx context.Context) ([]Observation, error)) Probe {
+	return &funcProbe{name: name, sample: sample}
+}
+
+// Name returns the name of the probe.
+func (probe *funcProbe) Name() string {
+	return probe.name
+}
+
+// Sample calls the function of the probe.
+func (probe *funcProbe) Sample(ctx context.Context) ([]Observation, error) {
+	return probe.sample(ctx)
+}
@@ -0,0 +1,268 @@
+package stability
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Sample is a single timestamped observation recorded by a Recorder, stored as one line of JSON. All samples from the
+// same probe in one round share a time. When the probe fails, a single sample with only the error is recorded.
+type Sample struct {
+	Time   time.Time `json:"time"`
+	Probe  string    `json:"probe"`
+	Key    string    `json:"key,omitempty"`
+	Kind   Kind      `json:"kind,omitempty"`
+	State  string    `json:"state,omitempty"`
+	Count  int64     `json:"count,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// Recorder samples a set of probes on an interval in a background goroutine, appending each sample to a JSON Lines
+// file and keeping them in memory to build a Summary.
+type Recorder struct {
+	outputPath string
+	interval   time.Duration
+	probes     []Probe
+
+	mutex    sync.Mutex
+	file     *os.File
+	writer   *bufio.Writer
+	samples  []Sample
+	writeErr error
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewRecorder creates a Recorder that samples probes every interval and writes the samples to outputPath, replacing
+// any existing file. The parent directory is created if it does not exist.
+func NewRecorder(outputPath string, interval time.Duration, probes ...Probe) (*Recorder, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("stability recorder interval must be positive, got %s", interval)
+	}
+
+	if len(probes) == 0 {
+		return nil, fmt.Errorf("stability recorder requires at least one probe")
+	}
+
+	probeNames := make(map[string]bool, len(probes))
+
+	for _, probe := range probes {
+		if probe == nil {
+			return nil, fmt.Errorf("stability recorder probe cannot be nil")
+		}
+
+		if probeNames[probe.Name()] {
+			return nil, fmt.Errorf("stability recorder has more than one probe named %q", probe.Name())
+		}
+
+		probeNames[probe.Name()] = true
+	}
+
+	err := os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory for stability output %s: %w", outputPath, err)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stability output %s: %w", outputPath, err)
+	}
+
+	return &Recorder{
+		outputPath: outputPath,
+		interval:   interval,
+		probes:     probes,
+		file:       file,
+		writer:     bufio.NewWriter(file),
+	}, nil
+}
+
+// Start samples every probe immediately and then every interval in a background goroutine until ctx is done or Stop
+// is called. It returns an error if the recorder has already been started.
+func (recorder *Recorder) Start(ctx context.Context) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.done != nil {
+		return fmt.Errorf("stability recorder for %s has already been started", recorder.outputPath)
+	}
+
+	ctx, recorder.cancel = context.WithCancel(ctx)
+	recorder.done = make(chan struct{})
+
+	klog.V(90).Infof("Starting stability recorder for %s with interval %s", recorder.outputPath, recorder.interval)
+
+	go recorder.run(ctx)
+
+	return nil
+}
+
+// Stop stops sampling, waits for any sample in progress to finish, closes the output file, and returns the Summary of
+// all samples. It returns an error if the recorder was never started or writing the samples failed.
+func (recorder *Recorder) Stop() (*Summary, error) {
+	recorder.mutex.Lock()
+	cancel, done := recorder.cancel, recorder.done
+	recorder.mutex.Unlock()
+
+	if done == nil {
+		return nil, fmt.Errorf("stability recorder for %s has not been started", recorder.outputPath)
+	}
+
+	cancel()
+	<-done
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.file != nil {
+		err := errors.Join(recorder.writer.Flush(), recorder.file.Close())
+		if err != nil && recorder.writeErr == nil {
+			recorder.writeErr = fmt.Errorf("failed to close stability output %s: %w", recorder.outputPath, err)
+		}
+
+		recorder.file = nil
+	}
+
+	return Summarize(recorder.samples), recorder.writeErr
+}
+
+// Run starts the recorder, waits for duration or until ctx is done, and then stops it, returning the Summary.
+func (recorder *Recorder) Run(ctx context.Context, duration time.Duration) (*Summary, error) {
+	err := recorder.Start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-time.After(duration):
+	case <-ctx.Done():
+	}
+
+	return recorder.Stop()
+}
+
+// Samples returns a copy of the samples recorded so far.
+func (recorder *Recorder) Samples() []Sample {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]Sample(nil), recorder.samples...)
+}
+
+// run samples the probes until ctx is done, closing done when it returns.
+func (recorder *Recorder) run(ctx context.Context) {
+	defer close(recorder.done)
+
+	ticker := time.NewTicker(recorder.interval)
+	defer ticker.Stop()
+
+	for {
+		recorder.sampleAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sampleAll samples each probe in order, recording either its observations or its error.
+func (recorder *Recorder) sampleAll(ctx context.Context) {
+	for _, probe := range recorder.probes {
+		if ctx.Err() != nil {
+			return
+		}
+
+		sampleTime := time.Now().UTC()
+
+		observations, err := probe.Sample(ctx)
+		if err != nil {
+			klog.V(90).Infof("Stability probe %s failed: %v", probe.Name(), err)
+
+			recorder.record(Sample{Time: sampleTime, Probe: probe.Name(), Error: err.Error()})
+
+			continue
+		}
+
+		for _, observation := range observations {
+			recorder.record(Sample{
+				Time:   sampleTime,
+				Probe:  probe.Name(),
+				Key:    observation.Key,
+				Kind:   observation.Kind,
+				State:  observation.State,
+				Count:  observation.Count,
+				Detail: observation.Detail,
+			})
+		}
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.writeErr == nil {
+		err := recorder.writer.Flush()
+		if err != nil {
+			recorder.writeErr = fmt.Errorf("failed to write stability output %s: %w", recorder.outputPath, err)
+		}
+	}
+}
+
+// record keeps sample in memory and appends it to the output file. Only the first write error is kept.
+func (recorder *Recorder) record(sample Sample) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.samples = append(recorder.samples, sample)
+
+	if recorder.writeErr != nil {
+		return
+	}
+
+	line, err := json.Marshal(sample)
+	if err == nil {
+		_, err = recorder.writer.Write(append(line, '\n'))
+	}
+
+	if err != nil {
+		recorder.writeErr = fmt.Errorf("failed to write stability output %s: %w", recorder.outputPath, err)
+	}
+}
+
+// ReadSamples reads the samples from a JSON Lines file written by a Recorder.
+func ReadSamples(path string) ([]Sample, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stability output %s: %w", path, err)
+	}
+
+	defer file.Close()
+
+	var samples []Sample
+
+	decoder := json.NewDecoder(file)
+
+	for decoder.More() {
+		var sample Sample
+
+		err = decoder.Decode(&sample)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode stability sample %d in %s: %w", len(samples)+1, path, err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
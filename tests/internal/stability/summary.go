@@ -0,0 +1,236 @@
+package stability
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// KeySummary describes how the value of a single key from one probe changed over the recording.
+type KeySummary struct {
+	Probe string `json:"probe"`
+	Key   string `json:"key"`
+	Kind  Kind   `json:"kind"`
+	// Samples is the number of rounds of the probe in which the key was present.
+	Samples int `json:"samples"`
+	// Missing is the number of successful rounds of the probe in which the key was absent, such as before a pod was
+	// created or after it was deleted. Absence alone is not a change, since a recreated pod reports a new key.
+	Missing    int    `json:"missing"`
+	FirstState string `json:"firstState,omitempty"`
+	LastState  string `json:"lastState,omitempty"`
+	// Transitions is the number of times the state differed from the previous sample of the key.
+	Transitions int   `json:"transitions"`
+	FirstCount  int64 `json:"firstCount,omitempty"`
+	LastCount   int64 `json:"lastCount,omitempty"`
+	// RestartDelta is the sum of increases in the count between samples of the key. Decreases are ignored.
+	RestartDelta int64 `json:"restartDelta"`
+	// FirstChange is the time of the first round in which the key changed state or increased.
+	FirstChange *time.Time `json:"firstChange,omitempty"`
+}
+
+// Changed returns true if the key changed state or increased between the rounds in which it was present.
+func (keySummary *KeySummary) Changed() bool {
+	return keySummary.Transitions > 0 || keySummary.RestartDelta > 0
+}
+
+// String describes the changes to the key.
+func (keySummary *KeySummary) String() string {
+	description := fmt.Sprintf("%s/%s:", keySummary.Probe, keySummary.Key)
+
+	if keySummary.Transitions > 0 {
+		description += fmt.Sprintf(" %d transitions from %q to %q;",
+			keySummary.Transitions, keySummary.FirstState, keySummary.LastState)
+	}
+
+	if keySummary.RestartDelta > 0 {
+		description += fmt.Sprintf(" increased by %d from %d to %d;",
+			keySummary.RestartDelta, keySummary.FirstCount, keySummary.LastCount)
+	}
+
+	if keySummary.Missing > 0 {
+		description += fmt.Sprintf(" missing from %d of %d samples;",
+			keySummary.Missing, keySummary.Missing+keySummary.Samples)
+	}
+
+	if keySummary.FirstChange != nil {
+		description += fmt.Sprintf(" first changed at %s", keySummary.FirstChange.Format(time.RFC3339))
+	}
+
+	return strings.TrimSuffix(description, ";")
+}
+
+// Summary is the result of a recording, with one KeySummary for every key reported by any probe.
+type Summary struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Rounds is the number of successful rounds of each probe.
+	Rounds map[string]int `json:"rounds"`
+	// ProbeErrors is the number of rounds of each probe that failed.
+	ProbeErrors map[string]int `json:"probeErrors"`
+	Keys        []KeySummary   `json:"keys"`
+}
+
+// probeRound is all of the observations from one successful round of a probe.
+type probeRound struct {
+	time    time.Time
+	samples map[string]Sample
+}
+
+// Summarize builds a Summary from samples, which do not need to be in order. Keys are sorted by probe and then key.
+func Summarize(samples []Sample) *Summary {
+	summary := &Summary{Rounds: make(map[string]int), ProbeErrors: make(map[string]int)}
+
+	sorted := slices.Clone(samples)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	if len(sorted) > 0 {
+		summary.Start = sorted[0].Time
+		summary.End = sorted[len(sorted)-1].Time
+	}
+
+	rounds := make(map[string][]*probeRound)
+	keys := make(map[string]map[string]bool)
+
+	for _, sample := range sorted {
+		if sample.Error != "" {
+			summary.ProbeErrors[sample.Probe]++
+
+			continue
+		}
+
+		probeRounds := rounds[sample.Probe]
+		if len(probeRounds) == 0 || !probeRounds[len(probeRounds)-1].time.Equal(sample.Time) {
+			probeRounds = append(probeRounds, &probeRound{time: sample.Time, samples: make(map[string]Sample)})
+			rounds[sample.Probe] = probeRounds
+		}
+
+		probeRounds[len(probeRounds)-1].samples[sample.Key] = sample
+
+		if keys[sample.Probe] == nil {
+			keys[sample.Probe] = make(map[string]bool)
+		}
+
+		keys[sample.Probe][sample.Key] = true
+	}
+
+	for probe, probeRounds := range rounds {
+		summary.Rounds[probe] = len(probeRounds)
+
+		for key := range keys[probe] {
+			summary.Keys = append(summary.Keys, summarizeKey(probe, key, probeRounds))
+		}
+	}
+
+	sort.Slice(summary.Keys, func(i, j int) bool {
+		if summary.Keys[i].Probe != summary.Keys[j].Probe {
+			return summary.Keys[i].Probe < summary.Keys[j].Probe
+		}
+
+		return summary.Keys[i].Key < summary.Keys[j].Key
+	})
+
+	return summary
+}
+
+// summarizeKey builds the KeySummary for key from the ordered rounds of its probe.
+func summarizeKey(probe, key string, rounds []*probeRound) KeySummary {
+	keySummary := KeySummary{Probe: probe, Key: key}
+
+	var previous *Sample
+
+	markChange := func(changeTime time.Time) {
+		if keySummary.FirstChange == nil {
+			keySummary.FirstChange = &changeTime
+		}
+	}
+
+	for _, round := range rounds {
+		sample, present := round.samples[key]
+		if !present {
+			keySummary.Missing++
+
+			continue
+		}
+
+		keySummary.Samples++
+		keySummary.LastState = sample.State
+		keySummary.LastCount = sample.Count
+
+		if previous == nil {
+			keySummary.Kind = sample.Kind
+			keySummary.FirstState = sample.State
+			keySummary.FirstCount = sample.Count
+		} else {
+			if sample.State != previous.State {
+				keySummary.Transitions++
+
+				markChange(round.time)
+			}
+
+			if sample.Count > previous.Count {
+				keySummary.RestartDelta += sample.Count - previous.Count
+
+				markChange(round.time)
+			}
+		}
+
+		previous = &sample
+	}
+
+	return keySummary
+}
+
+// Changed returns the summaries of every key that changed.
+func (summary *Summary) Changed() []KeySummary {
+	var changed []KeySummary
+
+	for _, keySummary := range summary.Keys {
+		if keySummary.Changed() {
+			changed = append(changed, keySummary)
+		}
+	}
+
+	return changed
+}
+
+// Err returns an error describing every key that changed and every probe that failed in all of its rounds, or nil if
+// there are none.
+func (summary *Summary) Err() error {
+	var errs []error
+
+	for _, probe := range slices.Sorted(maps.Keys(summary.ProbeErrors)) {
+		if summary.Rounds[probe] == 0 {
+			errs = append(errs,
+				fmt.Errorf("stability probe %s failed in all %d rounds", probe, summary.ProbeErrors[probe]))
+		}
+	}
+
+	for _, keySummary := range summary.Changed() {
+		errs = append(errs, fmt.Errorf("stability change detected in %s", keySummary.String()))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Save writes the summary to path as indented JSON.
+func (summary *Summary) Save(path string) error {
+	content, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal stability summary: %w", err)
+	}
+
+	err = os.WriteFile(path, append(content, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write stability summary %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package stability
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(round int) time.Time {
+		return start.Add(time.Duration(round) * time.Minute)
+	}
+
+	testCases := []struct {
+		name            string
+		samples         []Sample
+		expectedKey     KeySummary
+		expectedChanged bool
+	}{
+		{
+			name: "stable state",
+			samples: []Sample{
+				{Time: at(0), Probe: "ptp", Key: "ptp", Kind: KindState, State: "Sync"},
+				{Time: at(1), Probe: "ptp", Key: "ptp", Kind: KindState, State: "Sync"},
+			},
+			expectedKey: KeySummary{
+				Probe: "ptp", Key: "ptp", Kind: KindState, Samples: 2, FirstState: "Sync", LastState: "Sync"},
+		},
+		{
+			name: "state transitions",
+			samples: []Sample{
+				{Time: at(0), Probe: "ptp", Key: "ptp", Kind: KindState, State: "Sync"},
+				{Time: at(1), Probe: "ptp", Key: "ptp", Kind: KindState, State: "Unsync"},
+				{Time: at(2), Probe: "ptp", Key: "ptp", Kind: KindState, State: "Sync"},
+			},
+			expectedKey: KeySummary{
+				Probe: "ptp", Key: "ptp", Kind: KindState, Samples: 3, FirstState: "Sync", LastState: "Sync",
+				Transitions: 2, FirstChange: ptr.To(at(1))},
+			expectedChanged: true,
+		},
+		{
+			name: "counter increases",
+			samples: []Sample{
+				{Time: at(0), Probe: "pods", Key: "etcd-0", Kind: KindCounter, Count: 1},
+				{Time: at(1), Probe: "pods", Key: "etcd-0", Kind: KindCounter, Count: 1},
+				{Time: at(2), Probe: "pods", Key: "etcd-0", Kind: KindCounter, Count: 3},
+			},
+			expectedKey: KeySummary{
+				Probe: "pods", Key: "etcd-0", Kind: KindCounter, Samples: 3, FirstCount: 1, LastCount: 3,
+				RestartDelta: 2, FirstChange: ptr.To(at(2))},
+			expectedChanged: true,
+		},
+		{
+			name: "key disappears",
+			samples: []Sample{
+				{Time: at(0), Probe: "pods", Key: "etcd-0", Kind: KindCounter},
+				{Time: at(0), Probe: "pods", Key: "etcd-1", Kind: KindCounter},
+				{Time: at(1), Probe: "pods", Key: "etcd-1", Kind: KindCounter},
+			},
+			expectedKey: KeySummary{Probe: "pods", Key: "etcd-0", Kind: KindCounter, Samples: 1, Missing: 1},
+		},
+		{
+			name: "key appears",
+			samples: []Sample{
+				{Time: at(0), Probe: "pods", Key: "etcd-1", Kind: KindCounter},
+				{Time: at(1), Probe: "pods", Key: "etcd-0", Kind: KindCounter},
+				{Time: at(1), Probe: "pods", Key: "etcd-1", Kind: KindCounter},
+			},
+			expectedKey: KeySummary{Probe: "pods", Key: "etcd-0", Kind: KindCounter, Samples: 1, Missing: 1},
+		},
+		{
+			name: "probe error is not missing",
+			samples: []Sample{
+				{Time: at(0), Probe: "pods", Key: "etcd-0", Kind: KindCounter},
+				{Time: at(1), Probe: "pods", Error: "connection refused"},
+				{Time: at(2), Probe: "pods", Key: "etcd-0", Kind: KindCounter},
+			},
+			expectedKey: KeySummary{Probe: "pods", Key: "etcd-0", Kind: KindCounter, Samples: 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			summary := Summarize(testCase.samples)
+
+			assert.NotEmpty(t, summary.Keys)
+			assert.Equal(t, testCase.expectedKey, summary.Keys[0])
+			assert.Equal(t, testCase.expectedChanged, summary.Err() != nil)
+		})
+	}
+}
+
+func TestSummaryErr(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		samples       []Sample
+		expectedError string
+	}{
+		{
+			name: "probe fails in some rounds",
+			samples: []Sample{
+				{Time: start, Probe: "ptp", Error: "connection refused"},
+				{Time: start.Add(time.Minute), Probe: "ptp", Key: "ptp", Kind: KindState, State: "Sync"},
+			},
+		},
+		{
+			name: "probe fails in every round",
+			samples: []Sample{
+				{Time: start, Probe: "ptp", Error: "connection refused"},
+				{Time: start.Add(time.Minute), Probe: "ptp", Error: "connection refused"},
+				{Time: start, Probe: "pods", Key: "etcd-0", Kind: KindCounter},
+			},
+			expectedError: "stability probe ptp failed in all 2 rounds",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Summarize(testCase.samples).Err()
+
+			if testCase.expectedError == "" {
+				assert.Nil(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	var rounds atomic.Int64
+
+	probe := NewProbe("counter", func(context.Context) ([]Observation, error) {
+		round := rounds.Add(1)
+		if round == 2 {
+			return nil, fmt.Errorf("probe failed")
+		}
+
+		return []Observation{{Key: "restarts", Kind: KindCounter, Count: round}}, nil
+	})
+
+	outputPath := filepath.Join(t.TempDir(), "stability", "samples.jsonl")
+
+	recorder, err := NewRecorder(outputPath, 10*time.Millisecond, probe)
+	assert.Nil(t, err)
+
+	summary, err := recorder.Run(context.TODO(), 55*time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, summary.ProbeErrors["counter"])
+	assert.Len(t, summary.Keys, 1)
+	assert.Positive(t, summary.Keys[0].RestartDelta)
+	assert.NotNil(t, summary.Err())
+
+	samples, err := ReadSamples(outputPath)
+	assert.Nil(t, err)
+	assert.Equal(t, recorder.Samples(), samples)
+
+	err = recorder.Start(context.TODO())
+	assert.NotNil(t, err)
+}
+
+func TestNewRecorder(t *testing.T) {
+	probe := NewProbe("probe", func(context.Context) ([]Observation, error) {
+		return nil, nil
+	})
+	outputPath := filepath.Join(t.TempDir(), "samples.jsonl")
+
+	_, err := NewRecorder(outputPath, 0, probe)
+	assert.NotNil(t, err)
+
+	_, err = NewRecorder(outputPath, time.Second)
+	assert.NotNil(t, err)
+
+	_, err = NewRecorder(outputPath, time.Second, probe, probe)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,89 @@
+package testpmd
+
+import (
+	"fmt"
+)
+
+const (
+	// MinimumExpectedRxMpps is the receive rate a VF port is expected to sustain in the DPDK traffic tests.
+	MinimumExpectedRxMpps = 0.1
+)
+
+// MinRxRate returns an error when any snapshot of the window received less than minMpps.
+func MinRxRate(window []PortStats, minMpps float64) error {
+	if len(window) == 0 {
+		return fmt.Errorf("empty statistics window")
+	}
+
+	for _, portStats := range window {
+		if portStats.RxMpps() < minMpps {
+			return fmt.Errorf("port %d received %.3f Mpps, expected at least %.3f Mpps",
+				portStats.PortID, portStats.RxMpps(), minMpps)
+		}
+	}
+
+	return nil
+}
+
+// MinTxRate returns an error when any snapshot of the window transmitted less than minMpps.
+func MinTxRate(window []PortStats, minMpps float64) error {
+	if len(window) == 0 {
+		return fmt.Errorf("empty statistics window")
+	}
+
+	for _, portStats := range window {
+		if portStats.TxMpps() < minMpps {
+			return fmt.Errorf("port %d transmitted %.3f Mpps, expected at least %.3f Mpps",
+				portStats.PortID, portStats.TxMpps(), minMpps)
+		}
+	}
+
+	return nil
+}
+
+// ZeroDrops returns an error when the port missed, errored or ran out of mbufs for any packet during the window.
+// The first snapshot is the baseline, so the window needs at least two snapshots.
+func ZeroDrops(window []PortStats) error {
+	if len(window) < 2 {
+		return fmt.Errorf("statistics window has %d snapshots, expected at least 2", len(window))
+	}
+
+	first, last := window[0], window[len(window)-1]
+
+	if last.Drops() > first.Drops() {
+		return fmt.Errorf("port %d dropped %d packets during the window: "+
+			"%d RX missed, %d RX errors, %d RX no mbuf, %d TX errors",
+			last.PortID, last.Drops()-first.Drops(), last.RxMissed-first.RxMissed, last.RxErrors-first.RxErrors,
+			last.RxNoMbuf-first.RxNoMbuf, last.TxErrors-first.TxErrors)
+	}
+
+	return nil
+}
+
+// NoErrors returns an error when the port received or transmitted errored packets during the window. Unlike
+// ZeroDrops, packets missed because the port could not keep up are tolerated. The first snapshot is the baseline, so
+// the window needs at least two snapshots.
+func NoErrors(window []PortStats) error {
+	if len(window) < 2 {
+		return fmt.Errorf("statistics window has %d snapshots, expected at least 2", len(window))
+	}
+
+	first, last := window[0], window[len(window)-1]
+
+	if last.RxErrors > first.RxErrors || last.TxErrors > first.TxErrors {
+		return fmt.Errorf("port %d had %d RX errors and %d TX errors during the window",
+			last.PortID, last.RxErrors-first.RxErrors, last.TxErrors-first.TxErrors)
+	}
+
+	return nil
+}
+
+// ZeroForwardDrops returns an error when the forwarding summary reports dropped packets.
+func ZeroForwardDrops(fwdStats FwdStats) error {
+	if fwdStats.RxDropped > 0 || fwdStats.TxDropped > 0 {
+		return fmt.Errorf("port %d forwarding dropped %d RX and %d TX packets",
+			fwdStats.PortID, fwdStats.RxDropped, fwdStats.TxDropped)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package testpmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// packetsPerMpps is the number of packets per second in one Mpps.
+	packetsPerMpps = 1000000
+)
+
+var (
+	nicStatsHeader         = regexp.MustCompile(`NIC statistics for port (\d+)`)
+	fwdStatsHeader         = regexp.MustCompile(`Forward statistics for port (\d+)`)
+	accumulatedStatsHeader = regexp.MustCompile(`Accumulated forward statistics for all ports`)
+	statsCounter           = regexp.MustCompile(`([A-Za-z-]+):\s+(\d+)`)
+	statsFooter            = regexp.MustCompile(`^\s*[#+-]{10,}\s*$`)
+)
+
+// PortStats is a snapshot of the counters printed by testpmd for a port by show port stats or --stats-period.
+// Counters are cumulative since the port started; the pps and bps rates cover the time since the previous snapshot.
+type PortStats struct {
+	PortID    int
+	RxPackets uint64
+	RxMissed  uint64
+	RxBytes   uint64
+	RxErrors  uint64
+	RxNoMbuf  uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxBytes   uint64
+	RxPPS     uint64
+	RxBPS     uint64
+	TxPPS     uint64
+	TxBPS     uint64
+}
+
+// RxMpps returns the receive rate of the snapshot in millions of packets per second.
+func (portStats PortStats) RxMpps() float64 {
+	return float64(portStats.RxPPS) / packetsPerMpps
+}
+
+// TxMpps returns the transmit rate of the snapshot in millions of packets per second.
+func (portStats PortStats) TxMpps() float64 {
+	return float64(portStats.TxPPS) / packetsPerMpps
+}
+
+// Drops returns the number of packets the port failed to receive or transmit: missed, errored and dropped for lack
+// of mbufs.
+func (portStats PortStats) Drops() uint64 {
+	return portStats.RxMissed + portStats.RxErrors + portStats.RxNoMbuf + portStats.TxErrors
+}
+
+// FwdStats is the forwarding summary printed by testpmd for a port by show fwd stats or when it stops forwarding.
+// PortID is -1 for the summary accumulated over all ports.
+type FwdStats struct {
+	PortID    int
+	RxPackets uint64
+	RxDropped uint64
+	RxTotal   uint64
+	TxPackets uint64
+	TxDropped uint64
+	TxTotal   uint64
+}
+
+// Stats holds every statistics block found in a testpmd output, in output order.
+type Stats struct {
+	Ports       []PortStats
+	Forward     []FwdStats
+	Accumulated *FwdStats
+}
+
+// Parse extracts the port and forwarding statistics from the output of testpmd. It returns an error when the output
+// contains no statistics block.
+func Parse(output string) (*Stats, error) {
+	stats := &Stats{}
+
+	var (
+		portStats *PortStats
+		fwdStats  *FwdStats
+	)
+
+	flush := func() {
+		if portStats != nil {
+			stats.Ports = append(stats.Ports, *portStats)
+			portStats = nil
+		}
+
+		if fwdStats != nil {
+			if fwdStats.PortID < 0 {
+				stats.Accumulated = fwdStats
+			} else {
+				stats.Forward = append(stats.Forward, *fwdStats)
+			}
+
+			fwdStats = nil
+		}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if match := nicStatsHeader.FindStringSubmatch(line); match != nil {
+			flush()
+
+			portID, _ := strconv.Atoi(match[1])
+			portStats = &PortStats{PortID: portID}
+
+			continue
+		}
+
+		if match := fwdStatsHeader.FindStringSubmatch(line); match != nil {
+			flush()
+
+			portID, _ := strconv.Atoi(match[1])
+			fwdStats = &FwdStats{PortID: portID}
+
+			continue
+		}
+
+		if accumulatedStatsHeader.MatchString(line) {
+			flush()
+
+			fwdStats = &FwdStats{PortID: -1}
+
+			continue
+		}
+
+		if statsFooter.MatchString(line) {
+			flush()
+
+			continue
+		}
+
+		for _, counter := range statsCounter.FindAllStringSubmatch(line, -1) {
+			value, err := strconv.ParseUint(counter[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse testpmd counter %s: %w", counter[1], err)
+			}
+
+			switch {
+			case portStats != nil:
+				portStats.set(counter[1], value)
+			case fwdStats != nil:
+				fwdStats.set(counter[1], value)
+			}
+		}
+	}
+
+	flush()
+
+	if len(stats.Ports) == 0 && len(stats.Forward) == 0 && stats.Accumulated == nil {
+		return nil, fmt.Errorf("no port or forward statistics found in testpmd output")
+	}
+
+	return stats, nil
+}
+
+// PortSnapshots returns the snapshots of portID in output order.
+func (stats *Stats) PortSnapshots(portID int) []PortStats {
+	var snapshots []PortStats
+
+	for _, portStats := range stats.Ports {
+		if portStats.PortID == portID {
+			snapshots = append(snapshots, portStats)
+		}
+	}
+
+	return snapshots
+}
+
+// Window returns the last size snapshots of portID. The first snapshot is never included since testpmd reports zero
+// rates in it, even when traffic is already flowing. It returns an error when fewer snapshots were printed, which
+// usually means testpmd did not run for size+1 stats periods.
+func (stats *Stats) Window(portID, size int) ([]PortStats, error) {
+	snapshots := stats.PortSnapshots(portID)
+	if len(snapshots) > 0 {
+		snapshots = snapshots[1:]
+	}
+
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid window size %d", size)
+	}
+
+	if len(snapshots) < size {
+		return nil, fmt.Errorf("port %d has %d statistics snapshots after the first, expected at least %d",
+			portID, len(snapshots), size)
+	}
+
+	return snapshots[len(snapshots)-size:], nil
+}
+
+// ReceivedPackets returns true when any port snapshot reports received packets.
+func (stats *Stats) ReceivedPackets() bool {
+	for _, portStats := range stats.Ports {
+		if portStats.RxPackets > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (portStats *PortStats) set(counter string, value uint64) {
+	switch counter {
+	case "RX-packets":
+		portStats.RxPackets = value
+	case "RX-missed":
+		portStats.RxMissed = value
+	case "RX-bytes":
+		portStats.RxBytes = value
+	case "RX-errors":
+		portStats.RxErrors = value
+	case "RX-nombuf":
+		portStats.RxNoMbuf = value
+	case "TX-packets":
+		portStats.TxPackets = value
+	case "TX-errors":
+		portStats.TxErrors = value
+	case "TX-bytes":
+		portStats.TxBytes = value
+	case "Rx-pps":
+		portStats.RxPPS = value
+	case "Rx-bps":
+		portStats.RxBPS = value
+	case "Tx-pps":
+		portStats.TxPPS = value
+	case "Tx-bps":
+		portStats.TxBPS = value
+	}
+}
+
+func (fwdStats *FwdStats) set(counter string, value uint64) {
+	switch counter {
+	case "RX-packets":
+		fwdStats.RxPackets = value
+	case "RX-dropped":
+		fwdStats.RxDropped = value
+	case "RX-total":
+		fwdStats.RxTotal = value
+	case "TX-packets":
+		fwdStats.TxPackets = value
+	case "TX-dropped":
+		fwdStats.TxDropped = value
+	case "TX-total":
+		fwdStats.TxTotal = value
+	}
+}
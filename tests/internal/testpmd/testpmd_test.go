@@ -0,0 +1,158 @@
+package testpmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOutput = `EAL: Detected CPU lcores: 4
+Port 0: 60:00:00:00:00:01
+Port 1: 60:00:00:00:00:02
+
+Port statistics ====================================
+  ######################## NIC statistics for port 0  ########################
+  RX-packets: 1000000    RX-missed: 10         RX-bytes:  60000000
+  RX-errors: 0
+  RX-nombuf:  0
+  TX-packets: 0          TX-errors: 0          TX-bytes:  0
+
+  Throughput (since last show)
+  Rx-pps:       200000          Rx-bps:     96000000
+  Tx-pps:            0          Tx-bps:            0
+  ############################################################################
+
+  ######################## NIC statistics for port 1  ########################
+  RX-packets: 0          RX-missed: 0          RX-bytes:  0
+  RX-errors: 0
+  RX-nombuf:  0
+  TX-packets: 1000000    TX-errors: 0          TX-bytes:  60000000
+
+  Throughput (since last show)
+  Rx-pps:            0          Rx-bps:            0
+  Tx-pps:       200000          Tx-bps:     96000000
+  ############################################################################
+
+Port statistics ====================================
+  ######################## NIC statistics for port 0  ########################
+  RX-packets: 3500000    RX-missed: 10         RX-bytes:  210000000
+  RX-errors: 0
+  RX-nombuf:  0
+  TX-packets: 0          TX-errors: 0          TX-bytes:  0
+
+  Throughput (since last show)
+  Rx-pps:       500000          Rx-bps:    240000000
+  Tx-pps:            0          Tx-bps:            0
+  ############################################################################
+
+  ---------------------- Forward statistics for port 0  ----------------------
+  RX-packets: 3500000        RX-dropped: 0             RX-total: 3500000
+  TX-packets: 0              TX-dropped: 0             TX-total: 0
+  ----------------------------------------------------------------------------
+
+  ---------------------- Forward statistics for port 1  ----------------------
+  RX-packets: 0              RX-dropped: 0             RX-total: 0
+  TX-packets: 3499000        TX-dropped: 1000          TX-total: 3500000
+  ----------------------------------------------------------------------------
+
+  +++++++++++++++ Accumulated forward statistics for all ports+++++++++++++++
+  RX-packets: 3500000        RX-dropped: 0             RX-total: 3500000
+  TX-packets: 3499000        TX-dropped: 1000          TX-total: 3500000
+  ++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++
+`
+
+func TestParse(t *testing.T) {
+	stats, err := Parse(testOutput)
+	assert.NoError(t, err)
+
+	assert.Len(t, stats.Ports, 3)
+	assert.Equal(t, PortStats{
+		PortID: 0, RxPackets: 1000000, RxMissed: 10, RxBytes: 60000000, RxPPS: 200000, RxBPS: 96000000,
+	}, stats.Ports[0])
+	assert.Equal(t, 1, stats.Ports[1].PortID)
+	assert.Equal(t, uint64(1000000), stats.Ports[1].TxPackets)
+	assert.Equal(t, uint64(200000), stats.Ports[1].TxPPS)
+	assert.InDelta(t, 0.5, stats.Ports[2].RxMpps(), 1e-9)
+
+	assert.Len(t, stats.Forward, 2)
+	assert.Equal(t, FwdStats{
+		PortID: 1, TxPackets: 3499000, TxDropped: 1000, TxTotal: 3500000,
+	}, stats.Forward[1])
+	assert.NotNil(t, stats.Accumulated)
+	assert.Equal(t, -1, stats.Accumulated.PortID)
+	assert.Equal(t, uint64(3500000), stats.Accumulated.RxTotal)
+
+	assert.True(t, stats.ReceivedPackets())
+
+	_, err = Parse("EAL: Detected CPU lcores: 4\n")
+	assert.Error(t, err)
+}
+
+func TestWindow(t *testing.T) {
+	stats, err := Parse(testOutput)
+	assert.NoError(t, err)
+
+	window, err := stats.Window(0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3500000}, []uint64{window[0].RxPackets})
+
+	_, err = stats.Window(0, 2)
+	assert.ErrorContains(t, err, "port 0 has 1 statistics snapshots after the first, expected at least 2")
+
+	_, err = stats.Window(1, 1)
+	assert.Error(t, err)
+
+	_, err = stats.Window(0, 0)
+	assert.Error(t, err)
+}
+
+func TestMinRate(t *testing.T) {
+	window := []PortStats{{RxPPS: 200000, TxPPS: 100000}, {RxPPS: 500000, TxPPS: 100000}}
+
+	assert.NoError(t, MinRxRate(window, 0.2))
+	assert.Error(t, MinRxRate(window, 0.3))
+	assert.NoError(t, MinTxRate(window, 0.1))
+	assert.Error(t, MinTxRate(window, 0.2))
+	assert.Error(t, MinRxRate(nil, 0))
+}
+
+func TestZeroDrops(t *testing.T) {
+	testCases := []struct {
+		name      string
+		window    []PortStats
+		zeroDrops bool
+		noErrors  bool
+	}{
+		{
+			name:      "drops before the window",
+			window:    []PortStats{{RxMissed: 10, RxErrors: 1}, {RxMissed: 10, RxErrors: 1}},
+			zeroDrops: true,
+			noErrors:  true,
+		},
+		{
+			name:     "missed packets",
+			window:   []PortStats{{RxMissed: 10}, {RxMissed: 20}},
+			noErrors: true,
+		},
+		{
+			name:   "no mbuf and TX errors",
+			window: []PortStats{{}, {RxNoMbuf: 1, TxErrors: 1}},
+		},
+		{
+			name:   "single snapshot",
+			window: []PortStats{{}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.zeroDrops, ZeroDrops(testCase.window) == nil)
+			assert.Equal(t, testCase.noErrors, NoErrors(testCase.window) == nil)
+		})
+	}
+}
+
+func TestZeroForwardDrops(t *testing.T) {
+	assert.NoError(t, ZeroForwardDrops(FwdStats{RxPackets: 10, TxPackets: 10}))
+	assert.Error(t, ZeroForwardDrops(FwdStats{RxPackets: 10, TxPackets: 9, TxDropped: 1}))
+}
@@ -0,0 +1,304 @@
+package ublox
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MessageNavStatus is the UBX-NAV-STATUS message, reporting the receiver navigation status.
+	MessageNavStatus = "NAV-STATUS"
+	// MessageNavTimeLS is the UBX-NAV-TIMELS message, reporting leap second information.
+	MessageNavTimeLS = "NAV-TIMELS"
+	// MessageMonRF is the UBX-MON-RF message, reporting the RF and antenna status of each RF block.
+	MessageMonRF = "MON-RF"
+)
+
+// GPSFixType is the type of fix reported in the gpsFix field of UBX-NAV-STATUS.
+type GPSFixType int64
+
+const (
+	// GPSFixNone means the receiver has no fix.
+	GPSFixNone GPSFixType = 0
+	// GPSFixDeadReckoning means the receiver only has a dead reckoning fix.
+	GPSFixDeadReckoning GPSFixType = 1
+	// GPSFix2D means the receiver has a 2D fix.
+	GPSFix2D GPSFixType = 2
+	// GPSFix3D means the receiver has a 3D fix.
+	GPSFix3D GPSFixType = 3
+	// GPSFixGNSSDeadReckoning means the receiver has a GNSS fix combined with dead reckoning.
+	GPSFixGNSSDeadReckoning GPSFixType = 4
+	// GPSFixTimeOnly means the receiver has a time only fix, which is the usual state of a timing receiver in
+	// fixed position mode.
+	GPSFixTimeOnly GPSFixType = 5
+)
+
+// AntennaState is the antenna status reported in the antStatus field of UBX-MON-RF.
+type AntennaState int64
+
+const (
+	// AntennaStateInit means the antenna supervisor is initializing.
+	AntennaStateInit AntennaState = 0
+	// AntennaStateUnknown means the antenna status is not known, usually because supervision is disabled.
+	AntennaStateUnknown AntennaState = 1
+	// AntennaStateOK means the antenna is connected and working.
+	AntennaStateOK AntennaState = 2
+	// AntennaStateShort means the antenna is short circuited.
+	AntennaStateShort AntennaState = 3
+	// AntennaStateOpen means the antenna is disconnected.
+	AntennaStateOpen AntennaState = 4
+)
+
+const (
+	// navStatusGPSFixOK is the gpsFixOk bit of the UBX-NAV-STATUS flags field.
+	navStatusGPSFixOK = 0x1
+	// navTimeLSCurrLsValid is the validCurrLs bit of the UBX-NAV-TIMELS valid field.
+	navTimeLSCurrLsValid = 0x1
+	// navTimeLSTimeToLsEventValid is the validTimeToLsEvent bit of the UBX-NAV-TIMELS valid field.
+	navTimeLSTimeToLsEventValid = 0x2
+)
+
+// NavStatus is the decoded UBX-NAV-STATUS message.
+type NavStatus struct {
+	ITOW    int64
+	GPSFix  GPSFixType
+	Flags   int64
+	FixStat int64
+	Flags2  int64
+	// TTFF is the time to first fix in milliseconds.
+	TTFF int64
+	// MSSS is the time since startup or reset in milliseconds.
+	MSSS int64
+}
+
+// FixOK returns whether the gpsFixOk flag is set, meaning the fix is within the configured limits.
+func (status *NavStatus) FixOK() bool {
+	return status.Flags&navStatusGPSFixOK != 0
+}
+
+// HasFix returns whether the receiver has a valid fix usable for timing, that is a fix that is neither missing nor
+// dead reckoning only and has the gpsFixOk flag set.
+func (status *NavStatus) HasFix() bool {
+	return status.FixOK() && status.GPSFix != GPSFixNone && status.GPSFix != GPSFixDeadReckoning
+}
+
+// NavTimeLS is the decoded UBX-NAV-TIMELS message.
+type NavTimeLS struct {
+	ITOW        int64
+	SrcOfCurrLs int64
+	// CurrLs is the current number of leap seconds since 1980.
+	CurrLs        int64
+	SrcOfLsChange int64
+	LsChange      int64
+	TimeToLsEvent int64
+	DateOfLsGpsWn int64
+	DateOfLsGpsDn int64
+	Valid         int64
+}
+
+// CurrLsValid returns whether CurrLs is valid.
+func (timeLS *NavTimeLS) CurrLsValid() bool {
+	return timeLS.Valid&navTimeLSCurrLsValid != 0
+}
+
+// TimeToLsEventValid returns whether TimeToLsEvent, LsChange, and the date of the leap second event are valid.
+func (timeLS *NavTimeLS) TimeToLsEventValid() bool {
+	return timeLS.Valid&navTimeLSTimeToLsEventValid != 0
+}
+
+// RFBlock is the status of a single RF block decoded from UBX-MON-RF.
+type RFBlock struct {
+	BlockID    int64
+	AntStatus  AntennaState
+	AntPower   int64
+	JamInd     int64
+	NoisePerMS int64
+	AgcCnt     int64
+}
+
+// AntennaOK returns whether the antenna of the RF block is connected and working.
+func (block *RFBlock) AntennaOK() bool {
+	return block.AntStatus == AntennaStateOK
+}
+
+// field is a single name and value pair decoded from ubxtool output.
+type field struct {
+	name  string
+	value int64
+}
+
+var fieldNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// ParseNavStatus parses the last UBX-NAV-STATUS message in the ubxtool output.
+func ParseNavStatus(output string) (*NavStatus, error) {
+	values, err := parseLastMessage(output, MessageNavStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NavStatus{
+		ITOW:    values["iTOW"],
+		GPSFix:  GPSFixType(values["gpsFix"]),
+		Flags:   values["flags"],
+		FixStat: values["fixStat"],
+		Flags2:  values["flags2"],
+		TTFF:    values["ttff"],
+		MSSS:    values["msss"],
+	}, nil
+}
+
+// ParseNavTimeLS parses the last UBX-NAV-TIMELS message in the ubxtool output.
+func ParseNavTimeLS(output string) (*NavTimeLS, error) {
+	values, err := parseLastMessage(output, MessageNavTimeLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NavTimeLS{
+		ITOW:          values["iTOW"],
+		SrcOfCurrLs:   values["srcOfCurrLs"],
+		CurrLs:        values["currLs"],
+		SrcOfLsChange: values["srcOfLsChange"],
+		LsChange:      values["lsChange"],
+		TimeToLsEvent: values["timeToLsEvent"],
+		DateOfLsGpsWn: values["dateOfLsGpsWn"],
+		DateOfLsGpsDn: values["dateOfLsGpsDn"],
+		Valid:         values["valid"],
+	}, nil
+}
+
+// ParseMonRF parses the RF blocks of the last UBX-MON-RF message in the ubxtool output.
+func ParseMonRF(output string) ([]RFBlock, error) {
+	fields, err := lastMessageFields(output, MessageMonRF)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []RFBlock
+
+	for _, field := range fields {
+		if field.name == "blockId" {
+			blocks = append(blocks, RFBlock{BlockID: field.value})
+
+			continue
+		}
+
+		if len(blocks) == 0 {
+			continue
+		}
+
+		block := &blocks[len(blocks)-1]
+
+		switch field.name {
+		case "antStatus":
+			block.AntStatus = AntennaState(field.value)
+		case "antPower":
+			block.AntPower = field.value
+		case "jamInd":
+			block.JamInd = field.value
+		case "noisePerMS":
+			block.NoisePerMS = field.value
+		case "agcCnt":
+			block.AgcCnt = field.value
+		}
+	}
+
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no RF blocks found in UBX-%s message", MessageMonRF)
+	}
+
+	return blocks, nil
+}
+
+// parseLastMessage returns the fields of the last message in the ubxtool output as a map. Fields repeated within the
+// message keep their first value.
+func parseLastMessage(output, message string) (map[string]int64, error) {
+	fields, err := lastMessageFields(output, message)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]int64)
+
+	for _, field := range fields {
+		if _, found := values[field.name]; !found {
+			values[field.name] = field.value
+		}
+	}
+
+	return values, nil
+}
+
+// lastMessageFields returns the fields of the last occurrence of the message in the ubxtool output. Since ubxtool
+// prints every message received while waiting for the response, including periodic ones, the last occurrence is the
+// most recent. The message body consists of the indented lines following the UBX-<message>: header.
+func lastMessageFields(output, message string) ([]field, error) {
+	header := fmt.Sprintf("UBX-%s:", message)
+	lines := strings.Split(output, "\n")
+	start := -1
+
+	for index, line := range lines {
+		if strings.TrimSpace(line) == header {
+			start = index + 1
+		}
+	}
+
+	if start == -1 {
+		return nil, fmt.Errorf("no UBX-%s message found in ubxtool output", message)
+	}
+
+	var fields []field
+
+	for _, line := range lines[start:] {
+		if strings.TrimSpace(line) == "" || !strings.HasPrefix(line, " ") {
+			break
+		}
+
+		fields = append(fields, parseFields(line)...)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("UBX-%s message in ubxtool output has no fields", message)
+	}
+
+	return fields, nil
+}
+
+// parseFields parses a line of ubxtool output made of name value pairs, such as "ttff 25799, msss 4133998". Tokens
+// that are not part of a pair, such as the extra values of reserved arrays, are ignored.
+func parseFields(line string) []field {
+	tokens := strings.Fields(strings.ReplaceAll(line, ",", " "))
+
+	var fields []field
+
+	for index := 0; index < len(tokens)-1; index++ {
+		if !fieldNameRegex.MatchString(tokens[index]) {
+			continue
+		}
+
+		value, err := parseValue(tokens[index+1])
+		if err != nil {
+			continue
+		}
+
+		fields = append(fields, field{name: tokens[index], value: value})
+		index++
+	}
+
+	return fields
+}
+
+// parseValue parses a decimal value or a hexadecimal value prefixed with 0x or x, as ubxtool prints bit fields.
+func parseValue(token string) (int64, error) {
+	if hexValue, found := strings.CutPrefix(token, "0x"); found {
+		return strconv.ParseInt(hexValue, 16, 64)
+	}
+
+	if hexValue, found := strings.CutPrefix(token, "x"); found {
+		return strconv.ParseInt(hexValue, 16, 64)
+	}
+
+	return strconv.ParseInt(token, 10, 64)
+}
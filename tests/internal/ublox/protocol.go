@@ -0,0 +1,194 @@
+package ublox
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const (
+	// ProtocolVersionE825E830 is the u-blox protocol version of the receivers used with the e825 and e830 plugins.
+	// For more information, see https://content.u-blox.com/sites/default/files/documents/
+	// u-blox-F9-TIM-2.25_InterfaceDescription_UBXDOC-963802114-13231.pdf.
+	ProtocolVersionE825E830 = "29.25"
+	// ProtocolVersionE810 is the u-blox protocol version of the receivers used with the e810 plugin. For more
+	// information, see https://content.u-blox.com/sites/default/files/
+	// u-blox-F9-TIM-2.20_InterfaceDescription_UBX-21048598.pdf.
+	ProtocolVersionE810 = "29.20"
+)
+
+// ProtocolFromPlugins returns the protocol version to pass to ubxtool based on the plugins of a PTP profile. If one of
+// the e825 or e830 plugins is present, it returns ProtocolVersionE825E830. If the e810 plugin is present, it returns
+// ProtocolVersionE810. Otherwise, it returns false.
+func ProtocolFromPlugins(plugins map[string]*apiextensions.JSON) (string, bool) {
+	if _, hasE825 := plugins[string(ptp.PluginTypeE825)]; hasE825 {
+		return ProtocolVersionE825E830, true
+	}
+
+	if _, hasE830 := plugins[string(ptp.PluginTypeE830)]; hasE830 {
+		return ProtocolVersionE825E830, true
+	}
+
+	if _, hasE810 := plugins[string(ptp.PluginTypeE810)]; hasE810 {
+		return ProtocolVersionE810, true
+	}
+
+	return "", false
+}
+
+// ProtocolFromProfile returns the protocol version to pass to ubxtool based on the plugins of the provided profile.
+// It returns an error if the profile has none of the e825, e830, or e810 plugins.
+func ProtocolFromProfile(profile *ptpv1.PtpProfile) (string, error) {
+	if profile == nil {
+		return "", fmt.Errorf("profile is nil")
+	}
+
+	if profile.Name == nil {
+		return "", fmt.Errorf("profile name is nil")
+	}
+
+	if len(profile.Plugins) < 1 {
+		return "", fmt.Errorf("profile %q does not have any plugins", *profile.Name)
+	}
+
+	protocolVersion, found := ProtocolFromPlugins(profile.Plugins)
+	if !found {
+		return "", fmt.Errorf("profile %q does not have any e825, e830, or e810 plugins", *profile.Name)
+	}
+
+	return protocolVersion, nil
+}
+
+// ProtocolForNode returns the protocol version to pass to ubxtool based on the PtpConfig profile applied to the
+// provided node. Profiles are matched using the PtpConfig status first, then the recommendations by node name and
+// finally the recommendations by node label, with recommendations sorted by priority.
+func ProtocolForNode(apiClient *clients.Settings, nodeName string) (string, error) {
+	ptpConfigs, err := ptp.ListPtpConfigs(apiClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to list PtpConfigs: %w", err)
+	}
+
+	nodeBuilder, err := nodes.Pull(apiClient, nodeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull node %s: %w", nodeName, err)
+	}
+
+	for _, ptpConfigBuilder := range ptpConfigs {
+		ptpConfig := ptpConfigBuilder.Object
+		if ptpConfig == nil {
+			ptpConfig = ptpConfigBuilder.Definition
+		}
+
+		if ptpConfig == nil {
+			continue
+		}
+
+		if protocolVersion, found := protocolFromPtpConfig(ptpConfig, nodeName, nodeBuilder.Object.Labels); found {
+			return protocolVersion, nil
+		}
+	}
+
+	return "", fmt.Errorf("no PtpConfig profile with the %s, %s, or %s plugin applies to node %s",
+		ptp.PluginTypeE810, ptp.PluginTypeE825, ptp.PluginTypeE830, nodeName)
+}
+
+// protocolFromPtpConfig returns the protocol version of the profile in ptpConfig that applies to the provided node.
+func protocolFromPtpConfig(
+	ptpConfig *ptpv1.PtpConfig, nodeName string, nodeLabels map[string]string) (string, bool) {
+	for _, match := range ptpConfig.Status.MatchList {
+		if match.NodeName == nil || match.Profile == nil || *match.NodeName != nodeName {
+			continue
+		}
+
+		if protocolVersion, found := protocolFromProfileName(ptpConfig, *match.Profile); found {
+			return protocolVersion, true
+		}
+	}
+
+	recommendations := slices.Clone(ptpConfig.Spec.Recommend)
+	slices.SortStableFunc(recommendations, func(a, b ptpv1.PtpRecommend) int {
+		return cmp.Compare(recommendPriority(a), recommendPriority(b))
+	})
+
+	for _, recommendation := range recommendations {
+		if recommendation.Profile == nil {
+			continue
+		}
+
+		for _, rule := range recommendation.Match {
+			if rule.NodeName == nil || *rule.NodeName != nodeName {
+				continue
+			}
+
+			if protocolVersion, found := protocolFromProfileName(ptpConfig, *recommendation.Profile); found {
+				return protocolVersion, true
+			}
+		}
+	}
+
+	for _, recommendation := range recommendations {
+		if recommendation.Profile == nil {
+			continue
+		}
+
+		for _, rule := range recommendation.Match {
+			if rule.NodeLabel == nil || !nodeLabelMatches(*rule.NodeLabel, nodeLabels) {
+				continue
+			}
+
+			if protocolVersion, found := protocolFromProfileName(ptpConfig, *recommendation.Profile); found {
+				return protocolVersion, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// protocolFromProfileName returns the protocol version of the profile in ptpConfig with the provided name.
+func protocolFromProfileName(ptpConfig *ptpv1.PtpConfig, profileName string) (string, bool) {
+	for _, profile := range ptpConfig.Spec.Profile {
+		if profile.Name != nil && *profile.Name == profileName {
+			return ProtocolFromPlugins(profile.Plugins)
+		}
+	}
+
+	return "", false
+}
+
+// nodeLabelMatches returns whether the node labels match the node label rule of a recommendation. Rules are either a
+// label key, which only needs to be present, or a key=value pair.
+func nodeLabelMatches(rule string, nodeLabels map[string]string) bool {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return false
+	}
+
+	if key, value, found := strings.Cut(rule, "="); found {
+		actual, labelFound := nodeLabels[strings.TrimSpace(key)]
+
+		return labelFound && actual == strings.TrimSpace(value)
+	}
+
+	_, labelFound := nodeLabels[rule]
+
+	return labelFound
+}
+
+// recommendPriority returns the priority of a recommendation, treating recommendations without a priority as the
+// lowest priority.
+func recommendPriority(recommendation ptpv1.PtpRecommend) int64 {
+	if recommendation.Priority == nil {
+		return math.MaxInt64
+	}
+
+	return *recommendation.Priority
+}
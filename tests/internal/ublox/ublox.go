@@ -0,0 +1,343 @@
+// Package ublox controls the u-blox GNSS receivers used by the Intel PTP plugins through the ubxtool command in the
+// linuxptp daemon pod. It resolves the protocol version of a node, sends configuration, constellation, and restart
+// commands, and parses the status messages polled from the receiver.
+package ublox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	daemonNamespace     = "openshift-ptp"
+	daemonContainerName = "linuxptp-daemon-container"
+	daemonPodLabelKey   = "app"
+	daemonPodLabelValue = "linuxptp-daemon"
+	defaultAttempts     = 3
+	defaultRetryDelay   = 5 * time.Second
+	pollInterval        = 10 * time.Second
+	logLevel            = 90
+)
+
+const (
+	// navSpgInfilNcnoThrs is the number of satellites with acceptable noise covariance thresholds required for a fix
+	// to be attempted.
+	navSpgInfilNcnoThrs = "CFG-NAVSPG-INFIL_NCNOTHRS"
+	// syncLossSatellites is an artificially high number of satellites required for a fix to be attempted.
+	syncLossSatellites = 50
+	// defaultSatellites is the default number of satellites required for a fix to be attempted.
+	defaultSatellites = 0
+	// ramLayer specifies writes go to the RAM layer only, so configuration changes are in place until reboot.
+	ramLayer = 1
+)
+
+// Constellation is a GNSS constellation as named by ubxtool.
+type Constellation string
+
+const (
+	// ConstellationGPS is the GPS constellation.
+	ConstellationGPS Constellation = "GPS"
+	// ConstellationGalileo is the Galileo constellation.
+	ConstellationGalileo Constellation = "GALILEO"
+	// ConstellationGLONASS is the GLONASS constellation.
+	ConstellationGLONASS Constellation = "GLONASS"
+	// ConstellationBeiDou is the BeiDou constellation.
+	ConstellationBeiDou Constellation = "BEIDOU"
+	// ConstellationSBAS is the SBAS augmentation system.
+	ConstellationSBAS Constellation = "SBAS"
+	// ConstellationQZSS is the QZSS constellation.
+	ConstellationQZSS Constellation = "QZSS"
+)
+
+// RestartType is the type of receiver restart, as named by the ubxtool presets.
+type RestartType string
+
+const (
+	// RestartCold discards all navigation data, forcing the receiver to search for satellites from scratch.
+	RestartCold RestartType = "COLDBOOT"
+	// RestartWarm keeps the almanac but discards the ephemeris.
+	RestartWarm RestartType = "WARMBOOT"
+	// RestartHot keeps all navigation data.
+	RestartHot RestartType = "HOTBOOT"
+)
+
+// Executor runs command in the linuxptp daemon container on nodeName and returns its output.
+type Executor func(nodeName, command string) (string, error)
+
+// Receiver is the u-blox receiver of a node. It should be created using NewReceiver.
+type Receiver struct {
+	NodeName        string
+	ProtocolVersion string
+	executor        Executor
+}
+
+// ReceiverOption is a function type that can be used to set the options of a Receiver.
+type ReceiverOption func(*Receiver)
+
+// WithExecutor sets the function used to run ubxtool commands. It defaults to running the command in the linuxptp
+// daemon pod on the node, retrying on errors.
+func WithExecutor(executor Executor) ReceiverOption {
+	return func(receiver *Receiver) {
+		receiver.executor = executor
+	}
+}
+
+// NewReceiver returns a Receiver for the node that sends ubxtool commands using protocolVersion. The protocol version
+// can be resolved using ProtocolForNode or ProtocolFromProfile.
+func NewReceiver(
+	apiClient *clients.Settings, nodeName, protocolVersion string, options ...ReceiverOption) *Receiver {
+	receiver := &Receiver{
+		NodeName:        nodeName,
+		ProtocolVersion: protocolVersion,
+		executor:        newDaemonPodExecutor(apiClient),
+	}
+
+	for _, option := range options {
+		option(receiver)
+	}
+
+	return receiver
+}
+
+// SimulateSyncLoss simulates a loss of GNSS sync by setting the required number of satellites for a fix to be
+// artificially high. The change is written to the RAM layer only.
+func (receiver *Receiver) SimulateSyncLoss() error {
+	err := receiver.SetConfig(navSpgInfilNcnoThrs, syncLossSatellites)
+	if err != nil {
+		return fmt.Errorf("failed to simulate GNSS loss on node %s: %w", receiver.NodeName, err)
+	}
+
+	return nil
+}
+
+// SimulateSyncRecovery undoes SimulateSyncLoss by setting the required number of satellites for a fix to be attempted
+// back to the default value.
+func (receiver *Receiver) SimulateSyncRecovery() error {
+	err := receiver.SetConfig(navSpgInfilNcnoThrs, defaultSatellites)
+	if err != nil {
+		return fmt.Errorf("failed to restore GNSS sync on node %s: %w", receiver.NodeName, err)
+	}
+
+	return nil
+}
+
+// SetConfig sets the configuration item to value in the RAM layer of the receiver using a UBX-CFG-VALSET message.
+func (receiver *Receiver) SetConfig(item string, value int) error {
+	_, err := receiver.run("-w", "1", "-v", "3", "-z", fmt.Sprintf("%s,%d,%d", item, value, ramLayer))
+
+	return err
+}
+
+// EnableConstellation enables the GNSS constellation on the receiver.
+func (receiver *Receiver) EnableConstellation(constellation Constellation) error {
+	_, err := receiver.run("-w", "1", "-e", string(constellation))
+	if err != nil {
+		return fmt.Errorf("failed to enable constellation %s on node %s: %w", constellation, receiver.NodeName, err)
+	}
+
+	return nil
+}
+
+// DisableConstellation disables the GNSS constellation on the receiver.
+func (receiver *Receiver) DisableConstellation(constellation Constellation) error {
+	_, err := receiver.run("-w", "1", "-d", string(constellation))
+	if err != nil {
+		return fmt.Errorf("failed to disable constellation %s on node %s: %w", constellation, receiver.NodeName, err)
+	}
+
+	return nil
+}
+
+// Restart restarts the receiver using the provided restart type.
+func (receiver *Receiver) Restart(restartType RestartType) error {
+	_, err := receiver.run("-w", "1", "-p", string(restartType))
+	if err != nil {
+		return fmt.Errorf("failed to restart the receiver on node %s using %s: %w", receiver.NodeName, restartType, err)
+	}
+
+	return nil
+}
+
+// NavStatus polls and returns the UBX-NAV-STATUS message of the receiver.
+func (receiver *Receiver) NavStatus() (*NavStatus, error) {
+	output, err := receiver.poll(MessageNavStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseNavStatus(output)
+}
+
+// NavTimeLS polls and returns the UBX-NAV-TIMELS message of the receiver.
+func (receiver *Receiver) NavTimeLS() (*NavTimeLS, error) {
+	output, err := receiver.poll(MessageNavTimeLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseNavTimeLS(output)
+}
+
+// AntennaStatus polls the UBX-MON-RF message of the receiver and returns the status of each RF block.
+func (receiver *Receiver) AntennaStatus() ([]RFBlock, error) {
+	output, err := receiver.poll(MessageMonRF)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseMonRF(output)
+}
+
+// VerifyAntennaOK returns an error if the antenna of any RF block of the receiver is not reported as OK.
+func (receiver *Receiver) VerifyAntennaOK() error {
+	blocks, err := receiver.AntennaStatus()
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		if !block.AntennaOK() {
+			return fmt.Errorf("antenna of RF block %d on node %s has status %d, expected %d",
+				block.BlockID, receiver.NodeName, block.AntStatus, AntennaStateOK)
+		}
+	}
+
+	return nil
+}
+
+// VerifyLeapSeconds returns an error if the current number of leap seconds reported by the receiver is not valid or
+// does not equal expected.
+func (receiver *Receiver) VerifyLeapSeconds(expected int64) error {
+	timeLS, err := receiver.NavTimeLS()
+	if err != nil {
+		return err
+	}
+
+	if !timeLS.CurrLsValid() {
+		return fmt.Errorf("current leap seconds reported by node %s are not valid", receiver.NodeName)
+	}
+
+	if timeLS.CurrLs != expected {
+		return fmt.Errorf("node %s reports %d leap seconds, expected %d", receiver.NodeName, timeLS.CurrLs, expected)
+	}
+
+	return nil
+}
+
+// WaitForFix waits up to timeout for the receiver to report a valid fix.
+func (receiver *Receiver) WaitForFix(timeout time.Duration) error {
+	return receiver.waitForNavStatus(timeout, "a valid fix", (*NavStatus).HasFix)
+}
+
+// WaitForFixLoss waits up to timeout for the receiver to report no valid fix, such as after SimulateSyncLoss.
+func (receiver *Receiver) WaitForFixLoss(timeout time.Duration) error {
+	return receiver.waitForNavStatus(timeout, "no valid fix", func(status *NavStatus) bool {
+		return !status.HasFix()
+	})
+}
+
+// waitForNavStatus polls UBX-NAV-STATUS until condition is met or timeout elapses. Errors polling the receiver are
+// logged and retried.
+func (receiver *Receiver) waitForNavStatus(
+	timeout time.Duration, description string, condition func(*NavStatus) bool) error {
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			status, err := receiver.NavStatus()
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to get NAV-STATUS on node %s: %v", receiver.NodeName, err)
+
+				return false, nil
+			}
+
+			return condition(status), nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed waiting for node %s to report %s: %w", receiver.NodeName, description, err)
+	}
+
+	return nil
+}
+
+// poll polls the message from the receiver and returns the ubxtool output. ubxtool waits 2 seconds for the response,
+// printing every message received in the meantime.
+func (receiver *Receiver) poll(message string) (string, error) {
+	output, err := receiver.run("-w", "2", "-p", message)
+	if err != nil {
+		return "", fmt.Errorf("failed to poll %s on node %s: %w", message, receiver.NodeName, err)
+	}
+
+	return output, nil
+}
+
+// run runs ubxtool with the protocol version of the receiver and the provided arguments.
+func (receiver *Receiver) run(args ...string) (string, error) {
+	if receiver.ProtocolVersion == "" {
+		return "", fmt.Errorf("protocol version is empty")
+	}
+
+	command := fmt.Sprintf("ubxtool -P %s %s", receiver.ProtocolVersion, strings.Join(args, " "))
+
+	klog.V(logLevel).Infof("Running %q on node %s", command, receiver.NodeName)
+
+	return receiver.executor(receiver.NodeName, command)
+}
+
+// newDaemonPodExecutor returns an Executor that runs commands in the linuxptp daemon pod on the node. The pod is looked
+// up on every attempt to account for it being deleted and recreated.
+func newDaemonPodExecutor(apiClient *clients.Settings) Executor {
+	return func(nodeName, command string) (string, error) {
+		var lastErr error
+
+		for attempt := range defaultAttempts {
+			if attempt > 0 {
+				time.Sleep(defaultRetryDelay << (attempt - 1))
+			}
+
+			daemonPod, err := getDaemonPod(apiClient, nodeName)
+			if err != nil {
+				lastErr = err
+
+				continue
+			}
+
+			output, err := daemonPod.ExecCommand([]string{"sh", "-c", command}, daemonContainerName)
+			if err != nil {
+				lastErr = fmt.Errorf("failed to execute command on node %s: %w, output: %s",
+					nodeName, err, output.String())
+
+				continue
+			}
+
+			return output.String(), nil
+		}
+
+		return "", fmt.Errorf("failed after %d attempts: %w", defaultAttempts, lastErr)
+	}
+}
+
+// getDaemonPod returns the linuxptp daemon pod running on the node.
+func getDaemonPod(apiClient *clients.Settings, nodeName string) (*pod.Builder, error) {
+	daemonPods, err := pod.List(apiClient, daemonNamespace, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{daemonPodLabelKey: daemonPodLabelValue}).String(),
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": nodeName}).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PTP daemon pods on node %s: %w", nodeName, err)
+	}
+
+	if len(daemonPods) != 1 {
+		return nil, fmt.Errorf("expected exactly one PTP daemon pod on node %s, found %d", nodeName, len(daemonPods))
+	}
+
+	return daemonPods[0], nil
+}
@@ -0,0 +1,180 @@
+package ublox
+
+import (
+	"errors"
+	"testing"
+
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	"github.com/stretchr/testify/assert"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+)
+
+const testNavStatusOutput = `UBX-NAV-STATUS:
+  iTOW 158861000 gpsFix 0 flags 0xd0 fixStat 0x0 flags2 0x8
+  ttff 0, msss 4132998
+
+UBX-TIM-TP:
+  towMS 158862000 towSubMS 0 qErr 0 week 2311 flags 0x1b refInfo 0x0
+
+UBX-NAV-STATUS:
+  iTOW 158862000 gpsFix 5 flags 0xdd fixStat 0x0 flags2 0x8
+  ttff 25799, msss 4133998
+`
+
+const testNavTimeLSOutput = `UBX-NAV-TIMELS:
+  iTOW 158862000 version 0 reserved2 0 0 0 srcOfCurrLs 2
+  currLs 18 srcOfLsChange 2 lsChange 0 timeToLsEvent 0
+  dateOfLsGpsWn 2185 dateOfLsGpsDn 7 reserved2 0 0 0
+  valid x3
+`
+
+const testMonRFOutput = `UBX-MON-RF:
+ version 0 nBlocks 2 reserved1 0 0
+   blockId 0 flags x0 antStatus 2 antPower 1 postStatus 0 reserved2 0 0 0 0
+    noisePerMS 82 agcCnt 6318 jamInd 3 ofsI 15 magI 154 ofsQ 2 magQ 145
+    reserved3 0 0 0
+   blockId 1 flags x0 antStatus 4 antPower 1 postStatus 0 reserved2 0 0 0 0
+    noisePerMS 43 agcCnt 6673 jamInd 2 ofsI 12 magI 153 ofsQ 5 magQ 142
+    reserved3 0 0 0
+`
+
+func TestParseNavStatus(t *testing.T) {
+	status, err := ParseNavStatus(testNavStatusOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, &NavStatus{
+		ITOW: 158862000, GPSFix: GPSFixTimeOnly, Flags: 0xdd, Flags2: 0x8, TTFF: 25799, MSSS: 4133998,
+	}, status)
+	assert.True(t, status.HasFix())
+
+	status = &NavStatus{GPSFix: GPSFix3D}
+	assert.False(t, status.HasFix())
+
+	_, err = ParseNavStatus(testNavTimeLSOutput)
+	assert.Error(t, err)
+
+	_, err = ParseNavStatus("UBX-NAV-STATUS:\n\n")
+	assert.Error(t, err)
+}
+
+func TestParseNavTimeLS(t *testing.T) {
+	timeLS, err := ParseNavTimeLS(testNavTimeLSOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(18), timeLS.CurrLs)
+	assert.Equal(t, int64(2185), timeLS.DateOfLsGpsWn)
+	assert.Equal(t, int64(7), timeLS.DateOfLsGpsDn)
+	assert.True(t, timeLS.CurrLsValid())
+	assert.True(t, timeLS.TimeToLsEventValid())
+}
+
+func TestParseMonRF(t *testing.T) {
+	blocks, err := ParseMonRF(testMonRFOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, []RFBlock{
+		{BlockID: 0, AntStatus: AntennaStateOK, AntPower: 1, JamInd: 3, NoisePerMS: 82, AgcCnt: 6318},
+		{BlockID: 1, AntStatus: AntennaStateOpen, AntPower: 1, JamInd: 2, NoisePerMS: 43, AgcCnt: 6673},
+	}, blocks)
+	assert.True(t, blocks[0].AntennaOK())
+	assert.False(t, blocks[1].AntennaOK())
+
+	_, err = ParseMonRF("UBX-MON-RF:\n version 0 nBlocks 0\n")
+	assert.Error(t, err)
+}
+
+func TestReceiver(t *testing.T) {
+	var commands []string
+
+	output := testMonRFOutput
+	executor := func(nodeName, command string) (string, error) {
+		commands = append(commands, command)
+
+		return output, nil
+	}
+	receiver := NewReceiver(nil, "node", ProtocolVersionE810, WithExecutor(executor))
+
+	assert.NoError(t, receiver.SimulateSyncLoss())
+	assert.NoError(t, receiver.SimulateSyncRecovery())
+	assert.NoError(t, receiver.DisableConstellation(ConstellationGLONASS))
+	assert.NoError(t, receiver.EnableConstellation(ConstellationGLONASS))
+	assert.NoError(t, receiver.Restart(RestartCold))
+	assert.EqualError(t, receiver.VerifyAntennaOK(), "antenna of RF block 1 on node node has status 4, expected 2")
+
+	output = testNavTimeLSOutput
+	assert.NoError(t, receiver.VerifyLeapSeconds(18))
+	assert.Error(t, receiver.VerifyLeapSeconds(37))
+
+	assert.Equal(t, []string{
+		"ubxtool -P 29.20 -w 1 -v 3 -z CFG-NAVSPG-INFIL_NCNOTHRS,50,1",
+		"ubxtool -P 29.20 -w 1 -v 3 -z CFG-NAVSPG-INFIL_NCNOTHRS,0,1",
+		"ubxtool -P 29.20 -w 1 -d GLONASS",
+		"ubxtool -P 29.20 -w 1 -e GLONASS",
+		"ubxtool -P 29.20 -w 1 -p COLDBOOT",
+		"ubxtool -P 29.20 -w 2 -p MON-RF",
+		"ubxtool -P 29.20 -w 2 -p NAV-TIMELS",
+		"ubxtool -P 29.20 -w 2 -p NAV-TIMELS",
+	}, commands)
+
+	receiver.executor = func(string, string) (string, error) {
+		return "", errors.New("exec failed")
+	}
+	assert.Error(t, receiver.SimulateSyncLoss())
+
+	receiver.ProtocolVersion = ""
+	assert.EqualError(t, receiver.SetConfig(navSpgInfilNcnoThrs, 0), "protocol version is empty")
+}
+
+func TestProtocolFromProfile(t *testing.T) {
+	testCases := []struct {
+		plugins  map[string]*apiextensions.JSON
+		expected string
+	}{
+		{plugins: map[string]*apiextensions.JSON{"e810": {}}, expected: ProtocolVersionE810},
+		{plugins: map[string]*apiextensions.JSON{"e825": {}}, expected: ProtocolVersionE825E830},
+		{plugins: map[string]*apiextensions.JSON{"e830": {}, "e810": {}}, expected: ProtocolVersionE825E830},
+		{plugins: map[string]*apiextensions.JSON{"other": {}}},
+		{},
+	}
+
+	for _, testCase := range testCases {
+		protocolVersion, err := ProtocolFromProfile(&ptpv1.PtpProfile{Name: ptr.To("gm"), Plugins: testCase.plugins})
+		assert.Equal(t, testCase.expected, protocolVersion)
+		assert.Equal(t, testCase.expected == "", err != nil)
+	}
+
+	_, err := ProtocolFromProfile(nil)
+	assert.Error(t, err)
+}
+
+func TestProtocolFromPtpConfig(t *testing.T) {
+	ptpConfig := &ptpv1.PtpConfig{Spec: ptpv1.PtpConfigSpec{
+		Profile: []ptpv1.PtpProfile{
+			{Name: ptr.To("e810"), Plugins: map[string]*apiextensions.JSON{"e810": {}}},
+			{Name: ptr.To("e825"), Plugins: map[string]*apiextensions.JSON{"e825": {}}},
+		},
+		Recommend: []ptpv1.PtpRecommend{
+			{Profile: ptr.To("e810"), Priority: ptr.To[int64](10),
+				Match: []ptpv1.MatchRule{{NodeLabel: ptr.To("node-role.kubernetes.io/master")}}},
+			{Profile: ptr.To("e825"), Priority: ptr.To[int64](4),
+				Match: []ptpv1.MatchRule{{NodeLabel: ptr.To("ptp/gm=true")}}},
+			{Profile: ptr.To("e810"), Match: []ptpv1.MatchRule{{NodeName: ptr.To("node-a")}}},
+		},
+	}}
+
+	labels := map[string]string{"node-role.kubernetes.io/master": "", "ptp/gm": "true"}
+
+	protocolVersion, found := protocolFromPtpConfig(ptpConfig, "node-b", labels)
+	assert.True(t, found)
+	assert.Equal(t, ProtocolVersionE825E830, protocolVersion)
+
+	protocolVersion, found = protocolFromPtpConfig(ptpConfig, "node-a", labels)
+	assert.True(t, found)
+	assert.Equal(t, ProtocolVersionE810, protocolVersion)
+
+	ptpConfig.Status.MatchList = []ptpv1.NodeMatchList{{NodeName: ptr.To("node-b"), Profile: ptr.To("e810")}}
+	protocolVersion, found = protocolFromPtpConfig(ptpConfig, "node-b", labels)
+	assert.True(t, found)
+	assert.Equal(t, ProtocolVersionE810, protocolVersion)
+
+	_, found = protocolFromPtpConfig(ptpConfig, "node-c", map[string]string{"ptp/gm": "false"})
+	assert.False(t, found)
+}
@@ -2,18 +2,14 @@
 package ocpsriovconfig
 
 import (
-	"errors"
 	"fmt"
-	"io"
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/ocp/internal/ocpconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -73,16 +69,9 @@ func NewSriovOcpConfig() *SriovOcpConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultOcpSriovParamsFile)
 
-	err := readFile(&sriovOcpConf, confFile)
+	err := config.Loader{Section: "ocpsriov", DefaultFile: confFile, EnvPrefix: "ECO_OCP_SRIOV_"}.Load(&sriovOcpConf)
 	if err != nil {
-		log.Printf("Error to read config file %s: %v", confFile, err)
-
-		return nil
-	}
-
-	err = readEnv(&sriovOcpConf)
-	if err != nil {
-		log.Printf("Error to read environment variables: %v", err)
+		log.Printf("Error to load SriovOcpConfig: %v", err)
 
 		return nil
 	}
@@ -210,32 +199,3 @@ func (sriovOcpConfig *SriovOcpConfig) GetSwitchCredentials() (user, password, ip
 
 	return sriovOcpConfig.SwitchUser, sriovOcpConfig.SwitchPass, sriovOcpConfig.SwitchIP, nil
 }
-
-func readFile(sriovOcpConfig *SriovOcpConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(sriovOcpConfig)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(sriovOcpConfig *SriovOcpConfig) error {
-	err := envconfig.Process("", sriovOcpConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
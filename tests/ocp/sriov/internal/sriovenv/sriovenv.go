@@ -4,12 +4,10 @@ package sriovenv
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nad"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
@@ -56,120 +54,77 @@ func WaitForSriovPolicyReady(timeout time.Duration) error {
 // ============================================================================
 
 // NetworkOption is a function that modifies a NetworkBuilder.
-type NetworkOption func(*sriov.NetworkBuilder) *sriov.NetworkBuilder
+type NetworkOption = sriovoperator.NetworkOption
 
 // WithSpoof sets spoof checking on the network.
 func WithSpoof(enabled bool) NetworkOption {
-	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
-		return nb.WithSpoof(enabled)
-	}
+	return sriovoperator.WithSpoof(enabled)
 }
 
 // WithTrust sets trust flag on the network.
 func WithTrust(enabled bool) NetworkOption {
-	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
-		return nb.WithTrustFlag(enabled)
-	}
+	return sriovoperator.WithTrust(enabled)
 }
 
 // WithVLAN sets VLAN ID on the network.
 func WithVLAN(vlanID uint16) NetworkOption {
-	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
-		return nb.WithVLAN(vlanID)
-	}
+	return sriovoperator.WithVLAN(vlanID)
 }
 
 // WithVlanQoS sets VLAN QoS on the network.
 func WithVlanQoS(qos uint16) NetworkOption {
-	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
-		return nb.WithVlanQoS(qos)
-	}
+	return sriovoperator.WithVlanQoS(qos)
 }
 
 // WithMinTxRate sets minimum TX rate on the network.
 func WithMinTxRate(rate uint16) NetworkOption {
-	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
-		return nb.WithMinTxRate(rate)
-	}
+	return sriovoperator.WithMinTxRate(rate)
 }
 
 // WithMaxTxRate sets maximum TX rate on the network.
 func WithMaxTxRate(rate uint16) NetworkOption {
-	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
-		return nb.WithMaxTxRate(rate)
-	}
+	return sriovoperator.WithMaxTxRate(rate)
 }
 
 // WithLinkState sets link state on the network.
 func WithLinkState(state string) NetworkOption {
-	return func(nb *sriov.NetworkBuilder) *sriov.NetworkBuilder {
-		return nb.WithLinkState(state)
-	}
+	return sriovoperator.WithLinkState(state)
 }
 
 // CreateSriovNetwork creates a SRIOV network and waits for NAD.
 func CreateSriovNetwork(name, resourceName, targetNs string, opts ...NetworkOption) error {
-	sriovOpNs := SriovOcpConfig.OcpSriovOperatorNamespace
-
-	networkBuilder := sriov.NewNetworkBuilder(APIClient, name, sriovOpNs, targetNs, resourceName).
-		WithStaticIpam().WithMacAddressSupport().WithIPAddressSupport().WithLogLevel("debug")
-
-	for _, opt := range opts {
-		networkBuilder = opt(networkBuilder)
+	defaultOpts := []NetworkOption{
+		sriovoperator.WithStaticIPAM(),
+		sriovoperator.WithMacAddressSupport(),
+		sriovoperator.WithIPAddressSupport(),
+		sriovoperator.WithLogLevel("debug"),
 	}
 
-	if _, err := networkBuilder.Create(); err != nil {
-		return fmt.Errorf("failed to create network %q: %w", name, err)
-	}
+	networkBuilder := sriovoperator.NewNetwork(APIClient, name, SriovOcpConfig.OcpSriovOperatorNamespace,
+		targetNs, resourceName, append(defaultOpts, opts...)...)
 
-	return WaitForNADCreation(name, targetNs, tsparams.NADTimeout)
+	return sriovoperator.CreateSriovNetworkAndWaitForNADCreation(APIClient, networkBuilder, tsparams.NADTimeout)
 }
 
 // WaitForNADCreation waits for NetworkAttachmentDefinition to be created.
 func WaitForNADCreation(name, namespace string, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.Background(), tsparams.PollingInterval, timeout, true,
-		func(ctx context.Context) (bool, error) {
-			_, err := nad.Pull(APIClient, name, namespace)
-
-			return err == nil, nil
-		})
+	return sriovoperator.WaitForNADCreation(APIClient, name, namespace, timeout)
 }
 
 // WaitForNADDeletion waits for the NAD to be deleted.
 func WaitForNADDeletion(name, namespace string, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.Background(), tsparams.PollingInterval, timeout, true,
-		func(ctx context.Context) (bool, error) {
-			_, err := nad.Pull(APIClient, name, namespace)
-
-			return err != nil, nil
-		})
+	return sriovoperator.WaitForNADDeletion(APIClient, name, namespace, timeout)
 }
 
 // TargetNamespaceOf returns the target namespace of a SriovNetwork.
 // If the target namespace is not set, it returns the namespace of the SriovNetwork.
 func TargetNamespaceOf(sriovNetwork *sriov.NetworkBuilder) string {
-	if sriovNetwork.Object.Spec.NetworkNamespace != "" {
-		return sriovNetwork.Object.Spec.NetworkNamespace
-	}
-
-	return sriovNetwork.Object.Namespace
+	return sriovoperator.TargetNamespaceOf(sriovNetwork)
 }
 
 // RemoveSriovNetwork removes a SRIOV network by name.
 func RemoveSriovNetwork(name string, timeout time.Duration) error {
-	sriovOpNs := SriovOcpConfig.OcpSriovOperatorNamespace
-
-	network, err := sriov.PullNetwork(APIClient, name, sriovOpNs)
-	if err != nil {
-		// eco-goinfra's PullNetwork returns custom error, not k8s NotFound
-		if strings.Contains(err.Error(), "does not exist") {
-			return nil // Already deleted
-		}
-
-		return fmt.Errorf("failed to pull network %q: %w", name, err)
-	}
-
-	return network.DeleteAndWait(timeout)
+	return sriovoperator.RemoveSriovNetwork(APIClient, name, SriovOcpConfig.OcpSriovOperatorNamespace, timeout)
 }
 
 // ============================================================================
@@ -178,30 +133,7 @@ func RemoveSriovNetwork(name string, timeout time.Duration) error {
 
 // RemoveSriovPolicy removes a SRIOV policy by name and waits for deletion.
 func RemoveSriovPolicy(name string, timeout time.Duration) error {
-	sriovOpNs := SriovOcpConfig.OcpSriovOperatorNamespace
-
-	policy, err := sriov.PullPolicy(APIClient, name, sriovOpNs)
-	if err != nil {
-		// eco-goinfra's PullPolicy returns custom error, not k8s NotFound
-		// See: https://github.com/rh-ecosystem-edge/eco-goinfra/blob/main/pkg/sriov/policy.go#L289
-		if strings.Contains(err.Error(), "does not exist") {
-			return nil // Already deleted
-		}
-
-		return fmt.Errorf("failed to pull policy %q: %w", name, err)
-	}
-
-	if err := policy.Delete(); err != nil {
-		return fmt.Errorf("failed to delete policy %q: %w", name, err)
-	}
-
-	// Wait for policy to be deleted
-	return wait.PollUntilContextTimeout(context.Background(), tsparams.PollingInterval, timeout, true,
-		func(ctx context.Context) (bool, error) {
-			_, pullErr := sriov.PullPolicy(APIClient, name, sriovOpNs)
-
-			return pullErr != nil, nil // Policy is gone when pull fails
-		})
+	return sriovoperator.RemoveSriovPolicy(APIClient, name, SriovOcpConfig.OcpSriovOperatorNamespace, timeout)
 }
 
 // InitVF initializes VF for the given device using netdevice driver.
@@ -220,96 +152,30 @@ func InitDpdkVF(name, deviceID, interfaceName, vendor string, vfNum int, workerN
 	return initVFWithDevType(name, deviceID, interfaceName, vendor, "vfio-pci", vfNum, workerNodes)
 }
 
-// initVFWithDevType creates an SR-IOV policy for the specified device type.
-// It iterates through worker nodes and creates a policy on the first node where
-// the device is successfully discovered. Returns (true, nil) on success.
+// initVFWithDevType creates an SR-IOV policy for the specified device type on the first
+// node where the device is discovered and the policy is applied. Returns (true, nil) on success.
 func initVFWithDevType(name, deviceID, interfaceName, vendor, devType string, vfNum int,
 	workerNodes []*nodes.Builder) (bool, error) {
-	if vfNum <= 0 {
-		return false, fmt.Errorf("vfNum must be > 0, got %d", vfNum)
-	}
-
-	sriovOpNs := SriovOcpConfig.OcpSriovOperatorNamespace
-
-	// Cleanup existing policy
-	_ = RemoveSriovPolicy(name, tsparams.NamespaceTimeout)
+	nodeNames := make([]string, 0, len(workerNodes))
 
 	for _, node := range workerNodes {
-		nodeName := node.Definition.Name
-
-		// Try to discover the actual interface name
-		actualInterface := interfaceName
-
-		// Only discover interface if not explicitly provided
-		// This allows users to specify exact interface for multi-port NICs
-		if interfaceName == "" && vendor != "" && deviceID != "" {
-			if discovered, err := discoverInterfaceName(nodeName, vendor, deviceID); err == nil {
-				actualInterface = discovered
-			}
-		}
-
-		if actualInterface == "" {
-			klog.V(90).Infof("No interface resolved on node %q (vendor=%q deviceID=%q)", nodeName, vendor, deviceID)
-
-			continue
-		}
-
-		pfSelector := fmt.Sprintf("%s#0-%d", actualInterface, vfNum-1)
-
-		policy := sriov.NewPolicyBuilder(APIClient, name, sriovOpNs, name, vfNum,
-			[]string{pfSelector}, map[string]string{"kubernetes.io/hostname": nodeName}).
-			WithDevType(devType)
-
-		if vendor != "" {
-			policy.Definition.Spec.NicSelector.Vendor = vendor
-		}
-
-		if deviceID != "" {
-			policy.Definition.Spec.NicSelector.DeviceID = deviceID
-		}
-
-		if _, err := policy.Create(); err != nil {
-			klog.V(90).Infof("Failed to create policy on node %q: %v", nodeName, err)
-
-			continue
-		}
-
-		if err := WaitForSriovPolicyReady(tsparams.PolicyApplicationTimeout); err != nil {
-			klog.V(90).Infof("Policy not ready on node %q: %v", nodeName, err)
-
-			_ = policy.Delete()
-
-			continue
-		}
-
-		klog.V(90).Infof("Successfully created policy %q on node %q", name, nodeName)
-
-		return true, nil
-	}
-
-	return false, fmt.Errorf("failed to create policy %q on any node", name)
-}
-
-// discoverInterfaceName discovers the actual interface name on a node by matching Vendor and DeviceID.
-func discoverInterfaceName(nodeName, vendor, deviceID string) (string, error) {
-	sriovOpNs := SriovOcpConfig.OcpSriovOperatorNamespace
-
-	nodeState := sriov.NewNetworkNodeStateBuilder(APIClient, nodeName, sriovOpNs)
-	if err := nodeState.Discover(); err != nil {
-		return "", err
-	}
-
-	if nodeState.Objects == nil || nodeState.Objects.Status.Interfaces == nil {
-		return "", fmt.Errorf("no interfaces found on node %q", nodeName)
-	}
-
-	for _, iface := range nodeState.Objects.Status.Interfaces {
-		if iface.Vendor == vendor && iface.DeviceID == deviceID {
-			return iface.Name, nil
-		}
+		nodeNames = append(nodeNames, node.Definition.Name)
+	}
+
+	_, err := sriovoperator.CreatePolicyOnFirstMatchingNode(APIClient, SriovOcpConfig.OcpSriovOperatorNamespace,
+		"worker", sriovoperator.VFConfig{
+			PolicyName:    name,
+			InterfaceName: interfaceName,
+			VendorID:      vendor,
+			DeviceID:      deviceID,
+			DevType:       devType,
+			NumVFs:        vfNum,
+		}, nodeNames, tsparams.PolicyApplicationTimeout, tsparams.MCPStableInterval)
+	if err != nil {
+		return false, err
 	}
 
-	return "", fmt.Errorf("no interface found matching vendor %q deviceID %q", vendor, deviceID)
+	return true, nil
 }
 
 // UpdateSriovPolicyMTU updates the MTU of an existing SR-IOV policy.
@@ -361,44 +227,25 @@ func UpdateSriovPolicyMTU(policyName string, mtuValue int) error {
 
 // CreateTestPod creates a test pod with SRIOV network.
 func CreateTestPod(name, namespace, networkName, ip, mac string) (*pod.Builder, error) {
-	secNetwork := pod.StaticIPAnnotationWithMacAddress(networkName, []string{ip}, mac)
-
-	podBuilder := pod.NewBuilder(APIClient, name, namespace, SriovOcpConfig.OcpSriovTestContainer).
-		WithPrivilegedFlag().
-		WithSecondaryNetwork(secNetwork)
-
-	createdPod, err := podBuilder.Create()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create pod %q: %w", name, err)
-	}
-
-	if err := createdPod.WaitUntilReady(tsparams.PodReadyTimeout); err != nil {
-		return createdPod, fmt.Errorf("pod %q not ready: %w", name, err)
-	}
-
-	return createdPod, nil
+	return sriovoperator.CreateTestPod(APIClient, namespace, SriovOcpConfig.OcpSriovTestContainer,
+		sriovoperator.TestPodConfig{
+			Name:        name,
+			NetworkName: networkName,
+			MacAddress:  mac,
+			IPAddresses: []string{ip},
+		}, tsparams.PodReadyTimeout)
 }
 
 // CreateDpdkTestPod creates a DPDK test pod with SR-IOV network.
 func CreateDpdkTestPod(name, namespace, networkName string) (*pod.Builder, error) {
-	secNetwork := pod.StaticIPAnnotationWithMacAddress(networkName,
-		[]string{tsparams.TestPodClientIP}, tsparams.TestPodClientMAC)
-
-	podBuilder := pod.NewBuilder(APIClient, name, namespace, SriovOcpConfig.OcpSriovTestContainer).
-		WithPrivilegedFlag().
-		WithSecondaryNetwork(secNetwork).
-		WithLabel("name", "sriov-dpdk")
-
-	createdPod, err := podBuilder.Create()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create DPDK pod %q: %w", name, err)
-	}
-
-	if err := createdPod.WaitUntilReady(tsparams.PodReadyTimeout); err != nil {
-		return createdPod, fmt.Errorf("DPDK pod %q not ready: %w", name, err)
-	}
-
-	return createdPod, nil
+	return sriovoperator.CreateTestPod(APIClient, namespace, SriovOcpConfig.OcpSriovTestContainer,
+		sriovoperator.TestPodConfig{
+			Name:        name,
+			NetworkName: networkName,
+			MacAddress:  tsparams.TestPodClientMAC,
+			IPAddresses: []string{tsparams.TestPodClientIP},
+			Labels:      map[string]string{"name": "sriov-dpdk"},
+		}, tsparams.PodReadyTimeout)
 }
 
 // DeleteDpdkTestPod deletes a DPDK test pod.
@@ -784,40 +631,16 @@ func nodeOutput(outputMap map[string]string, nodeName string) string {
 // GetPciAddress gets the PCI address for a pod interface from network status annotation.
 // The podInterface parameter should be the interface name (e.g., "net1", "net2") which is unique per pod.
 func GetPciAddress(namespace, podName, podInterface string) (string, error) {
-	podObj, err := pod.Pull(APIClient, podName, namespace)
+	networkStatus, err := sriovoperator.InterfaceNetworkStatus(APIClient, podName, namespace, podInterface)
 	if err != nil {
-		return "", fmt.Errorf("failed to pull pod: %w", err)
-	}
-
-	annotation := podObj.Object.Annotations["k8s.v1.cni.cncf.io/network-status"]
-	if annotation == "" {
-		return "", fmt.Errorf("no network status annotation")
-	}
-
-	var status []struct {
-		Interface  string `json:"interface"`
-		DeviceInfo struct {
-			Pci struct {
-				PciAddress string `json:"pci-address"`
-			} `json:"pci"`
-		} `json:"device-info"`
-	}
-
-	if err := json.Unmarshal([]byte(annotation), &status); err != nil {
-		return "", fmt.Errorf("failed to parse network status: %w", err)
+		return "", err
 	}
 
-	for _, networkStatus := range status {
-		if networkStatus.Interface == podInterface {
-			if networkStatus.DeviceInfo.Pci.PciAddress != "" {
-				return networkStatus.DeviceInfo.Pci.PciAddress, nil
-			}
-
-			return "", fmt.Errorf("PCI address not present for interface %s", podInterface)
-		}
+	if networkStatus.DeviceInfo.Pci.PciAddress == "" {
+		return "", fmt.Errorf("PCI address not present for interface %s", podInterface)
 	}
 
-	return "", fmt.Errorf("interface %s not found in pod %s", podInterface, podName)
+	return networkStatus.DeviceInfo.Pci.PciAddress, nil
 }
 
 // ============================================================================
@@ -12,6 +12,10 @@ const (
 	TestNamespaceName2 = "sriov-tests-2"
 	// LabelSuite represents sriov label that can be used for test cases selection.
 	LabelSuite = "ocpsriov"
+	// PrometheusQuerierSAName is the ServiceAccount name for Prometheus API access.
+	PrometheusQuerierSAName = "ocpsriov-prometheus-querier"
+	// PrometheusQuerierCRBName is the ClusterRoleBinding name for Prometheus API access.
+	PrometheusQuerierCRBName = "ocpsriov-prometheus-querier-crb"
 	// LabelOcpSriovReinstallation represents an SR-IOV operator reinstallation label
 	// that can be used for test cases selection.
 	LabelOcpSriovReinstallation = "sriovreinstall"
@@ -1,15 +1,15 @@
 package tests
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/perfprofile"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/sriovoperator"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/ocp/sriov/internal/ocpsriovinittools"
 
@@ -36,12 +36,12 @@ type metricsTestResource struct {
 	pod     *pod.Builder
 }
 
-var serverPodRXPromQL = []string{"bash", "-c", "promtool query instant -o json " +
-	"http://localhost:9090 \"sum(sriov_vf_rx_packets * on(pciAddr) group_left(pod) " +
-	"sriov_kubepoddevice{pod=\\\"serverpod\\\"}) by (pod)\""}
-var serverPodTXPromQL = []string{"bash", "-c", "promtool query instant -o json " +
-	"http://localhost:9090 \"sum(sriov_vf_tx_packets * on(pciAddr) group_left(pod) " +
-	"sriov_kubepoddevice{pod=\\\"serverpod\\\"}) by (pod)\""}
+const (
+	serverPodRXPromQL = `sum(sriov_vf_rx_packets * on(pciAddr) group_left(pod) ` +
+		`sriov_kubepoddevice{pod="serverpod"}) by (pod)`
+	serverPodTXPromQL = `sum(sriov_vf_tx_packets * on(pciAddr) group_left(pod) ` +
+		`sriov_kubepoddevice{pod="serverpod"}) by (pod)`
+)
 
 var _ = Describe(
 	"SriovMetricsExporter", Ordered, Label(tsparams.LabelSriovMetricsTestCases, tsparams.LabelSriovHWEnabled),
@@ -519,62 +519,31 @@ func createMetricsTestResources(cRes, sRes metricsTestResource) *pod.Builder {
 }
 
 func checkMetricsWithPromQL() {
-	By("Wait until promQL gives serverpod metrics")
-	Eventually(func() bool {
-		return strings.Contains(execPromQLandReturnString(serverPodRXPromQL), "serverpod")
-	},
-		130*time.Second, 30*time.Second).Should(BeTrue(), "PromQL output does not contain server pod metrics")
-
-	By("Verify RX and TX packets counters are > 0")
-	Eventually(func() int { return fetchScalarFromPromQLoutput(execPromQLandReturnString(serverPodRXPromQL)) },
-		2*time.Minute, 30*time.Second).Should(BeNumerically(">", 0), "RX counters are zero")
-	Eventually(func() int { return fetchScalarFromPromQLoutput(execPromQLandReturnString(serverPodTXPromQL)) },
-		2*time.Minute, 30*time.Second).Should(BeNumerically(">", 0), "TX counters are zero")
-}
-
-func execPromQLandReturnString(query []string) string {
-	By("Running PromQL to fetch metrics of serverpod")
-
-	promPods, err := pod.List(APIClient,
-		SriovOcpConfig.PrometheusOperatorNamespace, metav1.ListOptions{LabelSelector: "prometheus=k8s"})
-	Expect(err).ToNot(HaveOccurred(), "Failed to get prometheus pods")
-	Expect(len(promPods)).To(BeNumerically(">", 0), "No prometheus pods found with label prometheus=k8s")
-
-	By(fmt.Sprintf("Running PromQL query: %s", query))
-	output, err := promPods[0].ExecCommand(query, "prometheus")
-	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf(
-		"Failed to get promQL output from prometheus pod. Output: %s", output.String()))
-
-	By(fmt.Sprintf("Received PromQL output: %s", output.String()))
-
-	return output.String()
-}
+	By("Creating Prometheus API client")
 
-func fetchScalarFromPromQLoutput(res string) int {
-	type podMetricPromQLoutput []struct {
-		Metric struct {
-			Pod string `json:"pod,omitempty"`
-		}
-		Value []interface{} `json:"value,omitempty"`
-	}
+	prometheusClient, err := prometheus.NewClient(APIClient,
+		prometheus.WithServiceAccount(tsparams.PrometheusQuerierSAName, tsparams.PrometheusQuerierCRBName))
+	Expect(err).ToNot(HaveOccurred(), "Failed to create Prometheus API client")
 
-	By("Fetch the final value from the PromQL output")
+	DeferCleanup(prometheusClient.Cleanup)
 
-	var outValue podMetricPromQLoutput
+	By("Wait until promQL gives serverpod metrics")
 
-	err := json.Unmarshal([]byte(res), &outValue)
-	Expect(err).ToNot(HaveOccurred(), "Failed to Unmarshal promQL output from prometheus pod")
+	err = prometheus.AssertQuery(context.TODO(), prometheusClient, serverPodRXPromQL, prometheus.NotEmpty(),
+		prometheus.AssertWithTimeout(130*time.Second), prometheus.AssertWithPollInterval(30*time.Second))
+	Expect(err).ToNot(HaveOccurred(), "PromQL output does not contain server pod metrics")
 
-	Expect(len(outValue)).To(BeNumerically(">", 0), "PromQL output contains no metrics")
-	Expect(len(outValue[0].Value)).To(BeNumerically(">", 1), "PromQL metric value array is incomplete")
+	By("Verify RX and TX packets counters are > 0")
 
-	valueStr, ok := outValue[0].Value[1].(string)
-	Expect(ok).To(BeTrue(), "PromQL metric value is not a string")
+	greaterThanZero := prometheus.AllMatch("greater than 0", func(value float64) bool { return value > 0 })
 
-	finalVal, err := strconv.Atoi(valueStr)
-	Expect(err).To(Not(HaveOccurred()), "Failed to convert counter value to int")
+	err = prometheus.AssertQuery(context.TODO(), prometheusClient, serverPodRXPromQL, greaterThanZero,
+		prometheus.AssertWithTimeout(2*time.Minute), prometheus.AssertWithPollInterval(30*time.Second))
+	Expect(err).ToNot(HaveOccurred(), "RX counters are zero")
 
-	return finalVal
+	err = prometheus.AssertQuery(context.TODO(), prometheusClient, serverPodTXPromQL, greaterThanZero,
+		prometheus.AssertWithTimeout(2*time.Minute), prometheus.AssertWithPollInterval(30*time.Second))
+	Expect(err).ToNot(HaveOccurred(), "TX counters are zero")
 }
 
 func setMetricsExporterFlag(flag bool) {
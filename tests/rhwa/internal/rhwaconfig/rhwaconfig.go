@@ -2,13 +2,10 @@ package rhwaconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -19,6 +16,8 @@ const (
 // RHWAConfig type keeps rhwa configuration.
 type RHWAConfig struct {
 	*config.GeneralConfig
+	// TargetWorker is the worker node disrupted by remediation tests. A worker is selected when it is empty.
+	TargetWorker string `yaml:"target_worker" envconfig:"ECO_RHWA_TARGET_WORKER"`
 }
 
 // NewRHWAConfig returns instance of RHWA config type.
@@ -33,48 +32,12 @@ func NewRHWAConfig() *RHWAConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultRhwaParamsFile)
 
-	err := readFile(&rhwaConf, confFile)
+	err := config.Loader{Section: "rhwa", DefaultFile: confFile}.Load(&rhwaConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&rhwaConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load RHWAConfig: %v", err)
 
 		return nil
 	}
 
 	return &rhwaConf
 }
-
-func readFile(rhwaConfig *RHWAConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&rhwaConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(rhwaConfig *RHWAConfig) error {
-	err := envconfig.Process("", rhwaConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
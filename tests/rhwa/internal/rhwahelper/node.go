@@ -0,0 +1,104 @@
+package rhwahelper
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodedisruption"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodeexec"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+// SelectWorkerNode returns the name of the worker node to disrupt. If preferred is set, it must be a worker node,
+// otherwise the last worker node sorted by name is returned so repeated runs target the same node.
+func SelectWorkerNode(apiClient *clients.Settings, workerLabelMap map[string]string, preferred string) (string, error) {
+	workers, err := nodes.List(
+		apiClient, metav1.ListOptions{LabelSelector: labels.Set(workerLabelMap).String()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list worker nodes: %w", err)
+	}
+
+	var workerNames []string
+
+	for _, worker := range workers {
+		workerNames = append(workerNames, worker.Object.Name)
+	}
+
+	if len(workerNames) == 0 {
+		return "", fmt.Errorf("no worker nodes found with labels %v", workerLabelMap)
+	}
+
+	if preferred != "" {
+		if !slices.Contains(workerNames, preferred) {
+			return "", fmt.Errorf("node %s is not one of the worker nodes %v", preferred, workerNames)
+		}
+
+		return preferred, nil
+	}
+
+	slices.Sort(workerNames)
+
+	return workerNames[len(workerNames)-1], nil
+}
+
+// StopKubelet stops the kubelet on nodeName and starts it again after duration, using the node executor transport
+// configured in generalConfig.
+func StopKubelet(
+	apiClient *clients.Settings, generalConfig *config.GeneralConfig, nodeName string, duration time.Duration) error {
+	return withExecutor(apiClient, generalConfig, func(executor nodeexec.NodeExecutor) error {
+		return nodedisruption.StopKubelet(executor, nodeName, duration)
+	})
+}
+
+// BlockAPIServer drops the traffic from nodeName to the API server and allows it again after duration, using the
+// node executor transport configured in generalConfig.
+func BlockAPIServer(
+	apiClient *clients.Settings, generalConfig *config.GeneralConfig, nodeName string, duration time.Duration) error {
+	return withExecutor(apiClient, generalConfig, func(executor nodeexec.NodeExecutor) error {
+		return nodedisruption.BlockAPIServer(executor, nodeName, duration)
+	})
+}
+
+// ExecOnNode executes command on the host of nodeName and returns its stdout, using the node executor transport
+// configured in generalConfig.
+func ExecOnNode(
+	apiClient *clients.Settings, generalConfig *config.GeneralConfig, nodeName, command string) (string, error) {
+	var stdout string
+
+	err := withExecutor(apiClient, generalConfig, func(executor nodeexec.NodeExecutor) error {
+		result, err := executor.Execute(context.TODO(), nodeName, command)
+		if err != nil {
+			return err
+		}
+
+		stdout = result.Stdout
+
+		return nil
+	})
+
+	return stdout, err
+}
+
+// withExecutor creates a node executor from generalConfig, calls run with it and cleans it up afterwards.
+func withExecutor(
+	apiClient *clients.Settings, generalConfig *config.GeneralConfig, run func(nodeexec.NodeExecutor) error) error {
+	executor, err := nodeexec.NewFromConfig(apiClient, generalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create node executor: %w", err)
+	}
+
+	defer func() {
+		if cleanupErr := executor.Cleanup(); cleanupErr != nil {
+			klog.V(90).Infof("Failed to clean up node executor: %v", cleanupErr)
+		}
+	}()
+
+	return run(executor)
+}
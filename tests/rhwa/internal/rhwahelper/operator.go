@@ -0,0 +1,133 @@
+// Package rhwahelper provides checks shared by the rhwa operator suites, such as verifying the operator deployment,
+// its ClusterServiceVersion and the security context of its pods, along with helpers for disrupting worker nodes.
+package rhwahelper
+
+import (
+	"errors"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/infrastructure"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// IsSNO returns true if the cluster control plane is a single replica.
+func IsSNO(apiClient *clients.Settings) (bool, error) {
+	infraConfig, err := infrastructure.Pull(apiClient)
+	if err != nil {
+		return false, fmt.Errorf("failed to pull infrastructure configuration: %w", err)
+	}
+
+	return infraConfig.Object.Status.ControlPlaneTopology == configv1.SingleReplicaTopologyMode, nil
+}
+
+// VerifyCSVAnnotations checks that the first ClusterServiceVersion matching csvNamePattern in namespace has every
+// annotation in expected set to the expected value. It returns an error describing each missing or wrong annotation.
+func VerifyCSVAnnotations(
+	apiClient *clients.Settings, csvNamePattern, namespace string, expected map[string]string) error {
+	klog.V(90).Infof("Verifying annotations of ClusterServiceVersion %s in namespace %s", csvNamePattern, namespace)
+
+	csvs, err := olm.ListClusterServiceVersionWithNamePattern(apiClient, csvNamePattern, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterServiceVersions matching %s: %w", csvNamePattern, err)
+	}
+
+	if len(csvs) == 0 {
+		return fmt.Errorf("no ClusterServiceVersion matching %s found in namespace %s", csvNamePattern, namespace)
+	}
+
+	annotations := csvs[0].Object.Annotations
+	if annotations == nil {
+		return fmt.Errorf("ClusterServiceVersion %s has no annotations", csvs[0].Object.Name)
+	}
+
+	var errs []error
+
+	for key, expectedValue := range expected {
+		value, exists := annotations[key]
+		if !exists {
+			errs = append(errs, fmt.Errorf("required annotation %q is missing", key))
+
+			continue
+		}
+
+		if value != expectedValue {
+			errs = append(errs, fmt.Errorf("annotation %q has value %q, expected %q", key, value, expectedValue))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// VerifyDeploymentReplicas checks that the deployment requests and has ready exactly expected replicas.
+func VerifyDeploymentReplicas(deploy *deployment.Builder, expected int32) error {
+	if deploy == nil || deploy.Object == nil {
+		return fmt.Errorf("cannot verify replicas of nil deployment")
+	}
+
+	if deploy.Object.Spec.Replicas == nil {
+		return fmt.Errorf("deployment %s has nil replicas", deploy.Object.Name)
+	}
+
+	if *deploy.Object.Spec.Replicas != expected {
+		return fmt.Errorf("deployment %s expected %d replica(s), found %d",
+			deploy.Object.Name, expected, *deploy.Object.Spec.Replicas)
+	}
+
+	if deploy.Object.Status.ReadyReplicas != expected {
+		return fmt.Errorf("deployment %s expected %d ready replica(s), found %d",
+			deploy.Object.Name, expected, deploy.Object.Status.ReadyReplicas)
+	}
+
+	return nil
+}
+
+// VerifyPodsRunAsNonRoot checks that every pod in namespace matching labelSelector sets runAsNonRoot and that none of
+// its containers run as user 0. It returns an error describing each violation.
+func VerifyPodsRunAsNonRoot(apiClient *clients.Settings, namespace, labelSelector string) error {
+	klog.V(90).Infof("Verifying pods %s in namespace %s run as non-root", labelSelector, namespace)
+
+	pods, err := pod.List(apiClient, namespace, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return fmt.Errorf("failed to list pods %s in namespace %s: %w", labelSelector, namespace, err)
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("no pods matching %s found in namespace %s", labelSelector, namespace)
+	}
+
+	var errs []error
+
+	for _, podBuilder := range pods {
+		podName := podBuilder.Object.Name
+		securityContext := podBuilder.Object.Spec.SecurityContext
+
+		switch {
+		case securityContext == nil:
+			errs = append(errs, fmt.Errorf("pod %s has nil SecurityContext", podName))
+		case securityContext.RunAsNonRoot == nil:
+			errs = append(errs, fmt.Errorf("pod %s has nil runAsNonRoot", podName))
+		case !*securityContext.RunAsNonRoot:
+			errs = append(errs, fmt.Errorf("pod %s has runAsNonRoot set to false", podName))
+		}
+
+		if len(podBuilder.Object.Spec.Containers) == 0 {
+			errs = append(errs, fmt.Errorf("pod %s has no containers", podName))
+		}
+
+		for index, container := range podBuilder.Object.Spec.Containers {
+			if container.SecurityContext != nil && container.SecurityContext.RunAsUser != nil &&
+				*container.SecurityContext.RunAsUser == 0 {
+				errs = append(errs, fmt.Errorf("container [%d] %s in pod %s runs as user 0",
+					index, container.Name, podName))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,64 @@
+package rhwahelper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// CreateSNR creates a SelfNodeRemediation for nodeName in namespace using strategy, which makes the SNR agent on the
+// node reboot it. The remediation is named after the node, like the ones created by NHC.
+func CreateSNR(apiClient *clients.Settings, namespace, nodeName, strategy string) error {
+	klog.V(90).Infof("Creating SelfNodeRemediation %s/%s with strategy %s", namespace, nodeName, strategy)
+
+	remediation := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"remediationStrategy": strategy},
+	}}
+	remediation.SetAPIVersion(rhwaparams.SNRGVR.GroupVersion().String())
+	remediation.SetKind("SelfNodeRemediation")
+	remediation.SetName(nodeName)
+	remediation.SetNamespace(namespace)
+
+	_, err := apiClient.Resource(rhwaparams.SNRGVR).Namespace(namespace).Create(
+		context.TODO(), remediation, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create SelfNodeRemediation %s/%s: %w", namespace, nodeName, err)
+	}
+
+	return nil
+}
+
+// DeleteSNR deletes the SelfNodeRemediation for nodeName in namespace. It does not return an error if it does not
+// exist.
+func DeleteSNR(apiClient *clients.Settings, namespace, nodeName string) error {
+	klog.V(90).Infof("Deleting SelfNodeRemediation %s/%s", namespace, nodeName)
+
+	err := apiClient.Resource(rhwaparams.SNRGVR).Namespace(namespace).Delete(
+		context.TODO(), nodeName, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete SelfNodeRemediation %s/%s: %w", namespace, nodeName, err)
+	}
+
+	return nil
+}
+
+// SNRExists returns true if a SelfNodeRemediation for nodeName exists in namespace.
+func SNRExists(apiClient *clients.Settings, namespace, nodeName string) (bool, error) {
+	_, err := apiClient.Resource(rhwaparams.SNRGVR).Namespace(namespace).Get(
+		context.TODO(), nodeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get SelfNodeRemediation %s/%s: %w", namespace, nodeName, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,34 @@
+package rhwaparams
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+var (
+	// SNRTemplateGVK is the GroupVersionKind of the SelfNodeRemediationTemplate.
+	SNRTemplateGVK = schema.GroupVersionKind{
+		Group:   "self-node-remediation.medik8s.io",
+		Version: "v1alpha1",
+		Kind:    "SelfNodeRemediationTemplate",
+	}
+
+	// SNRTemplateGVR is the GroupVersionResource of the SelfNodeRemediationTemplate.
+	SNRTemplateGVR = schema.GroupVersionResource{
+		Group:    "self-node-remediation.medik8s.io",
+		Version:  "v1alpha1",
+		Resource: "selfnoderemediationtemplates",
+	}
+
+	// SNRGVR is the GroupVersionResource of the SelfNodeRemediation, which is created by NHC from the template or
+	// directly by the tests.
+	SNRGVR = schema.GroupVersionResource{
+		Group:    "self-node-remediation.medik8s.io",
+		Version:  "v1alpha1",
+		Resource: "selfnoderemediations",
+	}
+
+	// SNRConfigGVR is the GroupVersionResource of the SelfNodeRemediationConfig.
+	SNRConfigGVR = schema.GroupVersionResource{
+		Group:    "self-node-remediation.medik8s.io",
+		Version:  "v1alpha1",
+		Resource: "selfnoderemediationconfigs",
+	}
+)
@@ -0,0 +1,226 @@
+// Package nhchelper provides helpers to create and inspect NodeHealthCheck resources. The NodeHealthCheck API is not
+// part of eco-goinfra, so the resources are handled through the dynamic client using the subset of the API defined
+// here.
+package nhchelper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/nhc-operator/internal/nhcparams"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// PhaseEnabled is the phase of a NodeHealthCheck that is watching nodes and not remediating any.
+	PhaseEnabled = "Enabled"
+	// PhaseRemediating is the phase of a NodeHealthCheck with at least one remediation in progress.
+	PhaseRemediating = "Remediating"
+	// PhaseDisabled is the phase of a NodeHealthCheck that cannot remediate, for example due to a missing template.
+	PhaseDisabled = "Disabled"
+
+	pollInterval = 5 * time.Second
+)
+
+// UnhealthyCondition is a node condition that makes NHC consider the node unhealthy once it has lasted Duration.
+type UnhealthyCondition struct {
+	Type     corev1.NodeConditionType `json:"type"`
+	Status   corev1.ConditionStatus   `json:"status"`
+	Duration metav1.Duration          `json:"duration"`
+}
+
+// Spec is the subset of the NodeHealthCheck spec used by the tests.
+type Spec struct {
+	Selector            metav1.LabelSelector    `json:"selector"`
+	MinHealthy          *intstr.IntOrString     `json:"minHealthy,omitempty"`
+	UnhealthyConditions []UnhealthyCondition    `json:"unhealthyConditions,omitempty"`
+	RemediationTemplate *corev1.ObjectReference `json:"remediationTemplate,omitempty"`
+}
+
+// Remediation is a remediation resource created by NHC for an unhealthy node.
+type Remediation struct {
+	Resource corev1.ObjectReference `json:"resource"`
+	Started  metav1.Time            `json:"started"`
+}
+
+// UnhealthyNode is a node that NHC considers unhealthy along with the remediations created for it.
+type UnhealthyNode struct {
+	Name         string        `json:"name"`
+	Remediations []Remediation `json:"remediations,omitempty"`
+}
+
+// Status is the subset of the NodeHealthCheck status used by the tests.
+type Status struct {
+	Phase                string             `json:"phase,omitempty"`
+	Reason               string             `json:"reason,omitempty"`
+	ObservedNodes        int                `json:"observedNodes,omitempty"`
+	HealthyNodes         int                `json:"healthyNodes,omitempty"`
+	UnhealthyNodes       []UnhealthyNode    `json:"unhealthyNodes,omitempty"`
+	InFlightRemediations map[string]string  `json:"inFlightRemediations,omitempty"`
+	Conditions           []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// DefaultUnhealthyConditions returns the unhealthy conditions used by the operator by default, a Ready condition that
+// is False or Unknown, with duration instead of the default five minutes.
+func DefaultUnhealthyConditions(duration time.Duration) []UnhealthyCondition {
+	return []UnhealthyCondition{
+		{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Duration: metav1.Duration{Duration: duration}},
+		{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Duration: metav1.Duration{Duration: duration}},
+	}
+}
+
+// SNRTemplateReference returns a reference to the automatic strategy SelfNodeRemediationTemplate in namespace.
+func SNRTemplateReference(namespace string) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: rhwaparams.SNRTemplateGVK.GroupVersion().String(),
+		Kind:       rhwaparams.SNRTemplateGVK.Kind,
+		Name:       nhcparams.SNRTemplateName,
+		Namespace:  namespace,
+	}
+}
+
+// Create creates a NodeHealthCheck named name with spec.
+func Create(apiClient *clients.Settings, name string, spec Spec) error {
+	klog.V(90).Infof("Creating NodeHealthCheck %s", name)
+
+	specMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return fmt.Errorf("failed to convert NodeHealthCheck %s spec: %w", name, err)
+	}
+
+	nodeHealthCheck := &unstructured.Unstructured{Object: map[string]any{"spec": specMap}}
+	nodeHealthCheck.SetAPIVersion(nhcparams.NodeHealthCheckGVR.GroupVersion().String())
+	nodeHealthCheck.SetKind("NodeHealthCheck")
+	nodeHealthCheck.SetName(name)
+
+	_, err = apiClient.Resource(nhcparams.NodeHealthCheckGVR).Create(
+		context.TODO(), nodeHealthCheck, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create NodeHealthCheck %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Delete deletes the NodeHealthCheck named name. It does not return an error if it does not exist.
+func Delete(apiClient *clients.Settings, name string) error {
+	klog.V(90).Infof("Deleting NodeHealthCheck %s", name)
+
+	err := apiClient.Resource(nhcparams.NodeHealthCheckGVR).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete NodeHealthCheck %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// GetStatus returns the status of the NodeHealthCheck named name.
+func GetStatus(apiClient *clients.Settings, name string) (*Status, error) {
+	nodeHealthCheck, err := apiClient.Resource(nhcparams.NodeHealthCheckGVR).Get(
+		context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NodeHealthCheck %s: %w", name, err)
+	}
+
+	status := &Status{}
+
+	statusMap, found, err := unstructured.NestedMap(nodeHealthCheck.Object, "status")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NodeHealthCheck %s status: %w", name, err)
+	}
+
+	if !found {
+		return status, nil
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert NodeHealthCheck %s status: %w", name, err)
+	}
+
+	return status, nil
+}
+
+// WaitForStatus waits up to timeout for the status of the NodeHealthCheck named name to satisfy condition. It returns
+// the last status retrieved, which is nil if none could be retrieved.
+func WaitForStatus(
+	apiClient *clients.Settings, name string, timeout time.Duration, condition func(*Status) bool) (*Status, error) {
+	var lastStatus *Status
+
+	err := wait.PollUntilContextTimeout(context.TODO(), pollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			status, err := GetStatus(apiClient, name)
+			if err != nil {
+				klog.V(100).Infof("Failed to get NodeHealthCheck status, retrying: %v", err)
+
+				return false, nil
+			}
+
+			lastStatus = status
+
+			return condition(status), nil
+		})
+	if err != nil {
+		return lastStatus, fmt.Errorf("NodeHealthCheck %s status did not reach the expected state within %s: %w",
+			name, timeout, err)
+	}
+
+	return lastStatus, nil
+}
+
+// WaitForPhase waits up to timeout for the NodeHealthCheck named name to report phase.
+func WaitForPhase(apiClient *clients.Settings, name, phase string, timeout time.Duration) (*Status, error) {
+	return WaitForStatus(apiClient, name, timeout, func(status *Status) bool {
+		return status.Phase == phase
+	})
+}
+
+// IsUnhealthy returns true if status lists nodeName as unhealthy.
+func (status *Status) IsUnhealthy(nodeName string) bool {
+	for _, node := range status.UnhealthyNodes {
+		if node.Name == nodeName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRemediating returns true if status lists at least one remediation for nodeName.
+func (status *Status) IsRemediating(nodeName string) bool {
+	for _, node := range status.UnhealthyNodes {
+		if node.Name == nodeName && len(node.Remediations) > 0 {
+			return true
+		}
+	}
+
+	_, inFlight := status.InFlightRemediations[nodeName]
+
+	return inFlight
+}
+
+// TemplateExists returns true if the SelfNodeRemediationTemplate referenced by template exists.
+func TemplateExists(apiClient *clients.Settings, template *corev1.ObjectReference) (bool, error) {
+	_, err := apiClient.Resource(rhwaparams.SNRTemplateGVR).Namespace(template.Namespace).Get(
+		context.TODO(), template.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get SelfNodeRemediationTemplate %s/%s: %w",
+			template.Namespace, template.Name, err)
+	}
+
+	return true, nil
+}
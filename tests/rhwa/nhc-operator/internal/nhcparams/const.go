@@ -1,6 +1,34 @@
 package nhcparams
 
+import "time"
+
 const (
 	// Label represents nhc operator label that can be used for test cases selection.
 	Label = "nhc"
+
+	// ExpectedReplicas defines the expected number of replicas for NHC controller manager.
+	ExpectedReplicas = int32(2)
+
+	// NodeHealthCheckName is the name of the NodeHealthCheck created by the tests.
+	NodeHealthCheckName = "eco-nhc-worker"
+
+	// UnhealthyDuration is how long a node condition must last before NHC considers the node unhealthy. It is shorter
+	// than the operator default so the tests do not wait five minutes for remediation to start.
+	UnhealthyDuration = 60 * time.Second
+
+	// KubeletStopDuration is how long the kubelet is stopped on the target worker. It must cover the time for the
+	// node to become NotReady, the unhealthy duration and the remediation check.
+	KubeletStopDuration = 8 * time.Minute
+
+	// NodeNotReadyTimeout is the maximum time to wait for the node to become NotReady after stopping the kubelet.
+	NodeNotReadyTimeout = 3 * time.Minute
+
+	// RemediationTimeout is the maximum time to wait for NHC to create a remediation for the unhealthy node.
+	RemediationTimeout = 5 * time.Minute
+
+	// NodeRecoveryTimeout is the maximum time to wait for the node to become Ready again after the disruption.
+	NodeRecoveryTimeout = 20 * time.Minute
+
+	// MinHealthyCheckDuration is how long the tests check that no remediation is created while minHealthy is not met.
+	MinHealthyCheckDuration = 2 * time.Minute
 )
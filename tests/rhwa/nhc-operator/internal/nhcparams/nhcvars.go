@@ -1,8 +1,50 @@
 package nhcparams
 
-import "github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+import (
+	"github.com/openshift-kni/k8sreporter"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
 
 var (
 	// Labels represents the range of labels that can be used for test cases selection.
 	Labels = []string{rhwaparams.Label, Label}
+
+	// OperatorDeploymentName represents NHC deployment name.
+	OperatorDeploymentName = "node-healthcheck-controller-manager"
+
+	// OperatorControllerPodLabel is how the controller pod is labeled.
+	OperatorControllerPodLabel = "node-healthcheck-operator"
+
+	// CSVNamePattern is the name pattern of the NHC ClusterServiceVersion.
+	CSVNamePattern = "node-healthcheck-operator"
+
+	// SNRTemplateName is the automatic strategy SelfNodeRemediationTemplate created by the SNR operator, which NHC
+	// uses as its default remediation template.
+	SNRTemplateName = "self-node-remediation-automatic-strategy-template"
+
+	// NodeHealthCheckGVR is the GroupVersionResource of the cluster scoped NodeHealthCheck.
+	NodeHealthCheckGVR = schema.GroupVersionResource{
+		Group:    "remediation.medik8s.io",
+		Version:  "v1alpha1",
+		Resource: "nodehealthchecks",
+	}
+
+	// ReporterNamespacesToDump tells to the reporter from where to collect logs.
+	ReporterNamespacesToDump = map[string]string{
+		rhwaparams.RhwaOperatorNs: rhwaparams.RhwaOperatorNs,
+	}
+
+	// ReporterCRDsToDump tells to the reporter what CRs to dump.
+	ReporterCRDsToDump = []k8sreporter.CRData{
+		{Cr: &corev1.PodList{}},
+	}
+
+	// RequiredAnnotations defines the required annotations and their expected values for NHC CSV.
+	RequiredAnnotations = map[string]string{
+		"features.operators.openshift.io/disconnected":   "true",
+		"features.operators.openshift.io/fips-compliant": "true",
+		"operatorframework.io/suggested-namespace":       rhwaparams.RhwaOperatorNs,
+	}
 )
@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodedisruption"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwahelper"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwainittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/nhc-operator/internal/nhchelper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/nhc-operator/internal/nhcparams"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe(
+	"NHC remediation tests",
+	Ordered,
+	Label(nhcparams.Label), func() {
+		var (
+			targetWorker string
+			workerCount  int
+			template     = nhchelper.SNRTemplateReference(rhwaparams.RhwaOperatorNs)
+		)
+
+		BeforeAll(func() {
+			By("Checking the cluster has enough workers")
+
+			workers, err := nodes.List(
+				APIClient, metav1.ListOptions{LabelSelector: labels.Set(RHWAConfig.WorkerLabelMap).String()})
+			Expect(err).ToNot(HaveOccurred(), "Failed to list worker nodes")
+
+			workerCount = len(workers)
+			if workerCount < 2 {
+				Skip("NHC remediation tests require at least two worker nodes")
+			}
+
+			By("Selecting the worker node to disrupt")
+
+			targetWorker, err = rhwahelper.SelectWorkerNode(
+				APIClient, RHWAConfig.WorkerLabelMap, RHWAConfig.TargetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to select the worker node to disrupt")
+
+			By("Verifying the remediation template exists")
+
+			exists, err := nhchelper.TemplateExists(APIClient, template)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get the remediation template")
+			Expect(exists).To(BeTrue(), "Remediation template %s/%s was not created", template.Namespace, template.Name)
+		})
+
+		AfterEach(func() {
+			By("Deleting the NodeHealthCheck")
+
+			err := nhchelper.Delete(APIClient, nhcparams.NodeHealthCheckName)
+			Expect(err).ToNot(HaveOccurred(), "Failed to delete NodeHealthCheck")
+		})
+
+		AfterAll(func() {
+			if targetWorker == "" {
+				return
+			}
+
+			By("Waiting for the disrupted worker to recover")
+
+			err := nodedisruption.WaitForNodeReady(APIClient, targetWorker, nhcparams.NodeRecoveryTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker node did not recover")
+		})
+
+		It("Verify NodeHealthCheck with invalid minHealthy is rejected", func() {
+			spec := newWorkerSpec(template, intstr.FromString("150%"))
+
+			err := nhchelper.Create(APIClient, nhcparams.NodeHealthCheckName, spec)
+			Expect(err).To(HaveOccurred(), "NodeHealthCheck with minHealthy above 100% was accepted")
+		})
+
+		It("Verify NodeHealthCheck observes the selected worker nodes", func() {
+			By("Creating the NodeHealthCheck")
+
+			err := nhchelper.Create(
+				APIClient, nhcparams.NodeHealthCheckName, newWorkerSpec(template, intstr.FromString("51%")))
+			Expect(err).ToNot(HaveOccurred(), "Failed to create NodeHealthCheck")
+
+			By("Waiting for the NodeHealthCheck to observe every worker")
+
+			observingWorkers := func(status *nhchelper.Status) bool {
+				return status.Phase == nhchelper.PhaseEnabled && status.ObservedNodes == workerCount
+			}
+
+			status, err := nhchelper.WaitForStatus(
+				APIClient, nhcparams.NodeHealthCheckName, rhwaparams.DefaultTimeout, observingWorkers)
+			Expect(err).ToNot(HaveOccurred(), "NodeHealthCheck is not enabled for all workers: %+v", status)
+			Expect(status.HealthyNodes).To(Equal(workerCount), "Not all workers are healthy")
+		})
+
+		It("Verify remediation is created for a NotReady worker", func() {
+			By("Creating the NodeHealthCheck")
+
+			err := nhchelper.Create(
+				APIClient, nhcparams.NodeHealthCheckName, newWorkerSpec(template, intstr.FromString("51%")))
+			Expect(err).ToNot(HaveOccurred(), "Failed to create NodeHealthCheck")
+
+			_, err = nhchelper.WaitForPhase(
+				APIClient, nhcparams.NodeHealthCheckName, nhchelper.PhaseEnabled, rhwaparams.DefaultTimeout)
+			Expect(err).ToNot(HaveOccurred(), "NodeHealthCheck is not enabled")
+
+			disruptWorker(targetWorker)
+
+			By("Waiting for NHC to remediate the worker")
+
+			remediating := func(status *nhchelper.Status) bool {
+				return status.IsRemediating(targetWorker)
+			}
+
+			status, err := nhchelper.WaitForStatus(
+				APIClient, nhcparams.NodeHealthCheckName, nhcparams.RemediationTimeout, remediating)
+			Expect(err).ToNot(HaveOccurred(), "NHC did not remediate worker %s: %+v", targetWorker, status)
+
+			By("Verifying the remediation was created from the template")
+
+			exists, err := rhwahelper.SNRExists(APIClient, template.Namespace, targetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get the remediation")
+			Expect(exists).To(BeTrue(), "No remediation was created for worker %s", targetWorker)
+
+			By("Waiting for the worker to recover")
+
+			err = nodedisruption.WaitForNodeReady(APIClient, targetWorker, nhcparams.NodeRecoveryTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker node did not recover")
+
+			_, err = nhchelper.WaitForPhase(
+				APIClient, nhcparams.NodeHealthCheckName, nhchelper.PhaseEnabled, nhcparams.NodeRecoveryTimeout)
+			Expect(err).ToNot(HaveOccurred(), "NodeHealthCheck did not finish remediating")
+		})
+
+		It("Verify minHealthy prevents remediation when too few workers are healthy", func() {
+			By("Creating the NodeHealthCheck requiring every worker to be healthy")
+
+			err := nhchelper.Create(
+				APIClient, nhcparams.NodeHealthCheckName, newWorkerSpec(template, intstr.FromString("100%")))
+			Expect(err).ToNot(HaveOccurred(), "Failed to create NodeHealthCheck")
+
+			_, err = nhchelper.WaitForPhase(
+				APIClient, nhcparams.NodeHealthCheckName, nhchelper.PhaseEnabled, rhwaparams.DefaultTimeout)
+			Expect(err).ToNot(HaveOccurred(), "NodeHealthCheck is not enabled")
+
+			disruptWorker(targetWorker)
+
+			By("Waiting for NHC to count the worker as unhealthy")
+
+			countedUnhealthy := func(status *nhchelper.Status) bool {
+				return status.HealthyNodes < status.ObservedNodes
+			}
+
+			status, err := nhchelper.WaitForStatus(
+				APIClient, nhcparams.NodeHealthCheckName, nhcparams.RemediationTimeout, countedUnhealthy)
+			Expect(err).ToNot(HaveOccurred(), "NHC did not count worker %s as unhealthy: %+v", targetWorker, status)
+
+			By("Verifying no remediation is created while minHealthy is not met")
+
+			Consistently(func() (bool, error) {
+				return rhwahelper.SNRExists(APIClient, template.Namespace, targetWorker)
+			}, nhcparams.UnhealthyDuration+nhcparams.MinHealthyCheckDuration, 10*time.Second).Should(BeFalse(),
+				"Remediation was created for worker %s although minHealthy was not met", targetWorker)
+
+			By("Waiting for the worker to recover")
+
+			err = nodedisruption.WaitForNodeReady(APIClient, targetWorker, nhcparams.NodeRecoveryTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker node did not recover")
+		})
+	})
+
+// newWorkerSpec returns a NodeHealthCheck spec selecting the worker nodes and remediating them with template.
+func newWorkerSpec(template *corev1.ObjectReference, minHealthy intstr.IntOrString) nhchelper.Spec {
+	return nhchelper.Spec{
+		Selector:            metav1.LabelSelector{MatchLabels: RHWAConfig.WorkerLabelMap},
+		MinHealthy:          &minHealthy,
+		UnhealthyConditions: nhchelper.DefaultUnhealthyConditions(nhcparams.UnhealthyDuration),
+		RemediationTemplate: template,
+	}
+}
+
+// disruptWorker stops the kubelet on nodeName and waits for the node to become NotReady.
+func disruptWorker(nodeName string) {
+	By("Stopping the kubelet on the worker")
+
+	err := rhwahelper.StopKubelet(APIClient, RHWAConfig.GeneralConfig, nodeName, nhcparams.KubeletStopDuration)
+	Expect(err).ToNot(HaveOccurred(), "Failed to stop the kubelet on worker %s", nodeName)
+
+	By("Waiting for the worker to become NotReady")
+
+	err = nodedisruption.WaitForNodeNotReady(APIClient, nodeName, nhcparams.NodeNotReadyTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Worker %s did not become NotReady", nodeName)
+}
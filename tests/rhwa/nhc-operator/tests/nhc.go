@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwahelper"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwainittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/nhc-operator/internal/nhcparams"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe(
+	"NHC Post Deployment tests",
+	Ordered,
+	ContinueOnFailure,
+	Label(nhcparams.Label), func() {
+		var nhcDeployment *deployment.Builder
+
+		BeforeAll(func() {
+			By("Get NHC deployment object")
+
+			var err error
+
+			nhcDeployment, err = deployment.Pull(
+				APIClient, nhcparams.OperatorDeploymentName, rhwaparams.RhwaOperatorNs)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get NHC deployment")
+
+			By("Verify NHC deployment is Ready")
+			Expect(nhcDeployment.IsReady(rhwaparams.DefaultTimeout)).To(BeTrue(), "NHC deployment is not Ready")
+		})
+
+		It("Verify Node Health Check Operator pod is running", func() {
+			listOptions := metav1.ListOptions{
+				LabelSelector: fmt.Sprintf("app.kubernetes.io/name=%s", nhcparams.OperatorControllerPodLabel),
+			}
+			_, err := pod.WaitForAllPodsInNamespaceRunning(
+				APIClient,
+				rhwaparams.RhwaOperatorNs,
+				rhwaparams.DefaultTimeout,
+				listOptions,
+			)
+			Expect(err).ToNot(HaveOccurred(), "Pod is not ready")
+		})
+
+		It("Verify NHC CSV has required annotations", func() {
+			By("Checking annotation values on NHC CSV")
+
+			err := rhwahelper.VerifyCSVAnnotations(
+				APIClient, nhcparams.CSVNamePattern, rhwaparams.RhwaOperatorNs, nhcparams.RequiredAnnotations)
+			Expect(err).ToNot(HaveOccurred(), "NHC CSV does not have the required annotations")
+		})
+
+		It("Verify NHC controller manager has correct number of replicas", func() {
+			By("Checking cluster topology")
+
+			isSNO, err := rhwahelper.IsSNO(APIClient)
+			Expect(err).ToNot(HaveOccurred(), "Failed to check cluster topology")
+
+			if isSNO {
+				Skip("Skipping test on SNO (Single Node OpenShift) cluster")
+			}
+
+			By("Checking deployment replicas")
+
+			err = rhwahelper.VerifyDeploymentReplicas(nhcDeployment, nhcparams.ExpectedReplicas)
+			Expect(err).ToNot(HaveOccurred(), "NHC controller manager has incorrect replicas")
+		})
+
+		It("Verify NHC container runs as non-root user", func() {
+			By("Verifying security context of NHC controller pods")
+
+			err := rhwahelper.VerifyPodsRunAsNonRoot(APIClient, rhwaparams.RhwaOperatorNs,
+				fmt.Sprintf("app.kubernetes.io/name=%s", nhcparams.OperatorControllerPodLabel))
+			Expect(err).ToNot(HaveOccurred(), "Testing user running NHC container failed")
+		})
+	})
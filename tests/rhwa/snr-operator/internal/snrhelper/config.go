@@ -0,0 +1,98 @@
+// Package snrhelper provides helpers to inspect the SelfNodeRemediationConfig and the effects of self node
+// remediation on nodes. The SelfNodeRemediation API is not part of eco-goinfra, so the resources are handled through
+// the dynamic client using the subset of the API defined here.
+package snrhelper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// ConfigSpec is the subset of the SelfNodeRemediationConfig spec checked by the tests. Fields that are not set in the
+// resource are nil.
+type ConfigSpec struct {
+	WatchdogFilePath        string           `json:"watchdogFilePath,omitempty"`
+	IsSoftwareRebootEnabled *bool            `json:"isSoftwareRebootEnabled,omitempty"`
+	APIServerTimeout        *metav1.Duration `json:"apiServerTimeout,omitempty"`
+	PeerAPIServerTimeout    *metav1.Duration `json:"peerApiServerTimeout,omitempty"`
+	PeerDialTimeout         *metav1.Duration `json:"peerDialTimeout,omitempty"`
+	PeerRequestTimeout      *metav1.Duration `json:"peerRequestTimeout,omitempty"`
+	PeerUpdateInterval      *metav1.Duration `json:"peerUpdateInterval,omitempty"`
+	APICheckInterval        *metav1.Duration `json:"apiCheckInterval,omitempty"`
+	MaxAPIErrorThreshold    *int             `json:"maxApiErrorThreshold,omitempty"`
+}
+
+// GetConfigSpec returns the spec of the SelfNodeRemediationConfig named name in namespace.
+func GetConfigSpec(apiClient *clients.Settings, name, namespace string) (*ConfigSpec, error) {
+	config, err := apiClient.Resource(rhwaparams.SNRConfigGVR).Namespace(namespace).Get(
+		context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SelfNodeRemediationConfig %s/%s: %w", namespace, name, err)
+	}
+
+	spec := &ConfigSpec{}
+
+	specMap, found, err := unstructured.NestedMap(config.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SelfNodeRemediationConfig %s/%s spec: %w", namespace, name, err)
+	}
+
+	if !found {
+		return spec, nil
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert SelfNodeRemediationConfig %s/%s spec: %w", namespace, name, err)
+	}
+
+	return spec, nil
+}
+
+// HasTaint returns true if nodeName has a taint with key.
+func HasTaint(apiClient *clients.Settings, nodeName, key string) (bool, error) {
+	node, err := nodes.Pull(apiClient, nodeName)
+	if err != nil {
+		return false, fmt.Errorf("failed to pull node %s: %w", nodeName, err)
+	}
+
+	for _, taint := range node.Object.Spec.Taints {
+		if taint.Key == key {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// WaitForTaint waits up to timeout for the presence of the taint with key on nodeName to match present. Errors getting
+// the node are ignored since the API server may be unavailable while the node is remediated.
+func WaitForTaint(apiClient *clients.Settings, nodeName, key string, present bool, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(context.TODO(), 5*time.Second, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			hasTaint, err := HasTaint(apiClient, nodeName, key)
+			if err != nil {
+				klog.V(90).Infof("Failed to check taints of node %s, retrying: %v", nodeName, err)
+
+				return false, nil
+			}
+
+			return hasTaint == present, nil
+		})
+	if err != nil {
+		return fmt.Errorf("taint %s on node %s did not reach presence %t within %s: %w",
+			key, nodeName, present, timeout, err)
+	}
+
+	return nil
+}
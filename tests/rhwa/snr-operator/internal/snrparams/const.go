@@ -1,6 +1,50 @@
 package snrparams
 
+import "time"
+
 const (
 	// Label represents snr operator label that can be used for test cases selection.
 	Label = "snr"
+
+	// StrategyResourceDeletion deletes the pods and volume attachments of the node after it is rebooted.
+	StrategyResourceDeletion = "ResourceDeletion"
+	// StrategyOutOfServiceTaint adds the out-of-service taint to the node after it is rebooted so that kubernetes
+	// deletes its workloads.
+	StrategyOutOfServiceTaint = "OutOfServiceTaint"
+
+	// OutOfServiceTaintKey is the key of the taint added by the OutOfServiceTaint strategy.
+	OutOfServiceTaintKey = "node.kubernetes.io/out-of-service"
+
+	// DefaultWatchdogFilePath is the default watchdog device used by the SNR agent.
+	DefaultWatchdogFilePath = "/dev/watchdog"
+	// DefaultAPIServerTimeout is the default timeout of the SNR agent API server checks.
+	DefaultAPIServerTimeout = 5 * time.Second
+	// DefaultPeerAPIServerTimeout is the default timeout of the API server checks done by peers.
+	DefaultPeerAPIServerTimeout = 5 * time.Second
+	// DefaultPeerDialTimeout is the default timeout for connecting to a peer.
+	DefaultPeerDialTimeout = 5 * time.Second
+	// DefaultPeerRequestTimeout is the default timeout of the requests to a peer.
+	DefaultPeerRequestTimeout = 5 * time.Second
+	// DefaultPeerUpdateInterval is the default interval at which the SNR agent updates its list of peers.
+	DefaultPeerUpdateInterval = 15 * time.Minute
+	// DefaultAPICheckInterval is the default interval at which the SNR agent checks the API server.
+	DefaultAPICheckInterval = 15 * time.Second
+	// DefaultMaxAPIErrorThreshold is the default number of consecutive API server errors before peers are asked.
+	DefaultMaxAPIErrorThreshold = 3
+
+	// TestNamespace is the namespace of the workload pinned to the remediated worker.
+	TestNamespace = "eco-snr-test"
+	// TestPodName is the name of the workload pod pinned to the remediated worker.
+	TestPodName = "eco-snr-workload"
+
+	// RemediationTimeout is the maximum time to wait for the SNR agent to remediate the worker.
+	RemediationTimeout = 15 * time.Minute
+	// NodeRecoveryTimeout is the maximum time to wait for the worker to become Ready again after remediation.
+	NodeRecoveryTimeout = 20 * time.Minute
+	// NodeNotReadyTimeout is the maximum time to wait for the worker to become NotReady after blocking the API
+	// server.
+	NodeNotReadyTimeout = 3 * time.Minute
+	// APIServerBlockDuration is how long the worker is cut off from the API server. It is longer than the time the
+	// SNR agent needs to exhaust its API server checks and ask its peers.
+	APIServerBlockDuration = 4 * time.Minute
 )
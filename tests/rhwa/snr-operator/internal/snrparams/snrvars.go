@@ -1,8 +1,48 @@
 package snrparams
 
-import "github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+import (
+	"github.com/openshift-kni/k8sreporter"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	corev1 "k8s.io/api/core/v1"
+)
 
 var (
 	// Labels represents the range of labels that can be used for test cases selection.
 	Labels = []string{rhwaparams.Label, Label}
+
+	// OperatorDeploymentName represents SNR deployment name.
+	OperatorDeploymentName = "self-node-remediation-controller-manager"
+
+	// OperatorControllerPodLabel is how the controller pod is labeled.
+	OperatorControllerPodLabel = "self-node-remediation-operator"
+
+	// AgentDaemonSetName is the name of the DaemonSet running the SNR agent on the nodes.
+	AgentDaemonSetName = "self-node-remediation-ds"
+
+	// CSVNamePattern is the name pattern of the SNR ClusterServiceVersion.
+	CSVNamePattern = "self-node-remediation"
+
+	// ConfigName is the name of the SelfNodeRemediationConfig created by the operator.
+	ConfigName = "self-node-remediation-config"
+
+	// TestPodImage is the image of the workload pod pinned to the remediated worker.
+	TestPodImage = "registry.redhat.io/ubi9/ubi-minimal:latest"
+
+	// ReporterNamespacesToDump tells to the reporter from where to collect logs.
+	ReporterNamespacesToDump = map[string]string{
+		rhwaparams.RhwaOperatorNs: rhwaparams.RhwaOperatorNs,
+		TestNamespace:             TestNamespace,
+	}
+
+	// ReporterCRDsToDump tells to the reporter what CRs to dump.
+	ReporterCRDsToDump = []k8sreporter.CRData{
+		{Cr: &corev1.PodList{}},
+	}
+
+	// RequiredAnnotations defines the required annotations and their expected values for SNR CSV.
+	RequiredAnnotations = map[string]string{
+		"features.operators.openshift.io/disconnected":   "true",
+		"features.operators.openshift.io/fips-compliant": "true",
+		"operatorframework.io/suggested-namespace":       rhwaparams.RhwaOperatorNs,
+	}
 )
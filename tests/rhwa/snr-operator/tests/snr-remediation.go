@@ -0,0 +1,183 @@
+package tests
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodedisruption"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwahelper"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwainittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/snr-operator/internal/snrhelper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/snr-operator/internal/snrparams"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var _ = Describe(
+	"SNR remediation tests",
+	Ordered,
+	Label(snrparams.Label), func() {
+		var targetWorker string
+
+		BeforeAll(func() {
+			By("Checking the cluster has enough workers")
+
+			workers, err := nodes.List(
+				APIClient, metav1.ListOptions{LabelSelector: labels.Set(RHWAConfig.WorkerLabelMap).String()})
+			Expect(err).ToNot(HaveOccurred(), "Failed to list worker nodes")
+
+			if len(workers) < 2 {
+				Skip("SNR remediation tests require at least two worker nodes")
+			}
+
+			By("Selecting the worker node to disrupt")
+
+			targetWorker, err = rhwahelper.SelectWorkerNode(
+				APIClient, RHWAConfig.WorkerLabelMap, RHWAConfig.TargetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to select the worker node to disrupt")
+
+			By("Creating the test namespace")
+
+			_, err = namespace.NewBuilder(APIClient, snrparams.TestNamespace).Create()
+			Expect(err).ToNot(HaveOccurred(), "Failed to create test namespace")
+		})
+
+		AfterEach(func() {
+			if targetWorker == "" {
+				return
+			}
+
+			By("Deleting the SelfNodeRemediation")
+
+			err := rhwahelper.DeleteSNR(APIClient, rhwaparams.RhwaOperatorNs, targetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to delete SelfNodeRemediation")
+
+			By("Waiting for the worker to recover")
+
+			err = nodedisruption.WaitForNodeReady(APIClient, targetWorker, snrparams.NodeRecoveryTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker node did not recover")
+		})
+
+		AfterAll(func() {
+			if targetWorker == "" {
+				return
+			}
+
+			By("Deleting the test namespace")
+
+			err := namespace.NewBuilder(APIClient, snrparams.TestNamespace).DeleteAndWait(rhwaparams.DefaultTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Failed to delete test namespace")
+		})
+
+		It("Verify ResourceDeletion strategy reboots the worker and deletes its workloads", func() {
+			workload := createWorkload(targetWorker)
+
+			bootID := remediate(targetWorker, snrparams.StrategyResourceDeletion)
+
+			By("Waiting for the workload on the worker to be deleted")
+
+			err := workload.WaitUntilDeleted(snrparams.RemediationTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Workload on worker %s was not deleted", targetWorker)
+
+			By("Waiting for the worker to reboot")
+
+			err = nodedisruption.WaitForNodeReboot(APIClient, targetWorker, bootID, snrparams.RemediationTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker %s was not rebooted", targetWorker)
+		})
+
+		It("Verify OutOfServiceTaint strategy reboots the worker and taints it out of service", func() {
+			workload := createWorkload(targetWorker)
+
+			bootID := remediate(targetWorker, snrparams.StrategyOutOfServiceTaint)
+
+			By("Waiting for the out-of-service taint on the worker")
+
+			err := snrhelper.WaitForTaint(
+				APIClient, targetWorker, snrparams.OutOfServiceTaintKey, true, snrparams.RemediationTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker %s was not tainted out of service", targetWorker)
+
+			By("Waiting for the workload on the worker to be deleted")
+
+			err = workload.WaitUntilDeleted(snrparams.RemediationTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Workload on worker %s was not deleted", targetWorker)
+
+			By("Waiting for the worker to reboot")
+
+			err = nodedisruption.WaitForNodeReboot(APIClient, targetWorker, bootID, snrparams.RemediationTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker %s was not rebooted", targetWorker)
+
+			By("Deleting the SelfNodeRemediation and waiting for the taint to be removed")
+
+			err = rhwahelper.DeleteSNR(APIClient, rhwaparams.RhwaOperatorNs, targetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to delete SelfNodeRemediation")
+
+			err = snrhelper.WaitForTaint(
+				APIClient, targetWorker, snrparams.OutOfServiceTaintKey, false, snrparams.NodeRecoveryTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Out-of-service taint was not removed from worker %s", targetWorker)
+		})
+
+		It("Verify worker is not rebooted when only the API server is unreachable", func() {
+			By("Recording the boot ID of the worker")
+
+			bootID, err := nodedisruption.GetBootID(APIClient, targetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get boot ID of worker %s", targetWorker)
+
+			By("Blocking the API server from the worker")
+
+			err = rhwahelper.BlockAPIServer(
+				APIClient, RHWAConfig.GeneralConfig, targetWorker, snrparams.APIServerBlockDuration)
+			Expect(err).ToNot(HaveOccurred(), "Failed to block the API server from worker %s", targetWorker)
+
+			err = nodedisruption.WaitForNodeNotReady(APIClient, targetWorker, snrparams.NodeNotReadyTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker %s did not lose the API server", targetWorker)
+
+			By("Waiting for the worker to reach the API server again")
+
+			err = nodedisruption.WaitForNodeReady(APIClient, targetWorker, snrparams.NodeRecoveryTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Worker %s did not recover", targetWorker)
+
+			By("Verifying the peers kept the worker from rebooting")
+
+			currentBootID, err := nodedisruption.GetBootID(APIClient, targetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get boot ID of worker %s", targetWorker)
+			Expect(currentBootID).To(Equal(bootID), "Worker %s rebooted although its peers were healthy", targetWorker)
+
+			exists, err := rhwahelper.SNRExists(APIClient, rhwaparams.RhwaOperatorNs, targetWorker)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get SelfNodeRemediation")
+			Expect(exists).To(BeFalse(), "SelfNodeRemediation was created for worker %s", targetWorker)
+		})
+	})
+
+// createWorkload creates a pod pinned to nodeName and waits for it to run.
+func createWorkload(nodeName string) *pod.Builder {
+	By("Creating a workload on the worker")
+
+	workload, err := pod.NewBuilder(APIClient, snrparams.TestPodName, snrparams.TestNamespace, snrparams.TestPodImage).
+		DefineOnNode(nodeName).
+		CreateAndWaitUntilRunning(rhwaparams.DefaultTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Failed to create workload on worker %s", nodeName)
+
+	return workload
+}
+
+// remediate records the boot ID of nodeName and creates a SelfNodeRemediation for it using strategy. It returns the
+// boot ID from before the remediation.
+func remediate(nodeName, strategy string) string {
+	By("Recording the boot ID of the worker")
+
+	bootID, err := nodedisruption.GetBootID(APIClient, nodeName)
+	Expect(err).ToNot(HaveOccurred(), "Failed to get boot ID of worker %s", nodeName)
+
+	By("Creating the SelfNodeRemediation with strategy " + strategy)
+
+	err = rhwahelper.CreateSNR(APIClient, rhwaparams.RhwaOperatorNs, nodeName, strategy)
+	Expect(err).ToNot(HaveOccurred(), "Failed to create SelfNodeRemediation for worker %s", nodeName)
+
+	return bootID
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/daemonset"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwahelper"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwainittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/internal/rhwaparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/snr-operator/internal/snrhelper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/rhwa/snr-operator/internal/snrparams"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var _ = Describe(
+	"SNR Post Deployment tests",
+	Ordered,
+	ContinueOnFailure,
+	Label(snrparams.Label), func() {
+		var configSpec *snrhelper.ConfigSpec
+
+		BeforeAll(func() {
+			By("Get SNR deployment object")
+
+			snrDeployment, err := deployment.Pull(
+				APIClient, snrparams.OperatorDeploymentName, rhwaparams.RhwaOperatorNs)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get SNR deployment")
+
+			By("Verify SNR deployment is Ready")
+			Expect(snrDeployment.IsReady(rhwaparams.DefaultTimeout)).To(BeTrue(), "SNR deployment is not Ready")
+
+			By("Get SelfNodeRemediationConfig")
+
+			configSpec, err = snrhelper.GetConfigSpec(APIClient, snrparams.ConfigName, rhwaparams.RhwaOperatorNs)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get SelfNodeRemediationConfig")
+		})
+
+		It("Verify Self Node Remediation Operator pod is running", func() {
+			listOptions := metav1.ListOptions{
+				LabelSelector: fmt.Sprintf("app.kubernetes.io/name=%s", snrparams.OperatorControllerPodLabel),
+			}
+			_, err := pod.WaitForAllPodsInNamespaceRunning(
+				APIClient,
+				rhwaparams.RhwaOperatorNs,
+				rhwaparams.DefaultTimeout,
+				listOptions,
+			)
+			Expect(err).ToNot(HaveOccurred(), "Pod is not ready")
+		})
+
+		It("Verify SNR CSV has required annotations", func() {
+			By("Checking annotation values on SNR CSV")
+
+			err := rhwahelper.VerifyCSVAnnotations(
+				APIClient, snrparams.CSVNamePattern, rhwaparams.RhwaOperatorNs, snrparams.RequiredAnnotations)
+			Expect(err).ToNot(HaveOccurred(), "SNR CSV does not have the required annotations")
+		})
+
+		It("Verify SNR agent runs on every worker", func() {
+			By("Get SNR agent DaemonSet")
+
+			agentDaemonSet, err := daemonset.Pull(APIClient, snrparams.AgentDaemonSetName, rhwaparams.RhwaOperatorNs)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get SNR agent DaemonSet")
+			Expect(agentDaemonSet.IsReady(rhwaparams.DefaultTimeout)).To(BeTrue(), "SNR agent DaemonSet is not Ready")
+
+			By("Comparing the scheduled agents with the workers")
+
+			workers, err := nodes.List(
+				APIClient, metav1.ListOptions{LabelSelector: labels.Set(RHWAConfig.WorkerLabelMap).String()})
+			Expect(err).ToNot(HaveOccurred(), "Failed to list worker nodes")
+			Expect(int(agentDaemonSet.Object.Status.NumberReady)).To(BeNumerically(">=", len(workers)),
+				"SNR agent is not ready on every worker")
+		})
+
+		It("Verify SelfNodeRemediationConfig has default values", func() {
+			Expect(configSpec.WatchdogFilePath).To(Equal(snrparams.DefaultWatchdogFilePath), "Unexpected watchdog path")
+			Expect(configSpec.IsSoftwareRebootEnabled).ToNot(BeNil(), "isSoftwareRebootEnabled is not set")
+			Expect(*configSpec.IsSoftwareRebootEnabled).To(BeTrue(), "Software reboot is not enabled")
+			Expect(configSpec.MaxAPIErrorThreshold).ToNot(BeNil(), "maxApiErrorThreshold is not set")
+			Expect(*configSpec.MaxAPIErrorThreshold).To(Equal(snrparams.DefaultMaxAPIErrorThreshold),
+				"Unexpected maxApiErrorThreshold")
+
+			durations := []struct {
+				field    string
+				value    *metav1.Duration
+				expected time.Duration
+			}{
+				{"apiServerTimeout", configSpec.APIServerTimeout, snrparams.DefaultAPIServerTimeout},
+				{"peerApiServerTimeout", configSpec.PeerAPIServerTimeout, snrparams.DefaultPeerAPIServerTimeout},
+				{"peerDialTimeout", configSpec.PeerDialTimeout, snrparams.DefaultPeerDialTimeout},
+				{"peerRequestTimeout", configSpec.PeerRequestTimeout, snrparams.DefaultPeerRequestTimeout},
+				{"peerUpdateInterval", configSpec.PeerUpdateInterval, snrparams.DefaultPeerUpdateInterval},
+				{"apiCheckInterval", configSpec.APICheckInterval, snrparams.DefaultAPICheckInterval},
+			}
+
+			for _, duration := range durations {
+				Expect(duration.value).ToNot(BeNil(), "%s is not set", duration.field)
+				Expect(duration.value.Duration).To(Equal(duration.expected), "Unexpected %s", duration.field)
+			}
+		})
+
+		It("Verify watchdog is configured on every worker", func() {
+			workers, err := nodes.List(
+				APIClient, metav1.ListOptions{LabelSelector: labels.Set(RHWAConfig.WorkerLabelMap).String()})
+			Expect(err).ToNot(HaveOccurred(), "Failed to list worker nodes")
+
+			softwareReboot := configSpec.IsSoftwareRebootEnabled != nil && *configSpec.IsSoftwareRebootEnabled
+
+			for _, worker := range workers {
+				By(fmt.Sprintf("Checking watchdog device on worker %s", worker.Object.Name))
+
+				output, err := rhwahelper.ExecOnNode(APIClient, RHWAConfig.GeneralConfig, worker.Object.Name,
+					fmt.Sprintf("test -c %s && echo present || echo missing", configSpec.WatchdogFilePath))
+				Expect(err).ToNot(HaveOccurred(), "Failed to check watchdog on worker %s", worker.Object.Name)
+
+				if strings.TrimSpace(output) != "present" {
+					Expect(softwareReboot).To(BeTrue(),
+						"Worker %s has no watchdog device %s and software reboot is disabled",
+						worker.Object.Name, configSpec.WatchdogFilePath)
+				}
+			}
+		})
+	})
@@ -2,16 +2,14 @@ package config
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"time"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	ecoconfig "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/diskencryption/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 )
 
@@ -43,16 +41,11 @@ func NewDiskEncryptionConfig() *DiskEncrptionConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultDiskEncryptionParamsFile)
 
-	err := readFile(&diskEncryptionConf, confFile)
-	if err != nil {
-		log.Printf("Error reading config file %s", confFile)
+	loader := ecoconfig.Loader{Section: "diskencryption", DefaultFile: confFile, EnvPrefix: "ECO_SYSTEM_TESTS_"}
 
-		return nil
-	}
-
-	err = readEnv(&diskEncryptionConf)
+	err := loader.Load(&diskEncryptionConf)
 	if err != nil {
-		log.Print("Error reading environment variables")
+		log.Printf("Error to load DiskEncrptionConfig: %v", err)
 
 		return nil
 	}
@@ -73,32 +66,3 @@ func NewDiskEncryptionConfig() *DiskEncrptionConfig {
 
 	return &diskEncryptionConf
 }
-
-func readFile(diskEncrptionConfig *DiskEncrptionConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&diskEncrptionConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(diskEncrptionConfig *DiskEncrptionConfig) error {
-	err := envconfig.Process("", diskEncrptionConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
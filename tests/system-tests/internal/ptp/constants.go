@@ -8,13 +8,8 @@ const (
 	DaemonPodLabelValueLinuxpt = "linuxptp-daemon"
 )
 
-// Intel GNSS plugin keys in PtpConfig profiles and matching ubxtool -P protocol revisions.
+// Offset threshold and daemon log markers used by PTP system tests.
 const (
-	PluginNameE825         = "e825"
-	PluginNameE830         = "e830"
-	PluginNameE810         = "e810"
-	UbloxProtocolE825E830  = "29.25"
-	UbloxProtocolE810      = "29.20"
 	DefaultMaxAbsOffsetNS  = 100
 	StateSubscribedLogMark = " s2"
 	LogKeywordHoldover     = "holdover"
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/nodedisruption"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
@@ -20,7 +21,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 )
 
@@ -148,74 +148,12 @@ func waitForNodeRebootCycle(nodeName string) error {
 
 // waitForNodeNotReady waits for the node to become NotReady.
 func waitForNodeNotReady(nodeName string) error {
-	klog.V(90).Infof("Waiting for node %s to become NotReady after power cycle", nodeName)
-
-	return wait.PollUntilContextTimeout(
-		context.TODO(),
-		5*time.Second,
-		10*time.Minute,
-		true,
-		func(ctx context.Context) (bool, error) {
-			node, nodeErr := nodes.Pull(APIClient, nodeName)
-			if nodeErr != nil {
-				klog.V(90).Infof("Error pulling node %s: %v", nodeName, nodeErr)
-
-				return false, nil // Node might be unreachable, which is expected
-			}
-
-			for _, condition := range node.Object.Status.Conditions {
-				if condition.Type == corev1.NodeReady {
-					if condition.Status != corev1.ConditionTrue {
-						klog.V(90).Infof("Node %s is NotReady: %s", nodeName, condition.Reason)
-
-						return true, nil
-					}
-				}
-			}
-
-			return false, nil
-		})
+	return nodedisruption.WaitForNodeNotReady(APIClient, nodeName, 10*time.Minute)
 }
 
 // waitForNodeReady waits for the node to become Ready.
 func waitForNodeReady(nodeName string) error {
-	klog.V(90).Infof("Waiting for node %s to become Ready again", nodeName)
-
-	// Wait for node to become Ready (indicating it's back up)
-	err := wait.PollUntilContextTimeout(
-		context.TODO(),
-		10*time.Second,
-		25*time.Minute,
-		true,
-		func(ctx context.Context) (bool, error) {
-			node, nodeErr := nodes.Pull(APIClient, nodeName)
-			if nodeErr != nil {
-				klog.V(90).Infof("Error pulling node %s (expected during reboot): %v", nodeName, nodeErr)
-
-				return false, nil // Continue polling, API might be unavailable during reboot
-			}
-
-			for _, condition := range node.Object.Status.Conditions {
-				if condition.Type == corev1.NodeReady {
-					if condition.Status == corev1.ConditionTrue {
-						klog.V(90).Infof("Node %s is Ready: %s", nodeName, condition.Reason)
-
-						return true, nil
-					}
-				}
-			}
-
-			klog.V(90).Infof("Node %s is still not Ready", nodeName)
-
-			return false, nil
-		})
-	if err != nil {
-		return fmt.Errorf("node %s did not become Ready within timeout: %w", nodeName, err)
-	}
-
-	klog.V(90).Infof("Node %s successfully came back online", nodeName)
-
-	return nil
+	return nodedisruption.WaitForNodeReady(APIClient, nodeName, 25*time.Minute)
 }
 
 // waitForAPIServerReady waits for the OpenShift API server to be available.
@@ -0,0 +1,131 @@
+// Package stabilityprobes provides the stability probes of the system tests, which sample PTP sync, pod restarts,
+// policy compliance and tuned profile applications on a cluster.
+package stabilityprobes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ocm"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/stability"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/ptp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PTPKey is the key of the observation recorded by the PTP probe.
+	PTPKey = "ptp"
+	// PTPStateSync is the state recorded by the PTP probe when the clocks are in sync.
+	PTPStateSync = "Sync"
+	// PTPStateUnsync is the state recorded by the PTP probe when the clocks are not in sync.
+	PTPStateUnsync = "Unsync"
+
+	tunedNamespace = "openshift-cluster-node-tuning-operator"
+	tunedContainer = "tuned"
+)
+
+// tunedAppliedRegex matches the log line printed by tuned each time it applies a profile.
+var tunedAppliedRegex = regexp.MustCompile(`static tuning from profile .* applied`)
+
+// PTPProbe returns a probe that records whether the PTP clocks are in sync, checking the logs from the last
+// logInterval. The probe error, if any, is kept as the detail of the observation so an unsync state is still recorded.
+func PTPProbe(apiClient *clients.Settings, logInterval time.Duration) stability.Probe {
+	return stability.NewProbe("ptp", func(context.Context) ([]stability.Observation, error) {
+		ptpOnSync, err := ptp.ValidatePTPStatus(apiClient, logInterval)
+
+		observation := stability.Observation{Key: PTPKey, Kind: stability.KindState, State: PTPStateUnsync}
+		if ptpOnSync {
+			observation.State = PTPStateSync
+		}
+
+		if err != nil {
+			observation.Detail = err.Error()
+		}
+
+		return []stability.Observation{observation}, nil
+	})
+}
+
+// PodRestartsProbe returns a probe that records the total container restarts of each pod in namespace, keyed by pod
+// name.
+func PodRestartsProbe(apiClient *clients.Settings, namespace string) stability.Probe {
+	probeName := fmt.Sprintf("pod-restarts-%s", namespace)
+
+	return stability.NewProbe(probeName, func(context.Context) ([]stability.Observation, error) {
+		podList, err := pod.List(apiClient, namespace, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+		}
+
+		observations := make([]stability.Observation, 0, len(podList))
+
+		for _, namespacePod := range podList {
+			var restarts int64
+
+			for _, containerStatus := range namespacePod.Object.Status.ContainerStatuses {
+				restarts += int64(containerStatus.RestartCount)
+			}
+
+			observations = append(observations, stability.Observation{
+				Key: namespacePod.Object.Name, Kind: stability.KindCounter, Count: restarts})
+		}
+
+		return observations, nil
+	})
+}
+
+// PolicyStatusProbe returns a probe that records the compliance state of each policy in the clusterName namespace,
+// keyed by policy name.
+func PolicyStatusProbe(apiClient *clients.Settings, clusterName string) stability.Probe {
+	return stability.NewProbe("policies", func(context.Context) ([]stability.Observation, error) {
+		policies, err := ocm.ListPoliciesInAllNamespaces(apiClient, runtimeclient.ListOptions{Namespace: clusterName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get policies in %q NS: %w", clusterName, err)
+		}
+
+		observations := make([]stability.Observation, 0, len(policies))
+
+		for _, policy := range policies {
+			observations = append(observations, stability.Observation{
+				Key:   policy.Definition.Name,
+				Kind:  stability.KindState,
+				State: string(policy.Object.Status.ComplianceState),
+			})
+		}
+
+		return observations, nil
+	})
+}
+
+// TunedRestartsProbe returns a probe that records the number of times each tuned pod has applied its profile, keyed
+// by pod name.
+func TunedRestartsProbe(apiClient *clients.Settings) stability.Probe {
+	return stability.NewProbe("tuned-restarts", func(context.Context) ([]stability.Observation, error) {
+		tunedPods, err := pod.ListByNamePattern(apiClient, "tuned", tunedNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tuned pods: %w", err)
+		}
+
+		observations := make([]stability.Observation, 0, len(tunedPods))
+
+		for _, tunedPod := range tunedPods {
+			tunedLog, err := tunedPod.GetFullLog(tunedContainer)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get log of tuned pod %s: %w", tunedPod.Object.Name, err)
+			}
+
+			observations = append(observations, stability.Observation{
+				Key:   tunedPod.Object.Name,
+				Kind:  stability.KindCounter,
+				Count: int64(len(tunedAppliedRegex.FindAllString(tunedLog, -1))),
+			})
+		}
+
+		return observations, nil
+	})
+}
@@ -0,0 +1,193 @@
+package iperf3workload
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ipsec/internal/ipsectunnel"
+)
+
+// TunnelDirection is the direction of traffic through the IPSec tunnel as seen from the cluster node.
+type TunnelDirection string
+
+const (
+	// TunnelEgress is traffic sent from the cluster node through the tunnel, counted in OutBytes.
+	TunnelEgress TunnelDirection = "egress"
+	// TunnelIngress is traffic received by the cluster node through the tunnel, counted in InBytes.
+	TunnelIngress TunnelDirection = "ingress"
+
+	// DefaultMinTunnelRatio is the minimum ratio of tunnel bytes to iperf3 bytes used when no ratio is provided. It is
+	// slightly below 1 to allow for the counters being sampled while the test is still starting or finishing.
+	DefaultMinTunnelRatio = 0.95
+)
+
+// assertOptions is a struct that holds the thresholds for the AssertResult function. It is unexported since the
+// AssertOption functions should be used to configure it. Zero values mean the threshold is not checked.
+type assertOptions struct {
+	minBitsPerSecond float64
+	minBytes         int64
+	maxRetransmits   int64
+	maxLostPercent   float64
+	maxJitterMs      float64
+	checkRetransmits bool
+	checkLoss        bool
+	checkJitter      bool
+}
+
+// AssertOption is a function that configures a threshold for the AssertResult function.
+type AssertOption func(*assertOptions)
+
+// noopAssertOption is an AssertOption that does nothing. It is used when the value provided to a function returning
+// an AssertOption is invalid.
+func noopAssertOption(*assertOptions) {}
+
+// AssertWithMinBitsPerSecond requires the received throughput to be at least minBitsPerSecond. If the value is less
+// than or equal to zero, it does nothing.
+func AssertWithMinBitsPerSecond(minBitsPerSecond float64) AssertOption {
+	if minBitsPerSecond <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.minBitsPerSecond = minBitsPerSecond
+	}
+}
+
+// AssertWithMinBytes requires at least minBytes to have been transferred. If the value is less than or equal to zero,
+// it does nothing.
+func AssertWithMinBytes(minBytes int64) AssertOption {
+	if minBytes <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.minBytes = minBytes
+	}
+}
+
+// AssertWithMaxRetransmits requires the TCP sender to have retransmitted at most maxRetransmits segments. The check
+// is skipped for results without sender statistics. If the value is negative, it does nothing.
+func AssertWithMaxRetransmits(maxRetransmits int64) AssertOption {
+	if maxRetransmits < 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.maxRetransmits = maxRetransmits
+		options.checkRetransmits = true
+	}
+}
+
+// AssertWithMaxLostPercent requires at most maxLostPercent of UDP packets to have been lost. The check is skipped for
+// TCP results. If the value is negative, it does nothing.
+func AssertWithMaxLostPercent(maxLostPercent float64) AssertOption {
+	if maxLostPercent < 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.maxLostPercent = maxLostPercent
+		options.checkLoss = true
+	}
+}
+
+// AssertWithMaxJitterMs requires the UDP jitter to be at most maxJitterMs milliseconds. The check is skipped for TCP
+// results. If the value is negative, it does nothing.
+func AssertWithMaxJitterMs(maxJitterMs float64) AssertOption {
+	if maxJitterMs < 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.maxJitterMs = maxJitterMs
+		options.checkJitter = true
+	}
+}
+
+// AssertResult checks result against every threshold provided in options, returning an error describing each
+// threshold that was not met. With no options, it only checks that some data was transferred.
+func AssertResult(result *Result, options ...AssertOption) error {
+	if result == nil {
+		return fmt.Errorf("cannot assert nil iperf3 result")
+	}
+
+	opts := &assertOptions{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	var errs []error
+
+	bytes := result.TransferredBytes()
+	if bytes <= 0 || bytes < opts.minBytes {
+		errs = append(errs, fmt.Errorf("iperf3 transferred %d bytes, expected at least %d", bytes, max(opts.minBytes, 1)))
+	}
+
+	bitsPerSecond := result.Received.BitsPerSecond
+	if bitsPerSecond == 0 {
+		bitsPerSecond = result.Sent.BitsPerSecond
+	}
+
+	if bitsPerSecond < opts.minBitsPerSecond {
+		errs = append(errs, fmt.Errorf("iperf3 throughput %.0f bits/sec is below minimum %.0f bits/sec",
+			bitsPerSecond, opts.minBitsPerSecond))
+	}
+
+	if opts.checkRetransmits && result.Protocol == ProtocolTCP && result.Sent.Retransmits > opts.maxRetransmits {
+		errs = append(errs, fmt.Errorf("iperf3 sender retransmitted %d segments, expected at most %d",
+			result.Sent.Retransmits, opts.maxRetransmits))
+	}
+
+	if opts.checkLoss && result.Protocol == ProtocolUDP && result.Received.LostPercent > opts.maxLostPercent {
+		errs = append(errs, fmt.Errorf("iperf3 lost %.2f%% of packets, expected at most %.2f%%",
+			result.Received.LostPercent, opts.maxLostPercent))
+	}
+
+	if opts.checkJitter && result.Protocol == ProtocolUDP && result.Received.JitterMs > opts.maxJitterMs {
+		errs = append(errs, fmt.Errorf("iperf3 jitter %.3f ms is above maximum %.3f ms",
+			result.Received.JitterMs, opts.maxJitterMs))
+	}
+
+	return errors.Join(errs...)
+}
+
+// AssertTrafficInTunnel checks that the IPSec tunnel counters increased in direction by at least minRatio times the
+// bytes transferred by iperf3, proving that the iperf3 traffic went through the tunnel. If minRatio is less than or
+// equal to zero, DefaultMinTunnelRatio is used.
+func AssertTrafficInTunnel(
+	result *Result,
+	before, after *ipsectunnel.IpsecTunnelPackets,
+	direction TunnelDirection,
+	minRatio float64) error {
+	if result == nil {
+		return fmt.Errorf("cannot correlate nil iperf3 result with tunnel counters")
+	}
+
+	if before == nil || after == nil {
+		return fmt.Errorf("cannot correlate iperf3 result with missing tunnel counters")
+	}
+
+	if minRatio <= 0 {
+		minRatio = DefaultMinTunnelRatio
+	}
+
+	var tunnelBytes int
+
+	switch direction {
+	case TunnelEgress:
+		tunnelBytes = after.OutBytes - before.OutBytes
+	case TunnelIngress:
+		tunnelBytes = after.InBytes - before.InBytes
+	default:
+		return fmt.Errorf("unknown tunnel direction %q", direction)
+	}
+
+	expectedBytes := float64(result.TransferredBytes()) * minRatio
+	if float64(tunnelBytes) < expectedBytes {
+		return fmt.Errorf("ipsec tunnel %s bytes increased by %d, expected at least %.0f for %d iperf3 bytes",
+			direction, tunnelBytes, expectedBytes, result.TransferredBytes())
+	}
+
+	return nil
+}
@@ -1,7 +1,7 @@
 package iperf3workload
 
 import (
-	"bytes"
+	"fmt"
 	"slices"
 	"strings"
 	"time"
@@ -212,16 +212,13 @@ func DeleteWorkload(apiClient *clients.Settings, deploymentName string, workload
 	return nil
 }
 
-// LaunchIperf3Command launches the iperf3 command in an already running workload
-// Return nil on success, otherwise return an error.
+// LaunchIperf3Command launches the iperf3 command in an already running workload and parses its JSON output, so the
+// command must include -J. It returns the parsed result, or an error if the command failed or its output could not be
+// parsed.
 func LaunchIperf3Command(apiClient *clients.Settings,
 	deploymentName string,
 	iperf3Command []string,
-	containerLabels string) bool {
-	// deployName       =>  deploymentName
-	// deployNS         =>  ipsecparams.TestNamespaceName
-	// deployLabel      =>  containerLabels
-	// containerName    =>  deploymentName
+	containerLabels string) (*Result, error) {
 	klog.V(ipsecparams.IpsecLogLevel).Infof("Check deployment %q exists in %q namespace",
 		deploymentName, ipsecparams.TestNamespaceName)
 
@@ -235,7 +232,6 @@ func LaunchIperf3Command(apiClient *clients.Settings,
 	var (
 		appPods []*pod.Builder
 		err     error
-		output  bytes.Buffer
 	)
 
 	klog.V(ipsecparams.IpsecLogLevel).Infof("Finding pod backed by deployment")
@@ -265,28 +261,37 @@ func LaunchIperf3Command(apiClient *clients.Settings,
 	}
 
 	if !pollSuccess {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Failed to find pods matching label %q",
-			containerLabels)
+		return nil, fmt.Errorf("failed to find pods matching label %q: %w", containerLabels, err)
+	}
 
-		return false
+	if len(appPods) == 0 {
+		return nil, fmt.Errorf("no pods matching label %q found in %q namespace",
+			containerLabels, ipsecparams.TestNamespaceName)
 	}
 
-	for _, _pod := range appPods {
-		cmdIperf3 := append(slices.Clone(ipsecparams.ContainerCmdBash), strings.Join(iperf3Command, " "))
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Running command %q from within a pod %q with labels %v",
-			cmdIperf3, _pod.Definition.Name, _pod.Definition.ObjectMeta.Labels)
+	appPod := appPods[0]
+	cmdIperf3 := append(slices.Clone(ipsecparams.ContainerCmdBash), strings.Join(iperf3Command, " "))
 
-		output, err = _pod.ExecCommand(cmdIperf3, deploymentName)
-		if err != nil {
-			klog.V(ipsecparams.IpsecLogLevel).Infof(
-				"Error running iperf3 lookup from within pod, output: [%s], err [%s]",
-				output.String(), err)
+	klog.V(ipsecparams.IpsecLogLevel).Infof("Running command %q from within a pod %q with labels %v",
+		cmdIperf3, appPod.Definition.Name, appPod.Definition.ObjectMeta.Labels)
+
+	output, execErr := appPod.ExecCommand(cmdIperf3, deploymentName)
+
+	klog.V(ipsecparams.IpsecLogLevel).Infof("Command's Output:\n%v\n", output.String())
 
-			return false
+	// iperf3 reports its own errors in the JSON output, so parse it first to return the most useful error.
+	result, err := ParseResult(output.String())
+	if err != nil {
+		if execErr != nil {
+			return nil, fmt.Errorf("failed to run iperf3 in pod %q: %w: %w", appPod.Definition.Name, execErr, err)
 		}
 
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Command's Output:\n%v\n", output.String())
+		return nil, err
+	}
+
+	if execErr != nil {
+		return nil, fmt.Errorf("failed to run iperf3 in pod %q: %w", appPod.Definition.Name, execErr)
 	}
 
-	return true
+	return result, nil
 }
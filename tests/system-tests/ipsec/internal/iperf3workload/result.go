@@ -0,0 +1,119 @@
+package iperf3workload
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProtocolTCP is the protocol reported by iperf3 for TCP tests.
+	ProtocolTCP = "TCP"
+	// ProtocolUDP is the protocol reported by iperf3 for UDP tests.
+	ProtocolUDP = "UDP"
+)
+
+// Measurement is the throughput of an iperf3 test over a period, either a single interval or the whole test. The UDP
+// fields are only set for UDP tests and the retransmits are only set for the sender of TCP tests.
+type Measurement struct {
+	Start         float64 `json:"start"`
+	End           float64 `json:"end"`
+	Seconds       float64 `json:"seconds"`
+	Bytes         int64   `json:"bytes"`
+	BitsPerSecond float64 `json:"bits_per_second"`
+	Retransmits   int64   `json:"retransmits"`
+	JitterMs      float64 `json:"jitter_ms"`
+	LostPackets   int64   `json:"lost_packets"`
+	Packets       int64   `json:"packets"`
+	LostPercent   float64 `json:"lost_percent"`
+}
+
+// Result is the parsed JSON output of an iperf3 client or server.
+type Result struct {
+	Protocol  string
+	Intervals []Measurement
+	// Sent is the summary for the sending side of the test.
+	Sent Measurement
+	// Received is the summary for the receiving side of the test. For UDP tests it includes the jitter and loss.
+	Received Measurement
+}
+
+// TransferredBytes returns the number of bytes received, falling back to the number of bytes sent when the receiver
+// summary is not available.
+func (result *Result) TransferredBytes() int64 {
+	if result.Received.Bytes > 0 {
+		return result.Received.Bytes
+	}
+
+	return result.Sent.Bytes
+}
+
+// iperf3Output is the subset of the iperf3 JSON output that is parsed.
+type iperf3Output struct {
+	Start struct {
+		TestStart struct {
+			Protocol string `json:"protocol"`
+		} `json:"test_start"`
+	} `json:"start"`
+	Intervals []struct {
+		Sum Measurement `json:"sum"`
+	} `json:"intervals"`
+	End struct {
+		Sum         *Measurement `json:"sum"`
+		SumSent     *Measurement `json:"sum_sent"`
+		SumReceived *Measurement `json:"sum_received"`
+	} `json:"end"`
+	Error string `json:"error"`
+}
+
+// ParseResult parses the JSON output of iperf3 run with -J. Any text before the JSON object, such as messages from
+// the shell or ssh, is ignored. It returns an error if the output cannot be parsed or iperf3 reported an error.
+func ParseResult(output string) (*Result, error) {
+	start := strings.Index(output, "{")
+	if start < 0 {
+		return nil, fmt.Errorf("failed to find iperf3 JSON output in: %q", output)
+	}
+
+	var parsed iperf3Output
+
+	err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode iperf3 JSON output: %w", err)
+	}
+
+	if parsed.Error != "" {
+		return nil, fmt.Errorf("iperf3 reported an error: %s", parsed.Error)
+	}
+
+	result := &Result{Protocol: strings.ToUpper(parsed.Start.TestStart.Protocol)}
+
+	for _, interval := range parsed.Intervals {
+		result.Intervals = append(result.Intervals, interval.Sum)
+	}
+
+	switch {
+	case parsed.End.SumSent != nil || parsed.End.SumReceived != nil:
+		if parsed.End.SumSent != nil {
+			result.Sent = *parsed.End.SumSent
+		}
+
+		if parsed.End.SumReceived != nil {
+			result.Received = *parsed.End.SumReceived
+		}
+
+		// Older iperf3 versions only report the jitter and loss for UDP tests in sum.
+		if parsed.End.Sum != nil && result.Received.Packets == 0 {
+			result.Received.JitterMs = parsed.End.Sum.JitterMs
+			result.Received.LostPackets = parsed.End.Sum.LostPackets
+			result.Received.Packets = parsed.End.Sum.Packets
+			result.Received.LostPercent = parsed.End.Sum.LostPercent
+		}
+	case parsed.End.Sum != nil:
+		result.Sent = *parsed.End.Sum
+		result.Received = *parsed.End.Sum
+	default:
+		return nil, fmt.Errorf("iperf3 JSON output has no end summary")
+	}
+
+	return result, nil
+}
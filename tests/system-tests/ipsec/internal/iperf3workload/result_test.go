@@ -0,0 +1,238 @@
+package iperf3workload
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ipsec/internal/ipsectunnel"
+	"github.com/stretchr/testify/assert"
+)
+
+// tcpClientOutput is trimmed output from iperf3 -J -c 172.16.123.10 -p 30000 -n 500M.
+const tcpClientOutput = `{
+	"start": {
+		"connected": [{"socket": 5, "local_host": "10.128.0.42", "local_port": 41094,
+			"remote_host": "172.16.123.10", "remote_port": 30000}],
+		"version": "iperf 3.9",
+		"test_start": {"protocol": "TCP", "num_streams": 1, "blksize": 131072, "omit": 0, "duration": 0,
+			"bytes": 524288000, "blocks": 0, "reverse": 0, "tos": 0}
+	},
+	"intervals": [
+		{"streams": [], "sum": {"start": 0, "end": 1.000142, "seconds": 1.000142, "bytes": 262144000,
+			"bits_per_second": 2096854021.5, "retransmits": 12, "omitted": false, "sender": true}},
+		{"streams": [], "sum": {"start": 1.000142, "end": 2.000118, "seconds": 0.999976, "bytes": 262144000,
+			"bits_per_second": 2097202316.4, "retransmits": 3, "omitted": false, "sender": true}}
+	],
+	"end": {
+		"streams": [],
+		"sum_sent": {"start": 0, "end": 2.000118, "seconds": 2.000118, "bytes": 524288000,
+			"bits_per_second": 2097028052.6, "retransmits": 15, "sender": true},
+		"sum_received": {"start": 0, "end": 2.041382, "seconds": 2.041382, "bytes": 523894784,
+			"bits_per_second": 2053084839.8, "sender": true},
+		"cpu_utilization_percent": {"host_total": 21.5, "remote_total": 35.1}
+	}
+}`
+
+// udpClientOutput is trimmed output from iperf3 -J -u -c 172.16.123.10 -b 100M -t 2 on an older iperf3 that only
+// reports sum at the end.
+const udpClientOutput = `{
+	"start": {"test_start": {"protocol": "UDP", "num_streams": 1, "blksize": 1448, "duration": 2}},
+	"intervals": [
+		{"streams": [], "sum": {"start": 0, "end": 1.000065, "seconds": 1.000065, "bytes": 12502328,
+			"bits_per_second": 100012123.9, "packets": 8634, "omitted": false, "sender": true}}
+	],
+	"end": {
+		"streams": [],
+		"sum": {"start": 0, "end": 2.000042, "seconds": 2.000042, "bytes": 25004656, "bits_per_second": 100016043.2,
+			"jitter_ms": 0.0312, "lost_packets": 35, "packets": 17268, "lost_percent": 0.2027, "sender": true}
+	}
+}`
+
+// errorOutput is the output of iperf3 -J -c when the server is not reachable.
+const errorOutput = `{
+	"start": {"connected": [], "version": "iperf 3.9", "system_info": "Linux iperf3-egress-0"},
+	"intervals": [],
+	"end": {},
+	"error": "unable to connect to server: Connection refused"
+}`
+
+func TestParseResult(t *testing.T) {
+	testCases := []struct {
+		name              string
+		output            string
+		expectedError     bool
+		expectedProtocol  string
+		expectedIntervals int
+		expectedSent      int64
+		expectedReceived  int64
+		expectedLost      float64
+	}{
+		{
+			name:              "tcp client",
+			output:            tcpClientOutput,
+			expectedProtocol:  ProtocolTCP,
+			expectedIntervals: 2,
+			expectedSent:      524288000,
+			expectedReceived:  523894784,
+		},
+		{
+			name:              "udp client with sum only",
+			output:            udpClientOutput,
+			expectedProtocol:  ProtocolUDP,
+			expectedIntervals: 1,
+			expectedSent:      25004656,
+			expectedReceived:  25004656,
+			expectedLost:      0.2027,
+		},
+		{
+			name:              "leading ssh output",
+			output:            "Warning: Permanently added '10.1.28.190' to the list of known hosts.\r\n" + tcpClientOutput,
+			expectedProtocol:  ProtocolTCP,
+			expectedIntervals: 2,
+			expectedSent:      524288000,
+			expectedReceived:  523894784,
+		},
+		{
+			name:          "iperf3 error",
+			output:        errorOutput,
+			expectedError: true,
+		},
+		{
+			name:          "not json",
+			output:        "iperf3: error - unable to connect to server",
+			expectedError: true,
+		},
+		{
+			name:          "truncated json",
+			output:        tcpClientOutput[:200],
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := ParseResult(testCase.output)
+			assert.Equal(t, testCase.expectedError, err != nil)
+
+			if testCase.expectedError {
+				return
+			}
+
+			assert.Equal(t, testCase.expectedProtocol, result.Protocol)
+			assert.Len(t, result.Intervals, testCase.expectedIntervals)
+			assert.Equal(t, testCase.expectedSent, result.Sent.Bytes)
+			assert.Equal(t, testCase.expectedReceived, result.Received.Bytes)
+			assert.Equal(t, testCase.expectedLost, result.Received.LostPercent)
+		})
+	}
+}
+
+func TestAssertResult(t *testing.T) {
+	tcpResult, err := ParseResult(tcpClientOutput)
+	assert.Nil(t, err)
+
+	udpResult, err := ParseResult(udpClientOutput)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name          string
+		result        *Result
+		options       []AssertOption
+		expectedError bool
+	}{
+		{name: "no thresholds", result: tcpResult},
+		{name: "nil result", expectedError: true},
+		{name: "empty result", result: &Result{Protocol: ProtocolTCP}, expectedError: true},
+		{
+			name:    "tcp within thresholds",
+			result:  tcpResult,
+			options: []AssertOption{AssertWithMinBitsPerSecond(1e9), AssertWithMaxRetransmits(20)},
+		},
+		{
+			name:          "tcp below throughput",
+			result:        tcpResult,
+			options:       []AssertOption{AssertWithMinBitsPerSecond(10e9)},
+			expectedError: true,
+		},
+		{
+			name:          "tcp too many retransmits",
+			result:        tcpResult,
+			options:       []AssertOption{AssertWithMaxRetransmits(10)},
+			expectedError: true,
+		},
+		{
+			name:    "loss ignored for tcp",
+			result:  tcpResult,
+			options: []AssertOption{AssertWithMaxLostPercent(0)},
+		},
+		{
+			name:          "udp too much loss",
+			result:        udpResult,
+			options:       []AssertOption{AssertWithMaxLostPercent(0.1)},
+			expectedError: true,
+		},
+		{
+			name:    "udp within thresholds",
+			result:  udpResult,
+			options: []AssertOption{AssertWithMaxLostPercent(1), AssertWithMaxJitterMs(1), AssertWithMinBytes(1e6)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := AssertResult(testCase.result, testCase.options...)
+			assert.Equal(t, testCase.expectedError, err != nil)
+		})
+	}
+}
+
+func TestAssertTrafficInTunnel(t *testing.T) {
+	result := &Result{Received: Measurement{Bytes: 1000}}
+
+	testCases := []struct {
+		name          string
+		before        *ipsectunnel.IpsecTunnelPackets
+		after         *ipsectunnel.IpsecTunnelPackets
+		direction     TunnelDirection
+		expectedError bool
+	}{
+		{
+			name:      "egress through tunnel",
+			before:    &ipsectunnel.IpsecTunnelPackets{OutBytes: 500},
+			after:     &ipsectunnel.IpsecTunnelPackets{OutBytes: 1600},
+			direction: TunnelEgress,
+		},
+		{
+			name:          "egress bypassed tunnel",
+			before:        &ipsectunnel.IpsecTunnelPackets{OutBytes: 500},
+			after:         &ipsectunnel.IpsecTunnelPackets{OutBytes: 600, InBytes: 5000},
+			direction:     TunnelEgress,
+			expectedError: true,
+		},
+		{
+			name:      "ingress through tunnel",
+			before:    &ipsectunnel.IpsecTunnelPackets{},
+			after:     &ipsectunnel.IpsecTunnelPackets{InBytes: 1000},
+			direction: TunnelIngress,
+		},
+		{
+			name:          "missing counters",
+			after:         &ipsectunnel.IpsecTunnelPackets{InBytes: 1000},
+			direction:     TunnelIngress,
+			expectedError: true,
+		},
+		{
+			name:          "unknown direction",
+			before:        &ipsectunnel.IpsecTunnelPackets{},
+			after:         &ipsectunnel.IpsecTunnelPackets{},
+			direction:     "sideways",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := AssertTrafficInTunnel(result, testCase.before, testCase.after, testCase.direction, 0)
+			assert.Equal(t, testCase.expectedError, err != nil)
+		})
+	}
+}
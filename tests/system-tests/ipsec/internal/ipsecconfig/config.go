@@ -2,13 +2,11 @@ package ipsecconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -32,11 +30,13 @@ type IpsecConfig struct {
 	SecGwServerIP       string `yaml:"secgw_server_ip" envconfig:"ECO_IPSEC_SECGW_SERVER_IP"`
 	Iperf3ServerOcpIPs  string `yaml:"iperf3_server_ocp_ips" envconfig:"ECO_IPSEC_IPERF3_SERVER_OCP_IPS"`
 	Iperf3ClientTxBytes string `yaml:"iperf3_client_tx_bytes" envconfig:"ECO_IPSEC_IPERF3_CLIENT_TX_BYTES"`
-	NodePort            string `yaml:"node_port" envconfig:"ECO_IPSEC_NODE_PORT"`
-	NodePortIncrement   string `yaml:"node_port_increment" envconfig:"ECO_IPSEC_NODE_PORT_INCREMENT"`
-	SSHUser             string `yaml:"ssh_user" envconfig:"ECO_SSH_USER"`
-	SSHPrivateKey       string `yaml:"ssh_private_key" envconfig:"ECO_SSH_PRIVATE_KEY"`
-	SSHPort             string `yaml:"ssh_port" envconfig:"ECO_SSH_PORT"`
+	// Iperf3MinBitsPerSecond is the minimum iperf3 throughput through the tunnel, zero disables the check
+	Iperf3MinBitsPerSecond float64 `yaml:"iperf3_min_bits_per_second" envconfig:"ECO_IPSEC_IPERF3_MIN_BITS_PER_SECOND"`
+	NodePort               string  `yaml:"node_port" envconfig:"ECO_IPSEC_NODE_PORT"`
+	NodePortIncrement      string  `yaml:"node_port_increment" envconfig:"ECO_IPSEC_NODE_PORT_INCREMENT"`
+	SSHUser                string  `yaml:"ssh_user" envconfig:"ECO_SSH_USER"`
+	SSHPrivateKey          string  `yaml:"ssh_private_key" envconfig:"ECO_SSH_PRIVATE_KEY"`
+	SSHPort                string  `yaml:"ssh_port" envconfig:"ECO_SSH_PORT"`
 }
 
 // NewIpsecConfig returns instance of IpsecConfig config type.
@@ -51,48 +51,12 @@ func NewIpsecConfig() *IpsecConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultIpsecParamsFile)
 
-	err := readFile(&ipsecConf, confFile)
+	err := config.Loader{Section: "ipsec", DefaultFile: confFile, EnvPrefix: "ECO_IPSEC_"}.Load(&ipsecConf)
 	if err != nil {
-		log.Printf("Error reading config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&ipsecConf)
-	if err != nil {
-		log.Print("Error reading environment variables")
+		log.Printf("Error to load IpsecConfig: %v", err)
 
 		return nil
 	}
 
 	return &ipsecConf
 }
-
-func readFile(ipsecConfig *IpsecConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&ipsecConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(ipsecConfig *IpsecConfig) error {
-	err := envconfig.Process("", ipsecConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -2,8 +2,6 @@ package ipsectunnel
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ipsec/internal/ipsecparams"
@@ -19,111 +17,69 @@ type IpsecTunnelPackets struct {
 // TunnelConnected Check if the IPSec tunnel is connected.
 // Return nil if its connected, otherwise return an error.
 func TunnelConnected(nodeName string) error {
-	// The ipsec show command should list 1 line per connection
-	klog.V(ipsecparams.IpsecLogLevel).Infof("Checking IPSec tunnel connection status. Exec cmd: %v",
-		ipsecparams.IpsecCmdShow)
-
-	ipsecShowStr, err := remote.ExecuteOnNodeWithDebugPod(ipsecparams.IpsecCmdShow, nodeName)
+	policies, err := TunnelPolicies(nodeName)
 	if err != nil {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("error could not execute command: %s", err)
-
 		return err
 	}
 
-	// Example output string:
-	//   (output will be empty if there are no tunnels connected)
-	// [core@sno ~]$ sudo ipsec show
-	// 10.1.232.10/32 <=> 172.16.123.0/24 using reqid 16389
-
-	if len(ipsecShowStr) < 1 {
-		return fmt.Errorf("error: IPSec tunnel is not up")
+	if len(policies) < 1 {
+		return fmt.Errorf("error: IPSec tunnel is not up on node %s", nodeName)
 	}
 
-	klog.V(ipsecparams.IpsecLogLevel).Infof("IPSec tunnel is connected: %s",
-		ipsecShowStr)
+	klog.V(ipsecparams.IpsecLogLevel).Infof("IPSec tunnel is connected on node %s with %d policies: %v",
+		nodeName, len(policies), policies)
 
 	return nil
 }
 
-// TunnelPackets Return the tunnel ingress and egress packets.
-func TunnelPackets(nodeName string) *IpsecTunnelPackets {
-	klog.V(ipsecparams.IpsecLogLevel).Infof("Checking IPSec tunnel traffic status. Exec cmd: %v",
-		ipsecparams.IpsecCmdTrafficStatus)
+// TunnelPolicies Return the IPSec policies on the node as reported by ipsec show.
+func TunnelPolicies(nodeName string) ([]Policy, error) {
+	klog.V(ipsecparams.IpsecLogLevel).Infof("Checking IPSec tunnel connection status. Exec cmd: %v",
+		ipsecparams.IpsecCmdShow)
 
-	ipsecOutput, err := remote.ExecuteOnNodeWithDebugPod(ipsecparams.IpsecCmdTrafficStatus, nodeName)
+	ipsecShowStr, err := remote.ExecuteOnNodeWithDebugPod(ipsecparams.IpsecCmdShow, nodeName)
 	if err != nil {
 		klog.V(ipsecparams.IpsecLogLevel).Infof("error could not execute command: %s", err)
 
-		return nil
+		return nil, err
 	}
 
-	klog.V(ipsecparams.IpsecLogLevel).Infof("IPSec packets: %s", ipsecOutput)
-
-	// Example output string:
-	//   (output will be empty if there are no tunnels connected)
-	// [core@sno ~]$ sudo ipsec trafficstatus
-	// 006 #12: "21939ab9-6546-4652-8eaf-1be04415ac24", type=ESP, add_time=1714739598, \
-	//		inBytes=0, outBytes=0, maxBytes=2^63B, id='CN=north'
-	if len(ipsecOutput) < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error IPSec tunnel is not up for traffic status")
-
-		return nil
-	}
-
-	//
-	// Get the inBytes
-	//
-	commaStr := ","
-	inBytesStr := "inBytes="
-
-	startIndex := strings.Index(ipsecOutput, inBytesStr)
-	if startIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status inBytes: %v", ipsecOutput)
-
-		return nil
-	}
-
-	endIndex := strings.Index(ipsecOutput[startIndex:], commaStr)
-	if endIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status inBytes ending: %v", ipsecOutput)
-
-		return nil
-	}
+	return ParseShow(ipsecShowStr)
+}
 
-	tunnelPackets := &IpsecTunnelPackets{}
+// TunnelConnections Return the traffic status of every IPSec SA on the node as reported by
+// ipsec trafficstatus.
+func TunnelConnections(nodeName string) ([]Connection, error) {
+	klog.V(ipsecparams.IpsecLogLevel).Infof("Checking IPSec tunnel traffic status. Exec cmd: %v",
+		ipsecparams.IpsecCmdTrafficStatus)
 
-	tunnelPackets.InBytes, err = strconv.Atoi(ipsecOutput[startIndex+len(inBytesStr) : startIndex+endIndex])
+	ipsecOutput, err := remote.ExecuteOnNodeWithDebugPod(ipsecparams.IpsecCmdTrafficStatus, nodeName)
 	if err != nil {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status inBytes value: %v", ipsecOutput)
+		klog.V(ipsecparams.IpsecLogLevel).Infof("error could not execute command: %s", err)
 
-		return nil
+		return nil, err
 	}
 
-	//
-	// Get the outBytes
-	//
-	outBytesStr := "outBytes="
-
-	startIndex = strings.Index(ipsecOutput, outBytesStr)
-	if startIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status outBytes: %v", ipsecOutput)
+	klog.V(ipsecparams.IpsecLogLevel).Infof("IPSec traffic status: %s", ipsecOutput)
 
-		return nil
-	}
+	return ParseTrafficStatus(ipsecOutput)
+}
 
-	endIndex = strings.Index(ipsecOutput[startIndex:], commaStr)
-	if endIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status outBytes ending: %v", ipsecOutput)
+// TunnelPackets Return the tunnel ingress and egress bytes summed over every IPSec SA on the node.
+// Return nil if the traffic status cannot be read or there are no SAs.
+func TunnelPackets(nodeName string) *IpsecTunnelPackets {
+	connections, err := TunnelConnections(nodeName)
+	if err != nil {
+		klog.V(ipsecparams.IpsecLogLevel).Infof("Error cannot get IPSec traffic status: %v", err)
 
 		return nil
 	}
 
-	tunnelPackets.OutBytes, err = strconv.Atoi(ipsecOutput[startIndex+len(outBytesStr) : startIndex+endIndex])
-	if err != nil {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status outBytes value: %v", ipsecOutput)
+	if len(connections) < 1 {
+		klog.V(ipsecparams.IpsecLogLevel).Infof("Error IPSec tunnel is not up for traffic status")
 
 		return nil
 	}
 
-	return tunnelPackets
+	return TotalPackets(connections)
 }
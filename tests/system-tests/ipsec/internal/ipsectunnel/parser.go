@@ -0,0 +1,206 @@
+package ipsectunnel
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// trafficStatusRegex matches a line of ipsec trafficstatus or ipsec whack --trafficstatus output. Older libreswan
+	// versions prefix each line with a three digit whack status code. The connection name may be followed by an
+	// instance number and peer address for instantiated connections, such as "name"[1] 10.1.232.10.
+	//
+	//   006 #12: "north", type=ESP, add_time=1714739598, inBytes=0, outBytes=0, maxBytes=2^63B, id='CN=north'
+	//   #3: "north/1x1"[1] 10.1.232.10, type=ESP, add_time=1714739598, inBytes=84, outBytes=84, id='@north'
+	trafficStatusRegex = regexp.MustCompile(`^(?:\d{3}\s+)?#(\d+):\s+"([^"]*)"(\[\d+\])?([^,]*),\s*(.*)$`)
+	// showRegex matches a line of ipsec show output.
+	//
+	//   10.1.232.10/32 <=> 172.16.123.0/24 using reqid 16389
+	showRegex = regexp.MustCompile(`^(\S+)\s+<=>\s+(\S+)\s+using reqid\s+(\d+)`)
+)
+
+// Connection is the traffic status of a single IPSec SA as reported by ipsec trafficstatus.
+type Connection struct {
+	// ID is the state serial number, such as 12 for #12.
+	ID int
+	// Name is the connection name, including the instance number for instantiated connections.
+	Name string
+	// PeerAddress is the address following the name of instantiated connections. It is empty otherwise.
+	PeerAddress string
+	Type        string
+	AddTime     time.Time
+	InBytes     int64
+	OutBytes    int64
+	MaxBytes    string
+	// PeerID is the ID of the remote end, such as CN=north or @north, without the surrounding quotes.
+	PeerID string
+	// Lease is the address leased to the peer, if any.
+	Lease string
+}
+
+// Policy is a single IPSec policy as reported by ipsec show.
+type Policy struct {
+	Local  string
+	Remote string
+	ReqID  int
+}
+
+// ParseTrafficStatus parses the output of ipsec trafficstatus or ipsec whack --trafficstatus into one Connection per
+// SA. Lines that are not SA entries, such as blank lines, are ignored. It returns an error if an SA entry cannot be
+// parsed.
+func ParseTrafficStatus(output string) ([]Connection, error) {
+	var connections []Connection
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		matches := trafficStatusRegex.FindStringSubmatch(line)
+		if matches == nil {
+			if strings.Contains(line, "#") && strings.Contains(line, "inBytes=") {
+				return nil, fmt.Errorf("failed to parse ipsec traffic status line: %q", line)
+			}
+
+			continue
+		}
+
+		connection, err := parseTrafficStatusMatch(matches)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ipsec traffic status line %q: %w", line, err)
+		}
+
+		connections = append(connections, connection)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ipsec traffic status output: %w", err)
+	}
+
+	return connections, nil
+}
+
+// parseTrafficStatusMatch builds a Connection from the submatches of trafficStatusRegex.
+func parseTrafficStatusMatch(matches []string) (Connection, error) {
+	var (
+		connection Connection
+		err        error
+	)
+
+	connection.ID, err = strconv.Atoi(matches[1])
+	if err != nil {
+		return connection, fmt.Errorf("invalid state serial number %q: %w", matches[1], err)
+	}
+
+	connection.Name = matches[2] + matches[3]
+	connection.PeerAddress = strings.TrimSpace(matches[4])
+
+	for key, value := range splitKeyValues(matches[5]) {
+		switch key {
+		case "type":
+			connection.Type = value
+		case "add_time":
+			addTime, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return connection, fmt.Errorf("invalid add_time %q: %w", value, err)
+			}
+
+			connection.AddTime = time.Unix(addTime, 0).UTC()
+		case "inBytes":
+			connection.InBytes, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return connection, fmt.Errorf("invalid inBytes %q: %w", value, err)
+			}
+		case "outBytes":
+			connection.OutBytes, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return connection, fmt.Errorf("invalid outBytes %q: %w", value, err)
+			}
+		case "maxBytes":
+			connection.MaxBytes = value
+		case "id":
+			connection.PeerID = value
+		case "lease":
+			connection.Lease = value
+		}
+	}
+
+	return connection, nil
+}
+
+// splitKeyValues splits a comma separated list of key=value pairs. Values in single quotes may contain commas and
+// have the quotes removed. Entries without an equals sign are ignored.
+func splitKeyValues(fields string) map[string]string {
+	keyValues := make(map[string]string)
+
+	for fields != "" {
+		fields = strings.TrimLeft(fields, ", ")
+
+		key, rest, found := strings.Cut(fields, "=")
+		if !found {
+			break
+		}
+
+		var value string
+
+		if strings.HasPrefix(rest, "'") {
+			end := strings.Index(rest[1:], "'")
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+
+		keyValues[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		fields = rest
+	}
+
+	return keyValues
+}
+
+// ParseShow parses the output of ipsec show into one Policy per line. Lines that are not policies, such as blank
+// lines, are ignored.
+func ParseShow(output string) ([]Policy, error) {
+	var policies []Policy
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+
+	for scanner.Scan() {
+		matches := showRegex.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if matches == nil {
+			continue
+		}
+
+		reqID, err := strconv.Atoi(matches[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid reqid %q in ipsec show output: %w", matches[3], err)
+		}
+
+		policies = append(policies, Policy{Local: matches[1], Remote: matches[2], ReqID: reqID})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ipsec show output: %w", err)
+	}
+
+	return policies, nil
+}
+
+// TotalPackets returns the sum of the byte counters of connections.
+func TotalPackets(connections []Connection) *IpsecTunnelPackets {
+	total := &IpsecTunnelPackets{}
+
+	for _, connection := range connections {
+		total.InBytes += int(connection.InBytes)
+		total.OutBytes += int(connection.OutBytes)
+	}
+
+	return total
+}
@@ -0,0 +1,120 @@
+package ipsectunnel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrafficStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		output        string
+		expected      []Connection
+		expectedError bool
+	}{
+		{
+			name:   "no tunnels",
+			output: "",
+		},
+		{
+			name: "libreswan 4 with status code",
+			output: `006 #12: "21939ab9-6546-4652-8eaf-1be04415ac24", type=ESP, add_time=1714739598, ` +
+				`inBytes=1024, outBytes=2048, maxBytes=2^63B, id='CN=north'` + "\r\n",
+			expected: []Connection{{
+				ID: 12, Name: "21939ab9-6546-4652-8eaf-1be04415ac24", Type: "ESP",
+				AddTime: time.Unix(1714739598, 0).UTC(), InBytes: 1024, OutBytes: 2048, MaxBytes: "2^63B",
+				PeerID: "CN=north",
+			}},
+		},
+		{
+			name: "libreswan 5 multiple tunnels",
+			output: `#3: "north/1x1", type=ESP, add_time=1714739598, inBytes=84, outBytes=168, maxBytes=2^63B, ` +
+				`id='@north'
+#5: "south/1x1", type=ESP, add_time=1714739600, inBytes=0, outBytes=0, maxBytes=2^63B, id='@south'
+`,
+			expected: []Connection{
+				{
+					ID: 3, Name: "north/1x1", Type: "ESP", AddTime: time.Unix(1714739598, 0).UTC(),
+					InBytes: 84, OutBytes: 168, MaxBytes: "2^63B", PeerID: "@north",
+				},
+				{
+					ID: 5, Name: "south/1x1", Type: "ESP", AddTime: time.Unix(1714739600, 0).UTC(),
+					MaxBytes: "2^63B", PeerID: "@south",
+				},
+			},
+		},
+		{
+			name: "whack instantiated connection with lease and comma in id",
+			output: `006 #8: "roadwarrior"[2] 10.1.232.11, type=ESP, add_time=1714739700, inBytes=10, outBytes=20, ` +
+				`maxBytes=2^63B, id='C=US, O=Example, CN=node-1', lease=192.0.2.10/32`,
+			expected: []Connection{{
+				ID: 8, Name: "roadwarrior[2]", PeerAddress: "10.1.232.11", Type: "ESP",
+				AddTime: time.Unix(1714739700, 0).UTC(), InBytes: 10, OutBytes: 20, MaxBytes: "2^63B",
+				PeerID: "C=US, O=Example, CN=node-1", Lease: "192.0.2.10/32",
+			}},
+		},
+		{
+			name: "invalid byte counter",
+			output: `006 #12: "north", type=ESP, add_time=1714739598, inBytes=lots, outBytes=0, maxBytes=2^63B, ` +
+				`id='CN=north'`,
+			expectedError: true,
+		},
+		{
+			name:          "unrecognized entry",
+			output:        `#12 north inBytes=0`,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			connections, err := ParseTrafficStatus(testCase.output)
+			assert.Equal(t, testCase.expectedError, err != nil)
+			assert.Equal(t, testCase.expected, connections)
+		})
+	}
+}
+
+func TestParseShow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		output   string
+		expected []Policy
+	}{
+		{
+			name:   "no tunnels",
+			output: "",
+		},
+		{
+			name:     "single tunnel",
+			output:   "10.1.232.10/32 <=> 172.16.123.0/24 using reqid 16389\r\n",
+			expected: []Policy{{Local: "10.1.232.10/32", Remote: "172.16.123.0/24", ReqID: 16389}},
+		},
+		{
+			name: "multiple tunnels with noise",
+			output: `10.1.232.10/32 <=> 172.16.123.0/24 using reqid 16389
+
+10.1.232.11/32 <=> 172.16.123.0/24 using reqid 16393
+Warning: unrecognized line`,
+			expected: []Policy{
+				{Local: "10.1.232.10/32", Remote: "172.16.123.0/24", ReqID: 16389},
+				{Local: "10.1.232.11/32", Remote: "172.16.123.0/24", ReqID: 16393},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policies, err := ParseShow(testCase.output)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, policies)
+		})
+	}
+}
+
+func TestTotalPackets(t *testing.T) {
+	total := TotalPackets([]Connection{{InBytes: 10, OutBytes: 20}, {InBytes: 1, OutBytes: 2}})
+	assert.Equal(t, &IpsecTunnelPackets{InBytes: 11, OutBytes: 22}, total)
+}
@@ -100,7 +100,9 @@ var _ = Describe(
 
 				// Channels for asynchronous calls below
 				sshChannel := make(chan *sshcommand.SSHCommandResult)
-				iperf3ClientChannel := make(chan bool)
+				iperf3ClientChannel := make(chan error)
+
+				var clientResult *iperf3workload.Result
 
 				// Asynchronously start the iperf3 server on the SecGW via SSH
 				go func(channel chan *sshcommand.SSHCommandResult) {
@@ -129,7 +131,7 @@ var _ = Describe(
 				packetsBefore := ipsectunnel.TunnelPackets(nodeName)
 
 				// Start the iperf3 client Asynchronously
-				go func(channel chan bool) {
+				go func(channel chan error) {
 					// The iperf3 client-mode command
 					iperf3ClientCmd := append(slices.Clone(ipsecparams.Iperf3ClientBaseCmd),
 						IpsecTestConfig.SecGwServerIP,
@@ -139,15 +141,19 @@ var _ = Describe(
 						IpsecTestConfig.Iperf3ClientTxBytes)
 
 					containerLabel := ipsecparams.CreateContainerLabelsStr(index, serviceDeploymentIngressPrefixName)
-					channel <- iperf3workload.LaunchIperf3Command(APIClient,
+
+					var err error
+
+					clientResult, err = iperf3workload.LaunchIperf3Command(APIClient,
 						srvDeplName,
 						iperf3ClientCmd,
 						containerLabel)
+					channel <- err
 				}(iperf3ClientChannel)
 
 				// Get the client via the channel
-				clientOutput := <-iperf3ClientChannel
-				Expect(clientOutput).To(BeTrue(), "Error in iperf3 client execution.")
+				err := <-iperf3ClientChannel
+				Expect(err).ToNot(HaveOccurred(), "Error in iperf3 client execution")
 
 				packetsAfter := ipsectunnel.TunnelPackets(nodeName)
 
@@ -156,12 +162,27 @@ var _ = Describe(
 				Expect(serverOutput.Err == nil).To(BeTrue(), "Error in iperf3 server execution: %v, %v",
 					serverOutput.Err, serverOutput.SSHOutput)
 
+				serverResult, err := iperf3workload.ParseResult(serverOutput.SSHOutput)
+				Expect(err).ToNot(HaveOccurred(), "Error parsing iperf3 server output")
+
+				Expect(packetsBefore != nil).To(BeTrue(), "Error unable to get packetsBefore.")
+				Expect(packetsAfter != nil).To(BeTrue(), "Error unable to get packetsAfter.")
+
 				klog.V(ipsecparams.IpsecLogLevel).Infof("Egress Packets Before in/outBytes %d/%d, After in/outBytes %d/%d",
 					packetsBefore.InBytes, packetsBefore.OutBytes,
 					packetsAfter.InBytes, packetsAfter.OutBytes)
+				klog.V(ipsecparams.IpsecLogLevel).Infof(
+					"Egress iperf3 sent %d bytes at %.0f bits/sec with %d retransmits, server received %d bytes",
+					clientResult.Sent.Bytes, clientResult.Sent.BitsPerSecond, clientResult.Sent.Retransmits,
+					serverResult.TransferredBytes())
+
+				err = iperf3workload.AssertResult(clientResult,
+					iperf3workload.AssertWithMinBitsPerSecond(IpsecTestConfig.Iperf3MinBitsPerSecond))
+				Expect(err).ToNot(HaveOccurred(), "iperf3 client result did not meet thresholds")
 
-				// Need to verify the packet counts better
-				Expect(packetsAfter.OutBytes-packetsBefore.OutBytes).To(BeNumerically(">", 0), "Invalid number of OutBytes")
+				err = iperf3workload.AssertTrafficInTunnel(serverResult, packetsBefore, packetsAfter,
+					iperf3workload.TunnelEgress, iperf3workload.DefaultMinTunnelRatio)
+				Expect(err).ToNot(HaveOccurred(), "iperf3 traffic was not sent via the IPSec tunnel")
 
 				np, _ := strconv.Atoi(nodePortStr)
 				nodePortStr = strconv.Itoa(np + nodePortIncrement)
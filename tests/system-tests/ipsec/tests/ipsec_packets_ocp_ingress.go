@@ -103,21 +103,27 @@ var _ = Describe(
 				srvDeplName := ipsecparams.CreateServiceDeploymentName(index, serviceDeploymentIngressPrefixName)
 
 				// Channels for asynchronous calls below
-				iperf3ServerChannel := make(chan bool)
+				iperf3ServerChannel := make(chan error)
+
+				var serverResult *iperf3workload.Result
 				sshChannel := make(chan *sshcommand.SSHCommandResult)
 
 				// Asynchronously start the iperf3 server on the SNO pod
-				go func(channel chan bool) {
+				go func(channel chan error) {
 					// Start the iperf3 app in server mode
 					iperf3ServerCmd := append(slices.Clone(ipsecparams.Iperf3ServerBaseCmd),
 						ipsecparams.Iperf3OptionPort,
 						nodePortStr)
 
 					containerLabel := ipsecparams.CreateContainerLabelsStr(index, serviceDeploymentIngressPrefixName)
-					channel <- iperf3workload.LaunchIperf3Command(APIClient,
+
+					var err error
+
+					serverResult, err = iperf3workload.LaunchIperf3Command(APIClient,
 						srvDeplName,
 						iperf3ServerCmd,
 						containerLabel)
+					channel <- err
 				}(iperf3ServerChannel)
 
 				// Sleep to let the iperf3 server get started before trying to start
@@ -158,8 +164,11 @@ var _ = Describe(
 				packetsAfter := ipsectunnel.TunnelPackets(nodeName)
 
 				// Get the server results via the channel
-				serverOutput := <-iperf3ServerChannel
-				Expect(serverOutput).To(BeTrue(), "Error in iperf3 server execution.")
+				err := <-iperf3ServerChannel
+				Expect(err).ToNot(HaveOccurred(), "Error in iperf3 server execution")
+
+				clientResult, err := iperf3workload.ParseResult(clientOutput.SSHOutput)
+				Expect(err).ToNot(HaveOccurred(), "Error parsing iperf3 client output")
 
 				Expect(packetsBefore != nil).To(BeTrue(), "Error unable to get packetsBefore.")
 				Expect(packetsAfter != nil).To(BeTrue(), "Error unable to get packetsAfter.")
@@ -168,9 +177,17 @@ var _ = Describe(
 					packetsBefore.InBytes, packetsBefore.OutBytes,
 					packetsAfter.InBytes, packetsAfter.OutBytes)
 
-				// Need to verify the packet counts better
-				Expect(packetsAfter.InBytes-packetsBefore.InBytes).To(BeNumerically(">", 0),
-					"Invalid number of InBytes")
+				klog.V(ipsecparams.IpsecLogLevel).Infof(
+					"Ingress iperf3 received %d bytes at %.0f bits/sec, client retransmitted %d segments",
+					serverResult.Received.Bytes, serverResult.Received.BitsPerSecond, clientResult.Sent.Retransmits)
+
+				err = iperf3workload.AssertResult(serverResult,
+					iperf3workload.AssertWithMinBitsPerSecond(IpsecTestConfig.Iperf3MinBitsPerSecond))
+				Expect(err).ToNot(HaveOccurred(), "iperf3 server result did not meet thresholds")
+
+				err = iperf3workload.AssertTrafficInTunnel(serverResult, packetsBefore, packetsAfter,
+					iperf3workload.TunnelIngress, iperf3workload.DefaultMinTunnelRatio)
+				Expect(err).ToNot(HaveOccurred(), "iperf3 traffic was not received via the IPSec tunnel")
 
 				np, _ := strconv.Atoi(nodePortStr)
 				nodePortStr = strconv.Itoa(np + nodePortIncrement)
@@ -23,6 +23,16 @@ var _ = Describe(
 				klog.V(ipsecparams.IpsecLogLevel).Infof("Check IPSec tunnel connection on node %s", nodeName)
 				err := ipsectunnel.TunnelConnected(nodeName)
 				Expect(err).ToNot(HaveOccurred(), "Error: The IPSec tunnel is not connected.")
+
+				connections, err := ipsectunnel.TunnelConnections(nodeName)
+				Expect(err).ToNot(HaveOccurred(), "Error getting IPSec traffic status on node %s", nodeName)
+				Expect(connections).ToNot(BeEmpty(), "Error: no IPSec SAs established on node %s", nodeName)
+
+				for _, connection := range connections {
+					klog.V(ipsecparams.IpsecLogLevel).Infof("Node %s IPSec SA #%d %q type %s peer %q added at %s",
+						nodeName, connection.ID, connection.Name, connection.Type, connection.PeerID, connection.AddTime)
+					Expect(connection.Type).To(Equal("ESP"), "Unexpected IPSec SA type on node %s", nodeName)
+				}
 			}
 		})
 	},
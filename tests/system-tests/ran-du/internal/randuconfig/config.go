@@ -3,14 +3,12 @@ package randuconfig
 import (
 	"fmt"
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -122,48 +120,12 @@ func NewRanDuConfig() *RanDuConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultRanDuParamsFile)
 
-	err := readFile(&randuConf, confFile)
+	err := config.Loader{Section: "randu", DefaultFile: confFile, EnvPrefix: "ECO_RANDU_"}.Load(&randuConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&randuConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load RanDuConfig: %v", err)
 
 		return nil
 	}
 
 	return &randuConf
 }
-
-func readFile(randuConfig *RanDuConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&randuConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(randuConfig *RanDuConfig) error {
-	err := envconfig.Process("", randuConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
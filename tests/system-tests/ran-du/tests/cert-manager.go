@@ -14,10 +14,9 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ingress"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/rbac"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/secret"
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/serviceaccount"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/prometheus"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/certmanager"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
@@ -65,12 +64,7 @@ var _ = Describe(
 
 			var createErr error
 
-			prometheusAPI, createErr = certmanager.NewPrometheusAPI(
-				APIClient,
-				randuparams.CertManagerPrometheusQuerierSAName,
-				randuparams.CertManagerPrometheusQuerierCRBName,
-				certmanager.OpenshiftMonitoringNamespace,
-			)
+			prometheusAPI, createErr = prometheus.NewClient(APIClient, prometheusClientOptions()...)
 			Expect(createErr).ToNot(HaveOccurred(), "Failed to create Prometheus API client")
 		})
 
@@ -214,29 +208,8 @@ var _ = Describe(
 			// Delete Prometheus querier resources
 			By("Deleting Prometheus querier resources")
 
-			crbBuilder, pullErr := rbac.PullClusterRoleBinding(APIClient,
-				randuparams.CertManagerPrometheusQuerierCRBName)
-			if pullErr != nil && !k8serrors.IsNotFound(pullErr) {
-				klog.V(100).Infof("Failed to pull ClusterRoleBinding %s: %v",
-					randuparams.CertManagerPrometheusQuerierCRBName, pullErr)
-			} else if crbBuilder != nil && crbBuilder.Exists() {
-				if deleteErr := crbBuilder.Delete(); deleteErr != nil {
-					klog.V(100).Infof("Failed to delete ClusterRoleBinding %s: %v",
-						randuparams.CertManagerPrometheusQuerierCRBName, deleteErr)
-				}
-			}
-
-			saBuilder, pullErr := serviceaccount.Pull(APIClient,
-				randuparams.CertManagerPrometheusQuerierSAName,
-				certmanager.OpenshiftMonitoringNamespace)
-			if pullErr != nil && !k8serrors.IsNotFound(pullErr) {
-				klog.V(100).Infof("Failed to pull ServiceAccount %s: %v",
-					randuparams.CertManagerPrometheusQuerierSAName, pullErr)
-			} else if saBuilder != nil && saBuilder.Exists() {
-				if deleteErr := saBuilder.Delete(); deleteErr != nil {
-					klog.V(100).Infof("Failed to delete ServiceAccount %s: %v",
-						randuparams.CertManagerPrometheusQuerierSAName, deleteErr)
-				}
+			if cleanupErr := prometheus.CleanupServiceAccount(APIClient, prometheusClientOptions()...); cleanupErr != nil {
+				klog.V(100).Infof("Failed to delete Prometheus querier resources: %v", cleanupErr)
 			}
 		})
 
@@ -967,3 +940,13 @@ func getIssuerName() string {
 
 	return "acme-issuer"
 }
+
+// prometheusClientOptions returns the options for the shared prometheus client so that creating and cleaning up the
+// querier resources use the same names.
+func prometheusClientOptions() []prometheus.ClientOption {
+	return []prometheus.ClientOption{
+		prometheus.WithNamespace(certmanager.OpenshiftMonitoringNamespace),
+		prometheus.WithServiceAccount(
+			randuparams.CertManagerPrometheusQuerierSAName, randuparams.CertManagerPrometheusQuerierCRBName),
+	}
+}
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/ublox"
 	sysptp "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/ptp"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
@@ -244,24 +245,26 @@ var _ = Describe(
 		It("Case 05: To verify t-gm ptp sync locked back after GNSS signal loss and retrieved",
 			reportxml.ID("99995"), func() {
 				for _, nodeName := range ptpNodes {
-					protocolVersion, err := sysptp.GetUbloxProtocolVersion(APIClient, nodeName)
+					protocolVersion, err := ublox.ProtocolForNode(APIClient, nodeName)
 					if err != nil {
 						Skip("GNSS simulation requires PtpConfig with e810/e825/e830 for node " + nodeName + ": " + err.Error())
 					}
 
+					receiver := ublox.NewReceiver(APIClient, nodeName, protocolVersion)
+
 					By("Verify clock status after GNSS signal loss, then after restore")
 
 					DeferCleanup(func() {
 						By("Restoring GNSS sync")
 
-						if restoreErr := sysptp.SimulateGNSSRecovery(APIClient, nodeName, protocolVersion); restoreErr != nil {
+						if restoreErr := receiver.SimulateSyncRecovery(); restoreErr != nil {
 							klog.Errorf("Failed to restore GNSS on node %s: %v", nodeName, restoreErr)
 						}
 					})
 
 					By("Simulating GNSS signal loss")
 
-					err = sysptp.SimulateGNSSLoss(APIClient, nodeName, protocolVersion)
+					err = receiver.SimulateSyncLoss()
 					Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS loss on node %s", nodeName)
 
 					gnssLossTime := time.Now()
@@ -298,7 +301,7 @@ var _ = Describe(
 
 					By("Restoring GNSS signal")
 
-					err = sysptp.SimulateGNSSRecovery(APIClient, nodeName, protocolVersion)
+					err = receiver.SimulateSyncRecovery()
 					Expect(err).ToNot(HaveOccurred(), "Failed to restore GNSS on node %s", nodeName)
 
 					By("Verifying clock status after GNSS signal is restored (sync locked)")
@@ -1,19 +1,23 @@
 package ran_du_system_test
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/stability"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/await"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/platform"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/shell"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/stability"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/stabilityprobes"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
+	"k8s.io/klog/v2"
 )
 
 var _ = Describe(
@@ -66,96 +70,46 @@ var _ = Describe(
 		})
 		It("StabilityWorkload", reportxml.ID("42744"), Label("StabilityWorkload"), func() {
 			outputDir := RanDuTestConfig.StabilityOutputPath
-			policiesOutputFile := fmt.Sprintf("%s/stability_workload_policies.log", outputDir)
-			ptpOutputFile := fmt.Sprintf("%s/stability_workload_ptp.log", outputDir)
-			tunedRestartsOutputFile := fmt.Sprintf("%s/stability_workload_tuned_restarts.log", outputDir)
 			namespaces := []string{"openshift-etcd", "openshift-apiserver"}
 
 			totalDuration := time.Duration(RanDuTestConfig.StabilityWorkloadDurMins) * time.Minute
 			interval := time.Duration(RanDuTestConfig.StabilityWorkloadIntMins) * time.Minute
-			startTime := time.Now()
 
-			By(fmt.Sprintf("Collecting metrics during %d minutes", RanDuTestConfig.StabilityWorkloadDurMins))
+			probes := []stability.Probe{stabilityprobes.TunedRestartsProbe(APIClient)}
 
-			for time.Since(startTime) < totalDuration {
-				if RanDuTestConfig.PtpEnabled {
-					err := stability.SavePTPStatus(APIClient, ptpOutputFile, interval)
-					if err != nil {
-						fmt.Printf("Error, could not save PTP status")
-					}
-				}
-
-				if RanDuTestConfig.StabilityPoliciesCheck {
-					err := stability.SavePolicyStatus(APIClient, clusterName, policiesOutputFile)
-					if err != nil {
-						fmt.Printf("Error, could not save policies status")
-					}
-				}
-
-				for _, namespace := range namespaces {
-					err := stability.SavePodsRestartsInNamespace(APIClient,
-						namespace, fmt.Sprintf("%s/stability_workload_%s.log", outputDir, namespace))
-					if err != nil {
-						fmt.Printf("Error, could not save pod restarts")
-					}
-				}
-
-				err := stability.SaveTunedRestarts(APIClient, tunedRestartsOutputFile)
-				if err != nil {
-					fmt.Printf("Error, could not save tuned restarts")
-				}
-
-				time.Sleep(interval)
+			if RanDuTestConfig.PtpEnabled {
+				probes = append(probes, stabilityprobes.PTPProbe(APIClient, interval))
 			}
 
-			// Final check of all values
-			By("Check all results")
-
-			var stabilityErrors []string
-
-			// Verify policies
-			By("Check Policy changes")
-
 			if RanDuTestConfig.StabilityPoliciesCheck {
-				_, err := stability.VerifyStabilityStatusChange(policiesOutputFile)
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
+				probes = append(probes, stabilityprobes.PolicyStatusProbe(APIClient, clusterName))
 			}
 
-			// Verify podRestarts
-			By("Check Pod restarts")
-
 			for _, namespace := range namespaces {
-				_, err := stability.VerifyStabilityStatusChange(fmt.Sprintf("%s/stability_workload_%s.log", outputDir, namespace))
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
+				probes = append(probes, stabilityprobes.PodRestartsProbe(APIClient, namespace))
 			}
 
-			// Verify PTP output
-			By("Check PTP results")
+			recorder, err := stability.NewRecorder(
+				filepath.Join(outputDir, "stability_workload.jsonl"), interval, probes...)
+			Expect(err).ToNot(HaveOccurred(), "Failed to create stability recorder")
 
-			if RanDuTestConfig.PtpEnabled {
-				_, err := stability.VerifyStabilityStatusChange(ptpOutputFile)
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
-			}
+			By(fmt.Sprintf("Collecting metrics during %d minutes", RanDuTestConfig.StabilityWorkloadDurMins))
 
-			// Verify tuned restarts
-			By("Check tuneds restarts")
+			summary, err := recorder.Run(context.TODO(), totalDuration)
+			Expect(err).ToNot(HaveOccurred(), "Failed to record stability samples")
 
-			_, err := stability.VerifyStabilityStatusChange(tunedRestartsOutputFile)
-			if err != nil {
-				stabilityErrors = append(stabilityErrors, err.Error())
-			}
+			By("Saving stability summary")
 
-			By("Check if there been any error")
+			err = summary.Save(filepath.Join(outputDir, "stability_workload_summary.json"))
+			Expect(err).ToNot(HaveOccurred(), "Failed to save stability summary")
 
-			if len(stabilityErrors) > 0 {
-				Expect(stabilityErrors).ToNot(HaveOccurred(), "One or more errors in stability tests: %s", stabilityErrors)
+			By("Check all results")
+
+			for probe, probeErrors := range summary.ProbeErrors {
+				klog.V(randuparams.RanDuLogLevel).Infof("Stability probe %s failed %d times", probe, probeErrors)
 			}
+
+			Expect(summary.Err()).ToNot(HaveOccurred(), "One or more errors in stability tests")
 		})
 		AfterAll(func() {
 			By("Cleaning up test workload resources")
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
@@ -16,11 +15,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
-	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/wait"
 
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/sriovoperator"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/apiobjectshelper"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -45,30 +43,12 @@ const (
 	sriovDevicePluginRecoveryDelay   = 2 * time.Minute
 	sriovNetworkNodeStateSyncTimeout = 15 * time.Minute
 	sriovResourcePollInterval        = 5 * time.Second
-	sriovResourcePrefix              = "openshift.io"
 )
 
 var (
 	podLevelBondPodLabelMap = map[string]string{"systemtest-test": "rdscore-pod-level-bond-privileged"}
 )
 
-type podNetworkAnnotation struct {
-	Name      string   `json:"name"`
-	Interface string   `json:"interface"`
-	Ips       []string `json:"ips,omitempty"`
-	Mac       string   `json:"mac"`
-	Default   bool     `json:"default,omitempty"`
-	DNS       struct {
-	} `json:"dns"`
-	DeviceInfo struct {
-		Type    string `json:"type"`
-		Version string `json:"version"`
-		Pci     struct {
-			PciAddress string `json:"pci-address"`
-		} `json:"pci"`
-	} `json:"device-info,omitempty"`
-}
-
 // createPrivilegedPodLevelBondDeployment cleans up any prior deployment, creates RBAC and a privileged
 // pod-level bond deployment.
 //
@@ -1031,9 +1011,7 @@ func getBondActiveInterfaceSrIovNetworkName(podObj *pod.Builder) (string, error)
 			podObj.Definition.Name, podObj.Definition.Namespace, err)
 	}
 
-	var podNetworkStatusType []podNetworkAnnotation
-
-	err = json.Unmarshal([]byte(podNetAnnotation), &podNetworkStatusType)
+	podNetworkStatusType, err := sriovoperator.ParseNetworkStatus(podNetAnnotation)
 	if err != nil {
 		klog.V(100).Infof("Error unmarshalling pod network status annotation %q: %v", podNetAnnotation, err)
 
@@ -1248,57 +1226,28 @@ func verifyConnectivity() {
 func waitForPodLevelBondNodesSriovSync() error {
 	By("Waiting for SRIOVNetworkNodeState to reach Succeeded sync status on pod-level bond nodes")
 
-	sriovNamespace := rdscoreparams.SriovOperatorNamespace
 	nodeNames := []string{
 		RDSCoreConfig.PodLevelBondPodOneScheduleOnHost,
 		RDSCoreConfig.PodLevelBondPodTwoScheduleOnHost,
 	}
 
-	var resyncedNodes []string
-
-	for _, nodeName := range nodeNames {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Checking SRIOVNetworkNodeState sync status on node %q", nodeName)
-
-		sriovNodeState := sriov.NewNetworkNodeStateBuilder(APIClient, nodeName, sriovNamespace)
-		if sriovNodeState == nil {
-			return fmt.Errorf("failed to create SRIOVNetworkNodeState builder for node %q", nodeName)
-		}
-
-		err := sriovNodeState.Discover()
-		if err != nil {
-			return fmt.Errorf("SRIOVNetworkNodeState not found for node %q: %w", nodeName, err)
-		}
-
-		if sriovNodeState.Objects.Status.SyncStatus == rdscoreparams.SriovNetworkNodeStateSucceededStatus {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-				"SRIOVNetworkNodeState on node %q is already Succeeded, no wait needed", nodeName)
-
-			continue
-		}
-
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-			"Waiting up to %v for SRIOVNetworkNodeState sync status '%s' on node %q",
-			sriovNetworkNodeStateSyncTimeout, rdscoreparams.SriovNetworkNodeStateSucceededStatus, nodeName)
-
-		err = sriovNodeState.WaitUntilSyncStatus(
-			rdscoreparams.SriovNetworkNodeStateSucceededStatus, sriovNetworkNodeStateSyncTimeout)
-		if err != nil {
-			return fmt.Errorf("timeout waiting for SRIOVNetworkNodeState sync on node %q: %w", nodeName, err)
-		}
-
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-			"Successfully waited for SRIOVNetworkNodeState sync on node %q", nodeName)
-
-		resyncedNodes = append(resyncedNodes, nodeName)
+	resyncedNodes, err := sriovoperator.WaitForNodeStatesSynced(
+		APIClient, rdscoreparams.SriovOperatorNamespace, nodeNames, sriovNetworkNodeStateSyncTimeout)
+	if err != nil {
+		return err
 	}
 
 	if len(resyncedNodes) > 0 {
+		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
+			"SRIOVNetworkNodeState was resynced on nodes %v, waiting for SR-IOV resources", resyncedNodes)
+
 		resourceNames, err := getSriovResourceNamesForPodLevelBond()
 		if err != nil {
 			return fmt.Errorf("failed to get SR-IOV resource names for pod-level bond: %w", err)
 		}
 
-		return waitForSriovResourcesOnNodes(resyncedNodes, resourceNames)
+		return sriovoperator.WaitForResourcesAllocatable(
+			APIClient, resyncedNodes, resourceNames, sriovResourcePollInterval, sriovDevicePluginRecoveryDelay)
 	}
 
 	return nil
@@ -1338,7 +1287,7 @@ func getSriovResourceNamesForPodLevelBond() ([]string, error) {
 				netName, time.Minute*1, err)
 		}
 
-		resourceName := sriovResourcePrefix + "/" + net.Object.Spec.ResourceName
+		resourceName := sriovoperator.ResourcePrefix + "/" + net.Object.Spec.ResourceName
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
 			"SriovNetwork %q has resource name %q", netName, resourceName)
 
@@ -1348,62 +1297,6 @@ func getSriovResourceNamesForPodLevelBond() ([]string, error) {
 	return resourceNames, nil
 }
 
-// waitForSriovResourcesOnNodes polls each node's status.allocatable until all expected
-// SR-IOV resources show a non-zero count, confirming the device plugin has re-enumerated
-// the VFs after a sync.
-func waitForSriovResourcesOnNodes(nodeNames, resourceNames []string) error {
-	for _, nodeName := range nodeNames {
-		for _, resourceName := range resourceNames {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-				"Waiting for SR-IOV resource %q to become available on node %q", resourceName, nodeName)
-
-			var finalQty resource.Quantity
-
-			err := wait.PollUntilContextTimeout(
-				context.TODO(), sriovResourcePollInterval, sriovDevicePluginRecoveryDelay, true,
-				func(ctx context.Context) (bool, error) {
-					nodeBuilder, pullErr := nodes.Pull(APIClient, nodeName)
-					if pullErr != nil {
-						klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-							"Failed to pull node %q: %v", nodeName, pullErr)
-
-						return false, nil
-					}
-
-					qty, exists := nodeBuilder.Object.Status.Allocatable[corev1.ResourceName(resourceName)]
-					if !exists {
-						klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-							"Resource %q not yet present in allocatable resources on node %q, retrying",
-							resourceName, nodeName)
-
-						return false, nil
-					}
-
-					if qty.IsZero() {
-						klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-							"Resource %q on node %q has zero allocatable quantity, retrying",
-							resourceName, nodeName)
-
-						return false, nil
-					}
-
-					finalQty = qty
-
-					return true, nil
-				})
-			if err != nil {
-				return fmt.Errorf("SR-IOV resource %q did not become available on node %q within %v: %w",
-					resourceName, nodeName, sriovDevicePluginRecoveryDelay, err)
-			}
-
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-				"SR-IOV resource %q is available on node %q with quantity %s", resourceName, nodeName, &finalQty)
-		}
-	}
-
-	return nil
-}
-
 // runPodLevelBondTopologyCase waits for SRIOV sync, deploys the second pod-level bond workload for the
 // given topology (sameNode / samePF), then verifies client/server connectivity.
 func runPodLevelBondTopologyCase(sameNode, samePF bool) {
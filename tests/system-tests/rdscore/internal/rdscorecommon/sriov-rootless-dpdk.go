@@ -3,13 +3,11 @@ package rdscorecommon
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/link"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/iplink"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -19,6 +17,8 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/sriovoperator"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/testpmd"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/apiobjectshelper"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +34,8 @@ const (
 	serverPodLabel           = "rds-app=rootless-dpdk-server"
 	maxMulticastNoiseRate    = 5000
 	minimumExpectedDPDKRate  = 1000000
+	testPmdRateWindow        = 2
+	vfTestPmdPortID          = 0
 	hugePages                = "2Gi"
 	memory                   = "1Gi"
 	cpu                      = 4
@@ -46,7 +48,7 @@ const (
 	firstInterfaceBasedOnTapThree  = "ext2.1"
 	secondInterfaceBasedOnTapThree = "ext2.2"
 
-	dpdkTestpmdTimeout = 20 * time.Second
+	dpdkTestpmdTimeout = 30 * time.Second
 	clientRxCmdTimeout = dpdkTestpmdTimeout + 10*time.Second
 	getLinkRxTimeout   = 3 * time.Second
 )
@@ -622,21 +624,35 @@ func rxTrafficOnClientPod(clientPod *pod.Builder, clientRxCmd string) error {
 
 	klog.V(90).Infof("Processing testpmd output from client pod \n%s", clientOut.String())
 
-	outPutTrue := checkRxOnly(clientOut.String())
-
-	if !outPutTrue {
+	stats, err := testpmd.Parse(clientOut.String())
+	if err != nil || !stats.ReceivedPackets() {
 		klog.V(100).Infof("Failed to parse the dpdk-pmd command execution output \n%s", clientOut.String())
 
 		return fmt.Errorf("failed to parse the output from RxTrafficOnClientPod \n%s", clientOut.String())
 	}
 
+	window, err := stats.Window(vfTestPmdPortID, testPmdRateWindow)
+	if err != nil {
+		return fmt.Errorf("failed to collect testpmd statistics of the VF port: %w", err)
+	}
+
+	err = testpmd.MinRxRate(window, testpmd.MinimumExpectedRxMpps)
+	if err != nil {
+		return fmt.Errorf("dpdk-pmd receive rate on the client pod %s is too low: %w", clientPod.Definition.Name, err)
+	}
+
+	err = testpmd.NoErrors(window)
+	if err != nil {
+		return fmt.Errorf("dpdk-pmd on the client pod %s reported errors: %w", clientPod.Definition.Name, err)
+	}
+
 	return nil
 }
 
 func getCurrentLinkRx(runningPod *pod.Builder) (map[string]int, error) {
 	var (
 		linksRawInfo  bytes.Buffer
-		linksInfoList []link.Link
+		linksInfoList []iplink.Link
 		err           error
 	)
 
@@ -666,7 +682,7 @@ func getCurrentLinkRx(runningPod *pod.Builder) (map[string]int, error) {
 				return false, nil
 			}
 
-			tmpLinksInfoList, err := link.NewListBuilder(linksRawInfo)
+			tmpLinksInfoList, err := iplink.Parse(linksRawInfo.Bytes())
 			if err != nil {
 				klog.V(100).Infof("Failed to build a links object list for %q due to %v",
 					linksRawInfo.String(), err)
@@ -696,54 +712,6 @@ func getCurrentLinkRx(runningPod *pod.Builder) (map[string]int, error) {
 	return linksInfoMap, nil
 }
 
-func checkRxOnly(out string) bool {
-	lines := strings.Split(out, "\n")
-	for index, line := range lines {
-		if strings.Contains(line, "NIC statistics for port") {
-			if len(lines[index+1]) < 3 {
-				klog.V(90).Info("Fail: line list contains less than 3 elements")
-
-				return false
-			}
-
-			if len(lines) > index && getNumberOfPackets(lines[index+1], "RX") > 0 {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
-func getNumberOfPackets(line, firstFieldSubstr string) int {
-	klog.V(100).Infof("Parsing line %s", line)
-
-	splitLine := strings.Fields(line)
-
-	klog.V(100).Infof("Parsing field %s", splitLine)
-
-	if !strings.Contains(splitLine[0], firstFieldSubstr) {
-		klog.V(90).Infof("Failed to find expected substring %s", firstFieldSubstr)
-
-		return 0
-	}
-
-	if len(splitLine) != 6 {
-		klog.V(90).Info("the slice doesn't contain 6 elements")
-
-		return 0
-	}
-
-	numberOfPackets, err := strconv.Atoi(splitLine[1])
-	if err != nil {
-		klog.V(90).Infof("failed to convert string to integer %s", err)
-
-		return 0
-	}
-
-	return numberOfPackets
-}
-
 func defineTestServerPmdCmd(ethPeer, pciAddress, txIPs string) []string {
 	baseCmd := fmt.Sprintf("dpdk-testpmd -a %s -- --forward-mode txonly --eth-peer=0,%s", pciAddress, ethPeer)
 	if txIPs != "" {
@@ -832,7 +800,7 @@ func getLinkRx(runningPod *pod.Builder, linkName string) (int, error) {
 			klog.V(100).Infof("Validating output of link %s info from pod %s in namespace %s",
 				linkName, runningPod.Definition.Name, runningPod.Definition.Namespace)
 
-			_, err = link.NewBuilder(linkRawInfo)
+			_, err = iplink.ParseSingle(linkRawInfo.Bytes())
 			if err != nil {
 				klog.V(100).Infof("Failed to parse %q link's info from pod %s in namespace %s with error %v",
 					linkName, runningPod.Definition.Name, runningPod.Definition.Namespace, err)
@@ -850,7 +818,7 @@ func getLinkRx(runningPod *pod.Builder, linkName string) (int, error) {
 			linkName, runningPod.Definition.Name, runningPod.Definition.Namespace, err)
 	}
 
-	linkInfo, err := link.NewBuilder(linkRawInfo)
+	linkInfo, err := iplink.ParseSingle(linkRawInfo.Bytes())
 	if err != nil {
 		klog.V(100).Infof("Failed to collect link %s info from pod %s in namespace %s with error %v",
 			linkName, runningPod.Definition.Name, runningPod.Definition.Namespace, err)
@@ -863,9 +831,7 @@ func getLinkRx(runningPod *pod.Builder, linkName string) (int, error) {
 }
 
 func getPCIAddressListFromSrIovNetworkName(podNetworkStatus, networkName string) ([]string, error) {
-	var podNetworkStatusType []podNetworkAnnotation
-
-	err := json.Unmarshal([]byte(podNetworkStatus), &podNetworkStatusType)
+	podNetworkStatusType, err := sriovoperator.ParseNetworkStatus(podNetworkStatus)
 	if err != nil {
 		klog.V(100).Infof("Failed to unmarshal pod network status %s with error %v", podNetworkStatus, err)
 
@@ -8,9 +8,8 @@ import (
 	"runtime"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -105,48 +104,12 @@ func NewSPKConfig() *SPKConfig {
 
 	log.Printf("Open config file %s", confFile)
 
-	err := readFile(&spkConf, confFile)
+	err := config.Loader{Section: "spk", DefaultFile: confFile, EnvPrefix: "ECO_SYSTEM_SPK_"}.Load(&spkConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&spkConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load SPKConfig: %v", err)
 
 		return nil
 	}
 
 	return &spkConf
 }
-
-func readFile(spkConfig *SPKConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&spkConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(spkConfig *SPKConfig) error {
-	err := envconfig.Process("", spkConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}